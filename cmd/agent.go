@@ -0,0 +1,127 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	"github.com/nextdoor/lumina/internal/cache"
+	"github.com/nextdoor/lumina/internal/controller"
+	"github.com/nextdoor/lumina/pkg/config"
+	"github.com/nextdoor/lumina/pkg/hub"
+	"github.com/nextdoor/lumina/pkg/metrics"
+)
+
+// runAgent runs the controller as an agent (config agent.hubURL): it watches this
+// cluster's nodes and pods and exports their costs as calculated by the hub, without
+// calling any AWS APIs. It returns when the manager stops.
+//
+// coverage:ignore - agent entrypoint, tested via E2E
+func runAgent(
+	mgr ctrl.Manager,
+	cfg *config.Config,
+	configWatcher *config.Watcher,
+	luminaMetrics *metrics.Metrics,
+) error {
+	setupLog.Info("starting in agent mode", "hub", cfg.Agent.HubURL)
+
+	nodeCache := cache.NewNodeCache()
+	if err := (&controller.NodeReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		NodeCache: nodeCache,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Node controller: %w", err)
+	}
+	setupLog.Info("registered node reconciler (event-driven)")
+
+	if cfg.Karpenter.Enabled {
+		if err := (&controller.NodeClaimReconciler{
+			Client:    mgr.GetClient(),
+			Scheme:    mgr.GetScheme(),
+			NodeCache: nodeCache,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create NodeClaim controller: %w", err)
+		}
+		setupLog.Info("registered nodeclaim reconciler")
+	}
+
+	// Fargate usage competes for Savings Plans, so it has to be part of the hub's
+	// calculation; an agent can't add it
+	if cfg.Serverless.Fargate.Enabled {
+		setupLog.Info("serverless.fargate.enabled has no effect in agent mode")
+	}
+	var podCache *cache.PodCache
+	if cfg.Allocation.Enabled {
+		podCache = cache.NewPodCache()
+		if err := (&controller.PodReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			PodCache: podCache,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create Pod controller: %w", err)
+		}
+		setupLog.Info("registered pod reconciler", "cpu_weight", cfg.GetAllocationCPUWeight())
+	}
+
+	agent := &controller.AgentReconciler{
+		Hub:           hub.NewClient(cfg.Agent.HubURL),
+		NodeCache:     nodeCache,
+		PodCache:      podCache,
+		NodePoolCosts: cfg.Karpenter.Enabled,
+		Config:        cfg,
+		Metrics:       luminaMetrics,
+		Log:           ctrl.Log.WithName("agent"),
+	}
+	if configWatcher != nil {
+		agent.ConfigProvider = configWatcher
+		configWatcher.OnChange(func(previous, current *config.Config) {
+			if settings := config.RestartRequired(previous, current); len(settings) > 0 {
+				setupLog.Info("reloaded configuration changes settings that only take effect after a restart",
+					"settings", settings)
+			}
+			luminaMetrics.SetConfig(current)
+		})
+		configWatcher.Start()
+		defer configWatcher.Stop()
+		setupLog.Info("watching configuration file for changes")
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+	go func() {
+		if err := agent.Run(ctx); err != nil && ctx.Err() == nil {
+			setupLog.Error(err, "agent reconciler stopped with error")
+		}
+	}()
+	setupLog.Info("started agent reconciler (goroutine)", "poll_interval", cfg.GetAgentPollInterval())
+
+	// There are no AWS credentials to validate, and a hub outage only makes the
+	// metrics stale, so the process is ready as soon as it runs
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		return fmt.Errorf("unable to set up health check: %w", err)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		return fmt.Errorf("unable to set up ready check: %w", err)
+	}
+
+	setupLog.Info("starting manager")
+	if err := mgr.Start(ctx); err != nil {
+		return fmt.Errorf("problem running manager: %w", err)
+	}
+	return nil
+}
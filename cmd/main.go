@@ -23,10 +23,12 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
 	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -48,9 +50,14 @@ import (
 	"github.com/nextdoor/lumina/internal/cache"
 	"github.com/nextdoor/lumina/internal/controller"
 	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/billing"
 	"github.com/nextdoor/lumina/pkg/config"
 	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/nextdoor/lumina/pkg/history"
+	"github.com/nextdoor/lumina/pkg/hub"
 	"github.com/nextdoor/lumina/pkg/metrics"
+	"github.com/nextdoor/lumina/pkg/recommend"
+	"github.com/nextdoor/lumina/pkg/whatif"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -68,7 +75,9 @@ type reconcilers struct {
 	SPRates     *controller.SPRatesReconciler
 	SpotPricing *controller.SpotPricingReconciler
 	Cost        *controller.CostReconciler
-	ReadyCh     chan struct{} // Channel for RISP->SPRates coordination
+	Billing     *controller.BillingReconciler  // nil unless billing.curPath is set
+	Snapshot    *controller.SnapshotReconciler // nil unless snapshot.path is set
+	ReadyCh     chan struct{}                  // Channel for RISP->SPRates coordination
 
 	// HealthTracker tracks reconciler liveness for the readiness probe.
 	// When any reconciler permanently fails (after exhausting retries), it marks
@@ -86,19 +95,25 @@ func initializeReconcilers(
 	ec2Cache *cache.EC2Cache,
 	pricingCache *cache.PricingCache,
 	nodeCache *cache.NodeCache,
+	podCache *cache.PodCache,
 	luminaMetrics *metrics.Metrics,
 	costCalculator *cost.Calculator,
+	accountDiscovery *aws.AccountDiscovery,
+	configWatcher *config.Watcher,
 ) *reconcilers {
 	// Create channels for reconciler coordination to ensure proper startup ordering.
 	// Each reconciler signals when its initial data load completes, allowing dependent
 	// reconcilers to wait for complete data before starting.
 	//
 	// Dependency graph:
-	//   Pricing ─┐
-	//            ├─→ SPRates ────┐
-	//   RISP ────┤                │
-	//            │                ├─→ Cost (waits for all 5)
-	//   EC2 ─────┴─→ SpotPricing ─┘
+	//   EC2 ─────┬─→ Pricing ─┐
+	//            │            ├─→ SPRates ────┐
+	//   RISP ────┼────────────┘               │
+	//            │                            ├─→ Cost (waits for all 5)
+	//            └─→ SpotPricing ─────────────┘
+	//
+	// Pricing waits for EC2 so its initial load covers the tenancies (dedicated,
+	// host) of running instances.
 	//
 	// This ensures the Cost reconciler's first calculation has complete, accurate data
 	// rather than running 5-6 times with increasingly complete data during startup.
@@ -113,41 +128,156 @@ func initializeReconcilers(
 	// it marks itself as failed here, causing the readiness probe to fail.
 	healthTracker := controller.NewReconcilerHealthTracker()
 
+	// With account discovery, reconcilers read the current accounts from it each
+	// cycle. Left as a nil interface otherwise, so they fall back to cfg.AWSAccounts.
+	var accounts aws.AccountLister
+	if accountDiscovery != nil {
+		accounts = accountDiscovery
+	}
+
+	// With a watched config file, reconcilers read the reloaded config each cycle.
+	// Left as a nil interface otherwise, so they use cfg.
+	var configProvider config.Provider
+	if configWatcher != nil {
+		configProvider = configWatcher
+	}
+
+	// One pod watch serves both pod cost allocation and Fargate usage, whichever
+	// are enabled
+	var allocationPods, fargatePods *cache.PodCache
+	if cfg.Allocation.Enabled {
+		allocationPods = podCache
+	}
+	if cfg.Serverless.Fargate.Enabled {
+		fargatePods = podCache
+	}
+
+	// Billing-hour Savings Plan accounting is opt-in (cost.savingsPlanLedger).
+	// The ledger is stateful, so it's created once and shared across calculations.
+	var spLedger *cost.SavingsPlanLedger
+	if cfg.Cost.SavingsPlanLedger {
+		spLedger = cost.NewSavingsPlanLedger()
+	}
+
+	// Billing comparison is enabled by pointing billing.curPath at an exported Cost
+	// and Usage Report. Estimates are kept one day longer than the lookback so the
+	// oldest compared day is never pruned mid-comparison.
+	var estimates *cost.DailyEstimates
+	var billingReconciler *controller.BillingReconciler
+	if cfg.Billing.CURPath != "" {
+		estimates = cost.NewDailyEstimates(controller.DefaultBillingLookbackDays + 1)
+		billingReconciler = &controller.BillingReconciler{
+			Source:         billing.NewCURSource(cfg.Billing.CURPath),
+			Estimates:      estimates,
+			Config:         cfg,
+			ConfigProvider: configProvider,
+			Accounts:       accounts,
+			Metrics:        luminaMetrics,
+			Log:            ctrl.Log.WithName("billing-reconciler"),
+		}
+	}
+
+	// Cache snapshots are enabled by snapshot.path. Restoring one lets the first cost
+	// calculation run immediately instead of after the multi-minute initial AWS load;
+	// the reconcilers still refresh every cache in the background.
+	var snapshotReconciler *controller.SnapshotReconciler
+	warmStart := false
+	if cfg.Snapshot.Path != "" {
+		monitored := cfg.AWSAccounts
+		if accountDiscovery != nil {
+			monitored = accountDiscovery.Accounts()
+		}
+		warmStart = restoreCacheSnapshot(cfg, monitored, ec2Cache, rispCache, pricingCache)
+		snapshotReconciler = &controller.SnapshotReconciler{
+			Path:           cfg.Snapshot.Path,
+			EC2Cache:       ec2Cache,
+			RISPCache:      rispCache,
+			PricingCache:   pricingCache,
+			Config:         cfg,
+			ConfigProvider: configProvider,
+			Log:            ctrl.Log.WithName("snapshot-reconciler"),
+		}
+	}
+
+	// Cost history is enabled by history.path. A store that can't be created (e.g.,
+	// an unwritable volume) only disables the history API, so it isn't fatal.
+	var historyStore *history.Store
+	if cfg.History.Path != "" {
+		var err error
+		historyStore, err = history.NewStore(
+			cfg.History.Path, cfg.GetHistoryResolution(), cfg.GetHistoryRetentionDays())
+		if err != nil {
+			setupLog.Error(err, "failed to open cost history store, history disabled", "path", cfg.History.Path)
+		} else {
+			setupLog.Info("recording cost history",
+				"path", cfg.History.Path,
+				"resolution", cfg.GetHistoryResolution().String(),
+				"retention_days", cfg.GetHistoryRetentionDays())
+		}
+	}
+
+	// Savings Plan recommendations are opt-in (recommendations.enabled). Spillover
+	// is recorded in memory, so recommendations start after a day of uptime.
+	var recommender *recommend.Recommender
+	if cfg.Recommendations.Enabled {
+		oneYear := cfg.GetRecommendationDiscounts(1)
+		threeYear := cfg.GetRecommendationDiscounts(3)
+		recommender = recommend.NewRecommender(recommend.Options{
+			Lookback:  cfg.GetRecommendationsLookback(),
+			OneYear:   recommend.RateMultipliers{Compute: oneYear.Compute, EC2Instance: oneYear.EC2Instance},
+			ThreeYear: recommend.RateMultipliers{Compute: threeYear.Compute, EC2Instance: threeYear.EC2Instance},
+		})
+	}
+
+	// The expiration forecast is opt-in (forecast.enabled)
+	var expirationForecast *whatif.ForecastCache
+	if cfg.Forecast.Enabled {
+		expirationForecast = whatif.NewForecastCache(controller.ForecastMaxAge)
+	}
+
 	return &reconcilers{
 		Pricing: &controller.PricingReconciler{
 			AWSClient:        awsClient,
 			Config:           cfg,
+			ConfigProvider:   configProvider,
 			Cache:            pricingCache,
+			EC2Cache:         ec2Cache,
 			Metrics:          luminaMetrics,
 			Log:              ctrl.Log.WithName("pricing-reconciler"),
-			Regions:          cfg.Regions,
 			OperatingSystems: cfg.GetOperatingSystems(),
+			EC2ReadyChan:     ec2ReadyCh,
 			ReadyChan:        pricingReadyCh,
 			HealthTracker:    healthTracker,
+			LoadEBSPricing:   cfg.Storage.Enabled,
 		},
 		RISP: &controller.RISPReconciler{
-			AWSClient:     awsClient,
-			Config:        cfg,
-			Cache:         rispCache,
-			Metrics:       luminaMetrics,
-			Log:           ctrl.Log.WithName("risp-reconciler"),
-			Regions:       cfg.Regions,
-			ReadyChan:     rispReadyCh,
-			HealthTracker: healthTracker,
+			AWSClient:      awsClient,
+			Config:         cfg,
+			ConfigProvider: configProvider,
+			Accounts:       accounts,
+			Cache:          rispCache,
+			Metrics:        luminaMetrics,
+			Log:            ctrl.Log.WithName("risp-reconciler"),
+			ReadyChan:      rispReadyCh,
+			HealthTracker:  healthTracker,
 		},
 		EC2: &controller.EC2Reconciler{
-			AWSClient:     awsClient,
-			Config:        cfg,
-			Cache:         ec2Cache,
-			Metrics:       luminaMetrics,
-			Log:           ctrl.Log.WithName("ec2-reconciler"),
-			Regions:       cfg.Regions,
-			ReadyChan:     ec2ReadyCh,
-			HealthTracker: healthTracker,
+			AWSClient:          awsClient,
+			Config:             cfg,
+			ConfigProvider:     configProvider,
+			Accounts:           accounts,
+			Cache:              ec2Cache,
+			Metrics:            luminaMetrics,
+			Log:                ctrl.Log.WithName("ec2-reconciler"),
+			ReadyChan:          ec2ReadyCh,
+			HealthTracker:      healthTracker,
+			FetchVolumes:       cfg.Storage.Enabled,
+			FetchInstanceTypes: cfg.UnitCosts.Enabled,
 		},
 		SPRates: &controller.SPRatesReconciler{
 			AWSClient:        awsClient,
 			Config:           cfg,
+			ConfigProvider:   configProvider,
 			EC2Cache:         ec2Cache,
 			RISPCache:        rispCache,
 			PricingCache:     pricingCache,
@@ -160,37 +290,224 @@ func initializeReconcilers(
 			HealthTracker:    healthTracker,
 		},
 		SpotPricing: &controller.SpotPricingReconciler{
-			AWSClient:     awsClient,
-			Config:        cfg,
-			EC2Cache:      ec2Cache,
-			Cache:         pricingCache,
-			Metrics:       luminaMetrics,
-			Log:           ctrl.Log.WithName("spot-pricing-reconciler"),
-			EC2ReadyChan:  ec2ReadyCh,
-			ReadyChan:     spotPricingReadyCh,
-			HealthTracker: healthTracker,
+			AWSClient:      awsClient,
+			Config:         cfg,
+			ConfigProvider: configProvider,
+			Accounts:       accounts,
+			EC2Cache:       ec2Cache,
+			Cache:          pricingCache,
+			Metrics:        luminaMetrics,
+			Log:            ctrl.Log.WithName("spot-pricing-reconciler"),
+			EC2ReadyChan:   ec2ReadyCh,
+			ReadyChan:      spotPricingReadyCh,
+			HealthTracker:  healthTracker,
 		},
 		Cost: &controller.CostReconciler{
 			Calculator:           costCalculator,
 			Config:               cfg,
+			ConfigProvider:       configProvider,
 			EC2Cache:             ec2Cache,
 			RISPCache:            rispCache,
 			PricingCache:         pricingCache,
 			NodeCache:            nodeCache,
+			PodCache:             allocationPods,
+			FargatePodCache:      fargatePods,
+			NodePoolCosts:        cfg.Karpenter.Enabled,
+			StorageCosts:         cfg.Storage.Enabled,
+			UnitCosts:            cfg.UnitCosts.Enabled,
+			ExpirationForecast:   expirationForecast,
 			Metrics:              luminaMetrics,
+			Ledger:               spLedger,
+			Estimates:            estimates,
+			History:              historyStore,
+			Recommender:          recommender,
 			Log:                  ctrl.Log.WithName("cost-reconciler"),
 			PricingReadyChan:     pricingReadyCh,
 			RISPReadyChan:        rispReadyCh,
 			EC2ReadyChan:         ec2ReadyCh,
 			SPRatesReadyChan:     spRatesReadyCh,
 			SpotPricingReadyChan: spotPricingReadyCh,
+			WarmStart:            warmStart,
 			HealthTracker:        healthTracker,
 		},
+		Billing:       billingReconciler,
+		Snapshot:      snapshotReconciler,
 		ReadyCh:       rispReadyCh,
 		HealthTracker: healthTracker,
 	}
 }
 
+// restoreCacheSnapshot loads the snapshot at snapshot.path into the caches, keeping
+// only the data of the given accounts.
+// Returns false, leaving the caches empty, if there is no usable snapshot; the
+// controller then waits for the initial AWS data load as usual.
+func restoreCacheSnapshot(
+	cfg *config.Config,
+	accounts []config.AWSAccount,
+	ec2Cache *cache.EC2Cache,
+	rispCache *cache.RISPCache,
+	pricingCache *cache.PricingCache,
+) bool {
+	snapshot, err := cache.LoadSnapshot(cfg.Snapshot.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		setupLog.Info("no cache snapshot found, waiting for initial data load", "path", cfg.Snapshot.Path)
+		return false
+	}
+	if err != nil {
+		setupLog.Error(err, "unable to load cache snapshot, waiting for initial data load")
+		return false
+	}
+
+	age := time.Since(snapshot.SavedAt)
+	if age > cfg.GetSnapshotMaxAge() {
+		setupLog.Info("cache snapshot is too old, waiting for initial data load",
+			"path", cfg.Snapshot.Path,
+			"age", age.Round(time.Second).String(),
+			"max_age", cfg.GetSnapshotMaxAge().String())
+		return false
+	}
+
+	accountIDs := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		accountIDs = append(accountIDs, account.AccountID)
+	}
+	snapshot.RetainAccounts(accountIDs)
+	snapshot.Restore(ec2Cache, rispCache, pricingCache)
+
+	setupLog.Info("restored caches from snapshot",
+		"path", cfg.Snapshot.Path,
+		"age", age.Round(time.Second).String(),
+		"instances", len(snapshot.EC2.Instances),
+		"on_demand_prices", len(snapshot.Pricing.OnDemandPrices),
+		"sp_rates", len(snapshot.Pricing.SPRates))
+	return true
+}
+
+// newAccountDiscovery creates account discovery if accountDiscovery.enabled is set,
+// or returns nil. The account list is refreshed once before returning so the
+// reconcilers' first cycle covers discovered accounts. A failed refresh isn't
+// fatal: the configured accounts are monitored until a later refresh succeeds.
+// If the Organizations client can't be created, only the configured accounts are
+// monitored and nil is returned.
+func newAccountDiscovery(ctx context.Context, awsClient aws.Client, cfg *config.Config) *aws.AccountDiscovery {
+	if !cfg.AccountDiscovery.Enabled {
+		return nil
+	}
+
+	orgClient, err := awsClient.Organizations(ctx)
+	if err != nil {
+		setupLog.Error(err, "unable to create Organizations client, monitoring configured accounts only")
+		return nil
+	}
+	discovery := aws.NewAccountDiscovery(orgClient, cfg)
+	if err := discovery.Refresh(ctx); err != nil {
+		setupLog.Error(err, "initial account discovery failed, monitoring configured accounts only")
+	}
+	setupLog.Info("discovered AWS accounts",
+		"accounts", len(discovery.Accounts()),
+		"configured", len(cfg.AWSAccounts),
+		"refreshInterval", cfg.GetAccountDiscoveryRefreshInterval().String())
+	return discovery
+}
+
+// startAccountDiscovery keeps the credential monitor in step with discovered
+// accounts, evicts the data and metrics of accounts that are no longer monitored,
+// and starts the periodic refresh. The reconcilers pick up new accounts on their
+// next cycle.
+func startAccountDiscovery(
+	discovery *aws.AccountDiscovery,
+	credMonitor *aws.CredentialMonitor,
+	ec2Cache *cache.EC2Cache,
+	rispCache *cache.RISPCache,
+	luminaMetrics *metrics.Metrics,
+) {
+	discovery.OnChange(func(accounts []config.AWSAccount, removed []config.AWSAccount) {
+		credMonitor.SetAccounts(accounts)
+		stopMonitoringAccounts(removed, ec2Cache, rispCache, luminaMetrics)
+	})
+	discovery.Start()
+}
+
+// stopMonitoringAccounts evicts the cached data and metrics of accounts that are
+// no longer monitored. Evicting from the caches triggers a cost recalculation.
+func stopMonitoringAccounts(
+	removed []config.AWSAccount,
+	ec2Cache *cache.EC2Cache,
+	rispCache *cache.RISPCache,
+	luminaMetrics *metrics.Metrics,
+) {
+	for _, account := range removed {
+		ec2Cache.RemoveAccount(account.AccountID)
+		rispCache.RemoveAccount(account.AccountID)
+		luminaMetrics.DeleteAccountMetrics(account.AccountID, account.Name)
+		setupLog.Info("stopped monitoring AWS account",
+			"account_id", account.AccountID,
+			"account_name", account.Name)
+	}
+}
+
+// startConfigWatcher applies reloaded configurations and starts watching the
+// config file. Reconcilers read the reloaded configuration themselves on their
+// next cycle; this applies the parts held elsewhere: metric label names, the
+// monitored accounts, and the discount fallbacks used by cost calculations.
+func startConfigWatcher(
+	watcher *config.Watcher,
+	discovery *aws.AccountDiscovery,
+	credMonitor *aws.CredentialMonitor,
+	ec2Cache *cache.EC2Cache,
+	rispCache *cache.RISPCache,
+	luminaMetrics *metrics.Metrics,
+	recalculateCosts func(),
+) {
+	watcher.OnChange(func(previous, current *config.Config) {
+		if settings := config.RestartRequired(previous, current); len(settings) > 0 {
+			setupLog.Info("reloaded configuration changes settings that only take effect after a restart",
+				"settings", settings)
+		}
+
+		luminaMetrics.SetConfig(current)
+
+		if discovery != nil {
+			// Discovery merges the configured accounts with the organization's and
+			// applies any change to the result through its own OnChange function
+			discovery.SetConfig(current)
+			if err := discovery.Refresh(context.Background()); err != nil {
+				setupLog.Error(err, "account discovery after configuration reload failed")
+			}
+		} else if !reflect.DeepEqual(previous.AWSAccounts, current.AWSAccounts) {
+			credMonitor.SetAccounts(current.AWSAccounts)
+			stopMonitoringAccounts(
+				aws.RemovedAccounts(previous.AWSAccounts, current.AWSAccounts), ec2Cache, rispCache, luminaMetrics)
+		}
+
+		// Recalculate costs with the reloaded discount fallbacks and metric labels
+		recalculateCosts()
+	})
+	watcher.Start()
+}
+
+// calculatorConfig returns the source of the cost calculator's discount fallbacks:
+// the current configuration when the config file is watched, cfg otherwise.
+func calculatorConfig(cfg *config.Config, watcher *config.Watcher) cost.ConfigReader {
+	if watcher == nil {
+		return cfg
+	}
+	return currentConfigReader{watcher}
+}
+
+// currentConfigReader reads discount fallbacks from the current configuration.
+type currentConfigReader struct {
+	provider config.Provider
+}
+
+func (r currentConfigReader) GetEC2InstanceDiscount() float64 {
+	return r.provider.Current().GetEC2InstanceDiscount()
+}
+
+func (r currentConfigReader) GetComputeDiscount() float64 {
+	return r.provider.Current().GetComputeDiscount()
+}
+
 // runStandalone runs the controller in standalone mode without Kubernetes integration.
 //
 // This mode is designed for local development and testing, enabling developers to run
@@ -214,6 +531,7 @@ func initializeReconcilers(
 // coverage:ignore - standalone mode, tested manually or via E2E
 func runStandalone(
 	cfg *config.Config,
+	configWatcher *config.Watcher,
 	metricsAddr string,
 	probeAddr string,
 	secureMetrics bool,
@@ -259,15 +577,22 @@ func runStandalone(
 	setupLog.Info("initialized pricing cache")
 
 	// Create cost calculator (needed before initializing reconcilers)
-	costCalculator := cost.NewCalculator(pricingCache, cfg)
+	costCalculator := cost.NewCalculator(pricingCache, calculatorConfig(cfg, configWatcher))
 
 	// Initialize all reconcilers using the helper function
 	// This reduces code duplication between standalone and Kubernetes modes
-	// Pass nil for nodeCache in standalone mode (no Kubernetes nodes to correlate)
-	recs := initializeReconcilers(awsClient, cfg, rispCache, ec2Cache, pricingCache, nil, luminaMetrics, costCalculator)
+	// Pass nil for nodeCache and podCache in standalone mode (no Kubernetes nodes to correlate)
+	ctx := ctrl.SetupSignalHandler()
+
+	// Discover accounts from AWS Organizations if enabled (nil otherwise)
+	accountDiscovery := newAccountDiscovery(ctx, awsClient, cfg)
+
+	recs := initializeReconcilers(
+		awsClient, cfg, rispCache, ec2Cache, pricingCache, nil, nil, luminaMetrics, costCalculator, accountDiscovery,
+		configWatcher,
+	)
 
 	// Start reconcilers in background goroutines
-	ctx := ctrl.SetupSignalHandler()
 
 	// Start pricing reconciler FIRST (blocking initial load)
 	// This ensures pricing cache is populated before other reconcilers need it
@@ -331,17 +656,54 @@ func runStandalone(
 	}()
 	setupLog.Info("started cost reconciler (event-driven with 1s debounce)")
 
+	// Start billing reconciler if a Cost and Usage Report path is configured
+	if recs.Billing != nil {
+		go func() {
+			if err := recs.Billing.Run(ctx); err != nil {
+				setupLog.Error(err, "billing reconciler stopped with error")
+			}
+		}()
+		setupLog.Info("started billing reconciler", "cur_path", cfg.Billing.CURPath)
+	}
+
+	// Start snapshot reconciler if a snapshot path is configured
+	if recs.Snapshot != nil {
+		go func() {
+			if err := recs.Snapshot.Run(ctx); err != nil {
+				setupLog.Error(err, "snapshot reconciler stopped with error")
+			}
+		}()
+		setupLog.Info("started snapshot reconciler", "path", cfg.Snapshot.Path)
+	}
+
 	// Create credential monitor for AWS health checks
 	// The monitor runs background checks at the configured interval instead of on every healthz probe,
 	// reducing AWS API calls from ~42/min to ~0.7/min (for 7 accounts with 10m interval).
 	validator := aws.NewAccountValidator(awsClient)
 	checkInterval := cfg.GetAccountValidationInterval()
-	credMonitor := aws.NewCredentialMonitor(validator, cfg.AWSAccounts, checkInterval)
+	monitoredAccounts := cfg.AWSAccounts
+	if accountDiscovery != nil {
+		monitoredAccounts = accountDiscovery.Accounts()
+	}
+	credMonitor := aws.NewCredentialMonitor(validator, monitoredAccounts, checkInterval)
 	credMonitor.Start()
 	setupLog.Info("started AWS credential monitor",
-		"accounts", len(cfg.AWSAccounts),
+		"accounts", len(monitoredAccounts),
 		"checkInterval", checkInterval)
 
+	if accountDiscovery != nil {
+		startAccountDiscovery(accountDiscovery, credMonitor, ec2Cache, rispCache, luminaMetrics)
+		defer accountDiscovery.Stop()
+		setupLog.Info("started account discovery")
+	}
+
+	if configWatcher != nil {
+		startConfigWatcher(configWatcher, accountDiscovery, credMonitor, ec2Cache, rispCache, luminaMetrics,
+			recs.Cost.Debouncer.Trigger)
+		defer configWatcher.Stop()
+		setupLog.Info("watching configuration file for changes")
+	}
+
 	// Setup metrics server using standard http package
 	// In standalone mode, we serve Prometheus metrics directly without authentication
 	metricsMux := http.NewServeMux()
@@ -349,7 +711,22 @@ func runStandalone(
 
 	// Register debug endpoints for cache inspection
 	// These endpoints are useful for debugging pricing issues and cache state
-	controller.RegisterDebugEndpoints(metricsMux, ec2Cache, rispCache, pricingCache)
+	controller.RegisterDebugEndpoints(metricsMux, ec2Cache, rispCache, pricingCache, recs.Cost)
+
+	// Register the cost history API (responds 503 unless history.path is set)
+	metricsMux.Handle(controller.HistoryCostsPath, controller.NewHistoryHandler(recs.Cost.History))
+
+	// Register Savings Plan recommendations (responds 503 unless recommendations.enabled is set)
+	metricsMux.Handle(controller.RecommendationsPath, controller.NewRecommendationHandler(recs.Cost.Recommender))
+
+	// Register what-if cost simulations against the live caches
+	metricsMux.Handle(controller.WhatIfPath, controller.NewWhatIfHandler(recs.Cost))
+
+	// Report invariant violations that kept cost calculations from being published
+	metricsMux.Handle(controller.InvariantsPath, controller.NewInvariantsHandler(recs.Cost))
+
+	// Serve instance costs to agents in other clusters (config agent.hubURL)
+	metricsMux.Handle(hub.InstanceCostsPath, controller.NewInstanceCostsHandler(recs.Cost))
 
 	var metricsServer *http.Server
 	if secureMetrics {
@@ -447,6 +824,10 @@ func init() {
 // nolint:gocyclo
 // coverage:ignore - main entrypoint, tested via E2E
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == whatIfCommand {
+		os.Exit(runWhatIf(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
@@ -499,6 +880,7 @@ func main() {
 	// Load controller configuration
 	// If config file doesn't exist, use empty config with defaults (for E2E tests)
 	cfg, err := config.Load(configFile)
+	var configWatcher *config.Watcher
 	if err != nil {
 		if _, statErr := os.Stat(configFile); os.IsNotExist(statErr) {
 			setupLog.Info("config file not found, using defaults", "config-file", configFile)
@@ -512,6 +894,9 @@ func main() {
 			"accounts", len(cfg.AWSAccounts),
 			"default-region", cfg.DefaultRegion,
 			"log-level", cfg.LogLevel)
+
+		// Reload the configuration when the file changes, e.g. on a ConfigMap update
+		configWatcher = config.NewWatcher(configFile, cfg, config.DefaultReloadCheckInterval)
 	}
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
@@ -529,9 +914,15 @@ func main() {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
+	// Agents watch Kubernetes nodes, so they can't run standalone
+	if noKubernetes && cfg.IsAgent() {
+		setupLog.Error(errors.New("agent.hubURL is set"), "agent mode requires Kubernetes")
+		os.Exit(1)
+	}
+
 	// If running in standalone mode, skip Kubernetes manager setup
 	if noKubernetes {
-		if err := runStandalone(cfg, metricsAddr, probeAddr, secureMetrics,
+		if err := runStandalone(cfg, configWatcher, metricsAddr, probeAddr, secureMetrics,
 			metricsCertPath, metricsCertName, metricsCertKey, tlsOpts); err != nil {
 			setupLog.Error(err, "standalone mode failed")
 			os.Exit(1)
@@ -582,10 +973,23 @@ func main() {
 	// Note: We need to create a temporary debug handler here before we have the caches
 	// We'll set up a placeholder and update it after caches are initialized
 	debugHandler := controller.NewDebugHandler(nil, nil, nil)
-	metricsServerOptions.ExtraHandlers = map[string]http.Handler{
-		"/debug/cache/": debugHandler,
+	historyHandler := controller.NewHistoryHandler(nil)
+	recommendationHandler := controller.NewRecommendationHandler(nil)
+	whatIfHandler := controller.NewWhatIfHandler(nil)
+	instanceCostsHandler := controller.NewInstanceCostsHandler(nil)
+	invariantsHandler := controller.NewInvariantsHandler(nil)
+	if !cfg.IsAgent() {
+		metricsServerOptions.ExtraHandlers = map[string]http.Handler{
+			"/debug/cache/":                debugHandler,
+			controller.ExplainPath:         debugHandler,
+			controller.HistoryCostsPath:    historyHandler,
+			controller.RecommendationsPath: recommendationHandler,
+			controller.WhatIfPath:          whatIfHandler,
+			hub.InstanceCostsPath:          instanceCostsHandler,
+			controller.InvariantsPath:      invariantsHandler,
+		}
+		setupLog.Info("registered debug endpoints on metrics server (caches will be set after initialization)")
 	}
-	setupLog.Info("registered debug endpoints on metrics server (caches will be set after initialization)")
 
 	// If the certificate is not specified, controller-runtime will automatically
 	// generate self-signed certificates for the metrics server. While convenient for development and testing,
@@ -636,6 +1040,16 @@ func main() {
 	luminaMetrics.ControllerRunning.Set(1)
 	setupLog.Info("metrics initialized and controller running metric set")
 
+	// Agents get their costs from the hub, so they need none of the AWS clients,
+	// caches, or reconcilers below
+	if cfg.IsAgent() {
+		if err := runAgent(mgr, cfg, configWatcher, luminaMetrics); err != nil {
+			setupLog.Error(err, "agent mode failed")
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Get default account for non-account-specific AWS calls (pricing, etc)
 	defaultAccount := cfg.GetDefaultAccount()
 
@@ -669,6 +1083,40 @@ func main() {
 	}
 	setupLog.Info("registered node reconciler (event-driven)")
 
+	// NodePool cost rollups (karpenter.enabled) need the NodeClaim CRD, so they're
+	// opt-in. NodeClaims record the NodePool of instances that haven't become nodes.
+	if cfg.Karpenter.Enabled {
+		if err := (&controller.NodeClaimReconciler{
+			Client:    mgr.GetClient(),
+			Scheme:    mgr.GetScheme(),
+			NodeCache: nodeCache,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "NodeClaim")
+			os.Exit(1)
+		}
+		setupLog.Info("registered nodeclaim reconciler")
+	}
+
+	// Pod cost allocation (allocation.enabled) and Fargate usage
+	// (serverless.fargate.enabled) are opt-in because they watch every pod in the
+	// cluster. Without allocation, costs stop at the instance level.
+	var podCache *cache.PodCache
+	if cfg.Allocation.Enabled || cfg.Serverless.Fargate.Enabled {
+		podCache = cache.NewPodCache()
+		if err := (&controller.PodReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			PodCache: podCache,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Pod")
+			os.Exit(1)
+		}
+		setupLog.Info("registered pod reconciler",
+			"allocation", cfg.Allocation.Enabled,
+			"cpu_weight", cfg.GetAllocationCPUWeight(),
+			"fargate", cfg.Serverless.Fargate.Enabled)
+	}
+
 	// Initialize RI/SP cache for Phase 2 data collection
 	rispCache := cache.NewRISPCache()
 	setupLog.Info("initialized RI/SP cache")
@@ -688,13 +1136,23 @@ func main() {
 	setupLog.Info("debug endpoints ready with cache references")
 
 	// Create cost calculator (needed before initializing reconcilers)
-	costCalculator := cost.NewCalculator(pricingCache, cfg)
+	costCalculator := cost.NewCalculator(pricingCache, calculatorConfig(cfg, configWatcher))
 
 	// Initialize all reconcilers using the helper function
 	// This reduces code duplication between standalone and Kubernetes modes
+	// Discover accounts from AWS Organizations if enabled (nil otherwise)
+	accountDiscovery := newAccountDiscovery(context.Background(), awsClient, cfg)
+
 	recs := initializeReconcilers(
-		awsClient, cfg, rispCache, ec2Cache, pricingCache, nodeCache, luminaMetrics, costCalculator,
+		awsClient, cfg, rispCache, ec2Cache, pricingCache, nodeCache, podCache, luminaMetrics, costCalculator,
+		accountDiscovery, configWatcher,
 	)
+	historyHandler.Store = recs.Cost.History
+	recommendationHandler.Recommender = recs.Cost.Recommender
+	whatIfHandler.Reconciler = recs.Cost
+	instanceCostsHandler.Reconciler = recs.Cost
+	invariantsHandler.Reconciler = recs.Cost
+	debugHandler.Reconciler = recs.Cost
 
 	// Start timer-based reconcilers as background goroutines
 	// These don't benefit from controller-runtime's event-driven machinery
@@ -733,6 +1191,26 @@ func main() {
 	}()
 	setupLog.Info("started spot pricing reconciler (goroutine)")
 
+	// Start billing reconciler if a Cost and Usage Report path is configured
+	if recs.Billing != nil {
+		go func() {
+			if err := recs.Billing.Run(ctx); err != nil {
+				setupLog.Error(err, "billing reconciler stopped with error")
+			}
+		}()
+		setupLog.Info("started billing reconciler (goroutine)", "cur_path", cfg.Billing.CURPath)
+	}
+
+	// Start snapshot reconciler if a snapshot path is configured
+	if recs.Snapshot != nil {
+		go func() {
+			if err := recs.Snapshot.Run(ctx); err != nil {
+				setupLog.Error(err, "snapshot reconciler stopped with error")
+			}
+		}()
+		setupLog.Info("started snapshot reconciler (goroutine)", "path", cfg.Snapshot.Path)
+	}
+
 	// Setup EC2 reconciler as event-driven controller
 	// This watches Node resources and reconciles on changes
 	if err := recs.EC2.SetupWithManager(mgr); err != nil {
@@ -781,12 +1259,29 @@ func main() {
 	// detecting credential issues within the configured check interval.
 	validator := aws.NewAccountValidator(awsClient)
 	checkInterval := cfg.GetAccountValidationInterval()
-	credMonitor := aws.NewCredentialMonitor(validator, cfg.AWSAccounts, checkInterval)
+	monitoredAccounts := cfg.AWSAccounts
+	if accountDiscovery != nil {
+		monitoredAccounts = accountDiscovery.Accounts()
+	}
+	credMonitor := aws.NewCredentialMonitor(validator, monitoredAccounts, checkInterval)
 	credMonitor.Start()
 	setupLog.Info("started AWS credential monitor",
-		"accounts", len(cfg.AWSAccounts),
+		"accounts", len(monitoredAccounts),
 		"checkInterval", checkInterval)
 
+	if accountDiscovery != nil {
+		startAccountDiscovery(accountDiscovery, credMonitor, ec2Cache, rispCache, luminaMetrics)
+		defer accountDiscovery.Stop()
+		setupLog.Info("started account discovery")
+	}
+
+	if configWatcher != nil {
+		startConfigWatcher(configWatcher, accountDiscovery, credMonitor, ec2Cache, rispCache, luminaMetrics,
+			recs.Cost.Debouncer.Trigger)
+		defer configWatcher.Stop()
+		setupLog.Info("watching configuration file for changes")
+	}
+
 	// The readiness probe (readyz) validates AWS account access using the credential monitor.
 	// This ensures the controller doesn't receive traffic until all configured AWS accounts
 	// are accessible. The health check reads from the monitor's cache, avoiding AWS API calls
@@ -0,0 +1,122 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/nextdoor/lumina/internal/cache"
+	"github.com/nextdoor/lumina/internal/controller"
+	"github.com/nextdoor/lumina/pkg/config"
+	"github.com/nextdoor/lumina/pkg/cost"
+)
+
+// whatIfCommand is the subcommand that simulates changes against a cache snapshot.
+const whatIfCommand = "whatif"
+
+// runWhatIf implements `lumina whatif`: it restores the caches from the snapshot
+// file (config snapshot.path), applies the changes read from -changes, and writes
+// the difference in cost calculation results as JSON. It returns the exit code.
+//
+// The changes file has the same format as the body of POST /debug/whatif.
+//
+// coverage:ignore - CLI entrypoint; simulation is tested in pkg/whatif and internal/controller
+func runWhatIf(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(whatIfCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configFile := flags.String("config", "/etc/lumina/config.yaml", "The path to the controller configuration file.")
+	snapshotPath := flags.String("snapshot", "", "The cache snapshot to simulate against (default: snapshot.path).")
+	changesFile := flags.String("changes", "-", "The file with the changes to simulate, or - for stdin.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if envConfigPath := os.Getenv("LUMINA_CONFIG_PATH"); envConfigPath != "" {
+		*configFile = envConfigPath
+	}
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to load configuration: %v\n", err)
+		return 1
+	}
+	if *snapshotPath == "" {
+		*snapshotPath = cfg.Snapshot.Path
+	}
+	if *snapshotPath == "" {
+		_, _ = fmt.Fprintln(stderr, "no cache snapshot: set snapshot.path or -snapshot")
+		return 2
+	}
+
+	var request controller.WhatIfRequest
+	changes := stdin
+	if *changesFile != "-" {
+		f, err := os.Open(*changesFile)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "failed to open changes: %v\n", err)
+			return 1
+		}
+		defer func() { _ = f.Close() }()
+		changes = f
+	}
+	decoder := json.NewDecoder(changes)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to read changes: %v\n", err)
+		return 1
+	}
+
+	snapshot, err := cache.LoadSnapshot(*snapshotPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to load cache snapshot: %v\n", err)
+		return 1
+	}
+	_, _ = fmt.Fprintf(stderr, "simulating against snapshot saved %s ago\n",
+		time.Since(snapshot.SavedAt).Round(time.Second))
+
+	ec2Cache := cache.NewEC2Cache()
+	rispCache := cache.NewRISPCache()
+	pricingCache := cache.NewPricingCache()
+	snapshot.Restore(ec2Cache, rispCache, pricingCache)
+
+	reconciler := &controller.CostReconciler{
+		Calculator:   cost.NewCalculator(pricingCache, cfg),
+		Config:       cfg,
+		EC2Cache:     ec2Cache,
+		RISPCache:    rispCache,
+		PricingCache: pricingCache,
+		StorageCosts: cfg.Storage.Enabled,
+		Log:          logr.Discard(),
+	}
+	result, err := reconciler.Simulate(request.Changes)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "invalid changes: %v\n", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to write result: %v\n", err)
+		return 1
+	}
+	return 0
+}
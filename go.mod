@@ -8,6 +8,7 @@ require (
 	github.com/aws/aws-sdk-go-v2/credentials v1.19.36
 	github.com/aws/aws-sdk-go-v2/service/ec2 v1.321.2
 	github.com/aws/aws-sdk-go-v2/service/iam v1.59.1
+	github.com/aws/aws-sdk-go-v2/service/organizations v1.52.1
 	github.com/aws/aws-sdk-go-v2/service/pricing v1.44.6
 	github.com/aws/aws-sdk-go-v2/service/savingsplans v1.35.6
 	github.com/aws/aws-sdk-go-v2/service/sts v1.45.6
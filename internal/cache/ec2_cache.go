@@ -28,9 +28,14 @@
 // - All instance states are cached (running, stopped, terminated) to track transitions
 // - Updates are atomic per account+region to allow partial refreshes without clearing the cache
 // - Thread-safe with sync.RWMutex allowing multiple concurrent readers
+//
+// EBS volumes are cached alongside instances, keyed by the instance they're attached
+// to, so a node's storage cost can be attributed to it. Instance type capacity
+// (vCPUs, memory, GPUs) is cached by instance type for per-unit costs.
 package cache
 
 import (
+	"slices"
 	"time"
 
 	"github.com/nextdoor/lumina/pkg/aws"
@@ -53,6 +58,14 @@ type EC2Cache struct {
 	// Key: instance ID (e.g., "i-1234567890abcdef0")
 	// Value: pointer to Instance struct (includes AccountID and Region fields)
 	instances map[string]*aws.Instance
+
+	// volumes maps instance ID to the EBS volumes attached to it. A Multi-Attach
+	// volume is listed under each of its instances. Unattached volumes aren't cached.
+	volumes map[string][]aws.Volume
+
+	// instanceTypes maps instance type to its capacity. Capacity doesn't vary by
+	// account or region, so entries are only added, never replaced per region.
+	instanceTypes map[string]aws.InstanceTypeInfo
 }
 
 // NewEC2Cache creates a new empty EC2 instance cache.
@@ -60,6 +73,9 @@ func NewEC2Cache() *EC2Cache {
 	return &EC2Cache{
 		BaseCache: NewBaseCache(),
 		instances: make(map[string]*aws.Instance),
+		volumes:   make(map[string][]aws.Volume),
+
+		instanceTypes: make(map[string]aws.InstanceTypeInfo),
 	}
 }
 
@@ -102,12 +118,157 @@ func (c *EC2Cache) SetInstances(accountID, region string, instances []aws.Instan
 	c.NotifyUpdate() // From BaseCache
 }
 
+// RemoveAccount removes all instances of an account, e.g. when the account is no
+// longer monitored. Subscribers are notified only if instances were removed.
+func (c *EC2Cache) RemoveAccount(accountID string) {
+	c.Lock() // From BaseCache
+	defer c.Unlock()
+
+	removed := false
+	for id, inst := range c.instances {
+		if inst.AccountID == accountID {
+			delete(c.instances, id)
+			removed = true
+		}
+	}
+	if c.removeVolumes(func(vol aws.Volume) bool { return vol.AccountID == accountID }) {
+		removed = true
+	}
+	if !removed {
+		return
+	}
+
+	c.MarkUpdated()  // From BaseCache
+	c.NotifyUpdate() // From BaseCache
+}
+
+// SetVolumes atomically replaces all EBS volumes for a specific account+region,
+// like SetInstances does for instances. Volumes that aren't attached to an
+// instance are skipped.
+func (c *EC2Cache) SetVolumes(accountID, region string, volumes []aws.Volume) {
+	c.Lock() // From BaseCache
+	defer c.Unlock()
+
+	c.removeVolumes(func(vol aws.Volume) bool {
+		return vol.AccountID == accountID && vol.Region == region
+	})
+	c.addVolumes(volumes)
+
+	c.MarkUpdated()  // From BaseCache
+	c.NotifyUpdate() // From BaseCache
+}
+
+// addVolumes indexes volumes by the instances they're attached to.
+// The caller must hold the write lock.
+func (c *EC2Cache) addVolumes(volumes []aws.Volume) {
+	for _, vol := range volumes {
+		for _, instanceID := range vol.AttachedInstanceIDs {
+			c.volumes[instanceID] = append(c.volumes[instanceID], vol)
+		}
+	}
+}
+
+// removeVolumes removes the volumes matching remove and reports whether any were
+// removed. The caller must hold the write lock.
+func (c *EC2Cache) removeVolumes(remove func(aws.Volume) bool) bool {
+	removed := false
+	for instanceID, vols := range c.volumes {
+		kept := slices.DeleteFunc(vols, remove)
+		if len(kept) == len(vols) {
+			continue
+		}
+		removed = true
+		if len(kept) == 0 {
+			delete(c.volumes, instanceID)
+		} else {
+			c.volumes[instanceID] = kept
+		}
+	}
+	return removed
+}
+
+// AddInstanceTypes adds the capacity of instance types to the cache. Cost is
+// recalculated only if a type wasn't known yet.
+func (c *EC2Cache) AddInstanceTypes(infos []aws.InstanceTypeInfo) {
+	c.Lock() // From BaseCache
+	defer c.Unlock()
+
+	added := false
+	for _, info := range infos {
+		if _, exists := c.instanceTypes[info.InstanceType]; !exists {
+			added = true
+		}
+		c.instanceTypes[info.InstanceType] = info
+	}
+
+	if added {
+		c.MarkUpdated()  // From BaseCache
+		c.NotifyUpdate() // From BaseCache
+	}
+}
+
+// GetInstanceTypes returns the capacity of each cached instance type, keyed by
+// instance type. The returned map is a copy.
+func (c *EC2Cache) GetInstanceTypes() map[string]aws.InstanceTypeInfo {
+	c.RLock() // From BaseCache
+	defer c.RUnlock()
+
+	result := make(map[string]aws.InstanceTypeInfo, len(c.instanceTypes))
+	for instanceType, info := range c.instanceTypes {
+		result[instanceType] = info
+	}
+	return result
+}
+
+// GetMissingInstanceTypes returns the instance types of instances whose capacity
+// isn't cached yet, sorted.
+func (c *EC2Cache) GetMissingInstanceTypes(instances []aws.Instance) []string {
+	c.RLock() // From BaseCache
+	defer c.RUnlock()
+
+	var missing []string
+	for _, inst := range instances {
+		if _, exists := c.instanceTypes[inst.InstanceType]; !exists && !slices.Contains(missing, inst.InstanceType) {
+			missing = append(missing, inst.InstanceType)
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
 // RegisterUpdateNotifier is inherited from BaseCache.
 // Multiple notifiers can be registered. Callbacks are invoked in separate goroutines
 // to prevent blocking cache operations.
 //
 // This is typically used to trigger cost recalculation when EC2 inventory changes.
 
+// GetVolumesByInstance returns the EBS volumes attached to each instance, keyed by
+// instance ID. The returned map is a copy.
+func (c *EC2Cache) GetVolumesByInstance() map[string][]aws.Volume {
+	c.RLock() // From BaseCache
+	defer c.RUnlock()
+
+	result := make(map[string][]aws.Volume, len(c.volumes))
+	for instanceID, vols := range c.volumes {
+		result[instanceID] = slices.Clone(vols)
+	}
+	return result
+}
+
+// GetVolumeCount returns the number of distinct EBS volumes cached.
+func (c *EC2Cache) GetVolumeCount() int {
+	c.RLock() // From BaseCache
+	defer c.RUnlock()
+
+	ids := make(map[string]bool)
+	for _, vols := range c.volumes {
+		for _, vol := range vols {
+			ids[vol.VolumeID] = true
+		}
+	}
+	return len(ids)
+}
+
 // GetInstance returns a specific instance by its ID.
 // Returns a copy of the instance and true if found, or nil and false if not found.
 //
@@ -218,6 +379,8 @@ func (c *EC2Cache) Clear() {
 	defer c.Unlock()
 
 	c.instances = make(map[string]*aws.Instance)
+	c.volumes = make(map[string][]aws.Volume)
+	c.instanceTypes = make(map[string]aws.InstanceTypeInfo)
 	// Reset lastUpdate to zero to indicate cache has never been populated
 	c.lastUpdate = time.Time{}
 }
@@ -365,6 +365,87 @@ func TestGetInstancesByAccount(t *testing.T) {
 	assert.Empty(t, nonExistentInstances, "Should return empty slice for non-existent account")
 }
 
+// TestRemoveAccount verifies that only the removed account's instances are dropped.
+func TestRemoveAccount(t *testing.T) {
+	cache := NewEC2Cache()
+	cache.SetInstances("111111111111", "us-west-2", []aws.Instance{
+		{InstanceID: "i-account1-1", Region: "us-west-2", AccountID: "111111111111", State: "running"},
+	})
+	cache.SetInstances("111111111111", "us-east-1", []aws.Instance{
+		{InstanceID: "i-account1-2", Region: "us-east-1", AccountID: "111111111111", State: "running"},
+	})
+	cache.SetInstances("222222222222", "us-west-2", []aws.Instance{
+		{InstanceID: "i-account2-1", Region: "us-west-2", AccountID: "222222222222", State: "running"},
+	})
+
+	cache.RemoveAccount("111111111111")
+
+	assert.Empty(t, cache.GetInstancesByAccount("111111111111"))
+	all := cache.GetAllInstances()
+	assert.Len(t, all, 1)
+	assert.Equal(t, "i-account2-1", all[0].InstanceID)
+}
+
+// TestSetVolumes verifies that volumes are indexed by attached instance and replaced
+// per account+region.
+func TestSetVolumes(t *testing.T) {
+	cache := NewEC2Cache()
+	cache.SetVolumes("111111111111", "us-west-2", []aws.Volume{
+		{VolumeID: "vol-root", Region: "us-west-2", AccountID: "111111111111", AttachedInstanceIDs: []string{"i-001"}},
+		{VolumeID: "vol-data", Region: "us-west-2", AccountID: "111111111111", AttachedInstanceIDs: []string{"i-001"}},
+		{VolumeID: "vol-shared", Region: "us-west-2", AccountID: "111111111111",
+			AttachedInstanceIDs: []string{"i-001", "i-002"}},
+		{VolumeID: "vol-spare", Region: "us-west-2", AccountID: "111111111111"},
+	})
+	cache.SetVolumes("222222222222", "us-west-2", []aws.Volume{
+		{VolumeID: "vol-other", Region: "us-west-2", AccountID: "222222222222", AttachedInstanceIDs: []string{"i-003"}},
+	})
+
+	byInstance := cache.GetVolumesByInstance()
+	assert.Len(t, byInstance["i-001"], 3)
+	assert.Len(t, byInstance["i-002"], 1, "Multi-Attach volume should be listed under each instance")
+	assert.Equal(t, 4, cache.GetVolumeCount(), "unattached volumes aren't cached")
+
+	// A refresh replaces the account+region's volumes, leaving other accounts alone
+	cache.SetVolumes("111111111111", "us-west-2", []aws.Volume{
+		{VolumeID: "vol-root", Region: "us-west-2", AccountID: "111111111111", AttachedInstanceIDs: []string{"i-001"}},
+	})
+	byInstance = cache.GetVolumesByInstance()
+	assert.Len(t, byInstance["i-001"], 1)
+	assert.NotContains(t, byInstance, "i-002")
+	assert.Len(t, byInstance["i-003"], 1)
+
+	cache.RemoveAccount("222222222222")
+	assert.NotContains(t, cache.GetVolumesByInstance(), "i-003")
+}
+
+// TestInstanceTypes verifies that instance type capacity is merged into the cache
+// and that instance types without capacity are reported as missing.
+func TestInstanceTypes(t *testing.T) {
+	cache := NewEC2Cache()
+	instances := []aws.Instance{
+		{InstanceID: "i-001", InstanceType: "m5.xlarge"},
+		{InstanceID: "i-002", InstanceType: "c5.large"},
+		{InstanceID: "i-003", InstanceType: "m5.xlarge"},
+	}
+	assert.Equal(t, []string{"c5.large", "m5.xlarge"}, cache.GetMissingInstanceTypes(instances))
+
+	cache.AddInstanceTypes([]aws.InstanceTypeInfo{{InstanceType: "m5.xlarge", VCPUs: 4, MemoryMiB: 16384}})
+	assert.Equal(t, []string{"c5.large"}, cache.GetMissingInstanceTypes(instances))
+
+	// Re-adding a known type doesn't mark the cache updated
+	updated := cache.GetLastUpdateTime()
+	cache.AddInstanceTypes([]aws.InstanceTypeInfo{{InstanceType: "m5.xlarge", VCPUs: 4, MemoryMiB: 16384}})
+	assert.Equal(t, updated, cache.GetLastUpdateTime())
+
+	types := cache.GetInstanceTypes()
+	require.Contains(t, types, "m5.xlarge")
+	assert.Equal(t, int32(4), types["m5.xlarge"].VCPUs)
+
+	cache.Clear()
+	assert.Empty(t, cache.GetInstanceTypes())
+}
+
 // TestGetInstancesByRegion verifies filtering by region.
 func TestGetInstancesByRegion(t *testing.T) {
 	cache := NewEC2Cache()
@@ -16,11 +16,31 @@ package cache
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
+// Well-known labels Karpenter sets on the nodes and NodeClaims it provisions.
+const (
+	// LabelKarpenterNodePool is the name of the NodePool that provisioned the node
+	LabelKarpenterNodePool = "karpenter.sh/nodepool"
+
+	// LabelKarpenterCapacityType is "spot", "on-demand", or "reserved"
+	LabelKarpenterCapacityType = "karpenter.sh/capacity-type"
+)
+
+// NodePoolMembership identifies the Karpenter NodePool an instance belongs to.
+type NodePoolMembership struct {
+	// NodePool is the value of the karpenter.sh/nodepool label
+	NodePool string
+
+	// CapacityType is the value of the karpenter.sh/capacity-type label, or empty
+	// if it isn't set
+	CapacityType string
+}
+
 // NodeCache maintains a thread-safe cache of Kubernetes nodes and their correlation
 // to EC2 instances. It maps EC2 instance IDs to Kubernetes node names, enabling
 // cost metrics to include node-level information.
@@ -40,6 +60,17 @@ type NodeCache struct {
 	// nodes stores full node objects for label/annotation extraction
 	// Key is node name
 	nodes map[string]*corev1.Node
+
+	// nodeClaims maps Karpenter NodeClaim name → the instance it launched and its
+	// NodePool. A NodeClaim has an instance before the instance registers as a node,
+	// so these cover instances that nodes don't yet.
+	nodeClaims map[string]nodeClaimEntry
+}
+
+// nodeClaimEntry is a Karpenter NodeClaim's instance and NodePool.
+type nodeClaimEntry struct {
+	instanceID string
+	membership NodePoolMembership
 }
 
 // NewNodeCache creates a new empty NodeCache.
@@ -47,6 +78,7 @@ func NewNodeCache() *NodeCache {
 	return &NodeCache{
 		instanceIDToNodeName: make(map[string]string),
 		nodes:                make(map[string]*corev1.Node),
+		nodeClaims:           make(map[string]nodeClaimEntry),
 	}
 }
 
@@ -106,6 +138,74 @@ func (c *NodeCache) DeleteNode(nodeName string) {
 	c.NotifyUpdate()
 }
 
+// UpsertNodeClaim adds or updates a Karpenter NodeClaim, recording the NodePool of
+// the instance it launched. providerID is the NodeClaim's status.providerID, which
+// is empty until the instance is launched; labels are the NodeClaim's labels.
+//
+// Returns the EC2 instance ID, or an error if providerID is missing or malformed.
+func (c *NodeCache) UpsertNodeClaim(name, providerID string, labels map[string]string) (instanceID string, err error) {
+	instanceID, err = parseProviderID(providerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse providerID for NodeClaim %s: %w", name, err)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.nodeClaims[name] = nodeClaimEntry{
+		instanceID: instanceID,
+		membership: NodePoolMembership{
+			NodePool:     labels[LabelKarpenterNodePool],
+			CapacityType: labels[LabelKarpenterCapacityType],
+		},
+	}
+
+	c.MarkUpdated()
+	c.NotifyUpdate()
+
+	return instanceID, nil
+}
+
+// DeleteNodeClaim removes a Karpenter NodeClaim from the cache by name.
+func (c *NodeCache) DeleteNodeClaim(name string) {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, exists := c.nodeClaims[name]; !exists {
+		return
+	}
+	delete(c.nodeClaims, name)
+
+	c.MarkUpdated()
+	c.NotifyUpdate()
+}
+
+// GetNodePools returns the Karpenter NodePool of each instance, keyed by EC2
+// instance ID. A node's own labels take precedence over its NodeClaim's; instances
+// without a NodePool label (not provisioned by Karpenter) are omitted.
+func (c *NodeCache) GetNodePools() map[string]NodePoolMembership {
+	c.RLock()
+	defer c.RUnlock()
+
+	result := make(map[string]NodePoolMembership)
+	for _, claim := range c.nodeClaims {
+		if claim.membership.NodePool != "" {
+			result[claim.instanceID] = claim.membership
+		}
+	}
+	for instanceID, nodeName := range c.instanceIDToNodeName {
+		node, exists := c.nodes[nodeName]
+		if !exists || node.Labels[LabelKarpenterNodePool] == "" {
+			continue
+		}
+		result[instanceID] = NodePoolMembership{
+			NodePool:     node.Labels[LabelKarpenterNodePool],
+			CapacityType: node.Labels[LabelKarpenterCapacityType],
+		}
+	}
+	return result
+}
+
 // GetNodeName returns the Kubernetes node name for a given EC2 instance ID.
 // Returns (nodeName, true) if found, ("", false) if not found.
 func (c *NodeCache) GetNodeName(instanceID string) (string, bool) {
@@ -130,6 +230,23 @@ func (c *NodeCache) GetNode(nodeName string) (*corev1.Node, bool) {
 	return node.DeepCopy(), true
 }
 
+// GetInstanceIDs returns the EC2 instance IDs of the cached nodes and NodeClaims,
+// sorted and without duplicates.
+func (c *NodeCache) GetInstanceIDs() []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	ids := make([]string, 0, len(c.instanceIDToNodeName)+len(c.nodeClaims))
+	for instanceID := range c.instanceIDToNodeName {
+		ids = append(ids, instanceID)
+	}
+	for _, claim := range c.nodeClaims {
+		ids = append(ids, claim.instanceID)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
 // GetNodeCount returns the number of nodes currently in the cache.
 func (c *NodeCache) GetNodeCount() int {
 	c.RLock()
@@ -154,6 +271,7 @@ func (c *NodeCache) Clear() {
 
 	c.instanceIDToNodeName = make(map[string]string)
 	c.nodes = make(map[string]*corev1.Node)
+	c.nodeClaims = make(map[string]nodeClaimEntry)
 }
 
 // parseProviderID extracts the EC2 instance ID from a Kubernetes node's providerID.
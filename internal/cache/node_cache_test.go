@@ -127,6 +127,58 @@ func TestNodeCache_UpsertNode(t *testing.T) {
 	assert.Equal(t, 1, cache.GetCorrelatedInstanceCount())
 }
 
+// TestNodeCache_NodePools tests resolving Karpenter NodePools from NodeClaims and
+// node labels.
+func TestNodeCache_NodePools(t *testing.T) {
+	cache := NewNodeCache()
+
+	// A NodeClaim whose instance hasn't registered as a node yet
+	instanceID, err := cache.UpsertNodeClaim("default-abcde", "aws:///us-west-2a/i-001", map[string]string{
+		LabelKarpenterNodePool:     "default",
+		LabelKarpenterCapacityType: "spot",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "i-001", instanceID)
+
+	// A NodeClaim that hasn't launched an instance
+	_, err = cache.UpsertNodeClaim("default-fghij", "", map[string]string{LabelKarpenterNodePool: "default"})
+	assert.Error(t, err)
+
+	// A Karpenter node, whose labels win over its NodeClaim's
+	_, err = cache.UpsertNodeClaim("batch-klmno", "aws:///us-west-2b/i-002", map[string]string{
+		LabelKarpenterNodePool: "stale",
+	})
+	require.NoError(t, err)
+	_, err = cache.UpsertNode(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-2",
+			Labels: map[string]string{
+				LabelKarpenterNodePool:     "batch",
+				LabelKarpenterCapacityType: "on-demand",
+			},
+		},
+		Spec: corev1.NodeSpec{ProviderID: "aws:///us-west-2b/i-002"},
+	})
+	require.NoError(t, err)
+
+	// A node not provisioned by Karpenter
+	_, err = cache.UpsertNode(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-3"},
+		Spec:       corev1.NodeSpec{ProviderID: "aws:///us-west-2c/i-003"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]NodePoolMembership{
+		"i-001": {NodePool: "default", CapacityType: "spot"},
+		"i-002": {NodePool: "batch", CapacityType: "on-demand"},
+	}, cache.GetNodePools())
+	assert.Equal(t, []string{"i-001", "i-002", "i-003"}, cache.GetInstanceIDs())
+
+	cache.DeleteNodeClaim("default-abcde")
+	cache.DeleteNodeClaim("missing")
+	assert.NotContains(t, cache.GetNodePools(), "i-001")
+}
+
 // TestNodeCache_UpsertNode_InvalidProviderID tests error handling for invalid providerIDs
 func TestNodeCache_UpsertNode_InvalidProviderID(t *testing.T) {
 	cache := NewNodeCache()
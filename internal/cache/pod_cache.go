@@ -0,0 +1,185 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// FargateProfileLabel is set on pods that EKS schedules onto Fargate
+	FargateProfileLabel = "eks.amazonaws.com/fargate-profile"
+
+	// FargateCapacityAnnotation records the vCPU and memory Fargate provisioned for
+	// a pod (e.g., "0.25vCPU 0.5GB")
+	FargateCapacityAnnotation = "CapacityProvisioned"
+)
+
+// PodInfo holds the scheduling and resource request data of a pod that cost
+// allocation needs. Full pod objects aren't kept to limit memory use.
+type PodInfo struct {
+	Namespace string
+	Name      string
+	NodeName  string
+
+	// CPUCores is the pod's effective CPU request in cores
+	CPUCores float64
+
+	// MemoryBytes is the pod's effective memory request in bytes
+	MemoryBytes float64
+
+	// Fargate is true for pods running on EKS Fargate, which are billed per pod
+	// rather than through an EC2 instance
+	Fargate bool
+
+	// FargateCapacity is the pod's FargateCapacityAnnotation, if set
+	FargateCapacity string
+}
+
+// PodCache maintains a thread-safe cache of the pods scheduled on each node and
+// their resource requests. It's used to split node costs among pods.
+//
+// Thread-safety: All public methods use read/write locks for safe concurrent access.
+//
+// Unlike the other caches, PodCache marks itself updated but doesn't notify
+// registered callbacks: pods change far more often than instances or prices, and a
+// steady stream of notifications would keep the cost debouncer from ever firing.
+// Pod costs are refreshed on the next cost calculation instead.
+type PodCache struct {
+	BaseCache // Provides: Lock/RLock, RegisterUpdateNotifier, NotifyUpdate, MarkUpdated, GetLastUpdate, etc.
+
+	// pods maps namespace/name → pod info
+	pods map[types.NamespacedName]PodInfo
+}
+
+// NewPodCache creates a new empty PodCache.
+func NewPodCache() *PodCache {
+	return &PodCache{
+		pods: make(map[types.NamespacedName]PodInfo),
+	}
+}
+
+// UpsertPod adds or updates a pod in the cache. Pods that aren't scheduled to a
+// node yet, or have finished (Succeeded or Failed), don't use node capacity and are
+// removed instead.
+func (c *PodCache) UpsertPod(pod *corev1.Pod) {
+	if pod == nil {
+		return
+	}
+	key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+
+	if pod.Spec.NodeName == "" ||
+		pod.Status.Phase == corev1.PodSucceeded ||
+		pod.Status.Phase == corev1.PodFailed {
+		c.DeletePod(key)
+		return
+	}
+
+	cpu, memory := podRequests(pod)
+
+	c.Lock()
+	defer c.Unlock()
+
+	_, fargate := pod.Labels[FargateProfileLabel]
+	c.pods[key] = PodInfo{
+		Namespace:       pod.Namespace,
+		Name:            pod.Name,
+		NodeName:        pod.Spec.NodeName,
+		CPUCores:        cpu,
+		MemoryBytes:     memory,
+		Fargate:         fargate,
+		FargateCapacity: pod.Annotations[FargateCapacityAnnotation],
+	}
+	c.MarkUpdated()
+}
+
+// DeletePod removes a pod from the cache.
+func (c *PodCache) DeletePod(key types.NamespacedName) {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, exists := c.pods[key]; !exists {
+		return
+	}
+	delete(c.pods, key)
+	c.MarkUpdated()
+}
+
+// GetPodsByNode returns the cached pods grouped by node name.
+func (c *PodCache) GetPodsByNode() map[string][]PodInfo {
+	c.RLock()
+	defer c.RUnlock()
+
+	byNode := make(map[string][]PodInfo)
+	for _, pod := range c.pods {
+		byNode[pod.NodeName] = append(byNode[pod.NodeName], pod)
+	}
+	return byNode
+}
+
+// GetFargatePods returns the cached pods running on EKS Fargate.
+func (c *PodCache) GetFargatePods() []PodInfo {
+	c.RLock()
+	defer c.RUnlock()
+
+	var pods []PodInfo
+	for _, pod := range c.pods {
+		if pod.Fargate {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// GetPodCount returns the number of pods currently in the cache.
+func (c *PodCache) GetPodCount() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return len(c.pods)
+}
+
+// podRequests returns a pod's effective CPU (cores) and memory (bytes) requests,
+// approximating what the scheduler reserves: the larger of the sum of the regular
+// and sidecar containers and the largest init container, plus pod overhead.
+func podRequests(pod *corev1.Pod) (cpu, memory float64) {
+	for _, container := range pod.Spec.Containers {
+		cpu += container.Resources.Requests.Cpu().AsApproximateFloat64()
+		memory += container.Resources.Requests.Memory().AsApproximateFloat64()
+	}
+
+	// Init containers run before the regular containers, so only the largest one
+	// counts. Sidecars (restartable init containers) keep running alongside them
+	// and count in full.
+	var initCPU, initMemory float64
+	for _, container := range pod.Spec.InitContainers {
+		containerCPU := container.Resources.Requests.Cpu().AsApproximateFloat64()
+		containerMemory := container.Resources.Requests.Memory().AsApproximateFloat64()
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			cpu += containerCPU
+			memory += containerMemory
+			continue
+		}
+		initCPU = max(initCPU, containerCPU)
+		initMemory = max(initMemory, containerMemory)
+	}
+	cpu = max(cpu, initCPU)
+	memory = max(memory, initMemory)
+
+	cpu += pod.Spec.Overhead.Cpu().AsApproximateFloat64()
+	memory += pod.Spec.Overhead.Memory().AsApproximateFloat64()
+	return cpu, memory
+}
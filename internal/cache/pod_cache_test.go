@@ -0,0 +1,189 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// testContainer returns a container requesting the given CPU and memory.
+func testContainer(cpu, memory string) corev1.Container {
+	return corev1.Container{
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+// testPod returns a running pod on nodeName with the given containers.
+func testPod(namespace, name, nodeName string, containers ...corev1.Container) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       corev1.PodSpec{NodeName: nodeName, Containers: containers},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+// TestPodCacheUpsertAndGroup tests that pods are grouped by node with summed requests.
+func TestPodCacheUpsertAndGroup(t *testing.T) {
+	c := NewPodCache()
+
+	c.UpsertPod(testPod("web", "frontend", "node-1", testContainer("500m", "1Gi"), testContainer("250m", "512Mi")))
+	c.UpsertPod(testPod("batch", "worker", "node-1", testContainer("2", "4Gi")))
+	c.UpsertPod(testPod("web", "api", "node-2", testContainer("1", "2Gi")))
+
+	assert.Equal(t, 3, c.GetPodCount())
+	assert.False(t, c.GetLastUpdate().IsZero())
+
+	byNode := c.GetPodsByNode()
+	require.Len(t, byNode["node-1"], 2)
+	require.Len(t, byNode["node-2"], 1)
+
+	for _, pod := range byNode["node-1"] {
+		if pod.Name == "frontend" {
+			assert.InDelta(t, 0.75, pod.CPUCores, 0.0001)
+			assert.InDelta(t, 1.5*1024*1024*1024, pod.MemoryBytes, 1)
+		}
+	}
+
+	// Moving a pod replaces its old entry
+	c.UpsertPod(testPod("web", "api", "node-1", testContainer("1", "2Gi")))
+	byNode = c.GetPodsByNode()
+	assert.Len(t, byNode["node-1"], 3)
+	assert.Empty(t, byNode["node-2"])
+}
+
+// TestPodCacheSkipsInactivePods tests that unscheduled and finished pods are not cached.
+func TestPodCacheSkipsInactivePods(t *testing.T) {
+	c := NewPodCache()
+
+	c.UpsertPod(testPod("web", "pending", "", testContainer("1", "1Gi")))
+	assert.Equal(t, 0, c.GetPodCount())
+
+	job := testPod("batch", "job", "node-1", testContainer("1", "1Gi"))
+	c.UpsertPod(job)
+	assert.Equal(t, 1, c.GetPodCount())
+
+	// A completed pod is removed
+	job.Status.Phase = corev1.PodSucceeded
+	c.UpsertPod(job)
+	assert.Equal(t, 0, c.GetPodCount())
+
+	c.UpsertPod(nil)
+	assert.Equal(t, 0, c.GetPodCount())
+}
+
+// TestPodCacheDeletePod tests removing pods by name.
+func TestPodCacheDeletePod(t *testing.T) {
+	c := NewPodCache()
+	c.UpsertPod(testPod("web", "frontend", "node-1", testContainer("1", "1Gi")))
+
+	c.DeletePod(types.NamespacedName{Namespace: "web", Name: "unknown"})
+	assert.Equal(t, 1, c.GetPodCount())
+
+	c.DeletePod(types.NamespacedName{Namespace: "web", Name: "frontend"})
+	assert.Equal(t, 0, c.GetPodCount())
+}
+
+// TestPodRequests tests effective request calculation with init containers,
+// sidecars, and pod overhead.
+func TestPodRequests(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	sidecar := testContainer("100m", "128Mi")
+	sidecar.RestartPolicy = &always
+
+	tests := []struct {
+		name       string
+		pod        *corev1.Pod
+		wantCPU    float64
+		wantMemory float64
+	}{
+		{
+			name:       "no requests",
+			pod:        testPod("ns", "pod", "node", corev1.Container{}),
+			wantCPU:    0,
+			wantMemory: 0,
+		},
+		{
+			name: "large init container dominates",
+			pod: func() *corev1.Pod {
+				p := testPod("ns", "pod", "node", testContainer("500m", "256Mi"))
+				p.Spec.InitContainers = []corev1.Container{testContainer("2", "128Mi")}
+				return p
+			}(),
+			wantCPU:    2,
+			wantMemory: 256 * 1024 * 1024,
+		},
+		{
+			name: "sidecar adds to containers",
+			pod: func() *corev1.Pod {
+				p := testPod("ns", "pod", "node", testContainer("500m", "256Mi"))
+				p.Spec.InitContainers = []corev1.Container{sidecar}
+				return p
+			}(),
+			wantCPU:    0.6,
+			wantMemory: 384 * 1024 * 1024,
+		},
+		{
+			name: "overhead is added",
+			pod: func() *corev1.Pod {
+				p := testPod("ns", "pod", "node", testContainer("1", "1Gi"))
+				p.Spec.Overhead = corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("250m"),
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				}
+				return p
+			}(),
+			wantCPU:    1.25,
+			wantMemory: (1024 + 64) * 1024 * 1024,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, memory := podRequests(tt.pod)
+			assert.InDelta(t, tt.wantCPU, cpu, 0.0001)
+			assert.InDelta(t, tt.wantMemory, memory, 1)
+		})
+	}
+}
+
+// TestPodCacheGetFargatePods tests that pods on EKS Fargate are reported with their
+// provisioned capacity.
+func TestPodCacheGetFargatePods(t *testing.T) {
+	c := NewPodCache()
+
+	fargatePod := testPod("web", "api", "fargate-ip-10-0-1-2.us-west-2.compute.internal", testContainer("250m", "256Mi"))
+	fargatePod.Labels = map[string]string{FargateProfileLabel: "default"}
+	fargatePod.Annotations = map[string]string{FargateCapacityAnnotation: "0.25vCPU 0.5GB"}
+	c.UpsertPod(fargatePod)
+	c.UpsertPod(testPod("web", "frontend", "node-1", testContainer("500m", "1Gi")))
+
+	pods := c.GetFargatePods()
+	require.Len(t, pods, 1)
+	assert.Equal(t, "api", pods[0].Name)
+	assert.True(t, pods[0].Fargate)
+	assert.Equal(t, "0.25vCPU 0.5GB", pods[0].FargateCapacity)
+}
@@ -90,9 +90,11 @@ func parseSPRateKey(key string) (instanceType, region, tenancy, os string, ok bo
 type PricingCache struct {
 	BaseCache // Provides: Lock/RLock, RegisterUpdateNotifier, NotifyUpdate, MarkUpdated, GetLastUpdate, etc.
 
-	// onDemandPrices stores on-demand pricing keyed by "region:instanceType:os"
+	// onDemandPrices stores on-demand pricing keyed by "region:instanceType:os" for
+	// shared tenancy and "region:instanceType:os:tenancy" for dedicated/host tenancy.
 	// All keys are lowercase for case-insensitive lookups.
-	// Example key: "us-west-2:m5.xlarge:linux" → 0.192
+	// Example keys: "us-west-2:m5.xlarge:linux" → 0.192
+	//               "us-west-2:m5.xlarge:linux:dedicated" → 0.211
 	onDemandPrices map[string]float64
 
 	// spRates stores actual Savings Plan rates keyed by "spArn:instanceType:region"
@@ -109,6 +111,11 @@ type PricingCache struct {
 	// We store the full SpotPrice struct to preserve individual timestamps per price.
 	spotPrices map[string]aws.SpotPrice
 
+	// ebsPrices stores EBS volume pricing keyed by "region:volumeType".
+	// All keys are lowercase for case-insensitive lookups.
+	// Example key: "us-west-2:gp3" → aws.EBSPrice{PerGBMonth: 0.08, ...}
+	ebsPrices map[string]aws.EBSPrice
+
 	// Domain-specific metadata
 	// spRatesLastUpdated tracks when SP rates were last updated (separate from on-demand prices)
 	spRatesLastUpdated time.Time
@@ -127,12 +134,13 @@ func NewPricingCache() *PricingCache {
 		onDemandPrices:  make(map[string]float64),
 		spRates:         make(map[string]float64),
 		spotPrices:      make(map[string]aws.SpotPrice),
+		ebsPrices:       make(map[string]aws.EBSPrice),
 		isPopulated:     false,
 		spotIsPopulated: false,
 	}
 }
 
-// GetOnDemandPrice returns the on-demand price for an instance type in a region.
+// GetOnDemandPrice returns the shared tenancy on-demand price for an instance type in a region.
 // Returns the price and true if found, or 0 and false if not found.
 //
 // This is an O(1) lookup operation.
@@ -150,8 +158,9 @@ func (c *PricingCache) GetOnDemandPrice(region, instanceType, operatingSystem st
 // SetOnDemandPrices replaces all on-demand pricing data in the cache.
 // This is typically called by the pricing reconciler after bulk-loading data.
 //
-// The input map should use keys in the format "region:instanceType:os".
-// All keys are normalized to lowercase for case-insensitive lookups.
+// The input map should use keys in the format "region:instanceType:os", with a
+// ":tenancy" suffix for dedicated and host tenancy prices (as returned by
+// aws.PricingClient.LoadAllPricing). All keys are normalized to lowercase for case-insensitive lookups.
 func (c *PricingCache) SetOnDemandPrices(prices map[string]float64) {
 	c.Lock() // From BaseCache
 	// Normalize all keys to lowercase for consistent lookups
@@ -174,6 +183,35 @@ func (c *PricingCache) SetOnDemandPrices(prices map[string]float64) {
 //
 // This is typically used to trigger cost recalculation when pricing data changes.
 
+// SetEBSPrices replaces all EBS volume pricing data in the cache.
+// The input map should use keys in the format "region:volumeType" (as returned by
+// aws.PricingClient.LoadEBSPricing).
+func (c *PricingCache) SetEBSPrices(prices map[string]aws.EBSPrice) {
+	c.Lock() // From BaseCache
+	normalizedPrices := make(map[string]aws.EBSPrice, len(prices))
+	for key, price := range prices {
+		normalizedPrices[strings.ToLower(key)] = price
+	}
+	c.ebsPrices = normalizedPrices
+	c.Unlock()
+
+	// Notify subscribers AFTER releasing the write lock to prevent deadlock
+	c.NotifyUpdate() // From BaseCache
+}
+
+// GetAllEBSPrices returns a copy of all EBS volume prices, keyed by "region:volumeType".
+// This is useful for populating CalculationInput.EBSPrices.
+func (c *PricingCache) GetAllEBSPrices() map[string]aws.EBSPrice {
+	c.RLock() // From BaseCache
+	defer c.RUnlock()
+
+	result := make(map[string]aws.EBSPrice, len(c.ebsPrices))
+	for k, v := range c.ebsPrices {
+		result[k] = v
+	}
+	return result
+}
+
 // GetAllOnDemandPrices returns a copy of all on-demand prices.
 // This is useful for populating CalculationInput.OnDemandPrices.
 func (c *PricingCache) GetAllOnDemandPrices() map[string]float64 {
@@ -189,27 +227,27 @@ func (c *PricingCache) GetAllOnDemandPrices() map[string]float64 {
 }
 
 // GetOnDemandPricesForInstances returns pricing data only for the specified
-// instance types and regions. This is more efficient than GetAllOnDemandPrices
-// when you only need a subset of the data.
+// instance types, regions, operating systems, and tenancies. This is more efficient
+// than GetAllOnDemandPrices when you only need a subset of the data.
 //
 // Uses OnDemandKey to ensure compile-time type safety - callers must provide
-// InstanceType and Region for each instance.
+// InstanceType, Region, and OperatingSystem for each instance.
 //
-// Returns a map keyed by "instanceType:region" (without OS) for easier lookup
-// by the cost calculator.
-func (c *PricingCache) GetOnDemandPricesForInstances(
-	instances []OnDemandKey,
-	operatingSystem string,
-) map[string]float64 {
+// Returns a map keyed by "instanceType:region:os" (lowercase), with a ":tenancy"
+// suffix for dedicated and host tenancy, for easier lookup by the cost calculator
+// (see cost.OnDemandPriceKey).
+func (c *PricingCache) GetOnDemandPricesForInstances(instances []OnDemandKey) map[string]float64 {
 	c.RLock() // From BaseCache
 	defer c.RUnlock()
 
 	result := make(map[string]float64, len(instances))
 	for _, inst := range instances {
-		key := BuildKey(":", inst.Region, inst.InstanceType, operatingSystem)
+		// Shared tenancy has no key segment (BuildKey skips empty parts)
+		tenancy := onDemandTenancy(inst.Tenancy)
+		key := BuildKey(":", inst.Region, inst.InstanceType, inst.OperatingSystem, tenancy)
 		if price, exists := c.onDemandPrices[key]; exists {
-			// Return with simplified key for calculator
-			resultKey := BuildKey(":", inst.InstanceType, inst.Region)
+			// Return with calculator key ordering
+			resultKey := BuildKey(":", inst.InstanceType, inst.Region, inst.OperatingSystem, tenancy)
 			result[resultKey] = price
 		}
 	}
@@ -222,6 +260,23 @@ func (c *PricingCache) GetOnDemandPricesForInstances(
 type OnDemandKey struct {
 	InstanceType string
 	Region       string
+	// OperatingSystem must match the pricing OS used when loading prices
+	// (e.g., "linux", "windows", "rhel"). Lookups are case-insensitive.
+	OperatingSystem string
+	// Tenancy is the EC2 instance tenancy ("default", "dedicated", "host").
+	// Empty is treated as "default" (shared tenancy).
+	Tenancy string
+}
+
+// onDemandTenancy returns the on-demand price key segment for an EC2 tenancy value.
+// Shared tenancy prices are stored without a tenancy segment, so "default" maps to "".
+func onDemandTenancy(tenancy string) string {
+	switch strings.ToLower(tenancy) {
+	case aws.TenancyDefault, aws.TenancyShared:
+		return ""
+	default:
+		return tenancy
+	}
 }
 
 // SpotPriceKey represents the required fields for looking up spot pricing.
@@ -259,6 +314,7 @@ func (c *PricingCache) GetStats() PricingStats {
 	lastUpdate := c.lastUpdate
 	return PricingStats{
 		OnDemandPriceCount: len(c.onDemandPrices),
+		EBSPriceCount:      len(c.ebsPrices),
 		LastUpdated:        lastUpdate,
 		IsPopulated:        c.isPopulated,
 		AgeHours:           time.Since(lastUpdate).Hours(),
@@ -268,6 +324,7 @@ func (c *PricingCache) GetStats() PricingStats {
 // PricingStats contains statistics about the pricing cache.
 type PricingStats struct {
 	OnDemandPriceCount int
+	EBSPriceCount      int
 	LastUpdated        time.Time
 	IsPopulated        bool
 	AgeHours           float64
@@ -121,6 +121,28 @@ func TestGetAllOnDemandPrices(t *testing.T) {
 	}
 }
 
+// TestSetEBSPrices tests storing EBS prices with case-insensitive keys.
+func TestSetEBSPrices(t *testing.T) {
+	cache := NewPricingCache()
+	cache.SetEBSPrices(map[string]aws.EBSPrice{
+		"US-WEST-2:gp3": {VolumeType: "gp3", Region: "us-west-2", PerGBMonth: 0.08},
+	})
+
+	prices := cache.GetAllEBSPrices()
+	if got := prices["us-west-2:gp3"].PerGBMonth; got != 0.08 {
+		t.Errorf("expected gp3 price 0.08, got %v", got)
+	}
+	if got := cache.GetStats().EBSPriceCount; got != 1 {
+		t.Errorf("expected 1 EBS price, got %d", got)
+	}
+
+	// Verify it's a copy (modifications don't affect cache)
+	prices["us-east-1:gp3"] = aws.EBSPrice{}
+	if len(cache.GetAllEBSPrices()) != 1 {
+		t.Error("external modifications should not affect cache")
+	}
+}
+
 // TestGetOnDemandPricesForInstances tests filtered price retrieval.
 func TestGetOnDemandPricesForInstances(t *testing.T) {
 	cache := NewPricingCache()
@@ -134,30 +156,74 @@ func TestGetOnDemandPricesForInstances(t *testing.T) {
 	cache.SetOnDemandPrices(prices)
 
 	instances := []OnDemandKey{
-		{InstanceType: "m5.xlarge", Region: "us-west-2"},
-		{InstanceType: "c5.2xlarge", Region: "us-west-2"},
-		{InstanceType: "r5.large", Region: "us-west-2"}, // Not in cache
+		{InstanceType: "m5.xlarge", Region: "us-west-2", OperatingSystem: "linux"},
+		{InstanceType: "c5.2xlarge", Region: "us-west-2", OperatingSystem: "Linux"},
+		{InstanceType: "m5.xlarge", Region: "us-east-1", OperatingSystem: "windows"},
+		{InstanceType: "r5.large", Region: "us-west-2", OperatingSystem: "linux"},     // Not in cache
+		{InstanceType: "c5.2xlarge", Region: "us-west-2", OperatingSystem: "windows"}, // OS not in cache
 	}
 
-	filtered := cache.GetOnDemandPricesForInstances(instances, "Linux")
+	filtered := cache.GetOnDemandPricesForInstances(instances)
 
-	if len(filtered) != 2 {
-		t.Errorf("expected 2 filtered prices, got %d", len(filtered))
+	if len(filtered) != 3 {
+		t.Errorf("expected 3 filtered prices, got %d", len(filtered))
 	}
 
-	// Check result key format (instanceType:region)
-	if price, exists := filtered["m5.xlarge:us-west-2"]; !exists || price != 0.192 {
+	// Check result key format (instanceType:region:os)
+	if price, exists := filtered["m5.xlarge:us-west-2:linux"]; !exists || price != 0.192 {
 		t.Errorf("expected m5.xlarge price 0.192, got %.4f (exists: %v)", price, exists)
 	}
 
-	if price, exists := filtered["c5.2xlarge:us-west-2"]; !exists || price != 0.34 {
+	if price, exists := filtered["c5.2xlarge:us-west-2:linux"]; !exists || price != 0.34 {
 		t.Errorf("expected c5.2xlarge price 0.34, got %.4f (exists: %v)", price, exists)
 	}
 
+	// Each instance gets the price for its own OS
+	if price, exists := filtered["m5.xlarge:us-east-1:windows"]; !exists || price != 0.384 {
+		t.Errorf("expected m5.xlarge Windows price 0.384, got %.4f (exists: %v)", price, exists)
+	}
+
 	// Should not include r5.large (not in cache)
-	if _, exists := filtered["r5.large:us-west-2"]; exists {
+	if _, exists := filtered["r5.large:us-west-2:linux"]; exists {
 		t.Error("should not include instances not in cache")
 	}
+
+	// Should not substitute another OS's price
+	if _, exists := filtered["c5.2xlarge:us-west-2:windows"]; exists {
+		t.Error("should not include prices for an OS that isn't cached")
+	}
+}
+
+// TestGetOnDemandPricesForInstancesTenancy tests that dedicated and host instances
+// get their tenancy's price while default tenancy uses the shared price.
+func TestGetOnDemandPricesForInstancesTenancy(t *testing.T) {
+	cache := NewPricingCache()
+
+	cache.SetOnDemandPrices(map[string]float64{
+		"us-west-2:m5.xlarge:Linux":           0.192,
+		"us-west-2:m5.xlarge:Linux:Dedicated": 0.211,
+		"us-west-2:m5.xlarge:Linux:Host":      0.0,
+	})
+
+	filtered := cache.GetOnDemandPricesForInstances([]OnDemandKey{
+		{InstanceType: "m5.xlarge", Region: "us-west-2", OperatingSystem: "linux", Tenancy: "default"},
+		{InstanceType: "m5.xlarge", Region: "us-west-2", OperatingSystem: "linux", Tenancy: "dedicated"},
+		{InstanceType: "m5.xlarge", Region: "us-west-2", OperatingSystem: "linux", Tenancy: "host"},
+		{InstanceType: "m5.xlarge", Region: "us-west-2", OperatingSystem: "windows", Tenancy: "dedicated"}, // Not in cache
+	})
+
+	if len(filtered) != 3 {
+		t.Errorf("expected 3 filtered prices, got %d", len(filtered))
+	}
+	if price, exists := filtered["m5.xlarge:us-west-2:linux"]; !exists || price != 0.192 {
+		t.Errorf("expected shared price 0.192, got %.4f (exists: %v)", price, exists)
+	}
+	if price, exists := filtered["m5.xlarge:us-west-2:linux:dedicated"]; !exists || price != 0.211 {
+		t.Errorf("expected dedicated price 0.211, got %.4f (exists: %v)", price, exists)
+	}
+	if _, exists := filtered["m5.xlarge:us-west-2:linux:host"]; !exists {
+		t.Error("expected host price to be returned")
+	}
 }
 
 // TestGetSpotPricesForInstances tests filtered spot price retrieval.
@@ -38,6 +38,10 @@ type RISPCache struct {
 	// Structure: map[region]map[accountID][]ReservedInstance
 	reservedInstances map[string]map[string][]aws.ReservedInstance
 
+	// Capacity Reservations indexed by region then account ID, like RIs
+	// Structure: map[region]map[accountID][]CapacityReservation
+	capacityReservations map[string]map[string][]aws.CapacityReservation
+
 	// Savings Plans indexed by account ID
 	// Savings Plans are organization-wide (not regional)
 	// Structure: map[accountID][]SavingsPlan
@@ -51,10 +55,11 @@ type RISPCache struct {
 // NewRISPCache creates a new empty RI/SP cache.
 func NewRISPCache() *RISPCache {
 	return &RISPCache{
-		BaseCache:         NewBaseCache(),
-		reservedInstances: make(map[string]map[string][]aws.ReservedInstance),
-		savingsPlans:      make(map[string][]aws.SavingsPlan),
-		freshness:         make(map[string]time.Time),
+		BaseCache:            NewBaseCache(),
+		reservedInstances:    make(map[string]map[string][]aws.ReservedInstance),
+		capacityReservations: make(map[string]map[string][]aws.CapacityReservation),
+		savingsPlans:         make(map[string][]aws.SavingsPlan),
+		freshness:            make(map[string]time.Time),
 	}
 }
 
@@ -81,6 +86,24 @@ func (c *RISPCache) UpdateReservedInstances(region, accountID string, ris []aws.
 	c.NotifyUpdate() // From BaseCache
 }
 
+// UpdateCapacityReservations atomically replaces all Capacity Reservation data for a
+// region/account. This should be called after successfully querying AWS APIs.
+func (c *RISPCache) UpdateCapacityReservations(region, accountID string, crs []aws.CapacityReservation) {
+	c.Lock() // From BaseCache
+	defer c.Unlock()
+
+	if c.capacityReservations[region] == nil {
+		c.capacityReservations[region] = make(map[string][]aws.CapacityReservation)
+	}
+	c.capacityReservations[region][accountID] = crs
+
+	key := BuildKey(":", region, accountID, "cr")
+	c.freshness[key] = time.Now()
+	c.MarkUpdated() // From BaseCache
+
+	c.NotifyUpdate() // From BaseCache
+}
+
 // UpdateSavingsPlans atomically replaces all SP data for an account.
 // This should be called after successfully querying AWS APIs.
 func (c *RISPCache) UpdateSavingsPlans(accountID string, sps []aws.SavingsPlan) {
@@ -99,6 +122,33 @@ func (c *RISPCache) UpdateSavingsPlans(accountID string, sps []aws.SavingsPlan)
 	c.NotifyUpdate() // From BaseCache
 }
 
+// RemoveAccount removes all RI, Capacity Reservation and SP data of an account, e.g. when the account
+// is no longer monitored, and notifies subscribers.
+func (c *RISPCache) RemoveAccount(accountID string) {
+	c.Lock() // From BaseCache
+	defer c.Unlock()
+
+	for region, accounts := range c.reservedInstances {
+		delete(accounts, accountID)
+		delete(c.freshness, BuildKey(":", region, accountID, "ri"))
+		if len(accounts) == 0 {
+			delete(c.reservedInstances, region)
+		}
+	}
+	for region, accounts := range c.capacityReservations {
+		delete(accounts, accountID)
+		delete(c.freshness, BuildKey(":", region, accountID, "cr"))
+		if len(accounts) == 0 {
+			delete(c.capacityReservations, region)
+		}
+	}
+	delete(c.savingsPlans, accountID)
+	delete(c.freshness, BuildKey(":", accountID, "sp"))
+
+	c.MarkUpdated()  // From BaseCache
+	c.NotifyUpdate() // From BaseCache
+}
+
 // RegisterUpdateNotifier is inherited from BaseCache.
 // Multiple notifiers can be registered. Callbacks are invoked in separate goroutines
 // to prevent blocking cache operations.
@@ -139,6 +189,22 @@ func (c *RISPCache) GetAllReservedInstances() []aws.ReservedInstance {
 	return all
 }
 
+// GetAllCapacityReservations returns all Capacity Reservations across all regions
+// and accounts. Returns empty slice if no data exists.
+func (c *RISPCache) GetAllCapacityReservations() []aws.CapacityReservation {
+	c.RLock() // From BaseCache
+	defer c.RUnlock()
+
+	var all []aws.CapacityReservation
+	for _, regionMap := range c.capacityReservations {
+		for _, crs := range regionMap {
+			all = append(all, crs...)
+		}
+	}
+
+	return all
+}
+
 // GetSavingsPlans returns all SPs for a specific account.
 // Returns empty slice if no data exists (never returns nil).
 func (c *RISPCache) GetSavingsPlans(accountID string) []aws.SavingsPlan {
@@ -198,6 +264,13 @@ func (c *RISPCache) GetStats() CacheStats {
 		}
 	}
 
+	crCount := 0
+	for _, regionMap := range c.capacityReservations {
+		for _, crs := range regionMap {
+			crCount += len(crs)
+		}
+	}
+
 	spCount := 0
 	for _, sps := range c.savingsPlans {
 		spCount += len(sps)
@@ -205,19 +278,21 @@ func (c *RISPCache) GetStats() CacheStats {
 
 	// Access lastUpdate directly to avoid double-locking (GetLastUpdate acquires its own lock)
 	return CacheStats{
-		ReservedInstanceCount: riCount,
-		SavingsPlanCount:      spCount,
-		LastUpdate:            c.lastUpdate,
-		RegionCount:           len(c.reservedInstances),
-		AccountCount:          len(c.savingsPlans),
+		ReservedInstanceCount:    riCount,
+		CapacityReservationCount: crCount,
+		SavingsPlanCount:         spCount,
+		LastUpdate:               c.lastUpdate,
+		RegionCount:              len(c.reservedInstances),
+		AccountCount:             len(c.savingsPlans),
 	}
 }
 
 // CacheStats contains statistics about the cache contents.
 type CacheStats struct {
-	ReservedInstanceCount int
-	SavingsPlanCount      int
-	LastUpdate            time.Time
-	RegionCount           int
-	AccountCount          int
+	ReservedInstanceCount    int
+	CapacityReservationCount int
+	SavingsPlanCount         int
+	LastUpdate               time.Time
+	RegionCount              int
+	AccountCount             int
 }
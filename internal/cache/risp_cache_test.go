@@ -304,6 +304,9 @@ func TestGetStats(t *testing.T) {
 	cache.UpdateReservedInstances("us-east-1", "111111111111", []aws.ReservedInstance{
 		{ReservedInstanceID: "ri-3"},
 	})
+	cache.UpdateCapacityReservations("us-west-2", "111111111111", []aws.CapacityReservation{
+		{CapacityReservationID: "cr-1"},
+	})
 	cache.UpdateSavingsPlans("111111111111", []aws.SavingsPlan{
 		{SavingsPlanARN: "arn:sp1"},
 	})
@@ -315,12 +318,39 @@ func TestGetStats(t *testing.T) {
 	// Verify stats
 	stats = cache.GetStats()
 	assert.Equal(t, 3, stats.ReservedInstanceCount)
+	assert.Equal(t, 1, stats.CapacityReservationCount)
 	assert.Equal(t, 3, stats.SavingsPlanCount)
 	assert.Equal(t, 2, stats.RegionCount)
 	assert.Equal(t, 2, stats.AccountCount)
 	assert.False(t, stats.LastUpdate.IsZero())
 }
 
+// TestRemoveAccount_RISP verifies that an account's RIs, Capacity Reservations, SPs,
+// and freshness are dropped.
+func TestRemoveAccount_RISP(t *testing.T) {
+	cache := NewRISPCache()
+	cache.UpdateReservedInstances("us-west-2", "111111111111", []aws.ReservedInstance{{ReservedInstanceID: "ri-1"}})
+	cache.UpdateReservedInstances("us-west-2", "222222222222", []aws.ReservedInstance{{ReservedInstanceID: "ri-2"}})
+	cache.UpdateReservedInstances("us-east-1", "111111111111", []aws.ReservedInstance{{ReservedInstanceID: "ri-3"}})
+	cache.UpdateCapacityReservations("us-east-1", "111111111111",
+		[]aws.CapacityReservation{{CapacityReservationID: "cr-1"}})
+	cache.UpdateSavingsPlans("111111111111", []aws.SavingsPlan{{SavingsPlanARN: "arn:sp1"}})
+	cache.UpdateSavingsPlans("222222222222", []aws.SavingsPlan{{SavingsPlanARN: "arn:sp2"}})
+
+	cache.RemoveAccount("111111111111")
+
+	stats := cache.GetStats()
+	assert.Equal(t, 1, stats.ReservedInstanceCount)
+	assert.Equal(t, 1, stats.SavingsPlanCount)
+	assert.Equal(t, 1, stats.RegionCount, "regions left without RIs should be dropped")
+	assert.Empty(t, cache.GetSavingsPlans("111111111111"))
+	assert.Empty(t, cache.GetAllCapacityReservations())
+	assert.True(t, cache.GetFreshness("us-west-2:111111111111:ri").IsZero())
+	assert.True(t, cache.GetFreshness("us-east-1:111111111111:cr").IsZero())
+	assert.True(t, cache.GetFreshness("111111111111:sp").IsZero())
+	assert.False(t, cache.GetFreshness("222222222222:sp").IsZero())
+}
+
 // TestGetFreshness_NotFound verifies behavior when freshness not tracked.
 func TestGetFreshness_NotFound(t *testing.T) {
 	cache := NewRISPCache()
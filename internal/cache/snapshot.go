@@ -0,0 +1,338 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+)
+
+// SnapshotVersion is the current snapshot format version. Snapshots written with a
+// different version are rejected on load rather than partially restored.
+const SnapshotVersion = 1
+
+// Snapshot is a point-in-time copy of the EC2, RI/SP, and pricing caches, persisted
+// so a restarted controller can publish cost metrics before its first AWS refresh
+// completes. All timestamps are preserved, so restored data ages (and is refreshed)
+// exactly as it would have in the previous process.
+type Snapshot struct {
+	Version int       `json:"version"`
+	SavedAt time.Time `json:"savedAt"`
+
+	EC2     EC2Snapshot     `json:"ec2"`
+	RISP    RISPSnapshot    `json:"risp"`
+	Pricing PricingSnapshot `json:"pricing"`
+}
+
+// EC2Snapshot holds the contents of an EC2Cache.
+type EC2Snapshot struct {
+	Instances []aws.Instance `json:"instances"`
+	// Volumes are the attached EBS volumes, each listed once
+	Volumes    []aws.Volume `json:"volumes,omitempty"`
+	LastUpdate time.Time    `json:"lastUpdate"`
+}
+
+// RISPSnapshot holds the contents of a RISPCache.
+type RISPSnapshot struct {
+	// ReservedInstances is keyed by region, then account ID
+	ReservedInstances map[string]map[string][]aws.ReservedInstance `json:"reservedInstances"`
+	// CapacityReservations is keyed by region, then account ID
+	CapacityReservations map[string]map[string][]aws.CapacityReservation `json:"capacityReservations"`
+	// SavingsPlans is keyed by account ID
+	SavingsPlans map[string][]aws.SavingsPlan `json:"savingsPlans"`
+	Freshness    map[string]time.Time         `json:"freshness"`
+	LastUpdate   time.Time                    `json:"lastUpdate"`
+}
+
+// PricingSnapshot holds the contents of a PricingCache, including Savings Plan rates.
+type PricingSnapshot struct {
+	OnDemandPrices     map[string]float64       `json:"onDemandPrices"`
+	SPRates            map[string]float64       `json:"spRates"`
+	SpotPrices         map[string]aws.SpotPrice `json:"spotPrices"`
+	EBSPrices          map[string]aws.EBSPrice  `json:"ebsPrices,omitempty"`
+	SPRatesLastUpdated time.Time                `json:"spRatesLastUpdated"`
+	SpotLastUpdated    time.Time                `json:"spotLastUpdated"`
+	LastUpdate         time.Time                `json:"lastUpdate"`
+}
+
+// NewSnapshot captures the current contents of the given caches.
+func NewSnapshot(ec2Cache *EC2Cache, rispCache *RISPCache, pricingCache *PricingCache) *Snapshot {
+	return &Snapshot{
+		Version: SnapshotVersion,
+		SavedAt: time.Now(),
+		EC2:     ec2Cache.snapshot(),
+		RISP:    rispCache.snapshot(),
+		Pricing: pricingCache.snapshot(),
+	}
+}
+
+// IsComplete returns true if every cache had completed at least one load when the
+// snapshot was taken. Restoring an incomplete snapshot would let cost calculations
+// run with partial data, so incomplete snapshots shouldn't be saved.
+func (s *Snapshot) IsComplete() bool {
+	return !s.EC2.LastUpdate.IsZero() &&
+		!s.RISP.LastUpdate.IsZero() &&
+		len(s.Pricing.OnDemandPrices) > 0
+}
+
+// RetainAccounts drops instances, Reserved Instances, and Savings Plans belonging to
+// accounts that aren't in accountIDs. The caches are refreshed per account, so data
+// for an account removed from the configuration would otherwise never be replaced.
+func (s *Snapshot) RetainAccounts(accountIDs []string) {
+	s.EC2.Instances = slices.DeleteFunc(s.EC2.Instances, func(inst aws.Instance) bool {
+		return !slices.Contains(accountIDs, inst.AccountID)
+	})
+	s.EC2.Volumes = slices.DeleteFunc(s.EC2.Volumes, func(vol aws.Volume) bool {
+		return !slices.Contains(accountIDs, vol.AccountID)
+	})
+	for _, byAccount := range s.RISP.ReservedInstances {
+		for accountID := range byAccount {
+			if !slices.Contains(accountIDs, accountID) {
+				delete(byAccount, accountID)
+			}
+		}
+	}
+	for _, byAccount := range s.RISP.CapacityReservations {
+		for accountID := range byAccount {
+			if !slices.Contains(accountIDs, accountID) {
+				delete(byAccount, accountID)
+			}
+		}
+	}
+	for accountID := range s.RISP.SavingsPlans {
+		if !slices.Contains(accountIDs, accountID) {
+			delete(s.RISP.SavingsPlans, accountID)
+		}
+	}
+}
+
+// Restore replaces the contents of the given caches with the snapshot data.
+//
+// Update notifiers aren't called: restoring happens at startup, before the cost
+// reconciler runs its first calculation.
+func (s *Snapshot) Restore(ec2Cache *EC2Cache, rispCache *RISPCache, pricingCache *PricingCache) {
+	ec2Cache.restore(s.EC2)
+	rispCache.restore(s.RISP)
+	pricingCache.restore(s.Pricing)
+}
+
+// SaveSnapshot writes a snapshot to path as gzipped JSON. The file is written to a
+// temporary file first and renamed into place, so a crash mid-write never leaves a
+// truncated snapshot behind.
+func SaveSnapshot(path string, snapshot *Snapshot) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	// Cleanup for the failure paths; after a successful rename this is a no-op
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	gz := gzip.NewWriter(tmp)
+	if err := json.NewEncoder(gz).Encode(snapshot); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace snapshot file: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot reads a snapshot written by SaveSnapshot.
+// Returns an error satisfying errors.Is(err, os.ErrNotExist) if there is no snapshot.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", path, err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(gz).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %w", path, err)
+	}
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d in %s (expected %d)",
+			snapshot.Version, path, SnapshotVersion)
+	}
+	return &snapshot, nil
+}
+
+// snapshot returns a copy of the cached instances.
+func (c *EC2Cache) snapshot() EC2Snapshot {
+	c.RLock()
+	defer c.RUnlock()
+
+	instances := make([]aws.Instance, 0, len(c.instances))
+	for _, inst := range c.instances {
+		instances = append(instances, *inst)
+	}
+
+	// Multi-Attach volumes are cached under each instance but saved once
+	var volumes []aws.Volume
+	seen := make(map[string]bool)
+	for _, vols := range c.volumes {
+		for _, vol := range vols {
+			if !seen[vol.VolumeID] {
+				seen[vol.VolumeID] = true
+				volumes = append(volumes, vol)
+			}
+		}
+	}
+	return EC2Snapshot{Instances: instances, Volumes: volumes, LastUpdate: c.lastUpdate}
+}
+
+// restore replaces the cached instances with the snapshot contents.
+func (c *EC2Cache) restore(s EC2Snapshot) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.instances = make(map[string]*aws.Instance, len(s.Instances))
+	for i := range s.Instances {
+		c.instances[s.Instances[i].InstanceID] = &s.Instances[i]
+	}
+	c.volumes = make(map[string][]aws.Volume)
+	c.addVolumes(s.Volumes)
+	c.lastUpdate = s.LastUpdate
+}
+
+// snapshot returns a copy of the cached RI/SP data.
+func (c *RISPCache) snapshot() RISPSnapshot {
+	c.RLock()
+	defer c.RUnlock()
+
+	ris := make(map[string]map[string][]aws.ReservedInstance, len(c.reservedInstances))
+	for region, byAccount := range c.reservedInstances {
+		ris[region] = make(map[string][]aws.ReservedInstance, len(byAccount))
+		for accountID, list := range byAccount {
+			ris[region][accountID] = slices.Clone(list)
+		}
+	}
+	crs := make(map[string]map[string][]aws.CapacityReservation, len(c.capacityReservations))
+	for region, byAccount := range c.capacityReservations {
+		crs[region] = make(map[string][]aws.CapacityReservation, len(byAccount))
+		for accountID, list := range byAccount {
+			crs[region][accountID] = slices.Clone(list)
+		}
+	}
+	sps := make(map[string][]aws.SavingsPlan, len(c.savingsPlans))
+	for accountID, list := range c.savingsPlans {
+		sps[accountID] = slices.Clone(list)
+	}
+
+	return RISPSnapshot{
+		ReservedInstances:    ris,
+		CapacityReservations: crs,
+		SavingsPlans:         sps,
+		Freshness:            maps.Clone(c.freshness),
+		LastUpdate:           c.lastUpdate,
+	}
+}
+
+// restore replaces the cached RI/SP data with the snapshot contents.
+func (c *RISPCache) restore(s RISPSnapshot) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.reservedInstances = make(map[string]map[string][]aws.ReservedInstance, len(s.ReservedInstances))
+	for region, byAccount := range s.ReservedInstances {
+		c.reservedInstances[region] = make(map[string][]aws.ReservedInstance, len(byAccount))
+		for accountID, list := range byAccount {
+			c.reservedInstances[region][accountID] = list
+		}
+	}
+	c.capacityReservations = make(map[string]map[string][]aws.CapacityReservation, len(s.CapacityReservations))
+	for region, byAccount := range s.CapacityReservations {
+		c.capacityReservations[region] = make(map[string][]aws.CapacityReservation, len(byAccount))
+		for accountID, list := range byAccount {
+			c.capacityReservations[region][accountID] = list
+		}
+	}
+	c.savingsPlans = make(map[string][]aws.SavingsPlan, len(s.SavingsPlans))
+	for accountID, list := range s.SavingsPlans {
+		c.savingsPlans[accountID] = list
+	}
+	c.freshness = make(map[string]time.Time, len(s.Freshness))
+	for key, t := range s.Freshness {
+		c.freshness[key] = t
+	}
+	c.lastUpdate = s.LastUpdate
+}
+
+// snapshot returns a copy of the cached prices and Savings Plan rates.
+func (c *PricingCache) snapshot() PricingSnapshot {
+	c.RLock()
+	defer c.RUnlock()
+
+	return PricingSnapshot{
+		OnDemandPrices:     maps.Clone(c.onDemandPrices),
+		SPRates:            maps.Clone(c.spRates),
+		SpotPrices:         maps.Clone(c.spotPrices),
+		EBSPrices:          maps.Clone(c.ebsPrices),
+		SPRatesLastUpdated: c.spRatesLastUpdated,
+		SpotLastUpdated:    c.spotLastUpdated,
+		LastUpdate:         c.lastUpdate,
+	}
+}
+
+// restore replaces the cached prices and Savings Plan rates with the snapshot contents.
+func (c *PricingCache) restore(s PricingSnapshot) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.onDemandPrices = make(map[string]float64, len(s.OnDemandPrices))
+	for key, price := range s.OnDemandPrices {
+		c.onDemandPrices[key] = price
+	}
+	c.spRates = make(map[string]float64, len(s.SPRates))
+	for key, rate := range s.SPRates {
+		c.spRates[key] = rate
+	}
+	c.spotPrices = make(map[string]aws.SpotPrice, len(s.SpotPrices))
+	for key, price := range s.SpotPrices {
+		c.spotPrices[key] = price
+	}
+	c.ebsPrices = make(map[string]aws.EBSPrice, len(s.EBSPrices))
+	for key, price := range s.EBSPrices {
+		c.ebsPrices[key] = price
+	}
+	c.spRatesLastUpdated = s.SPRatesLastUpdated
+	c.spotLastUpdated = s.SpotLastUpdated
+	c.lastUpdate = s.LastUpdate
+	c.isPopulated = len(c.onDemandPrices) > 0
+	c.spotIsPopulated = len(c.spotPrices) > 0
+}
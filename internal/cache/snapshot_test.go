@@ -0,0 +1,187 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// populatedCaches returns EC2, RISP, and pricing caches with data for two accounts.
+func populatedCaches() (*EC2Cache, *RISPCache, *PricingCache) {
+	ec2Cache := NewEC2Cache()
+	ec2Cache.SetInstances("111111111111", "us-west-2", []aws.Instance{
+		{InstanceID: "i-001", InstanceType: "m5.xlarge", Region: "us-west-2", AccountID: "111111111111", State: "running"},
+	})
+	ec2Cache.SetInstances("222222222222", "us-west-2", []aws.Instance{
+		{InstanceID: "i-002", InstanceType: "c5.large", Region: "us-west-2", AccountID: "222222222222", State: "running"},
+	})
+	ec2Cache.SetVolumes("222222222222", "us-west-2", []aws.Volume{
+		{VolumeID: "vol-002", VolumeType: "gp3", SizeGiB: 20, Region: "us-west-2", AccountID: "222222222222",
+			AttachedInstanceIDs: []string{"i-002"}},
+	})
+
+	rispCache := NewRISPCache()
+	rispCache.UpdateReservedInstances("us-west-2", "111111111111", []aws.ReservedInstance{
+		{ReservedInstanceID: "ri-001", InstanceType: "m5.xlarge", AccountID: "111111111111", InstanceCount: 1},
+	})
+	rispCache.UpdateReservedInstances("us-west-2", "222222222222", []aws.ReservedInstance{
+		{ReservedInstanceID: "ri-002", InstanceType: "c5.large", AccountID: "222222222222", InstanceCount: 1},
+	})
+	rispCache.UpdateCapacityReservations("us-west-2", "222222222222", []aws.CapacityReservation{
+		{CapacityReservationID: "cr-002", InstanceType: "c5.large", AccountID: "222222222222", TotalInstanceCount: 2},
+	})
+	rispCache.UpdateSavingsPlans("111111111111", []aws.SavingsPlan{
+		{SavingsPlanARN: "arn:aws:savingsplans::111111111111:savingsplan/sp-001", Commitment: 1.5},
+	})
+
+	pricingCache := NewPricingCache()
+	pricingCache.SetOnDemandPrices(map[string]float64{"us-west-2:m5.xlarge:linux": 0.192})
+	pricingCache.SetEBSPrices(map[string]aws.EBSPrice{"us-west-2:gp3": {VolumeType: "gp3", PerGBMonth: 0.08}})
+	pricingCache.AddSPRates(map[string]float64{
+		BuildSPRateKey("arn:aws:savingsplans::111111111111:savingsplan/sp-001", "m5.xlarge", "us-west-2", "default", "linux"): 0.12,
+	})
+	pricingCache.InsertSpotPrices(map[string]aws.SpotPrice{
+		"c5.large:us-west-2a:linux/unix": {
+			InstanceType:       "c5.large",
+			AvailabilityZone:   "us-west-2a",
+			ProductDescription: "Linux/UNIX",
+			SpotPrice:          0.034,
+			FetchedAt:          time.Now().Add(-2 * time.Hour),
+		},
+	})
+
+	return ec2Cache, rispCache, pricingCache
+}
+
+// TestSnapshotRoundTrip verifies that saving and restoring a snapshot reproduces the
+// cache contents, including their original timestamps.
+func TestSnapshotRoundTrip(t *testing.T) {
+	ec2Cache, rispCache, pricingCache := populatedCaches()
+	snapshot := NewSnapshot(ec2Cache, rispCache, pricingCache)
+	require.True(t, snapshot.IsComplete())
+
+	path := filepath.Join(t.TempDir(), "snapshot.json.gz")
+	require.NoError(t, SaveSnapshot(path, snapshot))
+
+	loaded, err := LoadSnapshot(path)
+	require.NoError(t, err)
+
+	restoredEC2, restoredRISP, restoredPricing := NewEC2Cache(), NewRISPCache(), NewPricingCache()
+	loaded.Restore(restoredEC2, restoredRISP, restoredPricing)
+
+	assert.ElementsMatch(t, ec2Cache.GetAllInstances(), restoredEC2.GetAllInstances())
+	assert.Equal(t, ec2Cache.GetVolumesByInstance(), restoredEC2.GetVolumesByInstance())
+	assert.True(t, ec2Cache.GetLastUpdate().Equal(restoredEC2.GetLastUpdate()))
+
+	assert.ElementsMatch(t, rispCache.GetAllReservedInstances(), restoredRISP.GetAllReservedInstances())
+	assert.Equal(t, rispCache.GetAllCapacityReservations(), restoredRISP.GetAllCapacityReservations())
+	assert.Equal(t, rispCache.GetAllSavingsPlans(), restoredRISP.GetAllSavingsPlans())
+	riKey := BuildKey(":", "us-west-2", "111111111111", "ri")
+	assert.True(t, rispCache.GetFreshness(riKey).Equal(restoredRISP.GetFreshness(riKey)))
+	assert.True(t, rispCache.GetLastUpdate().Equal(restoredRISP.GetLastUpdate()))
+
+	assert.True(t, restoredPricing.IsPopulated())
+	assert.Equal(t, pricingCache.GetAllOnDemandPrices(), restoredPricing.GetAllOnDemandPrices())
+	assert.Equal(t, pricingCache.GetAllSPRates(), restoredPricing.GetAllSPRates())
+	assert.Equal(t, pricingCache.GetAllEBSPrices(), restoredPricing.GetAllEBSPrices())
+	assert.True(t, restoredPricing.SpotIsPopulated())
+
+	original := pricingCache.GetAllSpotPricesWithTimestamps()
+	restored := restoredPricing.GetAllSpotPricesWithTimestamps()
+	require.Len(t, restored, len(original))
+	for key, price := range original {
+		assert.True(t, price.FetchedAt.Equal(restored[key].FetchedAt), "spot price %s should keep its fetch time", key)
+	}
+	assert.True(t, pricingCache.GetSPRateStats().LastUpdated.Equal(restoredPricing.GetSPRateStats().LastUpdated))
+}
+
+// TestSnapshotIsComplete verifies that snapshots of caches that were never loaded
+// are reported as incomplete.
+func TestSnapshotIsComplete(t *testing.T) {
+	assert.False(t, NewSnapshot(NewEC2Cache(), NewRISPCache(), NewPricingCache()).IsComplete())
+
+	ec2Cache, rispCache, _ := populatedCaches()
+	assert.False(t, NewSnapshot(ec2Cache, rispCache, NewPricingCache()).IsComplete(),
+		"missing pricing should be incomplete")
+}
+
+// TestSnapshotRetainAccounts verifies that data for unconfigured accounts is dropped.
+func TestSnapshotRetainAccounts(t *testing.T) {
+	snapshot := NewSnapshot(populatedCaches())
+	snapshot.RetainAccounts([]string{"111111111111"})
+
+	ec2Cache, rispCache, pricingCache := NewEC2Cache(), NewRISPCache(), NewPricingCache()
+	snapshot.Restore(ec2Cache, rispCache, pricingCache)
+
+	instances := ec2Cache.GetAllInstances()
+	require.Len(t, instances, 1)
+	assert.Equal(t, "i-001", instances[0].InstanceID)
+	assert.Empty(t, ec2Cache.GetVolumesByInstance())
+	assert.Len(t, rispCache.GetAllReservedInstances(), 1)
+	assert.Empty(t, rispCache.GetReservedInstances("us-west-2", "222222222222"))
+	assert.Empty(t, rispCache.GetAllCapacityReservations())
+	assert.Len(t, rispCache.GetSavingsPlans("111111111111"), 1)
+}
+
+// TestLoadSnapshotErrors verifies load failures for missing, corrupt, and
+// incompatible snapshot files.
+func TestLoadSnapshotErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadSnapshot(filepath.Join(dir, "missing.json.gz"))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	corrupt := filepath.Join(dir, "corrupt.json.gz")
+	require.NoError(t, os.WriteFile(corrupt, []byte("not gzip"), 0o600))
+	_, err = LoadSnapshot(corrupt)
+	assert.Error(t, err)
+
+	// A snapshot from a newer (or older) format version is rejected
+	future := filepath.Join(dir, "future.json.gz")
+	f, err := os.Create(future)
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	require.NoError(t, json.NewEncoder(gz).Encode(Snapshot{Version: SnapshotVersion + 1}))
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+
+	_, err = LoadSnapshot(future)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported snapshot version")
+}
+
+// TestSaveSnapshotErrors verifies that a failed save reports an error and leaves no
+// temporary files behind.
+func TestSaveSnapshotErrors(t *testing.T) {
+	err := SaveSnapshot(filepath.Join(t.TempDir(), "missing-dir", "snapshot.json.gz"), &Snapshot{})
+	assert.Error(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "snapshot.json.gz")
+	require.NoError(t, SaveSnapshot(path, &Snapshot{Version: SnapshotVersion}))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "only the snapshot file should remain")
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/config"
+)
+
+// monitoredAccounts returns the AWS accounts a reconciler should query. When
+// account discovery is enabled the lister supplies the current accounts, which
+// change between cycles; otherwise the configured accounts are used.
+func monitoredAccounts(cfg *config.Config, lister aws.AccountLister) []config.AWSAccount {
+	if lister != nil {
+		return lister.Accounts()
+	}
+	return cfg.AWSAccounts
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/nextdoor/lumina/internal/cache"
+	"github.com/nextdoor/lumina/pkg/config"
+	"github.com/nextdoor/lumina/pkg/hub"
+	"github.com/nextdoor/lumina/pkg/metrics"
+)
+
+// AgentReconciler exports the costs of this cluster's nodes when Lumina runs as an
+// agent (config agent.hubURL). Instead of collecting AWS data and running the
+// calculation, it periodically asks the hub for the costs of the instances behind
+// the nodes in NodeCache.
+//
+// Only per-instance metrics, and the pod and NodePool metrics derived from them,
+// are exported here. Savings Plan, Reserved Instance, and other organization-wide
+// metrics are left to the hub, so they aren't duplicated by every cluster.
+type AgentReconciler struct {
+	// Hub supplies the calculated instance costs
+	Hub *hub.Client
+
+	// NodeCache lists this cluster's instances and maps them to node names
+	NodeCache *cache.NodeCache
+
+	// PodCache holds the pods scheduled on each node and their resource requests.
+	// Optional: nil disables pod cost allocation (config allocation.enabled).
+	PodCache *cache.PodCache
+
+	// NodePoolCosts rolls up instance costs by the Karpenter NodePool recorded in
+	// NodeCache (config karpenter.enabled).
+	NodePoolCosts bool
+
+	// Config contains the poll interval and metric settings
+	Config *config.Config
+
+	// ConfigProvider optionally supplies the current configuration, e.g. from a
+	// config.Watcher that reloads the config file. If nil, Config is used.
+	ConfigProvider config.Provider
+
+	// Metrics for emitting cost metrics
+	Metrics *metrics.Metrics
+
+	// Logger
+	Log logr.Logger
+}
+
+// currentConfig returns the configuration in effect, which changes when the
+// config file is reloaded.
+func (r *AgentReconciler) currentConfig() *config.Config {
+	return activeConfig(r.Config, r.ConfigProvider)
+}
+
+// Reconcile fetches the costs of this cluster's instances from the hub and updates
+// the cost metrics. If the hub can't be reached, the previous metrics are kept; the
+// lumina_data_freshness_seconds metric shows how old they are.
+func (r *AgentReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("reconciler", "agent")
+
+	instanceIDs := r.NodeCache.GetInstanceIDs()
+	costs, err := r.Hub.InstanceCosts(ctx, instanceIDs)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to fetch instance costs from hub: %w", err)
+	}
+
+	// The response stands in for the EC2 cache, supplying the tags and DNS names
+	// behind the cluster_name, host_name, and node_name labels
+	result := costs.Result()
+	r.Metrics.UpdateInstanceCostMetrics(result, r.NodeCache, costs)
+
+	if r.PodCache != nil {
+		allocations := allocatePodCosts(result, r.NodeCache, r.PodCache, r.currentConfig().GetAllocationCPUWeight())
+		r.Metrics.UpdatePodCostMetrics(allocations)
+	}
+	if r.NodePoolCosts {
+		r.Metrics.UpdateNodePoolCostMetrics(nodePoolCosts(result, r.NodeCache))
+	}
+	r.Metrics.MarkDataUpdated("", "", "", "hub_instance_costs")
+
+	log.V(1).Info("updated cost metrics from hub",
+		"instances", len(instanceIDs),
+		"costed_instances", len(costs.Instances),
+		"calculated_at", costs.CalculatedAt)
+	return ctrl.Result{RequeueAfter: r.currentConfig().GetAgentPollInterval()}, nil
+}
+
+// Run runs the reconciler as a goroutine, polling the hub every agent.pollInterval.
+//
+// Failures are never fatal: the hub may be restarting or still running its first
+// calculation, and the next poll retries.
+//
+// coverage:ignore - This is a top-level runner called by main.go, not unit tested
+func (r *AgentReconciler) Run(ctx context.Context) error {
+	log := r.Log
+	log.Info("starting agent reconciler")
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{}); err != nil {
+		log.Error(err, "initial fetch from hub failed")
+	}
+
+	interval := r.currentConfig().GetAgentPollInterval()
+	log.Info("configured poll interval", "interval", interval.String())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("shutting down agent reconciler")
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := r.Reconcile(ctx, ctrl.Request{}); err != nil {
+				log.Error(err, "scheduled fetch from hub failed")
+				// Don't exit - continue with next cycle
+			}
+			interval = resetOnIntervalChange(log, ticker, interval, r.currentConfig().GetAgentPollInterval())
+		}
+	}
+}
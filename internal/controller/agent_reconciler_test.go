@@ -0,0 +1,155 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/nextdoor/lumina/internal/cache"
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/config"
+	"github.com/nextdoor/lumina/pkg/hub"
+	"github.com/nextdoor/lumina/pkg/metrics"
+)
+
+// TestAgentReconciler_Reconcile tests that an agent exports the costs the hub
+// calculated for its own nodes, and allocates them to pods and NodePools.
+func TestAgentReconciler_Reconcile(t *testing.T) {
+	// The hub sees an instance in another cluster too
+	hubReconciler := newHubReconciler(t)
+	hubReconciler.EC2Cache.SetInstances("123456789012", "us-east-1", []aws.Instance{{
+		InstanceID:   "i-other-cluster",
+		InstanceType: "m5.xlarge",
+		Region:       "us-east-1",
+		AccountID:    "123456789012",
+		State:        "running",
+	}})
+	_, err := hubReconciler.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+	server := httptest.NewServer(NewInstanceCostsHandler(hubReconciler))
+	defer server.Close()
+
+	nodeCache := cache.NewNodeCache()
+	_, err = nodeCache.UpsertNode(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{cache.LabelKarpenterNodePool: "default"},
+		},
+		Spec: corev1.NodeSpec{ProviderID: "aws:///us-west-2a/i-001"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+		},
+	})
+	require.NoError(t, err)
+	podCache := cache.NewPodCache()
+	podCache.UpsertPod(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: "frontend"},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	})
+
+	cfg := &config.Config{Agent: config.AgentConfig{HubURL: server.URL}}
+	m := metrics.NewMetrics(prometheus.NewRegistry(), cfg)
+	reconciler := &AgentReconciler{
+		Hub:           hub.NewClient(server.URL),
+		NodeCache:     nodeCache,
+		PodCache:      podCache,
+		NodePoolCosts: true,
+		Config:        cfg,
+		Metrics:       m,
+		Log:           logr.Discard(),
+	}
+
+	result, err := reconciler.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, cfg.GetAgentPollInterval(), result.RequeueAfter)
+
+	// Only this cluster's instance, at the Savings Plan rate the hub calculated
+	require.Equal(t, 1, testutil.CollectAndCount(m.EC2InstanceHourlyCost))
+	assert.InDelta(t, 0.72, testutil.ToFloat64(m.EC2InstanceHourlyCost), 0.0001)
+	assert.Equal(t, 0, testutil.CollectAndCount(m.SavingsPlanUtilizationPercent),
+		"Savings Plan metrics are left to the hub")
+	// 0.50 × 2/4 cores + 0.50 × 8/16 GiB of the $0.72 node
+	assert.InDelta(t, 0.36, testutil.ToFloat64(m.PodHourlyCost.WithLabelValues("web", "frontend", "node-1")), 0.0001)
+	assert.InDelta(t, 0.72, testutil.ToFloat64(m.NodePoolHourlyCost.WithLabelValues("default")), 0.0001)
+}
+
+// TestAgentReconciler_Reconcile_HubUnavailable tests that the previous metrics are
+// kept when the hub can't be reached.
+func TestAgentReconciler_Reconcile_HubUnavailable(t *testing.T) {
+	var unavailable atomic.Bool
+	hubReconciler := newHubReconciler(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unavailable.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		NewInstanceCostsHandler(hubReconciler).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	nodeCache := cache.NewNodeCache()
+	_, err := nodeCache.UpsertNode(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Spec:       corev1.NodeSpec{ProviderID: "aws:///us-west-2a/i-001"},
+	})
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	m := metrics.NewMetrics(prometheus.NewRegistry(), cfg)
+	reconciler := &AgentReconciler{
+		Hub:       hub.NewClient(server.URL),
+		NodeCache: nodeCache,
+		Config:    cfg,
+		Metrics:   m,
+		Log:       logr.Discard(),
+	}
+
+	_, err = reconciler.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+
+	unavailable.Store(true)
+	_, err = reconciler.Reconcile(context.Background(), ctrl.Request{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 503")
+	assert.Equal(t, 1, testutil.CollectAndCount(m.EC2InstanceHourlyCost))
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/billing"
+	"github.com/nextdoor/lumina/pkg/config"
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/nextdoor/lumina/pkg/metrics"
+)
+
+// DefaultBillingLookbackDays is how many days of estimates are kept for comparison
+// with billing data. The Cost and Usage Report typically lags usage by up to a day,
+// so a few days of history are needed to find a day present in both.
+const DefaultBillingLookbackDays = 4
+
+// minObservedFraction is how much of a day the estimates must cover before it's
+// compared with billing data. Partial days (e.g., the controller started mid-day)
+// would show as under-estimates that aren't real drift.
+const minObservedFraction = 0.95
+
+// BillingReconciler compares Lumina's estimated costs with actual AWS billing data.
+//
+// The CostReconciler records every calculation into Estimates. Periodically, this
+// reconciler loads actual daily costs from Source and reports the drift for the most
+// recent day that is both fully observed by Lumina and present in the billing data.
+type BillingReconciler struct {
+	// Source provides actual daily costs (e.g., a Cost and Usage Report on disk)
+	Source billing.Source
+
+	// Estimates holds Lumina's estimated daily costs, recorded by the CostReconciler
+	Estimates *cost.DailyEstimates
+
+	// Configuration with AWS account details and reconciliation interval
+	Config *config.Config
+
+	// ConfigProvider optionally supplies the current configuration, e.g. from a
+	// config.Watcher that reloads the config file. If nil, Config is used.
+	ConfigProvider config.Provider
+
+	// Accounts optionally supplies the AWS accounts to query, e.g. from account
+	// discovery. If nil, Config.AWSAccounts is used.
+	Accounts aws.AccountLister
+
+	// Metrics for emitting billing drift metrics
+	Metrics *metrics.Metrics
+
+	// Logger
+	Log logr.Logger
+
+	// LookbackDays is how many days back to search for a comparable day.
+	// Defaults to DefaultBillingLookbackDays if zero.
+	LookbackDays int
+}
+
+// currentConfig returns the configuration in effect, which changes when the
+// config file is reloaded.
+func (r *BillingReconciler) currentConfig() *config.Config {
+	return activeConfig(r.Config, r.ConfigProvider)
+}
+
+// Reconcile performs a single billing comparison.
+func (r *BillingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("reconciler", "billing")
+	log.Info("starting billing reconciliation cycle")
+
+	lookback := r.LookbackDays
+	if lookback <= 0 {
+		lookback = DefaultBillingLookbackDays
+	}
+
+	// Only complete days are comparable, so stop at the start of today
+	end := billing.DayStart(time.Now())
+	start := end.AddDate(0, 0, -lookback)
+
+	actual, err := r.Source.LoadDailyCosts(ctx, start, end)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to load billing data: %w", err)
+	}
+
+	accounts := monitoredAccounts(r.currentConfig(), r.Accounts)
+	accountIDs := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		accountIDs = append(accountIDs, account.AccountID)
+	}
+
+	// Walk back from the most recent day until one has both billing data and a
+	// fully-observed estimate
+	for i := len(actual) - 1; i >= 0; i-- {
+		estimate, ok := r.Estimates.Day(actual[i].Date)
+		if !ok || estimate.ObservedSeconds < minObservedFraction*24*3600 {
+			continue
+		}
+
+		report := billing.Compare(actual[i], estimate, accountIDs)
+		r.Metrics.UpdateBillingDriftMetrics(report)
+
+		log.Info("billing reconciliation completed",
+			"date", report.Date.Format(time.DateOnly),
+			"accounts", len(report.Accounts),
+			"savings_plans", len(report.SavingsPlans))
+		return ctrl.Result{RequeueAfter: r.getReconciliationInterval(log)}, nil
+	}
+
+	// Expected for the first day or two after startup, while estimates build up
+	log.Info("no day with both billing data and a complete estimate yet",
+		"billing_days", len(actual),
+		"lookback_days", lookback)
+	return ctrl.Result{RequeueAfter: r.getReconciliationInterval(log)}, nil
+}
+
+// getReconciliationInterval returns the configured billing interval (default: 6h).
+func (r *BillingReconciler) getReconciliationInterval(log logr.Logger) time.Duration {
+	defaultInterval := 6 * time.Hour
+
+	if r.currentConfig().Reconciliation.Billing == "" {
+		return defaultInterval
+	}
+
+	duration, err := time.ParseDuration(r.currentConfig().Reconciliation.Billing)
+	if err != nil {
+		log.Error(err, "invalid billing reconciliation interval, using default",
+			"configured_interval", r.currentConfig().Reconciliation.Billing,
+			"default", defaultInterval.String())
+		return defaultInterval
+	}
+
+	return duration
+}
+
+// Run runs the reconciler as a goroutine with timer-based reconciliation.
+//
+// Unlike the data collection reconcilers, failures here are never fatal: billing
+// drift is a diagnostic, and cost metrics are still valid without it.
+//
+// coverage:ignore - This is a top-level runner called by main.go, not unit tested
+func (r *BillingReconciler) Run(ctx context.Context) error {
+	log := r.Log
+	log.Info("starting billing reconciler")
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{}); err != nil {
+		log.Error(err, "initial billing reconciliation failed")
+	}
+
+	interval := r.getReconciliationInterval(log)
+	log.Info("configured reconciliation interval", "interval", interval.String())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("shutting down billing reconciler")
+			return ctx.Err()
+		case <-ticker.C:
+			log.V(1).Info("running scheduled reconciliation")
+			if _, err := r.Reconcile(ctx, ctrl.Request{}); err != nil {
+				log.Error(err, "scheduled reconciliation failed")
+				// Don't exit - continue with next cycle
+			}
+			interval = resetOnIntervalChange(log, ticker, interval, r.getReconciliationInterval(log))
+		}
+	}
+}
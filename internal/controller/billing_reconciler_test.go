@@ -0,0 +1,120 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/nextdoor/lumina/pkg/billing"
+	"github.com/nextdoor/lumina/pkg/config"
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/nextdoor/lumina/pkg/metrics"
+)
+
+// fakeBillingSource returns fixed daily costs for testing.
+type fakeBillingSource struct {
+	days []billing.DailyCosts
+	err  error
+}
+
+func (f *fakeBillingSource) LoadDailyCosts(_ context.Context, _, _ time.Time) ([]billing.DailyCosts, error) {
+	return f.days, f.err
+}
+
+// newTestBillingReconciler creates a BillingReconciler for one monitored account.
+func newTestBillingReconciler(source billing.Source, estimates *cost.DailyEstimates) *BillingReconciler {
+	cfg := &config.Config{
+		AWSAccounts: []config.AWSAccount{{AccountID: "111111111111", Name: "prod"}},
+	}
+	return &BillingReconciler{
+		Source:    source,
+		Estimates: estimates,
+		Config:    cfg,
+		Metrics:   metrics.NewMetrics(prometheus.NewRegistry(), cfg),
+		Log:       logr.Discard(),
+	}
+}
+
+// TestBillingReconciler_Reconcile tests that the most recent fully-observed day
+// is compared and exported.
+func TestBillingReconciler_Reconcile(t *testing.T) {
+	today := billing.DayStart(time.Now())
+	yesterday := today.AddDate(0, 0, -1)
+
+	// Lumina observed all of yesterday at $2/hour, but only part of the day before
+	estimates := cost.NewDailyEstimates(DefaultBillingLookbackDays)
+	result := cost.CalculationResult{
+		InstanceCosts: map[string]cost.InstanceCost{
+			"i-001": {InstanceID: "i-001", AccountID: "111111111111", EffectiveCost: 2.00},
+		},
+	}
+	estimates.Record(result, yesterday.Add(-2*time.Hour))
+	estimates.Record(result, today)
+
+	source := &fakeBillingSource{days: []billing.DailyCosts{
+		{Date: yesterday.AddDate(0, 0, -1), Accounts: map[string]float64{"111111111111": 40}},
+		{Date: yesterday, Accounts: map[string]float64{"111111111111": 50}},
+	}}
+
+	r := newTestBillingReconciler(source, estimates)
+	res, err := r.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, 6*time.Hour, res.RequeueAfter)
+
+	labels := prometheus.Labels{"account_id": "111111111111", "account_name": "prod"}
+	assert.Equal(t, 50.0, testutil.ToFloat64(r.Metrics.BillingAccountActualDailyCost.With(labels)))
+	assert.InDelta(t, 48.0, testutil.ToFloat64(r.Metrics.BillingAccountEstimatedDailyCost.With(labels)), 0.0001)
+	assert.InDelta(t, -4.0, testutil.ToFloat64(r.Metrics.BillingAccountDriftPercent.With(labels)), 0.0001)
+	assert.Equal(t, float64(yesterday.Unix()), testutil.ToFloat64(r.Metrics.BillingReconciledDate.WithLabelValues()))
+}
+
+// TestBillingReconciler_Reconcile_NoCompleteDay tests that nothing is exported
+// while estimates don't cover a full day.
+func TestBillingReconciler_Reconcile_NoCompleteDay(t *testing.T) {
+	yesterday := billing.DayStart(time.Now()).AddDate(0, 0, -1)
+
+	estimates := cost.NewDailyEstimates(DefaultBillingLookbackDays)
+	estimates.Record(cost.CalculationResult{}, yesterday.Add(12*time.Hour))
+	estimates.Record(cost.CalculationResult{}, yesterday.Add(18*time.Hour))
+
+	source := &fakeBillingSource{days: []billing.DailyCosts{
+		{Date: yesterday, Accounts: map[string]float64{"111111111111": 50}},
+	}}
+
+	r := newTestBillingReconciler(source, estimates)
+	_, err := r.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, testutil.CollectAndCount(r.Metrics.BillingAccountActualDailyCost))
+}
+
+// TestBillingReconciler_Reconcile_SourceError tests that source errors are returned.
+func TestBillingReconciler_Reconcile_SourceError(t *testing.T) {
+	source := &fakeBillingSource{err: errors.New("permission denied")}
+
+	r := newTestBillingReconciler(source, cost.NewDailyEstimates(1))
+	_, err := r.Reconcile(context.Background(), ctrl.Request{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied")
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/nextdoor/lumina/pkg/config"
+)
+
+// activeConfig returns the configuration a reconciler should use. When the
+// configuration file is watched the provider supplies the latest reloaded
+// configuration, which can change between (and during) cycles; otherwise the
+// configuration the reconciler was created with is used.
+func activeConfig(cfg *config.Config, provider config.Provider) *config.Config {
+	if provider != nil {
+		return provider.Current()
+	}
+	return cfg
+}
+
+// resetOnIntervalChange resets a Run loop's ticker when the configured interval
+// no longer matches the one it ticks at, and returns the interval in effect.
+// Run loops call it after each tick, so a reloaded interval applies from the
+// next cycle.
+func resetOnIntervalChange(log logr.Logger, ticker *time.Ticker, current, configured time.Duration) time.Duration {
+	if configured == current {
+		return current
+	}
+	log.Info("reconciliation interval changed", "previous", current.String(), "interval", configured.String())
+	ticker.Reset(configured)
+	return configured
+}
@@ -16,6 +16,7 @@ package controller
 
 import (
 	"context"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -26,13 +27,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/nextdoor/lumina/internal/cache"
+	"github.com/nextdoor/lumina/pkg/aws"
 	"github.com/nextdoor/lumina/pkg/config"
 	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/nextdoor/lumina/pkg/history"
 	"github.com/nextdoor/lumina/pkg/metrics"
+	"github.com/nextdoor/lumina/pkg/recommend"
+	"github.com/nextdoor/lumina/pkg/whatif"
 )
 
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 
+// ForecastMaxAge is how long an expiration forecast is reused while the Savings
+// Plan and Reserved Instance inventory is unchanged. Forecasting runs a full cost
+// calculation per expiring commitment, so it isn't repeated on every calculation,
+// but changes in instances and prices still show up within this time.
+const ForecastMaxAge = time.Hour
+
 // CostReconciler calculates per-instance costs and Savings Plans utilization.
 // It uses an event-driven architecture: cost calculations trigger automatically when
 // any data source (EC2, RISP, or Pricing caches) updates. A debouncer accumulates
@@ -56,6 +67,10 @@ type CostReconciler struct {
 	// Config contains general configuration (reconciliation intervals no longer used for cost)
 	Config *config.Config
 
+	// ConfigProvider optionally supplies the current configuration, e.g. from a
+	// config.Watcher that reloads the config file. If nil, Config is used.
+	ConfigProvider config.Provider
+
 	// EC2Cache provides EC2 instance inventory data
 	EC2Cache *cache.EC2Cache
 
@@ -69,9 +84,53 @@ type CostReconciler struct {
 	// Used to add node_name labels to cost metrics (Phase 8)
 	NodeCache *cache.NodeCache
 
+	// PodCache holds the pods scheduled on each node and their resource requests.
+	// Optional: nil disables pod cost allocation (config allocation.enabled).
+	// Requires NodeCache to map instances to nodes.
+	PodCache *cache.PodCache
+
+	// FargatePodCache supplies EKS Fargate pods, which draw on Compute Savings Plans.
+	// Optional: nil ignores Fargate pods (config serverless.fargate.enabled).
+	// May be the same cache as PodCache.
+	FargatePodCache *cache.PodCache
+
+	// NodePoolCosts rolls up instance costs by the Karpenter NodePool recorded in
+	// NodeCache (config karpenter.enabled).
+	NodePoolCosts bool
+
+	// StorageCosts attributes the cost of EBS volumes from EC2Cache to the instances
+	// they're attached to, priced from PricingCache (config storage.enabled).
+	StorageCosts bool
+
+	// UnitCosts divides instance costs by the capacity of their instance type from
+	// EC2Cache, per vCPU, GiB of memory, and GPU (config unitCosts.enabled).
+	UnitCosts bool
+
+	// ExpirationForecast re-runs the calculation with each Savings Plan and Reserved
+	// Instance expiring within the forecast horizon removed, reusing the forecast
+	// while those commitments are unchanged.
+	// Optional: nil disables the expiration forecast (config forecast.enabled).
+	ExpirationForecast *whatif.ForecastCache
+
 	// Metrics for emitting cost and utilization metrics
 	Metrics *metrics.Metrics
 
+	// Ledger accumulates Savings Plan usage within each billing hour.
+	// Optional: nil disables billing-hour accounting (config cost.savingsPlanLedger).
+	Ledger *cost.SavingsPlanLedger
+
+	// Estimates accumulates estimated daily costs for comparison with billing data.
+	// Optional: nil when billing comparison is disabled (config billing.curPath).
+	Estimates *cost.DailyEstimates
+
+	// History records per-instance costs for range queries.
+	// Optional: nil disables cost history (config history.path).
+	History *history.Store
+
+	// Recommender records on-demand spillover and sizes Savings Plan purchases.
+	// Optional: nil disables recommendations (config recommendations.enabled).
+	Recommender *recommend.Recommender
+
 	// Debouncer accumulates rapid cache updates and triggers recalculation
 	// after a period of quiet (default: 1 second)
 	Debouncer *cache.Debouncer
@@ -87,16 +146,35 @@ type CostReconciler struct {
 	SPRatesReadyChan     chan struct{} // Wait for SP rates to load
 	SpotPricingReadyChan chan struct{} // Wait for spot pricing to load
 
+	// WarmStart skips waiting on the ready channels because the caches were restored
+	// from a snapshot (config snapshot.path). The first calculation uses the restored
+	// data; refreshed data triggers recalculation through the debouncer as it arrives.
+	WarmStart bool
+
 	// initialized tracks whether the initial dependency wait has completed.
 	// This prevents the debouncer from triggering calculations before all
 	// dependencies (Pricing, RISP, EC2, SPRates, SpotPricing) are confirmed ready.
 	// Uses atomic operations for thread-safe access from multiple goroutines.
 	initialized atomic.Bool
 
+	// lastResult is the latest calculation without invariant violations, served to
+	// agents by InstanceCostsHandler
+	lastResult atomic.Pointer[cost.CalculationResult]
+
+	// lastAudit is the outcome of the invariant checks on the latest calculation,
+	// served by InvariantsHandler
+	lastAudit atomic.Pointer[InvariantAudit]
+
 	// HealthTracker is used to report permanent failures to the readiness probe.
 	HealthTracker *ReconcilerHealthTracker
 }
 
+// currentConfig returns the configuration in effect, which changes when the
+// config file is reloaded.
+func (r *CostReconciler) currentConfig() *config.Config {
+	return activeConfig(r.Config, r.ConfigProvider)
+}
+
 // Reconcile performs a single cost calculation cycle.
 // In the event-driven architecture, this is called by the debouncer when any
 // cache updates (EC2, RISP, or Pricing). It can also be called manually for
@@ -119,40 +197,14 @@ func (r *CostReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Re
 	startTime := time.Now()
 
 	// Gather all data needed for cost calculation
-	instances := r.EC2Cache.GetRunningInstances()
-	ris := r.RISPCache.GetAllReservedInstances()
-	sps := r.RISPCache.GetAllSavingsPlans()
-
-	// Build instance keys for pricing lookup
-	instanceKeys := make([]cache.OnDemandKey, 0, len(instances))
-	for _, inst := range instances {
-		instanceKeys = append(instanceKeys, cache.OnDemandKey{
-			InstanceType: inst.InstanceType,
-			Region:       inst.Region,
-		})
-	}
-
-	// Get on-demand pricing data for running instances
-	// The pricing cache returns a map keyed by "instance_type:region"
-	// Default to Linux OS pricing (most common). Future enhancement: detect actual OS per instance.
-	onDemandPrices := r.PricingCache.GetOnDemandPricesForInstances(instanceKeys, "Linux")
+	input := r.calculationInput()
 
 	log.V(1).Info("gathered data for cost calculation",
-		"instances", len(instances),
-		"reserved_instances", len(ris),
-		"savings_plans", len(sps),
-		"on_demand_prices", len(onDemandPrices))
-
-	// Build calculation input
-	// Pass the PricingCache directly so the calculator can use accessor methods
-	// for spot pricing instead of fragile map key lookups
-	input := cost.CalculationInput{
-		Instances:         instances,
-		ReservedInstances: ris,
-		SavingsPlans:      sps,
-		PricingCache:      r.PricingCache,
-		OnDemandPrices:    onDemandPrices,
-	}
+		"instances", len(input.Instances),
+		"reserved_instances", len(input.ReservedInstances),
+		"capacity_reservations", len(input.CapacityReservations),
+		"savings_plans", len(input.SavingsPlans),
+		"on_demand_prices", len(input.OnDemandPrices))
 
 	// Run cost calculation algorithm
 	result := r.Calculator.Calculate(input)
@@ -162,8 +214,22 @@ func (r *CostReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Re
 		"total_estimated_cost", result.TotalEstimatedCost,
 		"total_shelf_price", result.TotalShelfPrice,
 		"total_savings", result.TotalSavings,
+		"total_storage_cost", result.TotalStorageCost,
 		"instance_costs", len(result.InstanceCosts),
-		"sp_utilization", len(result.SavingsPlanUtilization))
+		"sp_utilization", len(result.SavingsPlanUtilization),
+		"serverless_costs", len(result.ServerlessCosts))
+
+	// A calculation that breaks the calculator's math invariants can't be trusted.
+	// Keep publishing the last good calculation and report the violations instead.
+	r.lastAudit.Store(&InvariantAudit{CalculatedAt: result.CalculatedAt, Violations: result.InvariantViolations})
+	if len(result.InvariantViolations) > 0 {
+		r.Metrics.RecordInvariantViolations(result.InvariantViolations)
+		log.Error(result.InvariantViolations[0], "cost calculation broke math invariants, keeping the last good result",
+			"violations", len(result.InvariantViolations),
+			"details_path", InvariantsPath)
+		return ctrl.Result{}, nil
+	}
+	r.lastResult.Store(&result)
 
 	// Update Prometheus metrics with cost calculation results
 	// This emits ec2_instance_hourly_cost and savings_plan_* utilization metrics
@@ -171,11 +237,325 @@ func (r *CostReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Re
 	r.Metrics.UpdateInstanceCostMetrics(result, r.NodeCache, r.EC2Cache)
 	log.V(1).Info("updated cost metrics")
 
+	// Split each node's cost among its pods by their resource requests
+	if r.PodCache != nil && r.NodeCache != nil {
+		allocations := allocatePodCosts(result, r.NodeCache, r.PodCache, r.currentConfig().GetAllocationCPUWeight())
+		r.Metrics.UpdatePodCostMetrics(allocations)
+		log.V(1).Info("updated pod cost metrics", "nodes", len(allocations))
+	}
+
+	// Roll up costs by Karpenter NodePool
+	if r.NodePoolCosts && r.NodeCache != nil {
+		pools := nodePoolCosts(result, r.NodeCache)
+		r.Metrics.UpdateNodePoolCostMetrics(pools)
+		log.V(1).Info("updated nodepool cost metrics", "nodepools", len(pools))
+	}
+
+	// Compare instance types by their discounted cost per unit of capacity
+	if r.UnitCosts {
+		unitCosts := cost.UnitCosts(result.InstanceCosts, r.EC2Cache.GetInstanceTypes())
+		r.Metrics.UpdateUnitCostMetrics(unitCosts)
+		log.V(1).Info("updated unit cost metrics", "groups", len(unitCosts))
+	}
+
+	// Project what upcoming Savings Plan and Reserved Instance expirations will cost
+	if r.ExpirationForecast != nil {
+		expiries := r.ExpirationForecast.Forecast(r.Calculator, input, time.Now(), r.currentConfig().GetForecastHorizon())
+		r.Metrics.UpdateExpirationForecastMetrics(expiries)
+		log.V(1).Info("updated expiration forecast metrics", "expiring_commitments", len(expiries))
+	}
+
+	// Accumulate this result into the current billing hour. The result's rates are
+	// treated as in effect until the next calculation.
+	if r.Ledger != nil {
+		entries := r.Ledger.Record(result, time.Now())
+		r.Metrics.UpdateSavingsPlanLedgerMetrics(entries)
+		log.V(1).Info("updated savings plan billing hour metrics", "savings_plans", len(entries))
+	}
+
+	// Accumulate daily totals for the BillingReconciler to compare with actual costs
+	if r.Estimates != nil {
+		r.Estimates.Record(result, time.Now())
+	}
+
+	// Refresh Savings Plan recommendations with the latest spillover
+	if r.Recommender != nil {
+		r.Recommender.Record(result, time.Now())
+		report := r.Recommender.Recommend()
+		r.Metrics.UpdateRecommendationMetrics(report)
+		log.V(1).Info("updated savings plan recommendations",
+			"observed_hours", report.ObservedHours,
+			"recommendations", len(report.Recommendations))
+	}
+
+	// Persist costs for the history API. A write failure loses one interval but
+	// doesn't affect metrics, so it's logged rather than returned.
+	if r.History != nil {
+		if err := r.History.Record(r.historyRates(result), time.Now()); err != nil {
+			log.Error(err, "failed to record cost history")
+		}
+	}
+
 	// Event-driven reconciliation: no requeue needed
 	// The debouncer will trigger the next calculation when caches update
 	return ctrl.Result{}, nil
 }
 
+// LastResult returns the latest cost calculation without invariant violations, or
+// false if there hasn't been one.
+func (r *CostReconciler) LastResult() (cost.CalculationResult, bool) {
+	result := r.lastResult.Load()
+	if result == nil {
+		return cost.CalculationResult{}, false
+	}
+	return *result, true
+}
+
+// InvariantAudit is the outcome of the invariant checks on a cost calculation.
+type InvariantAudit struct {
+	// CalculatedAt is when the audited calculation was performed
+	CalculatedAt time.Time `json:"calculatedAt"`
+
+	// Violations lists the invariants the calculation broke; empty if it was published
+	Violations []cost.InvariantViolation `json:"violations"`
+}
+
+// LastAudit returns the invariant audit of the latest cost calculation, or false if
+// there hasn't been one.
+func (r *CostReconciler) LastAudit() (InvariantAudit, bool) {
+	audit := r.lastAudit.Load()
+	if audit == nil {
+		return InvariantAudit{}, false
+	}
+	return *audit, true
+}
+
+// calculationInput gathers the inventory and prices for a cost calculation from the
+// caches.
+func (r *CostReconciler) calculationInput() cost.CalculationInput {
+	instances := r.EC2Cache.GetRunningInstances()
+	crs := r.RISPCache.GetAllCapacityReservations()
+
+	// Build calculation input
+	// Pass the PricingCache directly so the calculator can use accessor methods
+	// for spot pricing instead of fragile map key lookups
+	input := cost.CalculationInput{
+		Instances:            instances,
+		ReservedInstances:    r.RISPCache.GetAllReservedInstances(),
+		SavingsPlans:         r.RISPCache.GetAllSavingsPlans(),
+		CapacityReservations: crs,
+		PricingCache:         r.PricingCache,
+		OnDemandPrices:       onDemandPrices(r.PricingCache, instances, crs),
+		ServerlessUsage:      r.serverlessUsage(),
+	}
+	if cfg := r.currentConfig(); cfg != nil {
+		input.SharingDisabledAccounts = cfg.GetSharingDisabledAccounts()
+		input.SavingsPlanAllocation = cost.SavingsPlanAllocation(cfg.Cost.SavingsPlanAllocation)
+	}
+	if r.StorageCosts {
+		input.Volumes = r.EC2Cache.GetVolumesByInstance()
+		input.EBSPrices = r.PricingCache.GetAllEBSPrices()
+	}
+	return input
+}
+
+// onDemandPrices returns the on-demand prices of instances and Capacity Reservations
+// from the pricing cache, keyed by "instance_type:region:os[:tenancy]".
+//
+// Each instance is priced for its own OS and tenancy, so shelf prices agree with
+// the OS and tenancy used for SP rate and spot price lookups. Non-Linux and
+// non-shared instances also request the Linux and shared tenancy prices, which
+// the calculator uses as an estimate if the exact price isn't loaded.
+// Capacity Reservations are priced the same way, since their unused capacity is
+// billed at the on-demand price of their instance type.
+func onDemandPrices(
+	pricingCache *cache.PricingCache,
+	instances []aws.Instance,
+	crs []aws.CapacityReservation,
+) map[string]float64 {
+	priced := make([]aws.Instance, 0, len(instances)+len(crs))
+	priced = append(priced, instances...)
+	for _, cr := range crs {
+		priced = append(priced, cost.CapacityReservationInstance(cr))
+	}
+	instanceKeys := make([]cache.OnDemandKey, 0, len(priced))
+	for _, inst := range priced {
+		operatingSystems := []string{cost.PricingOperatingSystem(inst.Platform)}
+		if operatingSystems[0] != aws.PlatformLinux {
+			operatingSystems = append(operatingSystems, aws.PlatformLinux)
+		}
+		tenancies := []string{cost.PricingTenancy(inst.Tenancy)}
+		if tenancies[0] != aws.TenancyDefault {
+			tenancies = append(tenancies, aws.TenancyDefault)
+		}
+		for _, operatingSystem := range operatingSystems {
+			for _, tenancy := range tenancies {
+				instanceKeys = append(instanceKeys, cache.OnDemandKey{
+					InstanceType:    inst.InstanceType,
+					Region:          inst.Region,
+					OperatingSystem: operatingSystem,
+					Tenancy:         tenancy,
+				})
+			}
+		}
+	}
+	return pricingCache.GetOnDemandPricesForInstances(instanceKeys)
+}
+
+// Simulate calculates costs from the current caches with and without changes, and
+// returns the difference. It doesn't update metrics or any other state, so it can
+// run alongside reconciliation.
+func (r *CostReconciler) Simulate(changes []whatif.Change) (whatif.Result, error) {
+	// Both calculations are made at the same time, so time-derived fields such as
+	// Savings Plan RemainingHours don't show up as changes
+	baseline := r.calculationInput()
+	baseline.Now = time.Now()
+	simulated, err := whatif.Apply(baseline, changes, baseline.Now)
+	if err != nil {
+		return whatif.Result{}, err
+	}
+	simulated.OnDemandPrices = onDemandPrices(r.PricingCache, simulated.Instances, simulated.CapacityReservations)
+
+	return whatif.Compare(r.Calculator.Calculate(baseline), r.Calculator.Calculate(simulated)), nil
+}
+
+// Explain calculates costs from the current caches and returns how the calculation
+// arrived at instanceID's cost, or false if it has no cost for the instance. Like
+// Simulate, it doesn't update metrics or any other state.
+func (r *CostReconciler) Explain(instanceID string) (cost.Explanation, bool) {
+	return r.Calculator.Explain(r.calculationInput(), instanceID)
+}
+
+// serverlessUsage returns the Fargate and Lambda usage that competes with instances
+// for Compute Savings Plan commitment: Fargate pods in this cluster, priced by the
+// capacity Fargate provisioned for them, and the configured usage estimates.
+func (r *CostReconciler) serverlessUsage() []cost.ServerlessUsage {
+	cfg := r.currentConfig()
+	if cfg == nil {
+		return nil
+	}
+
+	var usage []cost.ServerlessUsage
+	if r.FargatePodCache != nil {
+		vCPUPrice, memoryPrice := cfg.GetFargatePrices()
+		rate := cfg.GetServerlessDiscount(config.ServiceFargate)
+		for _, pod := range r.FargatePodCache.GetFargatePods() {
+			vCPU, memoryGB := cost.FargatePodCapacity(pod.FargateCapacity, pod.CPUCores, pod.MemoryBytes)
+			onDemand := vCPU*vCPUPrice + memoryGB*memoryPrice
+			usage = append(usage, cost.ServerlessUsage{
+				ID:              cost.ServiceFargate + "/" + pod.Namespace + "/" + pod.Name,
+				Service:         cost.ServiceFargate,
+				AccountID:       cfg.GetFargateAccountID(),
+				Region:          cfg.GetFargateRegion(),
+				OnDemandCost:    onDemand,
+				SavingsPlanRate: onDemand * rate,
+			})
+		}
+	}
+
+	for _, estimate := range cfg.Serverless.Estimates {
+		usage = append(usage, cost.ServerlessUsage{
+			ID:              "estimate/" + estimate.Name,
+			Service:         estimate.Service,
+			AccountID:       estimate.AccountID,
+			Region:          estimate.Region,
+			OnDemandCost:    estimate.HourlyCost,
+			SavingsPlanRate: estimate.HourlyCost * cfg.GetServerlessDiscount(estimate.Service),
+		})
+	}
+	return usage
+}
+
+// historyRates converts per-instance costs into history rates, resolving cluster and
+// node names the same way the ec2_instance_hourly_cost metric does.
+func (r *CostReconciler) historyRates(result cost.CalculationResult) []history.Rate {
+	rates := make([]history.Rate, 0, len(result.InstanceCosts))
+	for _, ic := range result.InstanceCosts {
+		attributes := history.Attributes{
+			AccountID:    ic.AccountID,
+			AccountName:  ic.AccountName,
+			Region:       ic.Region,
+			InstanceID:   ic.InstanceID,
+			InstanceType: ic.InstanceType,
+			CostType:     string(ic.CoverageType),
+		}
+
+		instance, found := r.EC2Cache.GetInstance(ic.InstanceID)
+		if found {
+			attributes.ClusterName = instance.GetClusterName()
+		}
+		if r.NodeCache != nil {
+			attributes.NodeName, _ = r.NodeCache.GetNodeName(ic.InstanceID)
+		}
+		if attributes.NodeName == "" && attributes.ClusterName != "" {
+			attributes.NodeName = instance.Tags[r.currentConfig().GetNodeNameTagKey()]
+		}
+
+		rates = append(rates, history.Rate{Attributes: attributes, HourlyCost: ic.EffectiveCost})
+	}
+	return rates
+}
+
+// allocatePodCosts divides the effective cost of every instance that is a
+// Kubernetes node among the pods scheduled on it. Instances that aren't nodes in
+// this cluster are skipped. Allocations are sorted by node name.
+func allocatePodCosts(
+	result cost.CalculationResult,
+	nodeCache *cache.NodeCache,
+	podCache *cache.PodCache,
+	cpuWeight float64,
+) []cost.NodeAllocation {
+	podsByNode := podCache.GetPodsByNode()
+
+	allocations := make([]cost.NodeAllocation, 0, nodeCache.GetNodeCount())
+	for _, ic := range result.InstanceCosts {
+		nodeName, exists := nodeCache.GetNodeName(ic.InstanceID)
+		if !exists {
+			continue
+		}
+
+		// Allocatable is what the scheduler hands out to pods (capacity minus
+		// system reservations). If the node is gone or has no status yet, the
+		// capacity stays zero and the pods' requests are used instead.
+		var capacity cost.NodeCapacity
+		if node, found := nodeCache.GetNode(nodeName); found {
+			capacity.CPUCores = node.Status.Allocatable.Cpu().AsApproximateFloat64()
+			capacity.MemoryBytes = node.Status.Allocatable.Memory().AsApproximateFloat64()
+		}
+
+		pods := make([]cost.PodRequests, 0, len(podsByNode[nodeName]))
+		for _, pod := range podsByNode[nodeName] {
+			pods = append(pods, cost.PodRequests{
+				Namespace:   pod.Namespace,
+				Name:        pod.Name,
+				CPUCores:    pod.CPUCores,
+				MemoryBytes: pod.MemoryBytes,
+			})
+		}
+
+		allocations = append(allocations,
+			cost.AllocateNodeCost(nodeName, ic.InstanceID, ic.EffectiveCost, capacity, pods, cpuWeight))
+	}
+
+	sort.Slice(allocations, func(i, j int) bool {
+		return allocations[i].NodeName < allocations[j].NodeName
+	})
+	return allocations
+}
+
+// nodePoolCosts rolls up the calculated instance costs by Karpenter NodePool.
+func nodePoolCosts(result cost.CalculationResult, nodeCache *cache.NodeCache) map[string]cost.NodePoolCost {
+	memberships := nodeCache.GetNodePools()
+	members := make(map[string]cost.NodePoolMember, len(memberships))
+	for instanceID, membership := range memberships {
+		members[instanceID] = cost.NodePoolMember{
+			NodePool:     membership.NodePool,
+			CapacityType: membership.CapacityType,
+		}
+	}
+	return cost.NodePoolCosts(result.InstanceCosts, members)
+}
+
 // Run runs the reconciler as a goroutine with event-driven reconciliation.
 //
 // Runs an initial calculation on startup (after waiting for dependencies), then waits
@@ -263,6 +643,11 @@ func (r *CostReconciler) SetupWithManager(mgr ctrl.Manager) error {
 func (r *CostReconciler) waitForDependencies() {
 	log := r.Log.WithName("init")
 
+	if r.WarmStart {
+		log.Info("caches restored from snapshot, not waiting for initial data load")
+		return
+	}
+
 	// Wait for Pricing cache to be ready
 	if r.PricingReadyChan != nil {
 		log.Info("waiting for pricing cache to be ready")
@@ -21,13 +21,22 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/nextdoor/lumina/internal/cache"
+	"github.com/nextdoor/lumina/pkg/aws"
 	"github.com/nextdoor/lumina/pkg/config"
 	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/nextdoor/lumina/pkg/history"
 	"github.com/nextdoor/lumina/pkg/metrics"
+	"github.com/nextdoor/lumina/pkg/recommend"
+	"github.com/nextdoor/lumina/pkg/whatif"
 )
 
 // TestCostReconciler_Reconcile_BlocksWhenNotInitialized tests that Reconcile blocks
@@ -88,6 +97,517 @@ func TestCostReconciler_Reconcile_RunsWhenInitialized(t *testing.T) {
 	assert.Equal(t, ctrl.Result{}, result, "Reconcile should return empty result (event-driven, no requeue)")
 }
 
+// TestCostReconciler_Reconcile_SavingsPlanLedger tests that billing-hour metrics are
+// emitted when the Savings Plan ledger is enabled.
+func TestCostReconciler_Reconcile_SavingsPlanLedger(t *testing.T) {
+	rispCache := cache.NewRISPCache()
+	rispCache.UpdateSavingsPlans("123456789012", []aws.SavingsPlan{
+		{
+			SavingsPlanARN:  "arn:aws:savingsplans::123456789012:savingsplan/sp-001",
+			SavingsPlanType: "Compute",
+			Commitment:      10.0,
+			AccountID:       "123456789012",
+			End:             time.Now().Add(365 * 24 * time.Hour),
+		},
+	})
+	pricingCache := cache.NewPricingCache()
+	cfg := &config.Config{}
+	m := metrics.NewMetrics(prometheus.NewRegistry(), cfg)
+
+	reconciler := &CostReconciler{
+		Calculator:   cost.NewCalculator(pricingCache, cfg),
+		Config:       cfg,
+		EC2Cache:     cache.NewEC2Cache(),
+		RISPCache:    rispCache,
+		PricingCache: pricingCache,
+		Metrics:      m,
+		Ledger:       cost.NewSavingsPlanLedger(),
+		Log:          logr.Discard(),
+	}
+	reconciler.initialized.Store(true)
+
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{})
+	assert.NoError(t, err)
+
+	// The SP is tracked with no usage yet (first observation starts the billing hour)
+	assert.Equal(t, 1, testutil.CollectAndCount(m.SavingsPlanBillingHourCommitmentUsed))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.SavingsPlanBillingHourUtilizationPercent))
+}
+
+// TestCostReconciler_Reconcile_StorageCosts tests that attached EBS volumes are
+// priced and reported when storage costs are enabled.
+func TestCostReconciler_Reconcile_StorageCosts(t *testing.T) {
+	ec2Cache := cache.NewEC2Cache()
+	ec2Cache.SetInstances("123456789012", "us-west-2", []aws.Instance{
+		{
+			InstanceID:       "i-001",
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			Lifecycle:        "on-demand",
+		},
+	})
+	ec2Cache.SetVolumes("123456789012", "us-west-2", []aws.Volume{
+		{
+			VolumeID:            "vol-001",
+			VolumeType:          aws.VolumeTypeGP2,
+			SizeGiB:             730,
+			Region:              "us-west-2",
+			AttachedInstanceIDs: []string{"i-001"},
+		},
+	})
+	pricingCache := cache.NewPricingCache()
+	pricingCache.SetOnDemandPrices(map[string]float64{"us-west-2:m5.xlarge:linux": 1.00})
+	pricingCache.SetEBSPrices(map[string]aws.EBSPrice{
+		"us-west-2:gp2": {VolumeType: aws.VolumeTypeGP2, Region: "us-west-2", PerGBMonth: 0.10},
+	})
+	cfg := &config.Config{}
+	m := metrics.NewMetrics(prometheus.NewRegistry(), cfg)
+
+	reconciler := &CostReconciler{
+		Calculator:   cost.NewCalculator(pricingCache, cfg),
+		Config:       cfg,
+		EC2Cache:     ec2Cache,
+		RISPCache:    cache.NewRISPCache(),
+		PricingCache: pricingCache,
+		NodeCache:    cache.NewNodeCache(),
+		Metrics:      m,
+		StorageCosts: true,
+		Log:          logr.Discard(),
+	}
+	reconciler.initialized.Store(true)
+
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+
+	// 730 GiB at $0.10/GB-month is $0.10/hour
+	require.Equal(t, 1, testutil.CollectAndCount(m.EC2InstanceStorageHourlyCost))
+	assert.InDelta(t, 0.10, testutil.ToFloat64(m.EC2InstanceStorageHourlyCost), 0.0001)
+	assert.InDelta(t, 1.10, testutil.ToFloat64(m.EC2InstanceTotalHourlyCost), 0.0001)
+
+	// Disabled, no storage metrics are emitted
+	reconciler.StorageCosts = false
+	_, err = reconciler.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, testutil.CollectAndCount(m.EC2InstanceStorageHourlyCost))
+}
+
+// TestCostReconciler_Reconcile_Recommendations tests that spillover is recorded when
+// recommendations are enabled.
+func TestCostReconciler_Reconcile_Recommendations(t *testing.T) {
+	ec2Cache := cache.NewEC2Cache()
+	ec2Cache.SetInstances("123456789012", "us-west-2", []aws.Instance{
+		{
+			InstanceID:   "i-001",
+			InstanceType: "m5.xlarge",
+			Region:       "us-west-2",
+			AccountID:    "123456789012",
+			State:        "running",
+			Lifecycle:    "on-demand",
+		},
+	})
+	pricingCache := cache.NewPricingCache()
+	pricingCache.SetOnDemandPrices(map[string]float64{"us-west-2:m5.xlarge:linux": 1.00})
+
+	cfg := &config.Config{}
+	reconciler := &CostReconciler{
+		Calculator:   cost.NewCalculator(pricingCache, cfg),
+		Config:       cfg,
+		EC2Cache:     ec2Cache,
+		RISPCache:    cache.NewRISPCache(),
+		PricingCache: pricingCache,
+		NodeCache:    cache.NewNodeCache(),
+		Metrics:      metrics.NewMetrics(prometheus.NewRegistry(), cfg),
+		Recommender:  recommend.NewRecommender(recommend.Options{Lookback: 24 * time.Hour}),
+		Log:          logr.Discard(),
+	}
+	reconciler.initialized.Store(true)
+
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+
+	// A single calculation starts recording; no complete hour yet
+	report := reconciler.Recommender.Recommend()
+	assert.Zero(t, report.ObservedHours)
+	assert.Empty(t, report.Recommendations)
+}
+
+// TestCostReconciler_Reconcile_NodePoolCosts tests that instance costs are rolled up
+// by Karpenter NodePool, from node labels and NodeClaims.
+func TestCostReconciler_Reconcile_NodePoolCosts(t *testing.T) {
+	ec2Cache := cache.NewEC2Cache()
+	ec2Cache.SetInstances("123456789012", "us-west-2", []aws.Instance{
+		{
+			InstanceID:       "i-001",
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			Lifecycle:        "on-demand",
+		},
+		{
+			// Launched by a NodeClaim but not registered as a node yet
+			InstanceID:       "i-002",
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			Lifecycle:        "on-demand",
+		},
+	})
+	pricingCache := cache.NewPricingCache()
+	pricingCache.SetOnDemandPrices(map[string]float64{"us-west-2:m5.xlarge:linux": 1.00})
+
+	nodeCache := cache.NewNodeCache()
+	_, err := nodeCache.UpsertNode(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{cache.LabelKarpenterNodePool: "default"},
+		},
+		Spec: corev1.NodeSpec{ProviderID: "aws:///us-west-2a/i-001"},
+	})
+	require.NoError(t, err)
+	_, err = nodeCache.UpsertNodeClaim("default-abcde", "aws:///us-west-2a/i-002",
+		map[string]string{cache.LabelKarpenterNodePool: "default"})
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	m := metrics.NewMetrics(prometheus.NewRegistry(), cfg)
+	reconciler := &CostReconciler{
+		Calculator:    cost.NewCalculator(pricingCache, cfg),
+		Config:        cfg,
+		EC2Cache:      ec2Cache,
+		RISPCache:     cache.NewRISPCache(),
+		PricingCache:  pricingCache,
+		NodeCache:     nodeCache,
+		NodePoolCosts: true,
+		Metrics:       m,
+		Log:           logr.Discard(),
+	}
+	reconciler.initialized.Store(true)
+
+	_, err = reconciler.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2.00, testutil.ToFloat64(m.NodePoolHourlyCost.WithLabelValues("default")))
+	assert.Equal(t, 2.00, testutil.ToFloat64(m.NodePoolShelfHourlyCost.WithLabelValues("default")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.NodePoolNodeCount.WithLabelValues("default", "on-demand")))
+}
+
+// TestCostReconciler_Reconcile_UnitCosts tests that per-vCPU and per-GiB costs are
+// emitted for instance types with cached capacity.
+func TestCostReconciler_Reconcile_UnitCosts(t *testing.T) {
+	ec2Cache := cache.NewEC2Cache()
+	ec2Cache.SetInstances("123456789012", "us-west-2", []aws.Instance{
+		{
+			InstanceID:       "i-001",
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			Lifecycle:        "on-demand",
+		},
+		{
+			InstanceID:       "i-002",
+			InstanceType:     "c5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			Lifecycle:        "on-demand",
+		},
+	})
+	ec2Cache.AddInstanceTypes([]aws.InstanceTypeInfo{
+		{InstanceType: "m5.xlarge", VCPUs: 4, MemoryMiB: 16384},
+	})
+	pricingCache := cache.NewPricingCache()
+	pricingCache.SetOnDemandPrices(map[string]float64{
+		"us-west-2:m5.xlarge:linux": 0.192,
+		"us-west-2:c5.xlarge:linux": 0.17,
+	})
+
+	cfg := &config.Config{}
+	m := metrics.NewMetrics(prometheus.NewRegistry(), cfg)
+	reconciler := &CostReconciler{
+		Calculator:   cost.NewCalculator(pricingCache, cfg),
+		Config:       cfg,
+		EC2Cache:     ec2Cache,
+		RISPCache:    cache.NewRISPCache(),
+		PricingCache: pricingCache,
+		NodeCache:    cache.NewNodeCache(),
+		UnitCosts:    true,
+		Metrics:      m,
+		Log:          logr.Discard(),
+	}
+	reconciler.initialized.Store(true)
+
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+
+	// c5.xlarge's capacity isn't known yet, so only m5.xlarge is reported
+	assert.Equal(t, 1, testutil.CollectAndCount(m.InstanceTypeVCPUHourlyCost))
+	assert.InDelta(t, 0.048,
+		testutil.ToFloat64(m.InstanceTypeVCPUHourlyCost.WithLabelValues("m5.xlarge", "us-west-2", "on_demand")), 0.0001)
+	assert.InDelta(t, 0.012,
+		testutil.ToFloat64(m.InstanceTypeMemoryGiBHourlyCost.WithLabelValues("m5.xlarge", "us-west-2", "on_demand")), 0.0001)
+}
+
+// TestCostReconciler_Reconcile_ExpirationForecast tests that Reserved Instances
+// expiring within the forecast horizon are reported with their projected cost.
+func TestCostReconciler_Reconcile_ExpirationForecast(t *testing.T) {
+	ec2Cache := cache.NewEC2Cache()
+	ec2Cache.SetInstances("123456789012", "us-west-2", []aws.Instance{
+		{
+			InstanceID:       "i-001",
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			Lifecycle:        "on-demand",
+		},
+	})
+	end := time.Now().Add(30 * 24 * time.Hour)
+	rispCache := cache.NewRISPCache()
+	rispCache.UpdateReservedInstances("us-west-2", "123456789012", []aws.ReservedInstance{
+		{ReservedInstanceID: "ri-soon", InstanceType: "m5.xlarge", Region: "us-west-2", AccountID: "123456789012",
+			InstanceCount: 1, State: "active", End: end},
+		{ReservedInstanceID: "ri-later", InstanceType: "c5.xlarge", Region: "us-west-2", AccountID: "123456789012",
+			InstanceCount: 1, State: "active", End: time.Now().Add(365 * 24 * time.Hour)},
+	})
+	pricingCache := cache.NewPricingCache()
+	pricingCache.SetOnDemandPrices(map[string]float64{"us-west-2:m5.xlarge:linux": 0.192})
+
+	cfg := &config.Config{Forecast: config.ForecastConfig{Horizon: "2160h"}}
+	m := metrics.NewMetrics(prometheus.NewRegistry(), cfg)
+	reconciler := &CostReconciler{
+		Calculator:         cost.NewCalculator(pricingCache, cfg),
+		Config:             cfg,
+		EC2Cache:           ec2Cache,
+		RISPCache:          rispCache,
+		PricingCache:       pricingCache,
+		NodeCache:          cache.NewNodeCache(),
+		ExpirationForecast: whatif.NewForecastCache(ForecastMaxAge),
+		Metrics:            m,
+		Log:                logr.Discard(),
+	}
+	reconciler.initialized.Store(true)
+
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+
+	// Only ri-soon is within the horizon; without it, i-001 runs on demand
+	assert.Equal(t, 1, testutil.CollectAndCount(m.CommitmentExpirationHourlyCostIncrease))
+	labels := []string{"reserved_instance", "ri-soon", "123456789012", ""}
+	assert.InDelta(t, 0.192,
+		testutil.ToFloat64(m.CommitmentExpirationHourlyCostIncrease.WithLabelValues(labels...)), 0.0001)
+	assert.InDelta(t, 0.192,
+		testutil.ToFloat64(m.CommitmentExpirationHourlySpilloverIncrease.WithLabelValues(labels...)), 0.0001)
+	assert.Equal(t, float64(end.Unix()),
+		testutil.ToFloat64(m.CommitmentExpirationTimestamp.WithLabelValues(labels...)))
+}
+
+// TestCostReconciler_Reconcile_PodAllocation tests that node costs are split among
+// pods when a PodCache is configured.
+func TestCostReconciler_Reconcile_PodAllocation(t *testing.T) {
+	ec2Cache := cache.NewEC2Cache()
+	ec2Cache.SetInstances("123456789012", "us-west-2", []aws.Instance{
+		{
+			InstanceID:       "i-001",
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			Lifecycle:        "on-demand",
+		},
+		{
+			// Not a node in this cluster
+			InstanceID:       "i-002",
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			Lifecycle:        "on-demand",
+		},
+	})
+	pricingCache := cache.NewPricingCache()
+	pricingCache.SetOnDemandPrices(map[string]float64{"us-west-2:m5.xlarge:linux": 1.00})
+
+	nodeCache := cache.NewNodeCache()
+	_, err := nodeCache.UpsertNode(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Spec:       corev1.NodeSpec{ProviderID: "aws:///us-west-2a/i-001"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	podCache := cache.NewPodCache()
+	podCache.UpsertPod(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: "frontend"},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	})
+
+	cfg := &config.Config{}
+	m := metrics.NewMetrics(prometheus.NewRegistry(), cfg)
+	reconciler := &CostReconciler{
+		Calculator:   cost.NewCalculator(pricingCache, cfg),
+		Config:       cfg,
+		EC2Cache:     ec2Cache,
+		RISPCache:    cache.NewRISPCache(),
+		PricingCache: pricingCache,
+		NodeCache:    nodeCache,
+		PodCache:     podCache,
+		Metrics:      m,
+		Log:          logr.Discard(),
+	}
+	reconciler.initialized.Store(true)
+
+	_, err = reconciler.Reconcile(context.Background(), ctrl.Request{})
+	assert.NoError(t, err)
+
+	// Default weighting: 0.50 × 1/4 cores + 0.50 × 8/16 GiB of the $1.00 node
+	assert.Equal(t, 1, testutil.CollectAndCount(m.PodHourlyCost))
+	assert.InDelta(t, 0.375, testutil.ToFloat64(m.PodHourlyCost.WithLabelValues("web", "frontend", "node-1")), 0.0001)
+	assert.InDelta(t, 0.375, testutil.ToFloat64(m.NamespaceHourlyCost.WithLabelValues("web")), 0.0001)
+	assert.InDelta(t, 0.625, testutil.ToFloat64(m.NamespaceHourlyCost.WithLabelValues(cost.IdleNamespace)), 0.0001)
+}
+
+// TestCostReconciler_historyRates tests that history rates carry the cluster and
+// node names used by the instance cost metric, including the Name tag fallback.
+func TestCostReconciler_historyRates(t *testing.T) {
+	ec2Cache := cache.NewEC2Cache()
+	ec2Cache.SetInstances("123456789012", "us-west-2", []aws.Instance{
+		{
+			InstanceID: "i-001",
+			Tags:       map[string]string{"kubernetes.io/cluster/prod": "owned"},
+		},
+		{
+			// Not registered as a node yet
+			InstanceID: "i-002",
+			Tags: map[string]string{
+				"kubernetes.io/cluster/prod": "owned",
+				"Name":                       "ip-10-0-0-2.ec2.internal",
+			},
+		},
+	})
+
+	nodeCache := cache.NewNodeCache()
+	_, err := nodeCache.UpsertNode(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Spec:       corev1.NodeSpec{ProviderID: "aws:///us-west-2a/i-001"},
+	})
+	require.NoError(t, err)
+
+	reconciler := &CostReconciler{
+		Config:    &config.Config{},
+		EC2Cache:  ec2Cache,
+		NodeCache: nodeCache,
+	}
+	rates := reconciler.historyRates(cost.CalculationResult{
+		InstanceCosts: map[string]cost.InstanceCost{
+			"i-001": {
+				InstanceID:    "i-001",
+				AccountID:     "123456789012",
+				InstanceType:  "m5.xlarge",
+				Region:        "us-west-2",
+				CoverageType:  cost.CoverageOnDemand,
+				EffectiveCost: 0.192,
+			},
+			"i-002": {InstanceID: "i-002", AccountID: "123456789012", EffectiveCost: 0.1},
+			"i-003": {InstanceID: "i-003", AccountID: "123456789012", EffectiveCost: 0.05},
+		},
+	})
+
+	byInstance := make(map[string]history.Rate)
+	for _, rate := range rates {
+		byInstance[rate.InstanceID] = rate
+	}
+	require.Len(t, byInstance, 3)
+	assert.Equal(t, history.Attributes{
+		AccountID:    "123456789012",
+		Region:       "us-west-2",
+		ClusterName:  "prod",
+		NodeName:     "node-1",
+		InstanceID:   "i-001",
+		InstanceType: "m5.xlarge",
+		CostType:     string(cost.CoverageOnDemand),
+	}, byInstance["i-001"].Attributes)
+	assert.Equal(t, 0.192, byInstance["i-001"].HourlyCost)
+	assert.Equal(t, "ip-10-0-0-2.ec2.internal", byInstance["i-002"].NodeName)
+	assert.Empty(t, byInstance["i-003"].ClusterName)
+	assert.Empty(t, byInstance["i-003"].NodeName)
+}
+
+// TestCostReconciler_serverlessUsage tests that Fargate pods and configured estimates
+// are priced as serverless usage.
+func TestCostReconciler_serverlessUsage(t *testing.T) {
+	podCache := cache.NewPodCache()
+	podCache.UpsertPod(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "web",
+			Name:        "api",
+			Labels:      map[string]string{cache.FargateProfileLabel: "default"},
+			Annotations: map[string]string{cache.FargateCapacityAnnotation: "1vCPU 2GB"},
+		},
+		Spec:   corev1.PodSpec{NodeName: "fargate-ip-10-0-1-2.us-west-2.compute.internal"},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	})
+	podCache.UpsertPod(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: "frontend"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	})
+
+	reconciler := &CostReconciler{
+		Config: &config.Config{
+			DefaultRegion: "us-west-2",
+			AWSAccounts:   []config.AWSAccount{{AccountID: "123456789012"}},
+			Serverless: config.ServerlessConfig{
+				Fargate: config.FargateConfig{VCPUHourPrice: 0.04, MemoryGBHourPrice: 0.005},
+				Estimates: []config.ServerlessEstimate{
+					{Name: "functions", Service: config.ServiceLambda, AccountID: "210987654321", HourlyCost: 2},
+				},
+			},
+		},
+		FargatePodCache: podCache,
+	}
+
+	usage := reconciler.serverlessUsage()
+	require.Len(t, usage, 2)
+	assert.Equal(t, "fargate/web/api", usage[0].ID)
+	assert.Equal(t, cost.ServiceFargate, usage[0].Service)
+	assert.Equal(t, "123456789012", usage[0].AccountID)
+	assert.Equal(t, "us-west-2", usage[0].Region)
+	assert.InDelta(t, 0.04+2*0.005, usage[0].OnDemandCost, 0.0001)
+	assert.InDelta(t, (0.04+2*0.005)*0.80, usage[0].SavingsPlanRate, 0.0001)
+	assert.Equal(t, "estimate/functions", usage[1].ID)
+	assert.Equal(t, "210987654321", usage[1].AccountID)
+	assert.InDelta(t, 2*0.88, usage[1].SavingsPlanRate, 0.0001)
+}
+
 // TestCostReconciler_waitForDependencies tests waiting for all ready channels.
 func TestCostReconciler_waitForDependencies(t *testing.T) {
 	pricingReadyCh := make(chan struct{})
@@ -174,6 +694,30 @@ func TestCostReconciler_waitForDependencies_NilChannels(t *testing.T) {
 	}
 }
 
+// TestCostReconciler_waitForDependencies_WarmStart tests that the ready channels are
+// skipped when the caches were restored from a snapshot.
+func TestCostReconciler_waitForDependencies_WarmStart(t *testing.T) {
+	reconciler := &CostReconciler{
+		Log:              logr.Discard(),
+		PricingReadyChan: make(chan struct{}), // Never closed
+		EC2ReadyChan:     make(chan struct{}), // Never closed
+		WarmStart:        true,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		reconciler.waitForDependencies()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		// Success
+	case <-time.After(100 * time.Millisecond):
+		t.Error("waitForDependencies should not wait for ready channels on a warm start")
+	}
+}
+
 // TestCostReconciler_Run_SetsInitializedFlag tests that Run() sets the initialized flag.
 func TestCostReconciler_Run_SetsInitializedFlag(t *testing.T) {
 	pricingReadyCh := make(chan struct{})
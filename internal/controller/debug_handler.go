@@ -24,6 +24,10 @@ import (
 	"github.com/nextdoor/lumina/internal/cache"
 )
 
+// ExplainPath is the URL path of the cost explanation endpoint, which DebugHandler
+// serves alongside the cache endpoints under /debug/cache/.
+const ExplainPath = "/debug/cost/explain"
+
 // DebugHandler provides HTTP endpoints for inspecting internal caches.
 // These endpoints are useful for debugging and should only be enabled in development/staging.
 //
@@ -36,10 +40,14 @@ import (
 //   - GET /debug/cache/pricing/sp/lookup?instance_type=<type>&region=<region>&tenancy=<tenancy>&os=<os>&sp=<arn> - Lookup specific SP rate
 //   - GET /debug/cache/pricing/spot     - List all spot prices in cache
 //   - GET /debug/cache/stats            - Show cache statistics
+//   - GET /debug/cost/explain?instance_id=<id> - Explain how an instance's cost was calculated
 type DebugHandler struct {
 	EC2Cache     *cache.EC2Cache
 	RISPCache    *cache.RISPCache
 	PricingCache *cache.PricingCache
+
+	// Reconciler runs cost explanations; nil until it's initialized
+	Reconciler *CostReconciler
 }
 
 // ServeHTTP implements http.Handler interface.
@@ -50,6 +58,8 @@ func (h *DebugHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/debug/cache/")
 
 	switch path {
+	case ExplainPath: // Not under /debug/cache/, so left untrimmed
+		h.handleExplain(w, r)
 	case "ec2":
 		h.handleEC2(w, r)
 	case "risp":
@@ -81,6 +91,7 @@ func (h *DebugHandler) handleIndex(w http.ResponseWriter, _ *http.Request) {
 			"/debug/cache/pricing/sp/lookup?instance_type=<type>&region=<region>&tenancy=<tenancy>&os=<os>&sp=<arn> - Lookup specific SP rate",
 			"/debug/cache/pricing/spot     - List all spot prices",
 			"/debug/cache/stats            - Show cache statistics",
+			"/debug/cost/explain?instance_id=<id> - Explain how an instance's cost was calculated",
 		},
 	}
 	_ = json.NewEncoder(w).Encode(response) // Best-effort encoding for debug endpoint
@@ -120,7 +131,7 @@ func (h *DebugHandler) handleEC2(w http.ResponseWriter, _ *http.Request) {
 	_ = json.NewEncoder(w).Encode(response) // Best-effort encoding for debug endpoint
 }
 
-// handleRISP returns all Reserved Instances and Savings Plans in cache.
+// handleRISP returns all Reserved Instances, Capacity Reservations and Savings Plans in cache.
 func (h *DebugHandler) handleRISP(w http.ResponseWriter, _ *http.Request) {
 	if h.RISPCache == nil {
 		http.Error(w, "RISP cache not available", http.StatusServiceUnavailable)
@@ -128,6 +139,7 @@ func (h *DebugHandler) handleRISP(w http.ResponseWriter, _ *http.Request) {
 	}
 
 	ris := h.RISPCache.GetAllReservedInstances()
+	crs := h.RISPCache.GetAllCapacityReservations()
 	sps := h.RISPCache.GetAllSavingsPlans()
 	stats := h.RISPCache.GetStats()
 
@@ -138,6 +150,10 @@ func (h *DebugHandler) handleRISP(w http.ResponseWriter, _ *http.Request) {
 			"count": len(ris),
 			"items": ris,
 		},
+		"capacity_reservations": map[string]interface{}{
+			"count": len(crs),
+			"items": crs,
+		},
 		"savings_plans": map[string]interface{}{
 			"count": len(sps),
 			"items": sps,
@@ -377,15 +393,19 @@ func (h *DebugHandler) handleStats(w http.ResponseWriter, _ *http.Request) {
 		instances := h.EC2Cache.GetAllInstances()
 		stats["ec2"] = map[string]interface{}{
 			"total_instances": len(instances),
+			"ebs_volumes":     h.EC2Cache.GetVolumeCount(),
+			"instance_types":  len(h.EC2Cache.GetInstanceTypes()),
 		}
 	}
 
 	if h.RISPCache != nil {
 		ris := h.RISPCache.GetAllReservedInstances()
+		crs := h.RISPCache.GetAllCapacityReservations()
 		sps := h.RISPCache.GetAllSavingsPlans()
 		stats["risp"] = map[string]interface{}{
-			"reserved_instances": len(ris),
-			"savings_plans":      len(sps),
+			"reserved_instances":    len(ris),
+			"capacity_reservations": len(crs),
+			"savings_plans":         len(sps),
 		}
 	}
 
@@ -397,12 +417,48 @@ func (h *DebugHandler) handleStats(w http.ResponseWriter, _ *http.Request) {
 			"ondemand_prices": len(onDemand),
 			"sp_rates":        len(spRates),
 			"spot_prices":     spotStats.SpotPriceCount,
+			"ebs_prices":      len(h.PricingCache.GetAllEBSPrices()),
 		}
 	}
 
 	_ = json.NewEncoder(w).Encode(stats) // Best-effort encoding for debug endpoint
 }
 
+// handleExplain recalculates costs from the current caches and returns every Reserved
+// Instance and Savings Plan allocation decision involving an instance: which were
+// considered and why each didn't cover it, its rank by savings percentage, the
+// capacity or commitment left at the time, and its final cost breakdown.
+//
+// Query parameters:
+//   - instance_id (required): Instance to explain (e.g., "i-0abc123def456")
+func (h *DebugHandler) handleExplain(w http.ResponseWriter, r *http.Request) {
+	if h.Reconciler == nil {
+		http.Error(w, "Cost reconciler not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	instanceID := r.URL.Query().Get("instance_id")
+	if instanceID == "" {
+		http.Error(w, "Missing required parameter: instance_id", http.StatusBadRequest)
+		return
+	}
+
+	explanation, found := h.Reconciler.Explain(instanceID)
+	if !found {
+		http.Error(w, fmt.Sprintf("No cost calculated for instance %s", instanceID), http.StatusNotFound)
+		return
+	}
+
+	response := map[string]interface{}{
+		"instance":           explanation.Instance,
+		"cost":               explanation.Cost,
+		"reserved_instances": explanation.ReservedInstances,
+		"savings_plans":      explanation.SavingsPlans,
+	}
+
+	_ = json.NewEncoder(w).Encode(response) // Best-effort encoding for debug endpoint
+}
+
 // NewDebugHandler creates a new DebugHandler with the provided caches.
 func NewDebugHandler(
 	ec2Cache *cache.EC2Cache,
@@ -422,25 +478,30 @@ func NewDebugHandler(
 // Example usage:
 //
 //	mux := http.NewServeMux()
-//	controller.RegisterDebugEndpoints(mux, ec2Cache, rispCache, pricingCache)
+//	controller.RegisterDebugEndpoints(mux, ec2Cache, rispCache, pricingCache, costReconciler)
 //	http.ListenAndServe(":8080", mux)
 func RegisterDebugEndpoints(
 	mux *http.ServeMux,
 	ec2Cache *cache.EC2Cache,
 	rispCache *cache.RISPCache,
 	pricingCache *cache.PricingCache,
+	reconciler *CostReconciler,
 ) {
 	handler := NewDebugHandler(ec2Cache, rispCache, pricingCache)
+	handler.Reconciler = reconciler
 
-	// Register all debug endpoints under /debug/cache/
-	mux.HandleFunc("/debug/cache/", func(w http.ResponseWriter, r *http.Request) {
+	getOnly := func(w http.ResponseWriter, r *http.Request) {
 		// Only allow GET requests
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		handler.ServeHTTP(w, r)
-	})
+	}
+
+	// Register all debug endpoints under /debug/cache/, plus the cost explanation
+	mux.HandleFunc("/debug/cache/", getOnly)
+	mux.HandleFunc(ExplainPath, getOnly)
 
 	fmt.Println("Registered debug endpoints:")
 	fmt.Println("  GET /debug/cache/              - Index of available endpoints")
@@ -451,4 +512,5 @@ func RegisterDebugEndpoints(
 	fmt.Println("  GET /debug/cache/pricing/sp?sp=<arn> - Filter SP rates by ARN")
 	fmt.Println("  GET /debug/cache/pricing/sp/lookup?instance_type=<type>&region=<region>&tenancy=<tenancy>&os=<os>&sp=<arn> - Lookup specific SP rate")
 	fmt.Println("  GET /debug/cache/stats         - Show cache statistics")
+	fmt.Println("  GET /debug/cost/explain?instance_id=<id> - Explain how an instance's cost was calculated")
 }
@@ -0,0 +1,84 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/cost"
+)
+
+// TestDebugHandler_Explain tests explaining the cost of an instance covered by a
+// Savings Plan.
+func TestDebugHandler_Explain(t *testing.T) {
+	handler := NewDebugHandler(nil, nil, nil)
+	handler.Reconciler = newWhatIfReconciler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ExplainPath+"?instance_id=i-001", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response struct {
+		Instance          aws.Instance                    `json:"instance"`
+		Cost              cost.InstanceCost               `json:"cost"`
+		SavingsPlans      []cost.SavingsPlanDecision      `json:"savings_plans"`
+		ReservedInstances []cost.ReservedInstanceDecision `json:"reserved_instances"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "m5.xlarge", response.Instance.InstanceType)
+	assert.Equal(t, cost.CoverageComputeSavingsPlan, response.Cost.CoverageType)
+	assert.Empty(t, response.ReservedInstances)
+
+	// Owner pass, then the shared pass the owner's instance isn't part of
+	require.Len(t, response.SavingsPlans, 2)
+	assert.Equal(t, 1, response.SavingsPlans[0].Rank)
+	assert.Empty(t, response.SavingsPlans[0].Rejection)
+	assert.InDelta(t, 0.72, response.SavingsPlans[0].RemainingCommitment, 1e-9)
+	assert.InDelta(t, response.Cost.SavingsPlanCoverage, response.SavingsPlans[0].Coverage, 1e-9)
+	assert.Equal(t, cost.RejectedAccount, response.SavingsPlans[1].Rejection)
+}
+
+// TestDebugHandler_ExplainErrors tests the responses when an instance can't be explained.
+func TestDebugHandler_ExplainErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		reconciler *CostReconciler
+		query      string
+		wantStatus int
+	}{
+		{name: "not initialized", query: "?instance_id=i-001", wantStatus: http.StatusServiceUnavailable},
+		{name: "missing instance_id", reconciler: newWhatIfReconciler(), wantStatus: http.StatusBadRequest},
+		{name: "unknown instance", reconciler: newWhatIfReconciler(), query: "?instance_id=i-missing",
+			wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewDebugHandler(nil, nil, nil)
+			handler.Reconciler = tt.reconciler
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ExplainPath+tt.query, nil))
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
@@ -50,6 +50,14 @@ type EC2Reconciler struct {
 	// Configuration with AWS account details
 	Config *config.Config
 
+	// ConfigProvider optionally supplies the current configuration, e.g. from a
+	// config.Watcher that reloads the config file. If nil, Config is used.
+	ConfigProvider config.Provider
+
+	// Accounts optionally supplies the AWS accounts to query, e.g. from account
+	// discovery. If nil, Config.AWSAccounts is used.
+	Accounts aws.AccountLister
+
 	// Cache for storing EC2 instance data
 	Cache *cache.EC2Cache
 
@@ -74,6 +82,20 @@ type EC2Reconciler struct {
 
 	// HealthTracker is used to report permanent failures to the readiness probe.
 	HealthTracker *ReconcilerHealthTracker
+
+	// FetchVolumes enables querying the EBS volumes attached to instances, for
+	// storage cost attribution (storage.enabled).
+	FetchVolumes bool
+
+	// FetchInstanceTypes enables querying the capacity of running instance types,
+	// for per-vCPU and per-GiB costs (unitCosts.enabled).
+	FetchInstanceTypes bool
+}
+
+// currentConfig returns the configuration in effect, which changes when the
+// config file is reloaded.
+func (r *EC2Reconciler) currentConfig() *config.Config {
+	return activeConfig(r.Config, r.ConfigProvider)
 }
 
 // Reconcile performs a single reconciliation cycle.
@@ -97,7 +119,7 @@ func (r *EC2Reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Res
 	//
 	// Individual accounts can override this default by setting their own
 	// 'regions' field in the awsAccounts configuration.
-	defaultRegions := r.Config.Regions
+	defaultRegions := r.currentConfig().Regions
 	if len(defaultRegions) == 0 {
 		// Config didn't specify regions, try reconciler default
 		defaultRegions = r.Regions
@@ -110,10 +132,11 @@ func (r *EC2Reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Res
 	// Query all account+region combinations in parallel
 	// This is safe because each account+region pair is independent and the
 	// cache.SetInstances() method is thread-safe
+	accounts := monitoredAccounts(r.currentConfig(), r.Accounts)
 	var wg sync.WaitGroup
-	errors := make(chan error, len(r.Config.AWSAccounts)*len(defaultRegions))
+	errors := make(chan error, len(accounts)*len(defaultRegions))
 
-	for _, account := range r.Config.AWSAccounts {
+	for _, account := range accounts {
 		// Determine regions to query for this specific account.
 		// Account-specific regions (if configured) override the global default.
 		// This allows flexibility for accounts that only operate in certain regions.
@@ -178,18 +201,7 @@ func (r *EC2Reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Res
 		})
 	}
 
-	// Parse reconciliation interval from config, with default fallback to 5 minutes
-	// The interval determines how often we refresh EC2 instance inventory data
-	requeueAfter := 5 * time.Minute // Default
-	if r.Config.Reconciliation.EC2 != "" {
-		if duration, err := time.ParseDuration(r.Config.Reconciliation.EC2); err == nil {
-			requeueAfter = duration
-		} else {
-			log.Error(err, "invalid EC2 reconciliation interval, using default",
-				"configured_interval", r.Config.Reconciliation.EC2,
-				"default", "5m")
-		}
-	}
+	requeueAfter := r.getReconciliationInterval(log)
 
 	// Log the configured interval (helpful for verifying configuration)
 	log.V(1).Info("reconciliation interval configured", "next_run_in", requeueAfter.String())
@@ -224,12 +236,7 @@ func (r *EC2Reconciler) reconcileAccountRegion(
 	ec2Client, err := r.AWSClient.EC2(ctx, accountConfig)
 	if err != nil {
 		// Record failure in metrics
-		r.Metrics.DataLastSuccess.WithLabelValues(
-			account.AccountID,
-			account.Name,
-			region,
-			"ec2_instances",
-		).Set(0)
+		r.Metrics.RecordDataCollection(account.AccountID, account.Name, region, "ec2_instances", false)
 		return fmt.Errorf("failed to create EC2 client: %w", err)
 	}
 
@@ -242,12 +249,7 @@ func (r *EC2Reconciler) reconcileAccountRegion(
 
 	if err != nil {
 		// Record failure in metrics
-		r.Metrics.DataLastSuccess.WithLabelValues(
-			account.AccountID,
-			account.Name,
-			region,
-			"ec2_instances",
-		).Set(0)
+		r.Metrics.RecordDataCollection(account.AccountID, account.Name, region, "ec2_instances", false)
 
 		log.Error(err, "failed to describe instances")
 		return fmt.Errorf("failed to describe instances in %s: %w", region, err)
@@ -258,12 +260,7 @@ func (r *EC2Reconciler) reconcileAccountRegion(
 	r.Cache.SetInstances(account.AccountID, region, instances)
 
 	// Record success in metrics
-	r.Metrics.DataLastSuccess.WithLabelValues(
-		account.AccountID,
-		account.Name,
-		region,
-		"ec2_instances",
-	).Set(1)
+	r.Metrics.RecordDataCollection(account.AccountID, account.Name, region, "ec2_instances", true)
 
 	// Mark that ec2_instances data was updated for this account+region
 	r.Metrics.MarkDataUpdated(account.AccountID, account.Name, region, "ec2_instances")
@@ -279,9 +276,91 @@ func (r *EC2Reconciler) reconcileAccountRegion(
 		"state_breakdown", stateCount,
 		"duration_seconds", duration.Seconds())
 
+	if r.FetchVolumes {
+		r.reconcileVolumes(ctx, log, ec2Client, account, region)
+	}
+	if r.FetchInstanceTypes {
+		r.reconcileInstanceTypes(ctx, log, ec2Client, account, region, instances)
+	}
+
 	return nil
 }
 
+// reconcileVolumes queries the EBS volumes in a region and replaces them in the cache.
+// Failures are logged but don't fail the reconciliation: instance data is already
+// updated, and the previous volumes stay cached until the next cycle.
+func (r *EC2Reconciler) reconcileVolumes(
+	ctx context.Context,
+	log logr.Logger,
+	ec2Client aws.EC2Client,
+	account config.AWSAccount,
+	region string,
+) {
+	volumes, err := ec2Client.DescribeVolumes(ctx, []string{region})
+	if err != nil {
+		r.Metrics.RecordDataCollection(account.AccountID, account.Name, region, "ebs_volumes", false)
+		log.Error(err, "failed to describe volumes")
+		return
+	}
+
+	r.Cache.SetVolumes(account.AccountID, region, volumes)
+	r.Metrics.RecordDataCollection(account.AccountID, account.Name, region, "ebs_volumes", true)
+	r.Metrics.MarkDataUpdated(account.AccountID, account.Name, region, "ebs_volumes")
+
+	log.V(1).Info("updated EBS volumes", "total_count", len(volumes))
+}
+
+// reconcileInstanceTypes queries the capacity of instance types in a region that
+// aren't cached yet. Capacity doesn't change, so each type is only queried once,
+// in the first region it's seen in. Failures are logged but don't fail the
+// reconciliation; the types are retried next cycle.
+func (r *EC2Reconciler) reconcileInstanceTypes(
+	ctx context.Context,
+	log logr.Logger,
+	ec2Client aws.EC2Client,
+	account config.AWSAccount,
+	region string,
+	instances []aws.Instance,
+) {
+	missing := r.Cache.GetMissingInstanceTypes(instances)
+	if len(missing) == 0 {
+		return
+	}
+
+	infos, err := ec2Client.DescribeInstanceTypes(ctx, missing)
+	if err != nil {
+		r.Metrics.RecordDataCollection(account.AccountID, account.Name, region, "instance_types", false)
+		log.Error(err, "failed to describe instance types", "instance_types", missing)
+		return
+	}
+
+	r.Cache.AddInstanceTypes(infos)
+	r.Metrics.RecordDataCollection(account.AccountID, account.Name, region, "instance_types", true)
+	r.Metrics.MarkDataUpdated(account.AccountID, account.Name, region, "instance_types")
+
+	log.V(1).Info("updated instance types", "total_count", len(infos))
+}
+
+// getReconciliationInterval parses the reconciliation interval from config, falling
+// back to 5 minutes. It's read on every cycle so a reloaded config takes effect.
+func (r *EC2Reconciler) getReconciliationInterval(log logr.Logger) time.Duration {
+	defaultInterval := 5 * time.Minute
+
+	if r.currentConfig().Reconciliation.EC2 == "" {
+		return defaultInterval
+	}
+
+	duration, err := time.ParseDuration(r.currentConfig().Reconciliation.EC2)
+	if err != nil {
+		log.Error(err, "invalid EC2 reconciliation interval, using default",
+			"configured_interval", r.currentConfig().Reconciliation.EC2,
+			"default", defaultInterval.String())
+		return defaultInterval
+	}
+
+	return duration
+}
+
 // Run runs the reconciler as a goroutine with timer-based reconciliation.
 //
 // Uses a simple time.Ticker for periodic reconciliation instead of controller-runtime's
@@ -314,17 +393,7 @@ func (r *EC2Reconciler) Run(ctx context.Context) error {
 		log.V(1).Info("signaled that EC2 cache is ready for dependent reconcilers")
 	}
 
-	// Parse reconciliation interval from config, with default fallback to 5 minutes
-	interval := 5 * time.Minute // Default
-	if r.Config.Reconciliation.EC2 != "" {
-		if duration, err := time.ParseDuration(r.Config.Reconciliation.EC2); err == nil {
-			interval = duration
-		} else {
-			log.Error(err, "invalid EC2 reconciliation interval, using default",
-				"configured_interval", r.Config.Reconciliation.EC2,
-				"default", "5m")
-		}
-	}
+	interval := r.getReconciliationInterval(log)
 
 	// Setup ticker for EC2 data
 	log.Info("configured reconciliation interval", "interval", interval.String())
@@ -342,6 +411,7 @@ func (r *EC2Reconciler) Run(ctx context.Context) error {
 				log.Error(err, "scheduled reconciliation failed")
 				// Don't exit - continue with next cycle
 			}
+			interval = resetOnIntervalChange(log, ticker, interval, r.getReconciliationInterval(log))
 		}
 	}
 }
@@ -228,6 +228,93 @@ func TestEC2Reconciler_Reconcile_MultipleAccounts(t *testing.T) {
 	assert.Equal(t, "i-account2-1", account2Instances[0].InstanceID)
 }
 
+// staticAccountLister is an aws.AccountLister returning a fixed list of accounts.
+type staticAccountLister []config.AWSAccount
+
+func (l staticAccountLister) Accounts() []config.AWSAccount {
+	return l
+}
+
+// TestEC2Reconciler_Reconcile_AccountLister tests that accounts from the lister
+// (e.g., account discovery) replace the configured accounts.
+func TestEC2Reconciler_Reconcile_AccountLister(t *testing.T) {
+	mockClient := aws.NewMockClient()
+	ctx := context.Background()
+
+	for _, accountID := range []string{"111111111111", "222222222222"} {
+		ec2Client, err := mockClient.EC2(ctx, aws.AccountConfig{AccountID: accountID})
+		require.NoError(t, err)
+		ec2Client.(*aws.MockEC2Client).Instances = []aws.Instance{
+			{InstanceID: "i-" + accountID, Region: "us-west-2", AccountID: accountID, State: "running"},
+		}
+	}
+
+	ec2Cache := cache.NewEC2Cache()
+	reconciler := &EC2Reconciler{
+		AWSClient: mockClient,
+		Config: &config.Config{
+			AWSAccounts: []config.AWSAccount{{AccountID: "111111111111", Name: "configured"}},
+		},
+		Accounts: staticAccountLister{{AccountID: "222222222222", Name: "discovered"}},
+		Cache:    ec2Cache,
+		Metrics:  metrics.NewMetrics(prometheus.NewRegistry(), newTestConfig()),
+		Log:      logr.Discard(),
+		Regions:  []string{"us-west-2"},
+	}
+
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{})
+	require.NoError(t, err)
+
+	assert.Empty(t, ec2Cache.GetInstancesByAccount("111111111111"))
+	assert.Len(t, ec2Cache.GetInstancesByAccount("222222222222"), 1)
+}
+
+// staticConfigProvider is a config.Provider returning a fixed configuration.
+type staticConfigProvider struct {
+	cfg *config.Config
+}
+
+func (p staticConfigProvider) Current() *config.Config {
+	return p.cfg
+}
+
+// TestEC2Reconciler_Reconcile_ConfigProvider tests that accounts, regions and the
+// interval come from the provider's (reloaded) configuration.
+func TestEC2Reconciler_Reconcile_ConfigProvider(t *testing.T) {
+	mockClient := aws.NewMockClient()
+	ctx := context.Background()
+
+	ec2Client, err := mockClient.EC2(ctx, aws.AccountConfig{AccountID: "222222222222"})
+	require.NoError(t, err)
+	ec2Client.(*aws.MockEC2Client).Instances = []aws.Instance{
+		{InstanceID: "i-east", Region: "us-east-1", AccountID: "222222222222", State: "running"},
+	}
+
+	ec2Cache := cache.NewEC2Cache()
+	reconciler := &EC2Reconciler{
+		AWSClient: mockClient,
+		Config: &config.Config{
+			AWSAccounts: []config.AWSAccount{{AccountID: "111111111111", Name: "startup"}},
+			Regions:     []string{"us-west-2"},
+		},
+		ConfigProvider: staticConfigProvider{&config.Config{
+			AWSAccounts:    []config.AWSAccount{{AccountID: "222222222222", Name: "reloaded"}},
+			Regions:        []string{"us-east-1"},
+			Reconciliation: config.ReconciliationConfig{EC2: "2m"},
+		}},
+		Cache:   ec2Cache,
+		Metrics: metrics.NewMetrics(prometheus.NewRegistry(), newTestConfig()),
+		Log:     logr.Discard(),
+	}
+
+	result, err := reconciler.Reconcile(ctx, ctrl.Request{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2*time.Minute, result.RequeueAfter)
+	assert.Empty(t, ec2Cache.GetInstancesByAccount("111111111111"))
+	assert.Len(t, ec2Cache.GetInstancesByAccount("222222222222"), 1)
+}
+
 // TestEC2Reconciler_Reconcile_APIError tests handling of API errors.
 func TestEC2Reconciler_Reconcile_APIError(t *testing.T) {
 	// Create mock client
@@ -691,6 +778,106 @@ func TestEC2Reconciler_reconcileAccountRegion_Error(t *testing.T) {
 	assert.Empty(t, allInstances)
 }
 
+// TestEC2Reconciler_reconcileAccountRegion_Volumes tests that EBS volumes are cached
+// when FetchVolumes is set, and that a volume failure doesn't fail the region.
+func TestEC2Reconciler_reconcileAccountRegion_Volumes(t *testing.T) {
+	mockClient := aws.NewMockClient()
+	ctx := context.Background()
+
+	ec2Client, err := mockClient.EC2(ctx, aws.AccountConfig{
+		AccountID: "123456789012",
+		Region:    "us-west-2",
+	})
+	require.NoError(t, err)
+	mockEC2 := ec2Client.(*aws.MockEC2Client)
+	mockEC2.Instances = []aws.Instance{
+		{InstanceID: "i-test-123", InstanceType: "m5.large", Region: "us-west-2", State: "running"},
+	}
+	mockEC2.Volumes = []aws.Volume{
+		{
+			VolumeID:            "vol-001",
+			VolumeType:          aws.VolumeTypeGP3,
+			SizeGiB:             100,
+			Region:              "us-west-2",
+			AttachedInstanceIDs: []string{"i-test-123"},
+		},
+	}
+
+	ec2Cache := cache.NewEC2Cache()
+	reconciler := &EC2Reconciler{
+		AWSClient:    mockClient,
+		Config:       &config.Config{DefaultRegion: "us-west-2"},
+		Cache:        ec2Cache,
+		Metrics:      metrics.NewMetrics(prometheus.NewRegistry(), newTestConfig()),
+		Log:          logr.Discard(),
+		FetchVolumes: true,
+	}
+	account := config.AWSAccount{AccountID: "123456789012", Name: "test-account"}
+
+	require.NoError(t, reconciler.reconcileAccountRegion(ctx, account, "us-west-2"))
+	volumes := ec2Cache.GetVolumesByInstance()
+	require.Len(t, volumes["i-test-123"], 1)
+	assert.Equal(t, "vol-001", volumes["i-test-123"][0].VolumeID)
+
+	// A failed volume query keeps the previous volumes and still updates instances
+	mockEC2.DescribeVolumesError = assert.AnError
+	require.NoError(t, reconciler.reconcileAccountRegion(ctx, account, "us-west-2"))
+	assert.Equal(t, 1, ec2Cache.GetVolumeCount())
+	assert.Equal(t, 2, mockEC2.DescribeVolumesCallCount)
+
+	// Volumes aren't queried unless enabled
+	reconciler.FetchVolumes = false
+	require.NoError(t, reconciler.reconcileAccountRegion(ctx, account, "us-west-2"))
+	assert.Equal(t, 2, mockEC2.DescribeVolumesCallCount)
+}
+
+// TestEC2Reconciler_reconcileAccountRegion_InstanceTypes tests that instance type
+// capacity is only queried for types that aren't cached, and that failures are retried.
+func TestEC2Reconciler_reconcileAccountRegion_InstanceTypes(t *testing.T) {
+	mockClient := aws.NewMockClient()
+	ctx := context.Background()
+
+	ec2Client, err := mockClient.EC2(ctx, aws.AccountConfig{
+		AccountID: "123456789012",
+		Region:    "us-west-2",
+	})
+	require.NoError(t, err)
+	mockEC2 := ec2Client.(*aws.MockEC2Client)
+	mockEC2.Instances = []aws.Instance{
+		{InstanceID: "i-test-123", InstanceType: "m5.large", Region: "us-west-2", State: "running"},
+	}
+	mockEC2.InstanceTypes = []aws.InstanceTypeInfo{
+		{InstanceType: "m5.large", VCPUs: 2, MemoryMiB: 8192},
+	}
+	mockEC2.DescribeInstanceTypesError = assert.AnError
+
+	ec2Cache := cache.NewEC2Cache()
+	reconciler := &EC2Reconciler{
+		AWSClient:          mockClient,
+		Config:             &config.Config{DefaultRegion: "us-west-2"},
+		Cache:              ec2Cache,
+		Metrics:            metrics.NewMetrics(prometheus.NewRegistry(), newTestConfig()),
+		Log:                logr.Discard(),
+		FetchInstanceTypes: true,
+	}
+	account := config.AWSAccount{AccountID: "123456789012", Name: "test-account"}
+
+	// A failed query doesn't fail the region
+	require.NoError(t, reconciler.reconcileAccountRegion(ctx, account, "us-west-2"))
+	assert.Empty(t, ec2Cache.GetInstanceTypes())
+
+	// The type is retried and cached
+	mockEC2.DescribeInstanceTypesError = nil
+	require.NoError(t, reconciler.reconcileAccountRegion(ctx, account, "us-west-2"))
+	require.Contains(t, ec2Cache.GetInstanceTypes(), "m5.large")
+	assert.Equal(t, int32(2), ec2Cache.GetInstanceTypes()["m5.large"].VCPUs)
+	assert.Equal(t, 2, mockEC2.DescribeInstanceTypesCallCount)
+
+	// Cached types aren't queried again
+	require.NoError(t, reconciler.reconcileAccountRegion(ctx, account, "us-west-2"))
+	assert.Equal(t, 2, mockEC2.DescribeInstanceTypesCallCount)
+}
+
 // TestEC2Reconciler_Reconcile_StateBreakdown tests logging of instance state breakdown.
 func TestEC2Reconciler_Reconcile_StateBreakdown(t *testing.T) {
 	// Create mock client
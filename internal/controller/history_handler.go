@@ -0,0 +1,127 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/history"
+)
+
+// HistoryCostsPath is the URL path of the cost history query endpoint.
+const HistoryCostsPath = "/api/v1/history/costs"
+
+// HistoryHandler serves range queries over the cost history store.
+//
+// Endpoint:
+//   - GET /api/v1/history/costs?start=<time>&end=<time>&group_by=<dims>&<dim>=<value>
+//
+// start is required and end defaults to now. Both accept RFC 3339 timestamps or
+// YYYY-MM-DD dates (midnight UTC). group_by is a comma-separated list of dimensions,
+// and any other parameter filters on the dimension of the same name, e.g.
+//
+//	/api/v1/history/costs?start=2025-01-01&end=2025-02-01&account_id=123456789012&group_by=cluster_name
+type HistoryHandler struct {
+	// Store to query; nil until history is configured (config history.path)
+	Store *history.Store
+}
+
+// NewHistoryHandler creates a new HistoryHandler querying store.
+func NewHistoryHandler(store *history.Store) *HistoryHandler {
+	return &HistoryHandler{Store: store}
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h *HistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Store == nil {
+		http.Error(w, "cost history not enabled (set history.path)", http.StatusServiceUnavailable)
+		return
+	}
+
+	query, err := parseHistoryQuery(r, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.Store.Query(query)
+	if err != nil {
+		// The query was validated while parsing, so this is a storage failure
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result) // Best-effort encoding, headers already sent
+}
+
+// parseHistoryQuery builds a validated history query from the request parameters.
+func parseHistoryQuery(r *http.Request, now time.Time) (history.Query, error) {
+	params := r.URL.Query()
+	query := history.Query{End: now, Filters: make(map[string]string)}
+
+	start := params.Get("start")
+	if start == "" {
+		return history.Query{}, fmt.Errorf("missing required parameter: start")
+	}
+	var err error
+	if query.Start, err = parseHistoryTime(start); err != nil {
+		return history.Query{}, fmt.Errorf("invalid start: %w", err)
+	}
+	if end := params.Get("end"); end != "" {
+		if query.End, err = parseHistoryTime(end); err != nil {
+			return history.Query{}, fmt.Errorf("invalid end: %w", err)
+		}
+	}
+
+	if groupBy := params.Get("group_by"); groupBy != "" {
+		for _, dimension := range strings.Split(groupBy, ",") {
+			query.GroupBy = append(query.GroupBy, strings.TrimSpace(dimension))
+		}
+	}
+
+	for name, values := range params {
+		if name == "start" || name == "end" || name == "group_by" {
+			continue
+		}
+		query.Filters[name] = values[0]
+	}
+
+	// Unknown filter or group_by dimensions are rejected here rather than ignored
+	if err := query.Validate(); err != nil {
+		return history.Query{}, err
+	}
+	return query, nil
+}
+
+// parseHistoryTime parses an RFC 3339 timestamp or a YYYY-MM-DD date (midnight UTC).
+func parseHistoryTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 timestamp nor a YYYY-MM-DD date", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextdoor/lumina/pkg/history"
+)
+
+// newTestHistoryHandler returns a handler over a store holding two hours of costs
+// starting at 2025-01-15 10:00 UTC: $1/hour in cluster prod and $2/hour in staging.
+func newTestHistoryHandler(t *testing.T) *HistoryHandler {
+	store, err := history.NewStore(t.TempDir(), time.Hour, 7)
+	require.NoError(t, err)
+
+	rates := []history.Rate{
+		{Attributes: history.Attributes{AccountID: "111111111111", ClusterName: "prod", InstanceID: "i-001"}, HourlyCost: 1},
+		{Attributes: history.Attributes{AccountID: "111111111111", ClusterName: "staging", InstanceID: "i-002"}, HourlyCost: 2},
+	}
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Record(rates, start))
+	require.NoError(t, store.Record(rates, start.Add(2*time.Hour)))
+
+	return NewHistoryHandler(store)
+}
+
+// TestHistoryHandler_Query tests a grouped, filtered range query.
+func TestHistoryHandler_Query(t *testing.T) {
+	h := newTestHistoryHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/history/costs?start=2025-01-15&end=2025-01-16T00:00:00Z&account_id=111111111111&group_by=cluster_name", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var result history.Result
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Len(t, result.Groups, 2)
+	assert.Equal(t, map[string]string{"cluster_name": "staging"}, result.Groups[0].Labels)
+	assert.InDelta(t, 4, result.Groups[0].Cost, 0.0001)
+	assert.InDelta(t, 2, result.Groups[1].Cost, 0.0001)
+	assert.InDelta(t, 6, result.TotalCost, 0.0001)
+	assert.InDelta(t, 7200, result.ObservedSeconds, 0.001)
+}
+
+// TestHistoryHandler_Errors tests the responses for unusable requests.
+func TestHistoryHandler_Errors(t *testing.T) {
+	h := newTestHistoryHandler(t)
+
+	tests := []struct {
+		name     string
+		handler  *HistoryHandler
+		method   string
+		url      string
+		wantCode int
+	}{
+		{"not enabled", &HistoryHandler{}, http.MethodGet, "/api/v1/history/costs?start=2025-01-15", http.StatusServiceUnavailable},
+		{"wrong method", h, http.MethodPost, "/api/v1/history/costs?start=2025-01-15", http.StatusMethodNotAllowed},
+		{"missing start", h, http.MethodGet, "/api/v1/history/costs", http.StatusBadRequest},
+		{"invalid start", h, http.MethodGet, "/api/v1/history/costs?start=yesterday", http.StatusBadRequest},
+		{"end before start", h, http.MethodGet, "/api/v1/history/costs?start=2025-01-15&end=2025-01-14", http.StatusBadRequest},
+		{"unknown filter", h, http.MethodGet, "/api/v1/history/costs?start=2025-01-15&team=web", http.StatusBadRequest},
+		{"unknown group", h, http.MethodGet, "/api/v1/history/costs?start=2025-01-15&group_by=team", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, httptest.NewRequest(tt.method, tt.url, nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/nextdoor/lumina/pkg/hub"
+)
+
+// maxInstanceCostsRequestBytes limits the size of an instance costs request body,
+// which lists a cluster's instance IDs.
+const maxInstanceCostsRequestBytes = 4 << 20
+
+// InstanceCostsHandler serves the latest calculated instance costs to the agents
+// of other clusters (config agent.hubURL), so they don't each need to collect AWS
+// data and run the calculation themselves.
+//
+// Endpoints:
+//   - GET /api/v1/costs/instances - Returns the costs of every instance
+//   - POST /api/v1/costs/instances - Body: {"instanceIds": [...]}; returns those instances' costs
+//
+// Both return a hub.InstanceCosts.
+type InstanceCostsHandler struct {
+	// Reconciler supplies the latest calculation; nil until it's initialized
+	Reconciler *CostReconciler
+}
+
+// NewInstanceCostsHandler creates a new InstanceCostsHandler serving reconciler's
+// calculations.
+func NewInstanceCostsHandler(reconciler *CostReconciler) *InstanceCostsHandler {
+	return &InstanceCostsHandler{Reconciler: reconciler}
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h *InstanceCostsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var request hub.InstanceCostsRequest
+	if r.Method == http.MethodPost {
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxInstanceCostsRequestBytes))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		if request.InstanceIDs == nil {
+			// An agent with no nodes asks for nothing, not everything
+			request.InstanceIDs = []string{}
+		}
+	}
+
+	if h.Reconciler == nil {
+		http.Error(w, "cost calculation not initialized yet", http.StatusServiceUnavailable)
+		return
+	}
+	result, ok := h.Reconciler.LastResult()
+	if !ok {
+		http.Error(w, "costs not calculated yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(hub.NewInstanceCosts(result, h.Reconciler.EC2Cache, request.InstanceIDs))
+}
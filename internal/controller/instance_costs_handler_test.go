@@ -0,0 +1,114 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/nextdoor/lumina/internal/cache"
+	"github.com/nextdoor/lumina/pkg/hub"
+	"github.com/nextdoor/lumina/pkg/metrics"
+)
+
+// newHubReconciler returns a CostReconciler that has calculated the cost of one
+// m5.xlarge instance covered by a Compute Savings Plan.
+func newHubReconciler(t *testing.T) *CostReconciler {
+	reconciler := newWhatIfReconciler()
+	reconciler.NodeCache = cache.NewNodeCache()
+	reconciler.Metrics = metrics.NewMetrics(prometheus.NewRegistry(), reconciler.Config)
+	reconciler.initialized.Store(true)
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+	return reconciler
+}
+
+// TestInstanceCostsHandler tests returning every instance's cost, or only the
+// requested instances'.
+func TestInstanceCostsHandler(t *testing.T) {
+	handler := NewInstanceCostsHandler(newHubReconciler(t))
+
+	tests := []struct {
+		name          string
+		method        string
+		body          string
+		wantInstances int
+	}{
+		{name: "GET all instances", method: http.MethodGet, wantInstances: 1},
+		{name: "POST requested instances", method: http.MethodPost,
+			body: `{"instanceIds": ["i-001", "i-missing"]}`, wantInstances: 1},
+		{name: "POST no instances", method: http.MethodPost, body: `{"instanceIds": []}`},
+		{name: "POST without instanceIds", method: http.MethodPost, body: `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(tt.method, hub.InstanceCostsPath, strings.NewReader(tt.body)))
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			var costs hub.InstanceCosts
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &costs))
+			assert.False(t, costs.CalculatedAt.IsZero())
+			require.Len(t, costs.Instances, tt.wantInstances)
+			if tt.wantInstances > 0 {
+				assert.Equal(t, "i-001", costs.Instances[0].Cost.InstanceID)
+				assert.InDelta(t, 0.72, costs.Instances[0].Cost.EffectiveCost, 0.0001)
+				require.NotNil(t, costs.Instances[0].Instance)
+				assert.Equal(t, "m5.xlarge", costs.Instances[0].Instance.InstanceType)
+			}
+		})
+	}
+}
+
+// TestInstanceCostsHandler_Errors tests the responses before the first calculation
+// and for invalid requests.
+func TestInstanceCostsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		reconciler *CostReconciler
+		method     string
+		body       string
+		wantStatus int
+	}{
+		{name: "not initialized", method: http.MethodGet, wantStatus: http.StatusServiceUnavailable},
+		{name: "not calculated", reconciler: newWhatIfReconciler(), method: http.MethodGet,
+			wantStatus: http.StatusServiceUnavailable},
+		{name: "DELETE", reconciler: newHubReconciler(t), method: http.MethodDelete,
+			wantStatus: http.StatusMethodNotAllowed},
+		{name: "malformed body", reconciler: newHubReconciler(t), method: http.MethodPost, body: `{"instanceIds": [`,
+			wantStatus: http.StatusBadRequest},
+		{name: "unknown field", reconciler: newHubReconciler(t), method: http.MethodPost,
+			body: `{"instance_ids": ["i-001"]}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			NewInstanceCostsHandler(tt.reconciler).
+				ServeHTTP(w, httptest.NewRequest(tt.method, hub.InstanceCostsPath, strings.NewReader(tt.body)))
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
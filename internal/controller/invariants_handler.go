@@ -0,0 +1,75 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// InvariantsPath is the URL path of the cost calculation invariant audit endpoint.
+const InvariantsPath = "/debug/cost/invariants"
+
+// InvariantsResponse is the response of GET /debug/cost/invariants.
+type InvariantsResponse struct {
+	InvariantAudit
+
+	// PublishedCalculatedAt is when the calculation behind the published costs was
+	// performed; it's older than CalculatedAt while violations are being found, and
+	// omitted if no calculation has been published
+	PublishedCalculatedAt *time.Time `json:"publishedCalculatedAt,omitempty"`
+}
+
+// InvariantsHandler serves the math invariant violations of the latest cost
+// calculation. A calculation with violations isn't published, so this explains why
+// cost metrics stopped changing (lumina_calculation_invariant_violations_total).
+//
+// Endpoint:
+//   - GET /debug/cost/invariants - Returns an InvariantsResponse
+type InvariantsHandler struct {
+	// Reconciler supplies the latest audit; nil until it's initialized
+	Reconciler *CostReconciler
+}
+
+// NewInvariantsHandler creates a new InvariantsHandler reporting on reconciler's
+// calculations.
+func NewInvariantsHandler(reconciler *CostReconciler) *InvariantsHandler {
+	return &InvariantsHandler{Reconciler: reconciler}
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h *InvariantsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Reconciler == nil {
+		http.Error(w, "cost calculation not initialized yet", http.StatusServiceUnavailable)
+		return
+	}
+	audit, ok := h.Reconciler.LastAudit()
+	if !ok {
+		http.Error(w, "costs not calculated yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	response := InvariantsResponse{InvariantAudit: audit}
+	if result, ok := h.Reconciler.LastResult(); ok {
+		response.PublishedCalculatedAt = &result.CalculatedAt
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response) // Best-effort encoding for debug endpoint
+}
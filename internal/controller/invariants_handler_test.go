@@ -0,0 +1,95 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextdoor/lumina/pkg/cost"
+)
+
+// TestInvariantsHandler tests reporting a clean calculation, and then a calculation
+// whose violations kept it from replacing the published one.
+func TestInvariantsHandler(t *testing.T) {
+	reconciler := newHubReconciler(t)
+	handler := NewInvariantsHandler(reconciler)
+
+	get := func() InvariantsResponse {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, InvariantsPath, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response InvariantsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	// The reconciler's calculation had no violations and was published
+	response := get()
+	assert.Empty(t, response.Violations)
+	require.NotNil(t, response.PublishedCalculatedAt)
+	assert.True(t, response.PublishedCalculatedAt.Equal(response.CalculatedAt))
+
+	published, ok := reconciler.LastResult()
+	require.True(t, ok)
+	reconciler.lastAudit.Store(&InvariantAudit{
+		CalculatedAt: published.CalculatedAt.Add(time.Minute),
+		Violations: []cost.InvariantViolation{{
+			Invariant:   cost.InvariantCoverageBounds,
+			Description: "Total coverage exceeds shelf price",
+			InstanceID:  "i-001",
+			Expected:    1.00,
+			Actual:      1.44,
+		}},
+	})
+
+	response = get()
+	require.Len(t, response.Violations, 1)
+	assert.Equal(t, cost.InvariantCoverageBounds, response.Violations[0].Invariant)
+	assert.Equal(t, "i-001", response.Violations[0].InstanceID)
+	assert.True(t, response.PublishedCalculatedAt.Equal(published.CalculatedAt))
+	assert.True(t, response.PublishedCalculatedAt.Before(response.CalculatedAt))
+}
+
+// TestInvariantsHandler_Errors tests the responses before the first calculation and
+// for unsupported methods.
+func TestInvariantsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		reconciler *CostReconciler
+		method     string
+		wantStatus int
+	}{
+		{name: "not initialized", method: http.MethodGet, wantStatus: http.StatusServiceUnavailable},
+		{name: "not calculated", reconciler: newWhatIfReconciler(), method: http.MethodGet,
+			wantStatus: http.StatusServiceUnavailable},
+		{name: "POST", reconciler: newHubReconciler(t), method: http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			NewInvariantsHandler(tt.reconciler).ServeHTTP(w, httptest.NewRequest(tt.method, InvariantsPath, nil))
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/nextdoor/lumina/internal/cache"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NodeClaimGVK identifies the Karpenter NodeClaim resource. NodeClaims are read as
+// unstructured objects so Lumina doesn't depend on Karpenter's Go module.
+var NodeClaimGVK = schema.GroupVersionKind{Group: "karpenter.sh", Version: "v1", Kind: "NodeClaim"}
+
+// NodeClaimReconciler reconciles Karpenter NodeClaim objects, recording the NodePool
+// of each instance Karpenter launched in the NodeCache. The CostReconciler uses it
+// to roll up costs by NodePool.
+//
+// Only registered when karpenter.enabled is set.
+type NodeClaimReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// NodeCache stores the NodeClaim → instance and NodePool mappings
+	NodeCache *cache.NodeCache
+}
+
+// +kubebuilder:rbac:groups=karpenter.sh,resources=nodeclaims,verbs=get;list;watch
+
+// Reconcile handles NodeClaim add/update/delete events, keeping the NodeCache in
+// sync. NodeClaims that haven't launched an instance yet (no status.providerID)
+// are skipped until they have.
+func (r *NodeClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	nodeClaim := &unstructured.Unstructured{}
+	nodeClaim.SetGroupVersionKind(NodeClaimGVK)
+	if err := r.Get(ctx, req.NamespacedName, nodeClaim); err != nil {
+		if errors.IsNotFound(err) {
+			log.V(1).Info("nodeclaim deleted, removing from cache", "nodeclaim", req.Name)
+			r.NodeCache.DeleteNodeClaim(req.Name)
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, "failed to get nodeclaim")
+		return ctrl.Result{}, err
+	}
+
+	providerID, _, _ := unstructured.NestedString(nodeClaim.Object, "status", "providerID")
+	instanceID, err := r.NodeCache.UpsertNodeClaim(nodeClaim.GetName(), providerID, nodeClaim.GetLabels())
+	if err != nil {
+		// Expected while the instance is launching; the status update requeues it
+		log.V(2).Info("nodeclaim has no instance yet",
+			"nodeclaim", nodeClaim.GetName(),
+			"error", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	log.V(1).Info("correlated nodeclaim to EC2 instance",
+		"nodeclaim", nodeClaim.GetName(),
+		"instance_id", instanceID,
+		"nodepool", nodeClaim.GetLabels()[cache.LabelKarpenterNodePool])
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// coverage:ignore - controller-runtime boilerplate, tested via E2E
+func (r *NodeClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	nodeClaim := &unstructured.Unstructured{}
+	nodeClaim.SetGroupVersionKind(NodeClaimGVK)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(nodeClaim).
+		Named("nodeclaim").
+		Complete(r)
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nextdoor/lumina/internal/cache"
+)
+
+// TestNodeClaimReconciler_Reconcile tests that NodeClaims with an instance are added
+// to and removed from the cache.
+func TestNodeClaimReconciler_Reconcile(t *testing.T) {
+	nodeClaim := &unstructured.Unstructured{}
+	nodeClaim.SetGroupVersionKind(NodeClaimGVK)
+	nodeClaim.SetName("default-abcde")
+	nodeClaim.SetLabels(map[string]string{
+		cache.LabelKarpenterNodePool:     "default",
+		cache.LabelKarpenterCapacityType: "spot",
+	})
+
+	k8sClient := fake.NewClientBuilder().WithObjects(nodeClaim).Build()
+	nodeCache := cache.NewNodeCache()
+	r := &NodeClaimReconciler{Client: k8sClient, NodeCache: nodeCache}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "default-abcde"}}
+	ctx := context.Background()
+
+	// Not launched yet
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, nodeCache.GetNodePools())
+
+	require.NoError(t, unstructured.SetNestedField(nodeClaim.Object, "aws:///us-west-2a/i-001", "status", "providerID"))
+	require.NoError(t, k8sClient.Update(ctx, nodeClaim))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]cache.NodePoolMembership{
+		"i-001": {NodePool: "default", CapacityType: "spot"},
+	}, nodeCache.GetNodePools())
+
+	require.NoError(t, k8sClient.Delete(ctx, nodeClaim))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, nodeCache.GetNodePools())
+}
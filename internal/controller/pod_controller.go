@@ -0,0 +1,73 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/nextdoor/lumina/internal/cache"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// PodReconciler reconciles Pod objects, maintaining a cache of the pods scheduled on
+// each node and their resource requests. The CostReconciler uses it to split node
+// costs among pods and namespaces.
+//
+// Only registered when allocation.enabled is set.
+type PodReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// PodCache stores the scheduled pods and their resource requests
+	PodCache *cache.PodCache
+}
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// Reconcile handles Pod add/update/delete events, keeping the PodCache in sync.
+// Deleted pods are removed; all others are upserted, and the cache itself drops
+// pods that aren't scheduled or have finished.
+func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var pod corev1.Pod
+	if err := r.Get(ctx, req.NamespacedName, &pod); err != nil {
+		if errors.IsNotFound(err) {
+			log.V(2).Info("pod deleted, removing from cache", "pod", req.NamespacedName)
+			r.PodCache.DeletePod(req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, "failed to get pod")
+		return ctrl.Result{}, err
+	}
+
+	r.PodCache.UpsertPod(&pod)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// coverage:ignore - controller-runtime boilerplate, tested via E2E
+func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{}).
+		Named("pod").
+		Complete(r)
+}
@@ -17,6 +17,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -25,6 +26,7 @@ import (
 	"github.com/nextdoor/lumina/internal/cache"
 	"github.com/nextdoor/lumina/pkg/aws"
 	"github.com/nextdoor/lumina/pkg/config"
+	"github.com/nextdoor/lumina/pkg/cost"
 	"github.com/nextdoor/lumina/pkg/metrics"
 )
 
@@ -32,6 +34,8 @@ import (
 
 // PricingReconciler reconciles AWS pricing data by bulk-loading all on-demand
 // pricing for EC2 instances across configured regions and operating systems.
+// Shared tenancy prices are always loaded; dedicated and host tenancy prices are
+// loaded only when instances with that tenancy are present in the EC2 cache.
 //
 // AWS pricing data changes infrequently (typically monthly), so the default
 // 24-hour refresh cycle is appropriate. The reconciler preloads ALL pricing data
@@ -52,9 +56,21 @@ type PricingReconciler struct {
 	// Configuration with AWS account details
 	Config *config.Config
 
+	// ConfigProvider optionally supplies the current configuration, e.g. from a
+	// config.Watcher that reloads the config file. If nil, Config is used.
+	ConfigProvider config.Provider
+
 	// Cache for storing pricing data
 	Cache *cache.PricingCache
 
+	// EC2Cache is used to discover which instance tenancies need pricing.
+	// Optional: if nil, only shared tenancy pricing is loaded.
+	EC2Cache *cache.EC2Cache
+
+	// EC2ReadyChan is an optional channel used to wait for the EC2 cache to be
+	// populated, so the initial load includes dedicated and host tenancy pricing.
+	EC2ReadyChan chan struct{}
+
 	// Metrics for observability
 	Metrics *metrics.Metrics
 
@@ -80,6 +96,16 @@ type PricingReconciler struct {
 	// itself as failed in this tracker, causing the readiness probe to fail and
 	// Kubernetes to restart the pod.
 	HealthTracker *ReconcilerHealthTracker
+
+	// LoadEBSPricing enables loading EBS volume pricing alongside EC2 pricing,
+	// for storage cost attribution (storage.enabled).
+	LoadEBSPricing bool
+}
+
+// currentConfig returns the configuration in effect, which changes when the
+// config file is reloaded.
+func (r *PricingReconciler) currentConfig() *config.Config {
+	return activeConfig(r.Config, r.ConfigProvider)
 }
 
 // Reconcile performs a single reconciliation cycle.
@@ -90,6 +116,17 @@ type PricingReconciler struct {
 // AWS Pricing API.
 func (r *PricingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("reconciler", "pricing")
+
+	// Wait for the EC2 cache so we know which tenancies are in use
+	if r.EC2ReadyChan != nil {
+		select {
+		case <-r.EC2ReadyChan:
+			// EC2 cache is ready, proceed
+		case <-ctx.Done():
+			return ctrl.Result{}, ctx.Err()
+		}
+	}
+
 	log.Info("starting pricing reconciliation cycle")
 
 	// Track cycle timing
@@ -100,7 +137,7 @@ func (r *PricingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl
 	//  1. Config.Regions (from config file 'regions' field)
 	//  2. r.Regions (from reconciler initialization)
 	//  3. config.DefaultRegions (common US regions) as final fallback
-	regions := r.Config.Regions
+	regions := r.currentConfig().Regions
 	if len(regions) == 0 {
 		// Config didn't specify regions, try reconciler default
 		regions = r.Regions
@@ -115,7 +152,7 @@ func (r *PricingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl
 	//  1. Config.Pricing.OperatingSystems (from config file 'pricing.operatingSystems' field)
 	//  2. r.OperatingSystems (from reconciler initialization)
 	//  3. ["Linux", "Windows"] as final fallback
-	operatingSystems := r.Config.Pricing.OperatingSystems
+	operatingSystems := r.currentConfig().Pricing.OperatingSystems
 	if len(operatingSystems) == 0 {
 		// Config didn't specify OSes, try reconciler default
 		operatingSystems = r.OperatingSystems
@@ -125,20 +162,25 @@ func (r *PricingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl
 		operatingSystems = []string{"Linux", "Windows"}
 	}
 
+	// Determine non-shared tenancies to load pricing for (shared is always loaded)
+	tenancies := r.getNonSharedTenancies()
+
 	// Calculate expected pricing entries
 	// Note: Actual count may be lower if some instance types aren't available in all regions/OS
-	expectedEntries := 600 * len(regions) * len(operatingSystems) // ~600 instance types per region
-	estimatedAPIcalls := expectedEntries / 100                    // 100 entries per page
-	estimatedDuration := float64(estimatedAPIcalls) * 0.05        // ~0.05s per API call
+	// ~600 instance types per region, for shared tenancy plus each additional tenancy
+	expectedEntries := 600 * len(regions) * len(operatingSystems) * (1 + len(tenancies))
+	estimatedAPIcalls := expectedEntries / 100             // 100 entries per page
+	estimatedDuration := float64(estimatedAPIcalls) * 0.05 // ~0.05s per API call
 	// Check if we have test data configured (for E2E tests)
 	var prices map[string]float64
 	var err error
 	var duration time.Duration
 
-	if r.Config.TestData != nil && r.Config.TestData.Pricing() != nil {
+	usingTestData := r.currentConfig().TestData != nil && r.currentConfig().TestData.Pricing() != nil
+	if usingTestData {
 		// Use test data instead of calling AWS Pricing API
 		// This allows E2E tests to run hermetically without external API dependencies
-		prices = r.Config.TestData.Pricing()
+		prices = r.currentConfig().TestData.Pricing()
 		log.Info("using test data for pricing", "count", len(prices))
 		duration = time.Since(startTime)
 	} else {
@@ -146,6 +188,7 @@ func (r *PricingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl
 		log.Info("loading pricing data from AWS Pricing API",
 			"regions", regions,
 			"operating_systems", operatingSystems,
+			"additional_tenancies", tenancies,
 			"expected_entries", expectedEntries,
 			"estimated_duration_seconds", estimatedDuration)
 
@@ -155,26 +198,27 @@ func (r *PricingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl
 
 		// Bulk-load all pricing data
 		// This queries the AWS Pricing API with pagination to fetch all EC2 instance
-		// pricing for the specified regions and operating systems.
+		// pricing for the specified regions, operating systems, and tenancies.
 		//
 		// Expected performance:
 		//   - ~84,000 entries (600 types × 35 regions × 4 OS)
 		//   - ~840 API calls with 100 results per page
 		//   - ~42 seconds at 20 req/sec rate limit
 		//   - ~10-15 MB memory footprint
-		prices, err = pricingClient.LoadAllPricing(ctx, regions, operatingSystems)
+		prices, err = pricingClient.LoadAllPricing(ctx, regions, operatingSystems, tenancies)
 		duration = time.Since(startTime)
 	}
 
 	if err != nil {
 		// Record failure in metrics
 		// Pricing is global, so we use empty strings for account/account_name/region labels
-		r.Metrics.DataLastSuccess.WithLabelValues(
+		r.Metrics.RecordDataCollection(
 			"", // Not account-specific
 			"", // Not account_name-specific
 			"", // Not region-specific
 			"pricing",
-		).Set(0)
+			false,
+		)
 
 		log.Error(err, "failed to load pricing data",
 			"duration_seconds", duration.Seconds())
@@ -189,12 +233,13 @@ func (r *PricingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl
 	r.Cache.SetOnDemandPrices(prices)
 
 	// Record success in metrics
-	r.Metrics.DataLastSuccess.WithLabelValues(
+	r.Metrics.RecordDataCollection(
 		"", // Not account-specific
 		"", // Not account_name-specific
 		"", // Not region-specific
 		"pricing",
-	).Set(1)
+		true,
+	)
 
 	// Mark that pricing data was updated (pricing is global, not account or region-specific)
 	r.Metrics.MarkDataUpdated("", "", "", "pricing")
@@ -205,29 +250,67 @@ func (r *PricingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl
 		"price_count", stats.OnDemandPriceCount,
 		"regions", len(regions),
 		"operating_systems", len(operatingSystems),
+		"additional_tenancies", len(tenancies),
 		"cache_age_hours", stats.AgeHours,
 		"duration_seconds", duration.Seconds(),
 		"prices_per_second", float64(stats.OnDemandPriceCount)/duration.Seconds())
 
+	if r.LoadEBSPricing && !usingTestData {
+		r.loadEBSPricing(ctx, log, regions)
+	}
+
 	return r.scheduleNextReconciliation(log), nil
 }
 
+// loadEBSPricing loads EBS volume pricing into the cache. Failures are logged but
+// don't fail the cycle, since EC2 pricing is already loaded; the previous EBS prices
+// stay cached until the next cycle.
+func (r *PricingReconciler) loadEBSPricing(ctx context.Context, log logr.Logger, regions []string) {
+	startTime := time.Now()
+	prices, err := r.AWSClient.Pricing(ctx).LoadEBSPricing(ctx, regions)
+	if err != nil {
+		r.Metrics.RecordDataCollection("", "", "", "ebs_pricing", false)
+		log.Error(err, "failed to load EBS pricing data",
+			"duration_seconds", time.Since(startTime).Seconds())
+		return
+	}
+
+	r.Cache.SetEBSPrices(prices)
+	r.Metrics.RecordDataCollection("", "", "", "ebs_pricing", true)
+	r.Metrics.MarkDataUpdated("", "", "", "ebs_pricing")
+
+	log.Info("EBS pricing data loaded",
+		"price_count", len(prices),
+		"duration_seconds", time.Since(startTime).Seconds())
+}
+
+// getNonSharedTenancies returns the unique dedicated/host tenancies of instances in the
+// EC2 cache. Shared ("default") tenancy is excluded because it is always loaded.
+func (r *PricingReconciler) getNonSharedTenancies() []string {
+	if r.EC2Cache == nil {
+		return nil
+	}
+
+	tenancySet := make(map[string]bool)
+	for _, inst := range r.EC2Cache.GetAllInstances() {
+		tenancy := cost.PricingTenancy(inst.Tenancy)
+		if tenancy != aws.TenancyDefault {
+			tenancySet[tenancy] = true
+		}
+	}
+
+	tenancies := make([]string, 0, len(tenancySet))
+	for tenancy := range tenancySet {
+		tenancies = append(tenancies, tenancy)
+	}
+	sort.Strings(tenancies)
+	return tenancies
+}
+
 // scheduleNextReconciliation determines when to run the next reconciliation cycle.
 // Returns a ctrl.Result with the appropriate RequeueAfter duration.
 func (r *PricingReconciler) scheduleNextReconciliation(log logr.Logger) ctrl.Result {
-	// Parse reconciliation interval from config, with default fallback to 24 hours
-	// The interval determines how often we refresh pricing data
-	// AWS pricing changes monthly, so 24 hours is a safe and reasonable default
-	requeueAfter := 24 * time.Hour // Default
-	if r.Config.Reconciliation.Pricing != "" {
-		if duration, err := time.ParseDuration(r.Config.Reconciliation.Pricing); err == nil {
-			requeueAfter = duration
-		} else {
-			log.Error(err, "invalid pricing reconciliation interval, using default",
-				"configured_interval", r.Config.Reconciliation.Pricing,
-				"default", "24h")
-		}
-	}
+	requeueAfter := r.getReconciliationInterval(log)
 
 	// Log the configured interval (helpful for verifying configuration)
 	log.V(1).Info("reconciliation interval configured", "next_run_in", requeueAfter.String())
@@ -237,6 +320,26 @@ func (r *PricingReconciler) scheduleNextReconciliation(log logr.Logger) ctrl.Res
 	return ctrl.Result{RequeueAfter: requeueAfter}
 }
 
+// getReconciliationInterval parses the reconciliation interval from config, falling
+// back to 24 hours. It's read on every cycle so a reloaded config takes effect.
+func (r *PricingReconciler) getReconciliationInterval(log logr.Logger) time.Duration {
+	defaultInterval := 24 * time.Hour
+
+	if r.currentConfig().Reconciliation.Pricing == "" {
+		return defaultInterval
+	}
+
+	duration, err := time.ParseDuration(r.currentConfig().Reconciliation.Pricing)
+	if err != nil {
+		log.Error(err, "invalid pricing reconciliation interval, using default",
+			"configured_interval", r.currentConfig().Reconciliation.Pricing,
+			"default", defaultInterval.String())
+		return defaultInterval
+	}
+
+	return duration
+}
+
 // Run runs the reconciler as a goroutine with timer-based reconciliation.
 //
 // Performs an initial BLOCKING reconciliation on startup to ensure pricing cache is
@@ -271,17 +374,7 @@ func (r *PricingReconciler) Run(ctx context.Context) error {
 		log.V(1).Info("signaled that pricing cache is ready for dependent reconcilers")
 	}
 
-	// Parse reconciliation interval from config, with default fallback to 24 hours
-	interval := 24 * time.Hour // Default
-	if r.Config.Reconciliation.Pricing != "" {
-		if duration, err := time.ParseDuration(r.Config.Reconciliation.Pricing); err == nil {
-			interval = duration
-		} else {
-			log.Error(err, "invalid pricing reconciliation interval, using default",
-				"configured_interval", r.Config.Reconciliation.Pricing,
-				"default", "24h")
-		}
-	}
+	interval := r.getReconciliationInterval(log)
 
 	// Setup ticker for periodic pricing updates
 	log.Info("configured reconciliation interval", "interval", interval.String())
@@ -300,6 +393,7 @@ func (r *PricingReconciler) Run(ctx context.Context) error {
 				// Don't exit - continue with next cycle
 				// Periodic failures are not fatal (cache still has data from previous load)
 			}
+			interval = resetOnIntervalChange(log, ticker, interval, r.getReconciliationInterval(log))
 		}
 	}
 }
@@ -95,6 +95,94 @@ func TestPricingReconciler_Reconcile_Success(t *testing.T) {
 	assert.Equal(t, 0.384, price)
 }
 
+// TestPricingReconciler_Reconcile_Tenancy tests that dedicated tenancy pricing is loaded
+// only when the EC2 cache contains dedicated instances.
+func TestPricingReconciler_Reconcile_Tenancy(t *testing.T) {
+	mockClient := aws.NewMockClient()
+	ctx := context.Background()
+
+	mockPricing := mockClient.Pricing(ctx).(*aws.MockPricingClient)
+	mockPricing.SetOnDemandPrice("us-west-2", "m5.xlarge", "Linux", 0.192)
+	mockPricing.SetOnDemandPriceForTenancy("us-west-2", "m5.xlarge", "Linux", aws.TenancyDedicated, 0.2112)
+	mockPricing.SetOnDemandPriceForTenancy("us-west-2", "m5.xlarge", "Linux", aws.TenancyHost, 0)
+
+	ec2Cache := cache.NewEC2Cache()
+	ec2Cache.SetInstances("123456789012", "us-west-2", []aws.Instance{
+		{InstanceID: "i-shared", InstanceType: "m5.xlarge", Region: "us-west-2", Tenancy: aws.TenancyDefault},
+		{InstanceID: "i-dedicated", InstanceType: "m5.xlarge", Region: "us-west-2", Tenancy: aws.TenancyDedicated},
+	})
+	ec2ReadyChan := make(chan struct{})
+	close(ec2ReadyChan)
+
+	cfg := &config.Config{
+		DefaultRegion: "us-west-2",
+		Regions:       []string{"us-west-2"},
+	}
+	pricingCache := cache.NewPricingCache()
+
+	reconciler := &PricingReconciler{
+		AWSClient:        mockClient,
+		Config:           cfg,
+		Cache:            pricingCache,
+		EC2Cache:         ec2Cache,
+		EC2ReadyChan:     ec2ReadyChan,
+		Metrics:          metrics.NewMetrics(prometheus.NewRegistry(), cfg),
+		Log:              logr.Discard(),
+		OperatingSystems: []string{"Linux"},
+	}
+
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{})
+	require.NoError(t, err)
+
+	// Shared and dedicated prices are loaded; host isn't in use so it's skipped
+	prices := pricingCache.GetAllOnDemandPrices()
+	assert.Len(t, prices, 2)
+	assert.Equal(t, 0.192, prices["us-west-2:m5.xlarge:linux"])
+	assert.Equal(t, 0.2112, prices["us-west-2:m5.xlarge:linux:dedicated"])
+
+	// The cost reconciler's lookup returns the dedicated price under the calculator key
+	filtered := pricingCache.GetOnDemandPricesForInstances([]cache.OnDemandKey{
+		{InstanceType: "m5.xlarge", Region: "us-west-2", OperatingSystem: "linux", Tenancy: aws.TenancyDedicated},
+	})
+	assert.Equal(t, 0.2112, filtered["m5.xlarge:us-west-2:linux:dedicated"])
+}
+
+// TestPricingReconciler_Reconcile_EBSPricing tests that EBS pricing is loaded when
+// enabled, and that a failure to load it doesn't fail the cycle.
+func TestPricingReconciler_Reconcile_EBSPricing(t *testing.T) {
+	mockClient := aws.NewMockClient()
+	ctx := context.Background()
+
+	mockPricing := mockClient.Pricing(ctx).(*aws.MockPricingClient)
+	mockPricing.SetOnDemandPrice("us-west-2", "m5.large", "Linux", 0.096)
+	mockPricing.EBSPrices = map[string]aws.EBSPrice{
+		"us-west-2:gp3": {VolumeType: aws.VolumeTypeGP3, Region: "us-west-2", PerGBMonth: 0.08},
+	}
+
+	cfg := &config.Config{Regions: []string{"us-west-2"}}
+	pricingCache := cache.NewPricingCache()
+	reconciler := &PricingReconciler{
+		AWSClient:      mockClient,
+		Config:         cfg,
+		Cache:          pricingCache,
+		Metrics:        metrics.NewMetrics(prometheus.NewRegistry(), cfg),
+		Log:            logr.Discard(),
+		LoadEBSPricing: true,
+	}
+
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, 0.08, pricingCache.GetAllEBSPrices()["us-west-2:gp3"].PerGBMonth)
+	assert.Equal(t, 1, pricingCache.GetStats().EBSPriceCount)
+
+	// A failure keeps the previous EBS prices
+	mockPricing.LoadEBSPricingError = assert.AnError
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, pricingCache.GetStats().EBSPriceCount)
+	assert.Equal(t, 2, mockPricing.LoadEBSPricingCallCount)
+}
+
 // TestPricingReconciler_Reconcile_CustomInterval tests custom reconciliation interval.
 func TestPricingReconciler_Reconcile_CustomInterval(t *testing.T) {
 	// Create mock client
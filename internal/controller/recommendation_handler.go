@@ -0,0 +1,55 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nextdoor/lumina/pkg/recommend"
+)
+
+// RecommendationsPath is the URL path of the Savings Plan recommendations endpoint.
+const RecommendationsPath = "/debug/recommendations"
+
+// RecommendationHandler serves the current Savings Plan purchase recommendations.
+//
+// Endpoint:
+//   - GET /debug/recommendations - Recommendations and the hours of spillover they're based on
+type RecommendationHandler struct {
+	// Recommender to report on; nil until recommendations are enabled
+	// (config recommendations.enabled)
+	Recommender *recommend.Recommender
+}
+
+// NewRecommendationHandler creates a new RecommendationHandler for recommender.
+func NewRecommendationHandler(recommender *recommend.Recommender) *RecommendationHandler {
+	return &RecommendationHandler{Recommender: recommender}
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h *RecommendationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Recommender == nil {
+		http.Error(w, "recommendations not enabled (set recommendations.enabled)", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h.Recommender.Recommend()) // Best-effort encoding for debug endpoint
+}
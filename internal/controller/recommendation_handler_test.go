@@ -0,0 +1,73 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/nextdoor/lumina/pkg/recommend"
+)
+
+// TestRecommendationHandler tests serving a report after a day of steady spillover.
+func TestRecommendationHandler(t *testing.T) {
+	recommender := recommend.NewRecommender(recommend.Options{
+		Lookback:  7 * 24 * time.Hour,
+		OneYear:   recommend.RateMultipliers{Compute: 0.72, EC2Instance: 0.72},
+		ThreeYear: recommend.RateMultipliers{Compute: 0.50, EC2Instance: 0.50},
+	})
+	result := cost.CalculationResult{
+		InstanceCosts: map[string]cost.InstanceCost{
+			"i-001": {
+				InstanceID: "i-001", InstanceType: "m5.xlarge", Region: "us-west-2",
+				ShelfPrice: 1, EffectiveCost: 1, OnDemandCost: 1,
+			},
+		},
+	}
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	for hour := 0; hour <= 24; hour++ {
+		recommender.Record(result, start.Add(time.Duration(hour)*time.Hour))
+	}
+
+	w := httptest.NewRecorder()
+	NewRecommendationHandler(recommender).ServeHTTP(w, httptest.NewRequest(http.MethodGet, RecommendationsPath, nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var report recommend.Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, 24, report.ObservedHours)
+	// Compute and EC2 Instance plans for both terms
+	require.Len(t, report.Recommendations, 4)
+	assert.InDelta(t, 0.72, report.Recommendations[0].HourlyCommitment, 0.0001)
+}
+
+// TestRecommendationHandler_Errors tests the responses when disabled or misused.
+func TestRecommendationHandler_Errors(t *testing.T) {
+	w := httptest.NewRecorder()
+	NewRecommendationHandler(nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, RecommendationsPath, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	w = httptest.NewRecorder()
+	NewRecommendationHandler(recommend.NewRecommender(recommend.Options{})).
+		ServeHTTP(w, httptest.NewRequest(http.MethodPost, RecommendationsPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -32,8 +32,9 @@ import (
 
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create
 
-// RISPReconciler reconciles Reserved Instances and Savings Plans data.
-// It queries AWS APIs hourly to maintain an up-to-date cache of RI/SP inventory.
+// RISPReconciler reconciles Reserved Instances, Capacity Reservations and Savings
+// Plans data. It queries AWS APIs hourly to maintain an up-to-date cache of RI/SP
+// inventory.
 type RISPReconciler struct {
 	// AWS client for making API calls
 	AWSClient aws.Client
@@ -41,6 +42,14 @@ type RISPReconciler struct {
 	// Configuration with AWS account details
 	Config *config.Config
 
+	// ConfigProvider optionally supplies the current configuration, e.g. from a
+	// config.Watcher that reloads the config file. If nil, Config is used.
+	ConfigProvider config.Provider
+
+	// Accounts optionally supplies the AWS accounts to query, e.g. from account
+	// discovery. If nil, Config.AWSAccounts is used.
+	Accounts aws.AccountLister
+
 	// Cache for storing RI/SP data
 	Cache *cache.RISPCache
 
@@ -64,6 +73,12 @@ type RISPReconciler struct {
 	HealthTracker *ReconcilerHealthTracker
 }
 
+// currentConfig returns the configuration in effect, which changes when the
+// config file is reloaded.
+func (r *RISPReconciler) currentConfig() *config.Config {
+	return activeConfig(r.Config, r.ConfigProvider)
+}
+
 // Reconcile performs a single reconciliation cycle.
 // This is called by controller-runtime on a timer (hourly).
 func (r *RISPReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
@@ -81,7 +96,7 @@ func (r *RISPReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Re
 	//
 	// Individual accounts can override this default by setting their own
 	// 'regions' field in the awsAccounts configuration.
-	defaultRegions := r.Config.Regions
+	defaultRegions := r.currentConfig().Regions
 	if len(defaultRegions) == 0 {
 		// Config didn't specify regions, try reconciler default
 		defaultRegions = r.Regions
@@ -92,11 +107,12 @@ func (r *RISPReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Re
 	}
 
 	// Query all accounts in parallel
+	accounts := monitoredAccounts(r.currentConfig(), r.Accounts)
 	var wg sync.WaitGroup
-	errors := make(chan error, len(r.Config.AWSAccounts)*2) // Buffer for potential errors
+	errors := make(chan error, len(accounts)*3) // Buffer for potential errors
 
 	// Query RIs for each account/region
-	for _, account := range r.Config.AWSAccounts {
+	for _, account := range accounts {
 		wg.Add(1)
 		go func(acc config.AWSAccount) {
 			defer wg.Done()
@@ -118,11 +134,19 @@ func (r *RISPReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Re
 					"account_name", acc.Name)
 				errors <- err
 			}
+
+			// Capacity Reservations are zonal, so they're queried for the same regions
+			if err := r.reconcileCapacityReservations(ctx, acc, regions); err != nil {
+				log.Error(err, "failed to reconcile capacity reservations",
+					"account_id", acc.AccountID,
+					"account_name", acc.Name)
+				errors <- err
+			}
 		}(account)
 	}
 
 	// Query SPs for each account
-	for _, account := range r.Config.AWSAccounts {
+	for _, account := range accounts {
 		wg.Add(1)
 		go func(acc config.AWSAccount) {
 			defer wg.Done()
@@ -154,6 +178,7 @@ func (r *RISPReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Re
 	stats := r.Cache.GetStats()
 	log.Info("cache statistics",
 		"reserved_instances", stats.ReservedInstanceCount,
+		"capacity_reservations", stats.CapacityReservationCount,
 		"savings_plans", stats.SavingsPlanCount,
 		"regions", stats.RegionCount,
 		"accounts", stats.AccountCount)
@@ -172,18 +197,7 @@ func (r *RISPReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Re
 	r.Metrics.UpdateSavingsPlansInventoryMetrics(allSPs)
 	log.V(1).Info("updated SP metrics", "metric_count", len(allSPs))
 
-	// Parse reconciliation interval from config, with default fallback to 1 hour
-	// The interval determines how often we refresh RI/SP data
-	requeueAfter := 1 * time.Hour // Default
-	if r.Config.Reconciliation.RISP != "" {
-		if duration, err := time.ParseDuration(r.Config.Reconciliation.RISP); err == nil {
-			requeueAfter = duration
-		} else {
-			log.Error(err, "invalid RISP reconciliation interval, using default",
-				"configured_interval", r.Config.Reconciliation.RISP,
-				"default", "1h")
-		}
-	}
+	requeueAfter := r.getReconciliationInterval(log)
 
 	// Log the configured interval (helpful for verifying configuration)
 	log.V(1).Info("reconciliation interval configured", "next_run_in", requeueAfter.String())
@@ -210,7 +224,7 @@ func (r *RISPReconciler) reconcileReservedInstances(
 		AccountID:     account.AccountID,
 		Name:          account.Name,
 		AssumeRoleARN: account.AssumeRoleARN,
-		Region:        r.Config.DefaultRegion,
+		Region:        r.currentConfig().DefaultRegion,
 	}
 
 	ec2Client, err := r.AWSClient.EC2(ctx, accountConfig)
@@ -228,12 +242,7 @@ func (r *RISPReconciler) reconcileReservedInstances(
 
 		if err != nil {
 			// Record failure in metrics
-			r.Metrics.DataLastSuccess.WithLabelValues(
-				account.AccountID,
-				account.Name,
-				region,
-				"reserved_instances",
-			).Set(0)
+			r.Metrics.RecordDataCollection(account.AccountID, account.Name, region, "reserved_instances", false)
 
 			log.Error(err, "failed to describe reserved instances", "region", region)
 			return fmt.Errorf("failed to describe RIs in %s: %w", region, err)
@@ -254,12 +263,7 @@ func (r *RISPReconciler) reconcileReservedInstances(
 		}
 
 		// Record success in metrics
-		r.Metrics.DataLastSuccess.WithLabelValues(
-			account.AccountID,
-			account.Name,
-			region,
-			"reserved_instances",
-		).Set(1)
+		r.Metrics.RecordDataCollection(account.AccountID, account.Name, region, "reserved_instances", true)
 
 		// Mark that reserved_instances data was updated for this account+region
 		r.Metrics.MarkDataUpdated(account.AccountID, account.Name, region, "reserved_instances")
@@ -273,6 +277,68 @@ func (r *RISPReconciler) reconcileReservedInstances(
 	return nil
 }
 
+// reconcileCapacityReservations queries On-Demand Capacity Reservations for a single
+// account across all regions.
+func (r *RISPReconciler) reconcileCapacityReservations(
+	ctx context.Context,
+	account config.AWSAccount,
+	regions []string,
+) error {
+	log := r.Log.WithValues(
+		"reconciler", "risp",
+		"account_id", account.AccountID,
+		"account_name", account.Name,
+		"data_type", "capacity_reservations",
+	)
+
+	accountConfig := aws.AccountConfig{
+		AccountID:     account.AccountID,
+		Name:          account.Name,
+		AssumeRoleARN: account.AssumeRoleARN,
+		Region:        r.currentConfig().DefaultRegion,
+	}
+
+	ec2Client, err := r.AWSClient.EC2(ctx, accountConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create EC2 client: %w", err)
+	}
+
+	for _, region := range regions {
+		startTime := time.Now()
+
+		crs, err := ec2Client.DescribeCapacityReservations(ctx, []string{region})
+		if err != nil {
+			r.Metrics.RecordDataCollection(account.AccountID, account.Name, region, "capacity_reservations", false)
+
+			log.Error(err, "failed to describe capacity reservations", "region", region)
+			return fmt.Errorf("failed to describe capacity reservations in %s: %w", region, err)
+		}
+
+		r.Cache.UpdateCapacityReservations(region, account.AccountID, crs)
+
+		for _, cr := range crs {
+			log.V(1).Info("capacity reservation details",
+				"region", region,
+				"capacity_reservation_id", cr.CapacityReservationID,
+				"instance_type", cr.InstanceType,
+				"availability_zone", cr.AvailabilityZone,
+				"total_instance_count", cr.TotalInstanceCount,
+				"available_instance_count", cr.AvailableInstanceCount,
+				"instance_match_criteria", cr.InstanceMatchCriteria)
+		}
+
+		r.Metrics.RecordDataCollection(account.AccountID, account.Name, region, "capacity_reservations", true)
+		r.Metrics.MarkDataUpdated(account.AccountID, account.Name, region, "capacity_reservations")
+
+		log.V(1).Info("updated capacity reservations",
+			"region", region,
+			"count", len(crs),
+			"duration_seconds", time.Since(startTime).Seconds())
+	}
+
+	return nil
+}
+
 // reconcileSavingsPlans queries SPs for a single account (organization-wide).
 // If testData is configured, uses mock data instead of making AWS API calls.
 func (r *RISPReconciler) reconcileSavingsPlans(
@@ -290,8 +356,8 @@ func (r *RISPReconciler) reconcileSavingsPlans(
 	var sps []aws.SavingsPlan
 
 	// Check if we have test data for this account
-	if r.Config.TestData != nil && r.Config.TestData.SavingsPlans != nil {
-		testSPs, hasTestData := r.Config.TestData.SavingsPlans[account.AccountID]
+	if r.currentConfig().TestData != nil && r.currentConfig().TestData.SavingsPlans != nil {
+		testSPs, hasTestData := r.currentConfig().TestData.SavingsPlans[account.AccountID]
 		if hasTestData {
 			log.Info("using test data for savings plans", "count", len(testSPs))
 			sps = convertTestSavingsPlans(testSPs, account.AccountID, account.Name)
@@ -306,7 +372,7 @@ func (r *RISPReconciler) reconcileSavingsPlans(
 			AccountID:     account.AccountID,
 			Name:          account.Name,
 			AssumeRoleARN: account.AssumeRoleARN,
-			Region:        r.Config.DefaultRegion,
+			Region:        r.currentConfig().DefaultRegion,
 		}
 
 		spClient, err := r.AWSClient.SavingsPlans(ctx, accountConfig)
@@ -318,12 +384,13 @@ func (r *RISPReconciler) reconcileSavingsPlans(
 		sps, err = spClient.DescribeSavingsPlans(ctx)
 		if err != nil {
 			// Record failure in metrics
-			r.Metrics.DataLastSuccess.WithLabelValues(
+			r.Metrics.RecordDataCollection(
 				account.AccountID,
 				account.Name,
 				"", // SPs are not regional
 				"savings_plans",
-			).Set(0)
+				false,
+			)
 
 			log.Error(err, "failed to describe savings plans")
 			return fmt.Errorf("failed to describe SPs: %w", err)
@@ -336,12 +403,13 @@ func (r *RISPReconciler) reconcileSavingsPlans(
 	r.Cache.UpdateSavingsPlans(account.AccountID, sps)
 
 	// Record success in metrics
-	r.Metrics.DataLastSuccess.WithLabelValues(
+	r.Metrics.RecordDataCollection(
 		account.AccountID,
 		account.Name,
 		"", // SPs are not regional
 		"savings_plans",
-	).Set(1)
+		true,
+	)
 
 	// Mark that savings_plans data was updated for this account (SPs are not regional)
 	r.Metrics.MarkDataUpdated(account.AccountID, account.Name, "", "savings_plans")
@@ -403,6 +471,26 @@ func convertTestSavingsPlans(
 	return result
 }
 
+// getReconciliationInterval parses the reconciliation interval from config, falling
+// back to 1 hour. It's read on every cycle so a reloaded config takes effect.
+func (r *RISPReconciler) getReconciliationInterval(log logr.Logger) time.Duration {
+	defaultInterval := 1 * time.Hour
+
+	if r.currentConfig().Reconciliation.RISP == "" {
+		return defaultInterval
+	}
+
+	duration, err := time.ParseDuration(r.currentConfig().Reconciliation.RISP)
+	if err != nil {
+		log.Error(err, "invalid RISP reconciliation interval, using default",
+			"configured_interval", r.currentConfig().Reconciliation.RISP,
+			"default", defaultInterval.String())
+		return defaultInterval
+	}
+
+	return duration
+}
+
 // Run runs the reconciler as a goroutine with timer-based reconciliation.
 //
 // Uses a simple time.Ticker for periodic reconciliation instead of controller-runtime's
@@ -435,17 +523,7 @@ func (r *RISPReconciler) Run(ctx context.Context) error {
 		log.V(1).Info("signaled that RISP cache is ready for dependent reconcilers")
 	}
 
-	// Parse reconciliation interval from config, with default fallback to 1 hour
-	interval := 1 * time.Hour // Default
-	if r.Config.Reconciliation.RISP != "" {
-		if duration, err := time.ParseDuration(r.Config.Reconciliation.RISP); err == nil {
-			interval = duration
-		} else {
-			log.Error(err, "invalid RISP reconciliation interval, using default",
-				"configured_interval", r.Config.Reconciliation.RISP,
-				"default", "1h")
-		}
-	}
+	interval := r.getReconciliationInterval(log)
 
 	// Setup ticker for RISP data
 	log.Info("configured reconciliation interval", "interval", interval.String())
@@ -463,6 +541,7 @@ func (r *RISPReconciler) Run(ctx context.Context) error {
 				log.Error(err, "scheduled reconciliation failed")
 				// Don't exit - continue with next cycle
 			}
+			interval = resetOnIntervalChange(log, ticker, interval, r.getReconciliationInterval(log))
 		}
 	}
 }
@@ -76,6 +76,17 @@ func TestRISPReconciler_Reconcile_Success(t *testing.T) {
 	require.NoError(t, err)
 	mockEC2 := ec2Client.(*aws.MockEC2Client)
 	mockEC2.ReservedInstances = testRIs
+	mockEC2.CapacityReservations = []aws.CapacityReservation{
+		{
+			CapacityReservationID: "cr-uswest-789",
+			InstanceType:          "c5.large",
+			Region:                "us-west-2",
+			AvailabilityZone:      "us-west-2a",
+			AccountID:             "123456789012",
+			TotalInstanceCount:    2,
+			State:                 "active",
+		},
+	}
 
 	// Setup SavingsPlans client with SP data
 	spClient, err := mockClient.SavingsPlans(ctx, aws.AccountConfig{
@@ -124,6 +135,7 @@ func TestRISPReconciler_Reconcile_Success(t *testing.T) {
 	// Verify cache was populated
 	stats := rispCache.GetStats()
 	assert.Equal(t, 2, stats.ReservedInstanceCount, "should have 2 RIs")
+	assert.Equal(t, 1, stats.CapacityReservationCount, "should have 1 capacity reservation")
 	assert.Equal(t, 1, stats.SavingsPlanCount, "should have 1 SP")
 	assert.Equal(t, 2, stats.RegionCount, "should have 2 regions")
 	assert.Equal(t, 1, stats.AccountCount, "should have 1 account")
@@ -137,6 +149,11 @@ func TestRISPReconciler_Reconcile_Success(t *testing.T) {
 	assert.Len(t, eastRIs, 1)
 	assert.Equal(t, "ri-useast-456", eastRIs[0].ReservedInstanceID)
 
+	// Verify capacity reservations
+	crs := rispCache.GetAllCapacityReservations()
+	require.Len(t, crs, 1)
+	assert.Equal(t, "cr-uswest-789", crs[0].CapacityReservationID)
+
 	// Verify SPs
 	sps := rispCache.GetSavingsPlans("123456789012")
 	assert.Len(t, sps, 1)
@@ -626,6 +643,48 @@ func TestRISPReconciler_Reconcile_DescribeReservedInstancesError(t *testing.T) {
 	assert.Equal(t, 1*time.Hour, result.RequeueAfter)
 }
 
+// TestRISPReconciler_Reconcile_DescribeCapacityReservationsError tests that a failure to
+// describe Capacity Reservations (e.g., a missing IAM permission) doesn't affect RIs.
+func TestRISPReconciler_Reconcile_DescribeCapacityReservationsError(t *testing.T) {
+	mockClient := aws.NewMockClient()
+	ctx := context.Background()
+
+	ec2Client, err := mockClient.EC2(ctx, aws.AccountConfig{
+		AccountID: "123456789012",
+		Region:    "us-west-2",
+	})
+	require.NoError(t, err)
+	mockEC2 := ec2Client.(*aws.MockEC2Client)
+	mockEC2.ReservedInstances = []aws.ReservedInstance{
+		{ReservedInstanceID: "ri-123", Region: "us-west-2", AccountID: "123456789012", State: "active"},
+	}
+	mockEC2.DescribeCapacityReservationsError = assert.AnError
+
+	cfg := &config.Config{
+		AWSAccounts: []config.AWSAccount{
+			{AccountID: "123456789012", Name: "test-account"},
+		},
+		DefaultRegion: "us-west-2",
+	}
+
+	rispCache := cache.NewRISPCache()
+	reconciler := &RISPReconciler{
+		AWSClient: mockClient,
+		Config:    cfg,
+		Cache:     rispCache,
+		Metrics:   metrics.NewMetrics(prometheus.NewRegistry(), cfg),
+		Log:       logr.Discard(),
+		Regions:   []string{"us-west-2"},
+	}
+
+	result, err := reconciler.Reconcile(ctx, ctrl.Request{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1*time.Hour, result.RequeueAfter)
+	assert.Len(t, rispCache.GetAllReservedInstances(), 1)
+	assert.Empty(t, rispCache.GetAllCapacityReservations())
+}
+
 // TestRISPReconciler_Reconcile_DescribeSavingsPlansError tests error handling when DescribeSavingsPlans API call fails.
 func TestRISPReconciler_Reconcile_DescribeSavingsPlansError(t *testing.T) {
 	// Create mock client
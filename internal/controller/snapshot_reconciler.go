@@ -0,0 +1,135 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/nextdoor/lumina/internal/cache"
+	"github.com/nextdoor/lumina/pkg/config"
+)
+
+// SnapshotReconciler periodically saves the EC2, RI/SP, and pricing caches to a
+// snapshot file. On the next start, main restores the caches from it so cost metrics
+// can be published before the initial AWS data load completes.
+type SnapshotReconciler struct {
+	// Path is the snapshot file (config snapshot.path)
+	Path string
+
+	// Caches to save
+	EC2Cache     *cache.EC2Cache
+	RISPCache    *cache.RISPCache
+	PricingCache *cache.PricingCache
+
+	// Configuration with the snapshot interval
+	Config *config.Config
+
+	// ConfigProvider optionally supplies the current configuration, e.g. from a
+	// config.Watcher that reloads the config file. If nil, Config is used.
+	ConfigProvider config.Provider
+
+	// Logger
+	Log logr.Logger
+}
+
+// currentConfig returns the configuration in effect, which changes when the
+// config file is reloaded.
+func (r *SnapshotReconciler) currentConfig() *config.Config {
+	return activeConfig(r.Config, r.ConfigProvider)
+}
+
+// Reconcile saves a single snapshot. Caches that haven't completed their initial load
+// aren't saved, so a restart during startup never replaces a good snapshot with
+// partial data.
+func (r *SnapshotReconciler) Reconcile(_ context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("reconciler", "snapshot")
+
+	snapshot := cache.NewSnapshot(r.EC2Cache, r.RISPCache, r.PricingCache)
+	if !snapshot.IsComplete() {
+		log.V(1).Info("caches not fully loaded yet, skipping snapshot")
+		return ctrl.Result{RequeueAfter: r.getReconciliationInterval(log)}, nil
+	}
+
+	start := time.Now()
+	if err := cache.SaveSnapshot(r.Path, snapshot); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to save cache snapshot: %w", err)
+	}
+
+	log.V(1).Info("saved cache snapshot",
+		"path", r.Path,
+		"instances", len(snapshot.EC2.Instances),
+		"on_demand_prices", len(snapshot.Pricing.OnDemandPrices),
+		"sp_rates", len(snapshot.Pricing.SPRates),
+		"duration_seconds", time.Since(start).Seconds())
+	return ctrl.Result{RequeueAfter: r.getReconciliationInterval(log)}, nil
+}
+
+// getReconciliationInterval returns the configured snapshot interval (default: 10m).
+func (r *SnapshotReconciler) getReconciliationInterval(log logr.Logger) time.Duration {
+	defaultInterval := 10 * time.Minute
+
+	if r.currentConfig().Reconciliation.Snapshot == "" {
+		return defaultInterval
+	}
+
+	duration, err := time.ParseDuration(r.currentConfig().Reconciliation.Snapshot)
+	if err != nil {
+		log.Error(err, "invalid snapshot reconciliation interval, using default",
+			"configured_interval", r.currentConfig().Reconciliation.Snapshot,
+			"default", defaultInterval.String())
+		return defaultInterval
+	}
+
+	return duration
+}
+
+// Run runs the reconciler as a goroutine with timer-based reconciliation, and saves
+// a final snapshot on shutdown so the next start restores the most recent data.
+//
+// Failures are logged but never fatal: without a snapshot, the next start falls back
+// to waiting for the initial AWS data load.
+//
+// coverage:ignore - This is a top-level runner called by main.go, not unit tested
+func (r *SnapshotReconciler) Run(ctx context.Context) error {
+	log := r.Log
+	log.Info("starting snapshot reconciler", "path", r.Path)
+
+	interval := r.getReconciliationInterval(log)
+	log.Info("configured reconciliation interval", "interval", interval.String())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("shutting down snapshot reconciler, saving final snapshot")
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{}); err != nil {
+				log.Error(err, "final snapshot failed")
+			}
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := r.Reconcile(ctx, ctrl.Request{}); err != nil {
+				log.Error(err, "scheduled snapshot failed")
+				// Don't exit - continue with next cycle
+			}
+			interval = resetOnIntervalChange(log, ticker, interval, r.getReconciliationInterval(log))
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/nextdoor/lumina/internal/cache"
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/config"
+)
+
+// newTestSnapshotReconciler creates a SnapshotReconciler writing to a temporary file.
+func newTestSnapshotReconciler(t *testing.T) *SnapshotReconciler {
+	return &SnapshotReconciler{
+		Path:         filepath.Join(t.TempDir(), "snapshot.json.gz"),
+		EC2Cache:     cache.NewEC2Cache(),
+		RISPCache:    cache.NewRISPCache(),
+		PricingCache: cache.NewPricingCache(),
+		Config:       &config.Config{},
+		Log:          logr.Discard(),
+	}
+}
+
+// TestSnapshotReconciler_Reconcile tests that a snapshot is only written once all
+// caches have been loaded.
+func TestSnapshotReconciler_Reconcile(t *testing.T) {
+	r := newTestSnapshotReconciler(t)
+
+	// Nothing loaded yet: no snapshot is written
+	result, err := r.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, result.RequeueAfter)
+	_, err = os.Stat(r.Path)
+	assert.True(t, os.IsNotExist(err))
+
+	r.EC2Cache.SetInstances("111111111111", "us-west-2", []aws.Instance{
+		{InstanceID: "i-001", InstanceType: "m5.xlarge", AccountID: "111111111111", Region: "us-west-2"},
+	})
+	r.RISPCache.UpdateSavingsPlans("111111111111", nil)
+	r.PricingCache.SetOnDemandPrices(map[string]float64{"us-west-2:m5.xlarge:linux": 0.192})
+
+	_, err = r.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+
+	snapshot, err := cache.LoadSnapshot(r.Path)
+	require.NoError(t, err)
+	require.Len(t, snapshot.EC2.Instances, 1)
+	assert.Equal(t, "i-001", snapshot.EC2.Instances[0].InstanceID)
+	assert.Equal(t, 0.192, snapshot.Pricing.OnDemandPrices["us-west-2:m5.xlarge:linux"])
+}
+
+// TestSnapshotReconciler_ReconcileError tests that write failures are returned.
+func TestSnapshotReconciler_ReconcileError(t *testing.T) {
+	r := newTestSnapshotReconciler(t)
+	r.Path = filepath.Join(t.TempDir(), "missing-dir", "snapshot.json.gz")
+	r.Config.Reconciliation.Snapshot = "1m"
+
+	r.EC2Cache.SetInstances("111111111111", "us-west-2", nil)
+	r.RISPCache.UpdateSavingsPlans("111111111111", nil)
+	r.PricingCache.SetOnDemandPrices(map[string]float64{"us-west-2:m5.xlarge:linux": 0.192})
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to save cache snapshot")
+	assert.Equal(t, time.Minute, r.getReconciliationInterval(r.Log))
+}
@@ -60,6 +60,10 @@ type SPRatesReconciler struct {
 	// Configuration with AWS account details
 	Config *config.Config
 
+	// ConfigProvider optionally supplies the current configuration, e.g. from a
+	// config.Watcher that reloads the config file. If nil, Config is used.
+	ConfigProvider config.Provider
+
 	// Cache for EC2 instances - used to determine which instance types to fetch rates for
 	EC2Cache *cache.EC2Cache
 
@@ -100,6 +104,12 @@ type SPRatesReconciler struct {
 	HealthTracker *ReconcilerHealthTracker
 }
 
+// currentConfig returns the configuration in effect, which changes when the
+// config file is reloaded.
+func (r *SPRatesReconciler) currentConfig() *config.Config {
+	return activeConfig(r.Config, r.ConfigProvider)
+}
+
 // Reconcile performs a single reconciliation cycle.
 // Implements lazy-loading: only fetches rates for Savings Plans not yet in cache.
 func (r *SPRatesReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
@@ -229,6 +239,11 @@ func (r *SPRatesReconciler) findMissingSPRates(savingsPlans []aws.SavingsPlan) [
 	operatingSystems := r.getOperatingSystemsNormalized()
 
 	for _, sp := range savingsPlans {
+		// SageMaker and Database SPs have no EC2 instance rates to fetch
+		if !sp.AppliesToEC2() {
+			continue
+		}
+
 		// Check if we have ANY rate cached for this SP ARN
 		if !r.PricingCache.HasAnySPRate(sp.SavingsPlanARN) {
 			// No rates exist at all - need full fetch
@@ -324,7 +339,10 @@ func (r *SPRatesReconciler) getUniqueInstanceTypesRegionsAndTenancies() ([]strin
 // (e.g., "linux", "windows") for consistent lookups.
 func (r *SPRatesReconciler) getOperatingSystemsNormalized() []string {
 	// Use configured operating systems, defaulting to [Linux, Windows]
-	osList := r.OperatingSystems
+	osList := r.currentConfig().Pricing.OperatingSystems
+	if len(osList) == 0 {
+		osList = r.OperatingSystems
+	}
 	if len(osList) == 0 {
 		// Default to both Linux and Windows platforms
 		return []string{aws.PlatformLinux, aws.PlatformWindows}
@@ -354,8 +372,8 @@ func (r *SPRatesReconciler) fetchRatesForSPWithFilters(
 ) (map[string]float64, error) {
 	// Check if we have test data for this SP
 	// This is used in E2E tests when LocalStack doesn't support the DescribeSavingsPlanRates API
-	if r.Config.TestData != nil && r.Config.TestData.SavingsPlanRates != nil {
-		testRates, hasTestData := r.Config.TestData.SavingsPlanRates[sp.SavingsPlanID]
+	if r.currentConfig().TestData != nil && r.currentConfig().TestData.SavingsPlanRates != nil {
+		testRates, hasTestData := r.currentConfig().TestData.SavingsPlanRates[sp.SavingsPlanID]
 		if hasTestData {
 			r.Log.Info("using test data for SP rates",
 				"sp_id", sp.SavingsPlanID,
@@ -368,8 +386,8 @@ func (r *SPRatesReconciler) fetchRatesForSPWithFilters(
 	// No test data, query AWS API
 	accountConfig := aws.AccountConfig{
 		AccountID:     sp.AccountID,
-		Region:        r.Config.DefaultRegion,
-		AssumeRoleARN: r.Config.GetDefaultAccount().AssumeRoleARN,
+		Region:        r.currentConfig().DefaultRegion,
+		AssumeRoleARN: r.currentConfig().GetDefaultAccount().AssumeRoleARN,
 	}
 
 	spClient, err := r.AWSClient.SavingsPlans(ctx, accountConfig)
@@ -61,6 +61,14 @@ type SpotPricingReconciler struct {
 	// Configuration with AWS account details
 	Config *config.Config
 
+	// ConfigProvider optionally supplies the current configuration, e.g. from a
+	// config.Watcher that reloads the config file. If nil, Config is used.
+	ConfigProvider config.Provider
+
+	// Accounts optionally supplies the AWS accounts to query, e.g. from account
+	// discovery. If nil, Config.AWSAccounts is used.
+	Accounts aws.AccountLister
+
 	// EC2Cache provides running instance inventory to determine which instance types
 	// and availability zones to fetch spot prices for. This enables lazy-loading.
 	EC2Cache *cache.EC2Cache
@@ -92,6 +100,12 @@ type SpotPricingReconciler struct {
 	HealthTracker *ReconcilerHealthTracker
 }
 
+// currentConfig returns the configuration in effect, which changes when the
+// config file is reloaded.
+func (r *SpotPricingReconciler) currentConfig() *config.Config {
+	return activeConfig(r.Config, r.ConfigProvider)
+}
+
 // Reconcile performs a single reconciliation cycle using lazy-loading.
 // This is called by controller-runtime on a timer at the configured interval.
 //
@@ -128,7 +142,7 @@ func (r *SpotPricingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (
 		log.V(1).Info("no instances found in EC2Cache, skipping spot pricing query")
 
 		// Record metrics even when no instances - reconciliation succeeded, no data to fetch
-		r.Metrics.DataLastSuccess.WithLabelValues("", "", "", "spot-pricing").Set(1)
+		r.Metrics.RecordDataCollection("", "", "", "spot-pricing", true)
 		r.Metrics.MarkDataUpdated("", "", "", "spot-pricing")
 
 		// Signal ready on first cycle even with no instances
@@ -153,7 +167,7 @@ func (r *SpotPricingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (
 		log.V(1).Info("all spot prices are cached, no queries needed (0 API calls)")
 
 		// Record metrics even when all cached - reconciliation succeeded, cache is fresh
-		r.Metrics.DataLastSuccess.WithLabelValues("", "", "", "spot-pricing").Set(1)
+		r.Metrics.RecordDataCollection("", "", "", "spot-pricing", true)
 		r.Metrics.MarkDataUpdated("", "", "", "spot-pricing")
 
 		r.readyOnce.Do(func() {
@@ -185,9 +199,9 @@ func (r *SpotPricingReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (
 
 	// Record metrics
 	if len(fetchErrors) == 0 {
-		r.Metrics.DataLastSuccess.WithLabelValues("", "", "", "spot-pricing").Set(1)
+		r.Metrics.RecordDataCollection("", "", "", "spot-pricing", true)
 	} else {
-		r.Metrics.DataLastSuccess.WithLabelValues("", "", "", "spot-pricing").Set(0)
+		r.Metrics.RecordDataCollection("", "", "", "spot-pricing", false)
 		log.Info("reconciliation cycle completed with errors",
 			"error_count", len(fetchErrors),
 			"new_prices", len(newPrices))
@@ -292,14 +306,14 @@ func (r *SpotPricingReconciler) findMissingSpotPrices() []SpotPriceCombination {
 	// - Tight budget → shorter expiration (more accurate, more API calls)
 	// - Loose budget → longer expiration (less accurate, fewer API calls)
 	staleThreshold := 1 * time.Hour // Default: matches AWS's hourly spot price updates
-	if r.Config.Pricing.SpotPriceCacheExpiration != "" {
-		if duration, err := time.ParseDuration(r.Config.Pricing.SpotPriceCacheExpiration); err == nil {
+	if r.currentConfig().Pricing.SpotPriceCacheExpiration != "" {
+		if duration, err := time.ParseDuration(r.currentConfig().Pricing.SpotPriceCacheExpiration); err == nil {
 			staleThreshold = duration
 		} else {
 			// Invalid duration in config (e.g., "foo" or "1zz") - log and use default
 			// We continue with default rather than failing to ensure reconciliation proceeds
 			r.Log.V(1).Info("invalid spot price cache expiration in config, using default",
-				"configured", r.Config.Pricing.SpotPriceCacheExpiration,
+				"configured", r.currentConfig().Pricing.SpotPriceCacheExpiration,
 				"default", "1h",
 				"error", err.Error())
 		}
@@ -530,7 +544,7 @@ func (r *SpotPricingReconciler) fetchMissingSpotPrices(
 
 			// Find account config
 			var account config.AWSAccount
-			for _, acc := range r.Config.AWSAccounts {
+			for _, acc := range monitoredAccounts(r.currentConfig(), r.Accounts) {
 				if acc.AccountID == accountID {
 					account = acc
 					break
@@ -595,14 +609,14 @@ func (r *SpotPricingReconciler) fetchMissingSpotPrices(
 func (r *SpotPricingReconciler) getReconciliationInterval(log logr.Logger) time.Duration {
 	defaultInterval := 15 * time.Second
 
-	if r.Config.Reconciliation.SpotPricing == "" {
+	if r.currentConfig().Reconciliation.SpotPricing == "" {
 		return defaultInterval
 	}
 
-	duration, err := time.ParseDuration(r.Config.Reconciliation.SpotPricing)
+	duration, err := time.ParseDuration(r.currentConfig().Reconciliation.SpotPricing)
 	if err != nil {
 		log.Error(err, "invalid spot pricing reconciliation interval, using default",
-			"configured_interval", r.Config.Reconciliation.SpotPricing,
+			"configured_interval", r.currentConfig().Reconciliation.SpotPricing,
 			"default", defaultInterval.String())
 		return defaultInterval
 	}
@@ -671,6 +685,7 @@ func (r *SpotPricingReconciler) Run(ctx context.Context) error {
 				log.Error(err, "scheduled reconciliation failed")
 				// Don't exit - continue with next cycle
 			}
+			interval = resetOnIntervalChange(log, ticker, interval, r.getReconciliationInterval(log))
 		}
 	}
 }
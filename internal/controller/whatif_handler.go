@@ -0,0 +1,80 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/nextdoor/lumina/pkg/whatif"
+)
+
+// WhatIfPath is the URL path of the what-if simulation endpoint.
+const WhatIfPath = "/debug/whatif"
+
+// maxWhatIfRequestBytes limits the size of a simulation request body.
+const maxWhatIfRequestBytes = 1 << 20
+
+// WhatIfRequest is the body of a simulation request.
+type WhatIfRequest struct {
+	// Changes are applied in order to the current inventory
+	Changes []whatif.Change `json:"changes"`
+}
+
+// WhatIfHandler simulates changes to the current inventory, such as adding
+// instances or buying a Savings Plan, and returns how the cost calculation
+// result would change.
+//
+// Endpoint:
+//   - POST /debug/whatif - Body: {"changes": [...]}; returns a whatif.Result
+type WhatIfHandler struct {
+	// Reconciler supplies the caches and calculator; nil until they're initialized
+	Reconciler *CostReconciler
+}
+
+// NewWhatIfHandler creates a new WhatIfHandler simulating against reconciler.
+func NewWhatIfHandler(reconciler *CostReconciler) *WhatIfHandler {
+	return &WhatIfHandler{Reconciler: reconciler}
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h *WhatIfHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Reconciler == nil {
+		http.Error(w, "cost calculation not initialized yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	var request WhatIfRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWhatIfRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.Reconciler.Simulate(request.Changes)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result) // Best-effort encoding for debug endpoint
+}
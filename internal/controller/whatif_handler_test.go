@@ -0,0 +1,126 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextdoor/lumina/internal/cache"
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/config"
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/nextdoor/lumina/pkg/whatif"
+)
+
+// newWhatIfReconciler returns a CostReconciler with one m5.xlarge instance covered by
+// a Compute Savings Plan, and on-demand prices for m5.xlarge and c6i.xlarge.
+func newWhatIfReconciler() *CostReconciler {
+	ec2Cache := cache.NewEC2Cache()
+	ec2Cache.SetInstances("123456789012", "us-west-2", []aws.Instance{{
+		InstanceID:   "i-001",
+		InstanceType: "m5.xlarge",
+		Region:       "us-west-2",
+		AccountID:    "123456789012",
+		State:        "running",
+	}})
+	rispCache := cache.NewRISPCache()
+	rispCache.UpdateSavingsPlans("123456789012", []aws.SavingsPlan{{
+		SavingsPlanARN:  "arn:aws:savingsplans::123456789012:savingsplan/sp-001",
+		SavingsPlanType: aws.SavingsPlanTypeCompute,
+		Region:          "all",
+		Commitment:      0.72,
+		AccountID:       "123456789012",
+	}})
+	pricingCache := cache.NewPricingCache()
+	pricingCache.SetOnDemandPrices(map[string]float64{
+		"us-west-2:m5.xlarge:linux":  1.00,
+		"us-west-2:c6i.xlarge:linux": 0.50,
+	})
+
+	cfg := &config.Config{}
+	return &CostReconciler{
+		Calculator:   cost.NewCalculator(pricingCache, cfg),
+		Config:       cfg,
+		EC2Cache:     ec2Cache,
+		RISPCache:    rispCache,
+		PricingCache: pricingCache,
+		Log:          logr.Discard(),
+	}
+}
+
+// TestWhatIfHandler tests simulating a migration to a new instance type, priced
+// from the pricing cache, and letting the Savings Plan expire.
+func TestWhatIfHandler(t *testing.T) {
+	body := `{"changes": [
+		{"type": "remove_instances", "instanceType": "m5.xlarge", "region": "us-west-2"},
+		{"type": "add_instances", "instanceType": "c6i.xlarge", "region": "us-west-2", "count": 2},
+		{"type": "expire_savings_plan", "savingsPlanArn": "arn:aws:savingsplans::123456789012:savingsplan/sp-001"}
+	]}`
+	w := httptest.NewRecorder()
+	NewWhatIfHandler(newWhatIfReconciler()).
+		ServeHTTP(w, httptest.NewRequest(http.MethodPost, WhatIfPath, strings.NewReader(body)))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result whatif.Result
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+
+	assert.InDelta(t, 0.72, result.Baseline.EstimatedCost, 0.0001)
+	assert.InDelta(t, 1.00, result.Simulated.EstimatedCost, 0.0001)
+	assert.InDelta(t, 0.28, result.Difference.EstimatedCost, 0.0001)
+	assert.Len(t, result.Instances, 3)
+	require.Contains(t, result.Instances, "i-whatif-2-1")
+	assert.Equal(t, 0.50, result.Instances["i-whatif-2-1"].After.ShelfPrice)
+	require.Contains(t, result.SavingsPlans, "arn:aws:savingsplans::123456789012:savingsplan/sp-001")
+	assert.Nil(t, result.SavingsPlans["arn:aws:savingsplans::123456789012:savingsplan/sp-001"].After)
+}
+
+// TestWhatIfHandler_Errors tests the responses before initialization and for
+// invalid requests.
+func TestWhatIfHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		reconciler *CostReconciler
+		method     string
+		body       string
+		wantStatus int
+	}{
+		{name: "not initialized", method: http.MethodPost, body: `{}`, wantStatus: http.StatusServiceUnavailable},
+		{name: "GET", reconciler: newWhatIfReconciler(), method: http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed},
+		{name: "malformed body", reconciler: newWhatIfReconciler(), method: http.MethodPost, body: `{"changes": [`,
+			wantStatus: http.StatusBadRequest},
+		{name: "unknown field", reconciler: newWhatIfReconciler(), method: http.MethodPost,
+			body: `{"changes": [{"type": "add_instances", "instance_type": "m5.large"}]}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid change", reconciler: newWhatIfReconciler(), method: http.MethodPost,
+			body: `{"changes": [{"type": "remove_account"}]}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			NewWhatIfHandler(tt.reconciler).
+				ServeHTTP(w, httptest.NewRequest(tt.method, WhatIfPath, strings.NewReader(tt.body)))
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,377 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/nextdoor/lumina/pkg/config"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// AccountLister provides the AWS accounts currently being monitored.
+// Implementations must be safe for concurrent use.
+type AccountLister interface {
+	// Accounts returns the monitored accounts. Callers must not modify the result.
+	Accounts() []config.AWSAccount
+}
+
+// AccountChangeFunc is called with the new account list, and the accounts that
+// were dropped from it, whenever AccountDiscovery finds a different set of accounts.
+type AccountChangeFunc func(accounts []config.AWSAccount, removed []config.AWSAccount)
+
+// AccountDiscovery periodically lists the accounts in an AWS organization and
+// merges those matching the configured filters with the statically configured
+// accounts.
+//
+// Each discovered account is monitored by assuming the role named by
+// AccountDiscoveryConfig.RoleName, in the partition of the default account's
+// role. Statically configured accounts are always monitored and their settings
+// win over discovery. If a refresh fails, the previous account list is kept, so
+// an Organizations outage never drops accounts.
+type AccountDiscovery struct {
+	client OrganizationsClient
+
+	mu        sync.RWMutex                  // Protects the fields below
+	options   config.AccountDiscoveryConfig // Filters and role name, from the config
+	static    []config.AWSAccount           // Statically configured accounts
+	partition string                        // Partition of discovered accounts' roles
+	interval  time.Duration                 // Time between refreshes
+	accounts  []config.AWSAccount           // Current account list, sorted by account ID
+	listeners []AccountChangeFunc           // Called after the account list changes
+
+	ctx    context.Context    // Context for lifecycle management
+	cancel context.CancelFunc // Cancel function for stopping discovery
+	logger logr.Logger
+}
+
+// NewAccountDiscovery creates account discovery for the given configuration.
+// The configuration must have passed Validate with discovery enabled.
+//
+// Until the first successful Refresh, Accounts returns only the statically
+// configured accounts.
+func NewAccountDiscovery(client OrganizationsClient, cfg *config.Config) *AccountDiscovery {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d := &AccountDiscovery{
+		client: client,
+		ctx:    ctx,
+		cancel: cancel,
+		logger: ctrl.Log.WithName("account-discovery"),
+	}
+	d.SetConfig(cfg)
+	d.accounts = sortAccounts(slices.Clone(d.static))
+	return d
+}
+
+// SetConfig applies the discovery settings and statically configured accounts of
+// a reloaded configuration. They're used from the next Refresh, which callers
+// should run to apply them promptly; until then Accounts is unchanged.
+func (d *AccountDiscovery) SetConfig(cfg *config.Config) {
+	// Discovered roles live in the same partition as the role used to find them
+	partition := "aws"
+	if cfg.DefaultAccount != nil {
+		partition = rolePartition(cfg.DefaultAccount.AssumeRoleARN)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.options = cfg.AccountDiscovery
+	d.static = slices.Clone(cfg.AWSAccounts)
+	d.partition = partition
+	d.interval = cfg.GetAccountDiscoveryRefreshInterval()
+}
+
+// Accounts returns the statically configured and discovered accounts, sorted by
+// account ID.
+func (d *AccountDiscovery) Accounts() []config.AWSAccount {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.accounts
+}
+
+// OnChange registers a function to call after a refresh changes the account list.
+// Functions are called synchronously from the refresh, in registration order.
+func (d *AccountDiscovery) OnChange(fn AccountChangeFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.listeners = append(d.listeners, fn)
+}
+
+// Start begins refreshing the account list on the configured interval.
+// This method is non-blocking. The first refresh happens after one interval,
+// so callers that need discovered accounts at startup should call Refresh first.
+func (d *AccountDiscovery) Start() {
+	interval := d.getInterval()
+	d.logger.Info("Starting account discovery",
+		"accounts", len(d.Accounts()),
+		"refreshInterval", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := d.Refresh(d.ctx); err != nil {
+					d.logger.Error(err, "Account discovery failed, keeping previous accounts")
+				}
+				// Pick up a refresh interval changed by SetConfig
+				if next := d.getInterval(); next != interval {
+					interval = next
+					ticker.Reset(interval)
+				}
+			case <-d.ctx.Done():
+				d.logger.Info("Account discovery stopped")
+				return
+			}
+		}
+	}()
+}
+
+// getInterval returns the time between refreshes.
+func (d *AccountDiscovery) getInterval() time.Duration {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.interval
+}
+
+// Stop stops the periodic refresh.
+func (d *AccountDiscovery) Stop() {
+	d.cancel()
+}
+
+// Refresh lists the organization's accounts and updates the account list,
+// notifying OnChange functions if it changed. On error the list is unchanged.
+// This method is exported for testing and for the initial synchronous refresh.
+func (d *AccountDiscovery) Refresh(ctx context.Context) error {
+	d.mu.RLock()
+	options, static, partition := d.options, d.static, d.partition
+	d.mu.RUnlock()
+
+	orgAccounts, err := d.client.ListAccounts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list organization accounts: %w", err)
+	}
+
+	accounts := slices.Clone(static)
+	staticIDs := make(map[string]bool, len(static))
+	for _, account := range static {
+		staticIDs[account.AccountID] = true
+	}
+
+	// OU membership is shared by many accounts, so each filter OU is listed once per refresh
+	ouAccounts := make(map[string]map[string]bool)
+	var discovered int
+	for _, orgAccount := range orgAccounts {
+		if orgAccount.Status != OrganizationAccountStatusActive || staticIDs[orgAccount.AccountID] {
+			continue
+		}
+
+		matched, err := d.matches(ctx, options, orgAccount.AccountID, ouAccounts)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate filters for account %s: %w", orgAccount.AccountID, err)
+		}
+		if !matched {
+			continue
+		}
+
+		name := orgAccount.Name
+		if strings.TrimSpace(name) == "" {
+			name = orgAccount.AccountID
+		}
+		account := config.AWSAccount{
+			AccountID:     orgAccount.AccountID,
+			Name:          name,
+			AssumeRoleARN: options.RoleARN(partition, orgAccount.AccountID),
+		}
+		if err := account.Validate(); err != nil {
+			d.logger.Error(err, "Skipping discovered account", "accountID", orgAccount.AccountID)
+			continue
+		}
+		accounts = append(accounts, account)
+		discovered++
+	}
+	accounts = sortAccounts(accounts)
+
+	d.mu.Lock()
+	previous := d.accounts
+	changed := !slices.Equal(accountKeys(previous), accountKeys(accounts))
+	d.accounts = accounts
+	listeners := slices.Clone(d.listeners)
+	d.mu.Unlock()
+
+	d.logger.V(1).Info("Account discovery completed",
+		"organizationAccounts", len(orgAccounts),
+		"discovered", discovered,
+		"total", len(accounts))
+	if !changed {
+		return nil
+	}
+
+	removed := RemovedAccounts(previous, accounts)
+	d.logger.Info("Monitored accounts changed",
+		"total", len(accounts),
+		"removed", len(removed))
+	for _, fn := range listeners {
+		fn(accounts, removed)
+	}
+	return nil
+}
+
+// matches reports whether an account passes the OU and tag filters. OU members
+// and tags are only looked up when a filter needs them.
+func (d *AccountDiscovery) matches(
+	ctx context.Context,
+	options config.AccountDiscoveryConfig,
+	accountID string,
+	ouAccounts map[string]map[string]bool,
+) (bool, error) {
+	excluded, err := d.inAnyOU(ctx, accountID, options.ExcludeOUs, ouAccounts)
+	if err != nil {
+		return false, err
+	}
+	if excluded {
+		return false, nil
+	}
+	if len(options.IncludeOUs) > 0 {
+		included, err := d.inAnyOU(ctx, accountID, options.IncludeOUs, ouAccounts)
+		if err != nil {
+			return false, err
+		}
+		if !included {
+			return false, nil
+		}
+	}
+
+	if len(options.IncludeTags) > 0 || len(options.ExcludeTags) > 0 {
+		tags, err := d.client.ListTags(ctx, accountID)
+		if err != nil {
+			return false, err
+		}
+		if matchesAnyTag(tags, options.ExcludeTags) {
+			return false, nil
+		}
+		if len(options.IncludeTags) > 0 && !matchesAnyTag(tags, options.IncludeTags) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// inAnyOU reports whether an account is anywhere beneath any of the OUs or roots
+// in ouIDs. The accounts beneath each OU are listed on first use and kept in
+// ouAccounts.
+func (d *AccountDiscovery) inAnyOU(
+	ctx context.Context,
+	accountID string,
+	ouIDs []string,
+	ouAccounts map[string]map[string]bool,
+) (bool, error) {
+	for _, ouID := range ouIDs {
+		accounts, ok := ouAccounts[ouID]
+		if !ok {
+			var err error
+			if accounts, err = d.accountsBeneath(ctx, ouID); err != nil {
+				return false, err
+			}
+			ouAccounts[ouID] = accounts
+		}
+		if accounts[accountID] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// accountsBeneath returns the IDs of the accounts in an OU or root and all of the
+// OUs nested in it.
+func (d *AccountDiscovery) accountsBeneath(ctx context.Context, ouID string) (map[string]bool, error) {
+	accounts := make(map[string]bool)
+	for pending := []string{ouID}; len(pending) > 0; {
+		parentID := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		children, err := d.client.ListChildren(ctx, parentID, OrganizationChildTypeAccount)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range children {
+			accounts[id] = true
+		}
+
+		ous, err := d.client.ListChildren(ctx, parentID, OrganizationChildTypeOrganizationalUnit)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, ous...)
+	}
+	return accounts, nil
+}
+
+// matchesAnyTag reports whether tags match any filter. A filter is "key=value",
+// or "key" to match any value.
+func matchesAnyTag(tags map[string]string, filters []string) bool {
+	for _, filter := range filters {
+		key, value, hasValue := strings.Cut(filter, "=")
+		if actual, ok := tags[key]; ok && (!hasValue || actual == value) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortAccounts sorts accounts by account ID, so refreshes that find the same
+// accounts produce the same list.
+func sortAccounts(accounts []config.AWSAccount) []config.AWSAccount {
+	slices.SortFunc(accounts, func(a, b config.AWSAccount) int {
+		return strings.Compare(a.AccountID, b.AccountID)
+	})
+	return accounts
+}
+
+// accountKeys returns the identifying fields of each account, for detecting changes.
+func accountKeys(accounts []config.AWSAccount) []string {
+	keys := make([]string, len(accounts))
+	for i, account := range accounts {
+		keys[i] = account.AccountID + "|" + account.Name + "|" + account.AssumeRoleARN
+	}
+	return keys
+}
+
+// RemovedAccounts returns the accounts in previous that aren't in current.
+// It's exported for the config watcher, which stops monitoring accounts removed
+// from a reloaded configuration when account discovery is disabled.
+func RemovedAccounts(previous, current []config.AWSAccount) []config.AWSAccount {
+	currentIDs := make(map[string]bool, len(current))
+	for _, account := range current {
+		currentIDs[account.AccountID] = true
+	}
+	var removed []config.AWSAccount
+	for _, account := range previous {
+		if !currentIDs[account.AccountID] {
+			removed = append(removed, account)
+		}
+	}
+	return removed
+}
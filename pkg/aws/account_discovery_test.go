@@ -0,0 +1,306 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/nextdoor/lumina/pkg/config"
+)
+
+// newTestOrganization returns a mock organization with this structure:
+//
+//	r-root
+//	├── 111111111111 management (ACTIVE)
+//	├── ou-root-prod00001
+//	│   ├── 222222222222 prod-web (ACTIVE, team=web)
+//	│   └── ou-prod-data0001
+//	│       └── 333333333333 prod-data (ACTIVE, team=data)
+//	└── ou-root-sandbox01
+//	    ├── 444444444444 sandbox (ACTIVE, lumina=skip)
+//	    └── 555555555555 closed (SUSPENDED)
+func newTestOrganization() *MockOrganizationsClient {
+	org := NewMockOrganizationsClient()
+	org.Accounts = []OrganizationAccount{
+		{AccountID: "111111111111", Name: "management", Status: OrganizationAccountStatusActive},
+		{AccountID: "222222222222", Name: "prod-web", Status: OrganizationAccountStatusActive},
+		{AccountID: "333333333333", Name: "prod-data", Status: OrganizationAccountStatusActive},
+		{AccountID: "444444444444", Name: "sandbox", Status: OrganizationAccountStatusActive},
+		{AccountID: "555555555555", Name: "closed", Status: "SUSPENDED"},
+	}
+	org.Parents = map[string]string{
+		"111111111111":      "r-root",
+		"222222222222":      "ou-root-prod00001",
+		"333333333333":      "ou-prod-data0001",
+		"444444444444":      "ou-root-sandbox01",
+		"555555555555":      "ou-root-sandbox01",
+		"ou-root-prod00001": "r-root",
+		"ou-prod-data0001":  "ou-root-prod00001",
+		"ou-root-sandbox01": "r-root",
+	}
+	org.Tags = map[string]map[string]string{
+		"222222222222": {"team": "web"},
+		"333333333333": {"team": "data"},
+		"444444444444": {"lumina": "skip"},
+	}
+	return org
+}
+
+// newTestDiscoveryConfig returns a config discovering accounts with the given filters.
+func newTestDiscoveryConfig(discovery config.AccountDiscoveryConfig) *config.Config {
+	discovery.Enabled = true
+	discovery.RoleName = "lumina-readonly"
+	return &config.Config{
+		DefaultAccount: &config.AWSAccount{
+			AccountID:     "111111111111",
+			Name:          "management",
+			AssumeRoleARN: "arn:aws:iam::111111111111:role/lumina-org-reader",
+		},
+		AccountDiscovery: discovery,
+	}
+}
+
+// accountIDs returns the IDs of accounts, in order.
+func accountIDs(accounts []config.AWSAccount) []string {
+	ids := make([]string, len(accounts))
+	for i, account := range accounts {
+		ids[i] = account.AccountID
+	}
+	return ids
+}
+
+func TestAccountDiscoveryFilters(t *testing.T) {
+	tests := []struct {
+		name      string
+		discovery config.AccountDiscoveryConfig
+		want      []string
+	}{
+		{
+			name: "no filters",
+			want: []string{"111111111111", "222222222222", "333333333333", "444444444444"},
+		},
+		{
+			name:      "include OU matches nested accounts",
+			discovery: config.AccountDiscoveryConfig{IncludeOUs: []string{"ou-root-prod00001"}},
+			want:      []string{"222222222222", "333333333333"},
+		},
+		{
+			name: "exclude OU wins over include",
+			discovery: config.AccountDiscoveryConfig{
+				IncludeOUs: []string{"ou-root-prod00001"},
+				ExcludeOUs: []string{"ou-prod-data0001"},
+			},
+			want: []string{"222222222222"},
+		},
+		{
+			name:      "include tag with value",
+			discovery: config.AccountDiscoveryConfig{IncludeTags: []string{"team=data"}},
+			want:      []string{"333333333333"},
+		},
+		{
+			name:      "exclude tag key",
+			discovery: config.AccountDiscoveryConfig{ExcludeTags: []string{"lumina"}},
+			want:      []string{"111111111111", "222222222222", "333333333333"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discovery := NewAccountDiscovery(newTestOrganization(), newTestDiscoveryConfig(tt.discovery))
+			if err := discovery.Refresh(context.Background()); err != nil {
+				t.Fatalf("Refresh() unexpected error: %v", err)
+			}
+
+			got := accountIDs(discovery.Accounts())
+			if len(got) != len(tt.want) {
+				t.Fatalf("Accounts() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Accounts() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAccountDiscoveryAccounts(t *testing.T) {
+	org := newTestOrganization()
+	cfg := newTestDiscoveryConfig(config.AccountDiscoveryConfig{})
+	cfg.DefaultAccount.AssumeRoleARN = "arn:aws-us-gov:iam::111111111111:role/lumina-org-reader"
+	cfg.AWSAccounts = []config.AWSAccount{
+		{
+			AccountID:     "222222222222",
+			Name:          "web",
+			AssumeRoleARN: "arn:aws-us-gov:iam::222222222222:role/custom",
+			Regions:       []string{"us-gov-west-1"},
+		},
+	}
+
+	discovery := NewAccountDiscovery(org, cfg)
+	if err := discovery.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+
+	accounts := discovery.Accounts()
+	if len(accounts) != 4 {
+		t.Fatalf("expected 4 accounts, got %v", accountIDs(accounts))
+	}
+
+	// Configured accounts keep their settings
+	if accounts[1].Name != "web" || accounts[1].AssumeRoleARN != "arn:aws-us-gov:iam::222222222222:role/custom" {
+		t.Errorf("configured account was overridden: %+v", accounts[1])
+	}
+
+	// Discovered accounts use the role name in the default account's partition
+	want := config.AWSAccount{
+		AccountID:     "333333333333",
+		Name:          "prod-data",
+		AssumeRoleARN: "arn:aws-us-gov:iam::333333333333:role/lumina-readonly",
+	}
+	if got := accounts[2]; got.AccountID != want.AccountID || got.Name != want.Name ||
+		got.AssumeRoleARN != want.AssumeRoleARN {
+		t.Errorf("discovered account = %+v, want %+v", got, want)
+	}
+
+	// Filters that don't need OU members or tags don't look them up
+	if org.ListChildrenCallCount != 0 || org.ListTagsCallCount != 0 {
+		t.Errorf("expected no OU or tag lookups, got %d and %d", org.ListChildrenCallCount, org.ListTagsCallCount)
+	}
+}
+
+func TestAccountDiscoveryOnChange(t *testing.T) {
+	org := newTestOrganization()
+	discovery := NewAccountDiscovery(org, newTestDiscoveryConfig(config.AccountDiscoveryConfig{}))
+
+	var calls int
+	var lastAccounts, lastRemoved []config.AWSAccount
+	discovery.OnChange(func(accounts []config.AWSAccount, removed []config.AWSAccount) {
+		calls++
+		lastAccounts, lastRemoved = accounts, removed
+	})
+
+	if err := discovery.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+	if calls != 1 || len(lastAccounts) != 4 || len(lastRemoved) != 0 {
+		t.Fatalf("after first refresh: calls=%d accounts=%d removed=%d", calls, len(lastAccounts), len(lastRemoved))
+	}
+
+	// An unchanged organization doesn't notify
+	if err := discovery.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected no notification for an unchanged account list, got %d calls", calls)
+	}
+
+	// An account leaving the organization is reported as removed
+	org.Accounts = org.Accounts[:3]
+	if err := discovery.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+	if calls != 2 || len(lastAccounts) != 3 {
+		t.Fatalf("after removal: calls=%d accounts=%d", calls, len(lastAccounts))
+	}
+	if len(lastRemoved) != 1 || lastRemoved[0].AccountID != "444444444444" {
+		t.Errorf("expected 444444444444 removed, got %v", accountIDs(lastRemoved))
+	}
+}
+
+func TestAccountDiscoveryRefreshError(t *testing.T) {
+	org := newTestOrganization()
+	discovery := NewAccountDiscovery(org, newTestDiscoveryConfig(config.AccountDiscoveryConfig{
+		ExcludeTags: []string{"lumina=skip"},
+	}))
+	if err := discovery.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+
+	// Failures listing accounts or evaluating filters keep the previous accounts
+	org.ListTagsError = errors.New("throttled")
+	if err := discovery.Refresh(context.Background()); err == nil {
+		t.Error("expected error when tags can't be listed")
+	}
+	org.ListAccountsError = errors.New("access denied")
+	if err := discovery.Refresh(context.Background()); err == nil {
+		t.Error("expected error when accounts can't be listed")
+	}
+
+	if got := discovery.Accounts(); len(got) != 3 {
+		t.Errorf("expected previous 3 accounts to be kept, got %v", accountIDs(got))
+	}
+}
+
+func TestAccountDiscoverySetConfig(t *testing.T) {
+	discovery := NewAccountDiscovery(newTestOrganization(), newTestDiscoveryConfig(config.AccountDiscoveryConfig{}))
+	if err := discovery.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+
+	// A reloaded config narrows the filters and adds an account outside the organization
+	cfg := newTestDiscoveryConfig(config.AccountDiscoveryConfig{IncludeTags: []string{"team"}})
+	cfg.AWSAccounts = []config.AWSAccount{{
+		AccountID:     "999999999999",
+		Name:          "partner",
+		AssumeRoleARN: "arn:aws:iam::999999999999:role/lumina",
+	}}
+	discovery.SetConfig(cfg)
+	if got := discovery.Accounts(); len(got) != 4 {
+		t.Fatalf("SetConfig() changed accounts before a refresh: %v", accountIDs(got))
+	}
+
+	var removed []config.AWSAccount
+	discovery.OnChange(func(_ []config.AWSAccount, r []config.AWSAccount) { removed = r })
+	if err := discovery.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+
+	got := accountIDs(discovery.Accounts())
+	want := []string{"222222222222", "333333333333", "999999999999"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Accounts() = %v, want %v", got, want)
+	}
+	if ids := accountIDs(removed); !slices.Equal(ids, []string{"111111111111", "444444444444"}) {
+		t.Errorf("removed = %v, want 111111111111 and 444444444444", ids)
+	}
+}
+
+func TestAccountDiscoveryListsOUsOncePerRefresh(t *testing.T) {
+	org := newTestOrganization()
+	discovery := NewAccountDiscovery(org, newTestDiscoveryConfig(config.AccountDiscoveryConfig{
+		IncludeOUs: []string{"ou-root-prod00001"},
+	}))
+	if err := discovery.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+
+	// The accounts and OUs in ou-root-prod00001 and its nested ou-prod-data0001,
+	// however many accounts are evaluated
+	if org.ListChildrenCallCount != 4 {
+		t.Errorf("expected 4 ListChildren calls, got %d", org.ListChildrenCallCount)
+	}
+
+	org.ListChildrenError = errors.New("throttled")
+	if err := discovery.Refresh(context.Background()); err == nil {
+		t.Error("expected error when OU children can't be listed")
+	}
+	if got := accountIDs(discovery.Accounts()); !slices.Equal(got, []string{"222222222222", "333333333333"}) {
+		t.Errorf("expected previous accounts to be kept, got %v", got)
+	}
+}
@@ -33,6 +33,11 @@ type Client interface {
 
 	// Pricing returns a PricingClient (does not require account-specific credentials)
 	Pricing(ctx context.Context) PricingClient
+
+	// Organizations returns an OrganizationsClient using the default account's
+	// credentials, which must be the organization's management account or a
+	// delegated administrator.
+	Organizations(ctx context.Context) (OrganizationsClient, error)
 }
 
 // EC2Client provides access to EC2 API operations needed for cost calculation.
@@ -45,6 +50,18 @@ type EC2Client interface {
 	// If regions is empty, queries all regions.
 	DescribeReservedInstances(ctx context.Context, regions []string) ([]ReservedInstance, error)
 
+	// DescribeCapacityReservations returns all active On-Demand Capacity Reservations
+	// in the specified regions. If regions is empty, queries all regions.
+	DescribeCapacityReservations(ctx context.Context, regions []string) ([]CapacityReservation, error)
+
+	// DescribeVolumes returns all EBS volumes in the specified regions.
+	// If regions is empty, queries all regions.
+	DescribeVolumes(ctx context.Context, regions []string) ([]Volume, error)
+
+	// DescribeInstanceTypes returns the vCPU, memory, GPU, and network capacity of the
+	// specified instance types, as offered in the client's region.
+	DescribeInstanceTypes(ctx context.Context, instanceTypes []string) ([]InstanceTypeInfo, error)
+
 	// DescribeSpotPriceHistory returns current spot prices for the specified instance types.
 	// If instanceTypes is empty, returns prices for all instance types.
 	// If regions is empty, queries all regions.
@@ -144,14 +161,39 @@ type PricingClient interface {
 	// Parameters:
 	//   - regions: List of AWS regions to load pricing for (e.g., ["us-west-2", "us-east-1"])
 	//   - operatingSystems: List of OS types to load (e.g., ["Linux", "Windows"])
+	//   - tenancies: EC2 tenancy values to load in addition to shared tenancy
+	//     (e.g., ["dedicated", "host"]). Shared tenancy is always loaded.
 	//
-	// Returns a map of "region:instanceType:os" -> price ($/hour) for all loaded prices.
-	// This allows callers to populate their own caches if needed.
+	// Returns a map of "region:instanceType:os" -> price ($/hour) for shared tenancy
+	// prices and "region:instanceType:os:tenancy" -> price for dedicated and host
+	// tenancy prices. This allows callers to populate their own caches if needed.
 	LoadAllPricing(
 		ctx context.Context,
 		regions []string,
 		operatingSystems []string,
+		tenancies []string,
 	) (map[string]float64, error)
+
+	// LoadEBSPricing loads EBS volume pricing (storage, provisioned IOPS, and
+	// provisioned throughput) for every volume type in the specified regions.
+	//
+	// Returns a map of "region:volumeType" -> price.
+	LoadEBSPricing(ctx context.Context, regions []string) (map[string]EBSPrice, error)
+}
+
+// OrganizationsClient provides access to the AWS Organizations API operations
+// used to discover the accounts in an organization.
+type OrganizationsClient interface {
+	// ListAccounts returns every account in the organization, in any status.
+	ListAccounts(ctx context.Context) ([]OrganizationAccount, error)
+
+	// ListChildren returns the IDs of the accounts (OrganizationChildTypeAccount) or
+	// organizational units (OrganizationChildTypeOrganizationalUnit) directly in an
+	// organizational unit (e.g., "ou-ab12-cd34ef56") or root (e.g., "r-ab12").
+	ListChildren(ctx context.Context, parentID, childType string) ([]string, error)
+
+	// ListTags returns the tags attached to an account.
+	ListTags(ctx context.Context, accountID string) (map[string]string, error)
 }
 
 // ClientConfig configures the AWS client creation.
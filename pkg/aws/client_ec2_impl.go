@@ -17,7 +17,9 @@ package aws
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	aws "github.com/aws/aws-sdk-go-v2/aws"
@@ -168,6 +170,112 @@ func (c *RealEC2Client) DescribeReservedInstances(ctx context.Context, regions [
 	return allRIs, nil
 }
 
+// DescribeCapacityReservations returns all active On-Demand Capacity Reservations in
+// the specified regions. If regions is empty, queries the client's configured region.
+//
+// Capacity Reservations are zonal resources, queried per-region like Reserved Instances.
+// coverage:ignore - requires real AWS credentials, tested via E2E with LocalStack
+func (c *RealEC2Client) DescribeCapacityReservations(
+	ctx context.Context,
+	regions []string,
+) ([]CapacityReservation, error) {
+	queryRegions := regions
+	if len(queryRegions) == 0 {
+		queryRegions = []string{c.region}
+	}
+
+	var allReservations []CapacityReservation
+
+	for _, region := range queryRegions {
+		input := &ec2.DescribeCapacityReservationsInput{
+			// Only active reservations are billed (not pending/expired/cancelled)
+			Filters: []types.Filter{
+				{
+					Name:   aws.String("state"),
+					Values: []string{string(types.CapacityReservationStateActive)},
+				},
+			},
+		}
+
+		paginator := ec2.NewDescribeCapacityReservationsPaginator(c.client, input)
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe capacity reservations in %s: %w", region, err)
+			}
+
+			for _, cr := range output.CapacityReservations {
+				allReservations = append(allReservations,
+					convertCapacityReservation(cr, region, c.accountID, c.accountName))
+			}
+		}
+	}
+
+	return allReservations, nil
+}
+
+// DescribeVolumes returns all EBS volumes in the specified regions, attached or not.
+// If regions is empty, queries the client's configured region.
+// coverage:ignore - requires real AWS credentials, tested via E2E with LocalStack
+func (c *RealEC2Client) DescribeVolumes(ctx context.Context, regions []string) ([]Volume, error) {
+	queryRegions := regions
+	if len(queryRegions) == 0 {
+		queryRegions = []string{c.region}
+	}
+
+	var allVolumes []Volume
+
+	for _, region := range queryRegions {
+		paginator := ec2.NewDescribeVolumesPaginator(c.client, &ec2.DescribeVolumesInput{})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe volumes in %s: %w", region, err)
+			}
+
+			for _, vol := range output.Volumes {
+				allVolumes = append(allVolumes, convertVolume(vol, region, c.accountID, c.accountName))
+			}
+		}
+	}
+
+	return allVolumes, nil
+}
+
+// describeInstanceTypesBatchSize is the most instance types DescribeInstanceTypes
+// accepts in one request.
+const describeInstanceTypesBatchSize = 100
+
+// DescribeInstanceTypes returns the capacity of the specified instance types in the
+// client's region. Types the region doesn't offer are left out.
+// coverage:ignore - requires real AWS credentials, tested via E2E with LocalStack
+func (c *RealEC2Client) DescribeInstanceTypes(ctx context.Context, instanceTypes []string) ([]InstanceTypeInfo, error) {
+	var allTypes []InstanceTypeInfo
+
+	for batch := range slices.Chunk(instanceTypes, describeInstanceTypesBatchSize) {
+		input := &ec2.DescribeInstanceTypesInput{
+			InstanceTypes: make([]types.InstanceType, len(batch)),
+		}
+		for i, instanceType := range batch {
+			input.InstanceTypes[i] = types.InstanceType(instanceType)
+		}
+
+		paginator := ec2.NewDescribeInstanceTypesPaginator(c.client, input)
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe instance types in %s: %w", c.region, err)
+			}
+
+			for _, info := range output.InstanceTypes {
+				allTypes = append(allTypes, convertInstanceTypeInfo(info))
+			}
+		}
+	}
+
+	return allTypes, nil
+}
+
 // DescribeSpotPriceHistory returns current spot prices for the specified instance types.
 // If regions is empty, queries the client's configured region.
 // If instanceTypes is empty, returns prices for all instance types.
@@ -298,10 +406,18 @@ func convertInstance(inst types.Instance, region, accountID, accountName string)
 	}
 
 	// Normalize platform name
-	// AWS returns "windows" for Windows, but nothing for Linux
+	// AWS returns "windows" for Windows, but nothing for Linux. Licensed Linux
+	// distributions are only distinguishable through PlatformDetails
+	// (e.g., "Red Hat Enterprise Linux", "SUSE Linux"), and are priced differently.
 	platform := PlatformLinux
-	if inst.Platform == types.PlatformValuesWindows {
+	platformDetails := aws.ToString(inst.PlatformDetails)
+	switch {
+	case inst.Platform == types.PlatformValuesWindows:
 		platform = PlatformWindows
+	case strings.HasPrefix(platformDetails, "Red Hat"):
+		platform = PlatformRHEL
+	case strings.HasPrefix(platformDetails, ProductDescriptionSUSE):
+		platform = PlatformSUSE
 	}
 
 	// Convert tags to map
@@ -333,6 +449,7 @@ func convertInstance(inst types.Instance, region, accountID, accountName string)
 		Platform:              platform,
 		Tenancy:               tenancy,
 		SpotInstanceRequestID: aws.ToString(inst.SpotInstanceRequestId),
+		CapacityReservationID: aws.ToString(inst.CapacityReservationId),
 	}
 }
 
@@ -361,7 +478,92 @@ func convertReservedInstance(
 		OfferingClass:      string(ri.OfferingClass),
 		OfferingType:       string(ri.OfferingType),
 		Platform:           string(ri.ProductDescription),
+		Tenancy:            string(ri.InstanceTenancy),
 		AccountID:          accountID,
 		AccountName:        accountName,
 	}
 }
+
+// convertCapacityReservation converts an AWS SDK CapacityReservation to our type.
+func convertCapacityReservation(
+	cr types.CapacityReservation,
+	region, accountID, accountName string,
+) CapacityReservation {
+	var start, end time.Time
+	if cr.StartDate != nil {
+		start = *cr.StartDate
+	}
+	if cr.EndDate != nil {
+		end = *cr.EndDate
+	}
+
+	return CapacityReservation{
+		CapacityReservationID:  aws.ToString(cr.CapacityReservationId),
+		InstanceType:           aws.ToString(cr.InstanceType),
+		AvailabilityZone:       aws.ToString(cr.AvailabilityZone),
+		Region:                 region,
+		Platform:               string(cr.InstancePlatform),
+		Tenancy:                string(cr.Tenancy),
+		TotalInstanceCount:     aws.ToInt32(cr.TotalInstanceCount),
+		AvailableInstanceCount: aws.ToInt32(cr.AvailableInstanceCount),
+		InstanceMatchCriteria:  string(cr.InstanceMatchCriteria),
+		State:                  string(cr.State),
+		Start:                  start,
+		End:                    end,
+		AccountID:              accountID,
+		AccountName:            accountName,
+	}
+}
+
+// convertInstanceTypeInfo converts an AWS SDK InstanceTypeInfo to our InstanceTypeInfo type.
+func convertInstanceTypeInfo(info types.InstanceTypeInfo) InstanceTypeInfo {
+	result := InstanceTypeInfo{InstanceType: string(info.InstanceType)}
+	if info.VCpuInfo != nil {
+		result.VCPUs = aws.ToInt32(info.VCpuInfo.DefaultVCpus)
+	}
+	if info.MemoryInfo != nil {
+		result.MemoryMiB = aws.ToInt64(info.MemoryInfo.SizeInMiB)
+	}
+	if info.GpuInfo != nil {
+		for _, gpu := range info.GpuInfo.Gpus {
+			result.GPUs += aws.ToInt32(gpu.Count)
+		}
+	}
+	if info.NetworkInfo != nil {
+		result.NetworkPerformance = aws.ToString(info.NetworkInfo.NetworkPerformance)
+		for _, card := range info.NetworkInfo.NetworkCards {
+			result.NetworkBaselineGbps += aws.ToFloat64(card.BaselineBandwidthInGbps)
+		}
+	}
+	return result
+}
+
+// convertVolume converts an AWS SDK Volume to our Volume type.
+// Detached and detaching attachments are left out, so a volume being moved
+// between instances is only attributed to the one it's attached to.
+func convertVolume(vol types.Volume, region, accountID, accountName string) Volume {
+	var attached []string
+	for _, attachment := range vol.Attachments {
+		switch attachment.State {
+		case types.VolumeAttachmentStateDetached, types.VolumeAttachmentStateDetaching:
+			continue
+		}
+		if id := aws.ToString(attachment.InstanceId); id != "" {
+			attached = append(attached, id)
+		}
+	}
+
+	return Volume{
+		VolumeID:            aws.ToString(vol.VolumeId),
+		VolumeType:          string(vol.VolumeType),
+		SizeGiB:             aws.ToInt32(vol.Size),
+		IOPS:                aws.ToInt32(vol.Iops),
+		Throughput:          aws.ToInt32(vol.Throughput),
+		AvailabilityZone:    aws.ToString(vol.AvailabilityZone),
+		Region:              region,
+		State:               string(vol.State),
+		AttachedInstanceIDs: attached,
+		AccountID:           accountID,
+		AccountName:         accountName,
+	}
+}
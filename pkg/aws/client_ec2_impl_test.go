@@ -336,6 +336,143 @@ func TestConvertReservedInstance(t *testing.T) {
 	})
 }
 
+// TestConvertCapacityReservation tests the convertCapacityReservation function.
+func TestConvertCapacityReservation(t *testing.T) {
+	t.Run("complete data", func(t *testing.T) {
+		startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		endTime := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		awsCR := types.CapacityReservation{
+			CapacityReservationId:  aws.String("cr-12345678"),
+			InstanceType:           aws.String("m5.large"),
+			AvailabilityZone:       aws.String("us-west-2a"),
+			InstancePlatform:       types.CapacityReservationInstancePlatformLinuxUnix,
+			Tenancy:                types.CapacityReservationTenancyDefault,
+			TotalInstanceCount:     aws.Int32(4),
+			AvailableInstanceCount: aws.Int32(1),
+			InstanceMatchCriteria:  types.InstanceMatchCriteriaOpen,
+			State:                  types.CapacityReservationStateActive,
+			StartDate:              &startTime,
+			EndDate:                &endTime,
+		}
+
+		result := convertCapacityReservation(awsCR, testRegion, "123456789012", "test-account")
+
+		expected := CapacityReservation{
+			CapacityReservationID:  "cr-12345678",
+			InstanceType:           "m5.large",
+			AvailabilityZone:       "us-west-2a",
+			Region:                 testRegion,
+			Platform:               "Linux/UNIX",
+			Tenancy:                "default",
+			TotalInstanceCount:     4,
+			AvailableInstanceCount: 1,
+			InstanceMatchCriteria:  "open",
+			State:                  "active",
+			Start:                  startTime,
+			End:                    endTime,
+			AccountID:              "123456789012",
+			AccountName:            "test-account",
+		}
+		if result != expected {
+			t.Errorf("expected %+v, got %+v", expected, result)
+		}
+	})
+
+	// Reservations with an "unlimited" end date have no EndDate
+	t.Run("minimal data with nil pointers", func(t *testing.T) {
+		result := convertCapacityReservation(types.CapacityReservation{}, "us-east-1", "987654321098", "another")
+
+		if result.CapacityReservationID != "" || result.InstanceType != "" {
+			t.Errorf("expected empty ID and instance type, got %q and %q",
+				result.CapacityReservationID, result.InstanceType)
+		}
+		if result.TotalInstanceCount != 0 || result.AvailableInstanceCount != 0 {
+			t.Errorf("expected zero counts, got %d and %d", result.TotalInstanceCount, result.AvailableInstanceCount)
+		}
+		if !result.Start.IsZero() || !result.End.IsZero() {
+			t.Errorf("expected zero times, got %v and %v", result.Start, result.End)
+		}
+	})
+}
+
+// TestConvertVolume tests that only current attachments are kept.
+func TestConvertVolume(t *testing.T) {
+	awsVol := types.Volume{
+		VolumeId:         aws.String("vol-0123"),
+		VolumeType:       types.VolumeTypeGp3,
+		Size:             aws.Int32(100),
+		Iops:             aws.Int32(4000),
+		Throughput:       aws.Int32(250),
+		AvailabilityZone: aws.String("us-west-2a"),
+		State:            types.VolumeStateInUse,
+		Attachments: []types.VolumeAttachment{
+			{InstanceId: aws.String("i-old"), State: types.VolumeAttachmentStateDetaching},
+			{InstanceId: aws.String("i-new"), State: types.VolumeAttachmentStateAttached},
+		},
+	}
+
+	result := convertVolume(awsVol, testRegion, "123456789012", "test-account")
+
+	if result.VolumeID != "vol-0123" || result.VolumeType != VolumeTypeGP3 {
+		t.Errorf("expected vol-0123 (gp3), got %s (%s)", result.VolumeID, result.VolumeType)
+	}
+	if result.SizeGiB != 100 || result.IOPS != 4000 || result.Throughput != 250 {
+		t.Errorf("unexpected size/IOPS/throughput: %d/%d/%d", result.SizeGiB, result.IOPS, result.Throughput)
+	}
+	if result.Region != testRegion || result.AvailabilityZone != "us-west-2a" || result.State != "in-use" {
+		t.Errorf("unexpected location/state: %s/%s/%s", result.Region, result.AvailabilityZone, result.State)
+	}
+	if len(result.AttachedInstanceIDs) != 1 || result.AttachedInstanceIDs[0] != "i-new" {
+		t.Errorf("expected only i-new to be attached, got %v", result.AttachedInstanceIDs)
+	}
+	if result.AccountID != "123456789012" || result.AccountName != "test-account" {
+		t.Errorf("unexpected account: %s/%s", result.AccountID, result.AccountName)
+	}
+
+	unattached := convertVolume(types.Volume{VolumeId: aws.String("vol-spare")}, testRegion, "123456789012", "")
+	if len(unattached.AttachedInstanceIDs) != 0 {
+		t.Errorf("expected no attachments, got %v", unattached.AttachedInstanceIDs)
+	}
+}
+
+// TestConvertInstanceTypeInfo tests converting instance type capacity, including
+// types with several GPUs and network cards.
+func TestConvertInstanceTypeInfo(t *testing.T) {
+	info := types.InstanceTypeInfo{
+		InstanceType: types.InstanceTypeP4d24xlarge,
+		VCpuInfo:     &types.VCpuInfo{DefaultVCpus: aws.Int32(96)},
+		MemoryInfo:   &types.MemoryInfo{SizeInMiB: aws.Int64(1179648)},
+		GpuInfo: &types.GpuInfo{Gpus: []types.GpuDeviceInfo{
+			{Name: aws.String("A100"), Count: aws.Int32(8)},
+		}},
+		NetworkInfo: &types.NetworkInfo{
+			NetworkPerformance: aws.String("4x 100 Gigabit"),
+			NetworkCards: []types.NetworkCardInfo{
+				{BaselineBandwidthInGbps: aws.Float64(100)},
+				{BaselineBandwidthInGbps: aws.Float64(100)},
+			},
+		},
+	}
+
+	result := convertInstanceTypeInfo(info)
+	if result.InstanceType != "p4d.24xlarge" || result.VCPUs != 96 || result.GPUs != 8 {
+		t.Errorf("unexpected type/vCPUs/GPUs: %s/%d/%d", result.InstanceType, result.VCPUs, result.GPUs)
+	}
+	if result.MemoryGiB() != 1152 {
+		t.Errorf("expected 1152 GiB, got %v", result.MemoryGiB())
+	}
+	if result.NetworkPerformance != "4x 100 Gigabit" || result.NetworkBaselineGbps != 200 {
+		t.Errorf("unexpected network: %s/%v", result.NetworkPerformance, result.NetworkBaselineGbps)
+	}
+
+	// Types without GPU or network details
+	minimal := convertInstanceTypeInfo(types.InstanceTypeInfo{InstanceType: types.InstanceTypeT3Micro})
+	if minimal.InstanceType != "t3.micro" || minimal.GPUs != 0 || minimal.NetworkBaselineGbps != 0 {
+		t.Errorf("unexpected minimal conversion: %+v", minimal)
+	}
+}
+
 // TestConvertInstance tests the convertInstance function.
 func TestConvertInstance(t *testing.T) {
 	// Test with complete instance data (Linux on-demand)
@@ -435,6 +572,27 @@ func TestConvertInstance(t *testing.T) {
 		}
 	})
 
+	// Test with an instance running in a Capacity Reservation
+	t.Run("capacity reservation", func(t *testing.T) {
+		awsInst := types.Instance{
+			InstanceId:            aws.String("i-cr123"),
+			InstanceType:          types.InstanceTypeM5Large,
+			CapacityReservationId: aws.String("cr-abc123"),
+			Placement: &types.Placement{
+				AvailabilityZone: aws.String("us-east-1a"),
+			},
+			State: &types.InstanceState{
+				Name: types.InstanceStateNameRunning,
+			},
+		}
+
+		result := convertInstance(awsInst, "us-east-1", "987654321098", "test-account")
+
+		if result.CapacityReservationID != "cr-abc123" {
+			t.Errorf("expected CapacityReservationID cr-abc123, got %s", result.CapacityReservationID)
+		}
+	})
+
 	// Test with Windows instance
 	t.Run("Windows instance", func(t *testing.T) {
 		awsInst := types.Instance{
@@ -456,6 +614,36 @@ func TestConvertInstance(t *testing.T) {
 		}
 	})
 
+	// Test with licensed Linux distributions (only visible via PlatformDetails)
+	t.Run("RHEL and SUSE instances", func(t *testing.T) {
+		tests := map[string]string{
+			"Red Hat Enterprise Linux":         "rhel",
+			"Red Hat Enterprise Linux with HA": "rhel",
+			"SUSE Linux":                       "suse",
+			"Linux/UNIX":                       "linux",
+		}
+
+		for details, expected := range tests {
+			awsInst := types.Instance{
+				InstanceId:      aws.String("i-licensed123"),
+				InstanceType:    types.InstanceTypeM5Large,
+				PlatformDetails: aws.String(details),
+				Placement: &types.Placement{
+					AvailabilityZone: aws.String("us-west-2b"),
+				},
+				State: &types.InstanceState{
+					Name: types.InstanceStateNameRunning,
+				},
+			}
+
+			result := convertInstance(awsInst, testRegion, "123456789012", "test-account")
+
+			if result.Platform != expected {
+				t.Errorf("PlatformDetails %q: expected Platform %s, got %s", details, expected, result.Platform)
+			}
+		}
+	})
+
 	// Test with minimal data (nil pointers)
 	t.Run("minimal data with nil pointers", func(t *testing.T) {
 		awsInst := types.Instance{
@@ -187,6 +187,19 @@ func (c *RealClient) Pricing(ctx context.Context) PricingClient {
 	return c.pricingCache
 }
 
+// Organizations returns an OrganizationsClient authenticated as the default account.
+// Like pricing, the Organizations API isn't account-specific: it always answers for
+// the organization the default account belongs to. The endpoint is the one for the
+// partition of the default account's role.
+func (c *RealClient) Organizations(ctx context.Context) (OrganizationsClient, error) {
+	region := OrganizationsRegion(rolePartition(c.defaultAccountConfig.AssumeRoleARN))
+	client, err := NewRealOrganizationsClient(ctx, c.getCredentials(c.defaultAccountConfig), region, c.endpointURL)
+	if err != nil { // coverage:ignore - AWS SDK config errors are difficult to trigger in unit tests
+		return nil, err
+	}
+	return client, nil
+}
+
 // BrokenPricingClient is a PricingClient that always returns an error.
 // This is used as a fallback when the real pricing client fails to initialize.
 // It allows the controller to continue operating even if pricing API is unavailable.
@@ -219,10 +232,16 @@ func (b *BrokenPricingClient) LoadAllPricing(
 	_ context.Context,
 	_ []string,
 	_ []string,
+	_ []string,
 ) (map[string]float64, error) {
 	return nil, b.err
 }
 
+// LoadEBSPricing always returns the initialization error.
+func (b *BrokenPricingClient) LoadEBSPricing(_ context.Context, _ []string) (map[string]EBSPrice, error) {
+	return nil, b.err
+}
+
 // getCredentials returns a credential provider for the specified account.
 // If AssumeRoleARN is set, it returns an AssumeRoleProvider that automatically
 // refreshes credentials before expiration. Otherwise, it returns the default
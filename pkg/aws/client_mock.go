@@ -17,6 +17,8 @@ package aws
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 )
@@ -35,6 +37,9 @@ type MockClient struct {
 	// PricingClientInstance is the mock pricing client
 	PricingClientInstance *MockPricingClient
 
+	// OrganizationsClientInstance is the mock Organizations client
+	OrganizationsClientInstance *MockOrganizationsClient
+
 	// AssumeRoleCalls tracks all AssumeRole attempts
 	AssumeRoleCalls []AssumeRoleCall
 
@@ -54,10 +59,11 @@ type AssumeRoleCall struct {
 // NewMockClient creates a new MockClient with initialized maps.
 func NewMockClient() *MockClient {
 	return &MockClient{
-		EC2Clients:            make(map[string]*MockEC2Client),
-		SavingsPlansClients:   make(map[string]*MockSavingsPlansClient),
-		PricingClientInstance: NewMockPricingClient(),
-		AssumeRoleCalls:       []AssumeRoleCall{},
+		EC2Clients:                  make(map[string]*MockEC2Client),
+		SavingsPlansClients:         make(map[string]*MockSavingsPlansClient),
+		PricingClientInstance:       NewMockPricingClient(),
+		OrganizationsClientInstance: NewMockOrganizationsClient(),
+		AssumeRoleCalls:             []AssumeRoleCall{},
 	}
 }
 
@@ -122,6 +128,11 @@ func (m *MockClient) Pricing(ctx context.Context) PricingClient {
 	return m.PricingClientInstance
 }
 
+// Organizations returns the mock OrganizationsClient.
+func (m *MockClient) Organizations(ctx context.Context) (OrganizationsClient, error) {
+	return m.OrganizationsClientInstance, nil
+}
+
 // MockEC2Client is a mock implementation of EC2Client for testing.
 type MockEC2Client struct {
 	mu sync.RWMutex
@@ -132,28 +143,45 @@ type MockEC2Client struct {
 	// ReservedInstances is the mock RI data
 	ReservedInstances []ReservedInstance
 
+	// CapacityReservations is the mock Capacity Reservation data
+	CapacityReservations []CapacityReservation
+
+	// Volumes is the mock EBS volume data
+	Volumes []Volume
+
+	// InstanceTypes is the mock instance type capacity data
+	InstanceTypes []InstanceTypeInfo
+
 	// SpotPrices is the mock spot price data
 	SpotPrices []SpotPrice
 
 	// Error injection for testing error paths
-	DescribeInstancesError         error
-	DescribeReservedInstancesError error
-	DescribeSpotPriceHistoryError  error
-	GetInstanceByIDError           error
+	DescribeInstancesError            error
+	DescribeReservedInstancesError    error
+	DescribeCapacityReservationsError error
+	DescribeVolumesError              error
+	DescribeInstanceTypesError        error
+	DescribeSpotPriceHistoryError     error
+	GetInstanceByIDError              error
 
 	// CallCounts tracks method call counts
-	DescribeInstancesCallCount         int
-	DescribeReservedInstancesCallCount int
-	DescribeSpotPriceHistoryCallCount  int
-	GetInstanceByIDCallCount           int
+	DescribeInstancesCallCount            int
+	DescribeReservedInstancesCallCount    int
+	DescribeCapacityReservationsCallCount int
+	DescribeVolumesCallCount              int
+	DescribeInstanceTypesCallCount        int
+	DescribeSpotPriceHistoryCallCount     int
+	GetInstanceByIDCallCount              int
 }
 
 // NewMockEC2Client creates a new MockEC2Client.
 func NewMockEC2Client() *MockEC2Client {
 	return &MockEC2Client{
-		Instances:         []Instance{},
-		ReservedInstances: []ReservedInstance{},
-		SpotPrices:        []SpotPrice{},
+		Instances:            []Instance{},
+		ReservedInstances:    []ReservedInstance{},
+		CapacityReservations: []CapacityReservation{},
+		Volumes:              []Volume{},
+		SpotPrices:           []SpotPrice{},
 	}
 }
 
@@ -219,6 +247,91 @@ func (m *MockEC2Client) DescribeReservedInstances(ctx context.Context, regions [
 	return filtered, nil
 }
 
+// DescribeCapacityReservations returns the mock Capacity Reservation data.
+func (m *MockEC2Client) DescribeCapacityReservations(
+	ctx context.Context,
+	regions []string,
+) ([]CapacityReservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DescribeCapacityReservationsCallCount++
+
+	// Return error if set (for testing error paths)
+	if m.DescribeCapacityReservationsError != nil {
+		return nil, m.DescribeCapacityReservationsError
+	}
+
+	// Filter by region if specified
+	if len(regions) == 0 {
+		return m.CapacityReservations, nil
+	}
+
+	regionMap := make(map[string]bool)
+	for _, r := range regions {
+		regionMap[r] = true
+	}
+
+	filtered := []CapacityReservation{}
+	for _, cr := range m.CapacityReservations {
+		if regionMap[cr.Region] {
+			filtered = append(filtered, cr)
+		}
+	}
+
+	return filtered, nil
+}
+
+// DescribeVolumes returns the mock EBS volume data.
+func (m *MockEC2Client) DescribeVolumes(ctx context.Context, regions []string) ([]Volume, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DescribeVolumesCallCount++
+
+	// Return error if set (for testing error paths)
+	if m.DescribeVolumesError != nil {
+		return nil, m.DescribeVolumesError
+	}
+
+	// Filter by region if specified
+	if len(regions) == 0 {
+		return m.Volumes, nil
+	}
+
+	regionMap := make(map[string]bool)
+	for _, r := range regions {
+		regionMap[r] = true
+	}
+
+	filtered := []Volume{}
+	for _, vol := range m.Volumes {
+		if regionMap[vol.Region] {
+			filtered = append(filtered, vol)
+		}
+	}
+
+	return filtered, nil
+}
+
+// DescribeInstanceTypes returns the mock capacity of the requested instance types.
+func (m *MockEC2Client) DescribeInstanceTypes(ctx context.Context, instanceTypes []string) ([]InstanceTypeInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DescribeInstanceTypesCallCount++
+
+	if m.DescribeInstanceTypesError != nil {
+		return nil, m.DescribeInstanceTypesError
+	}
+
+	filtered := []InstanceTypeInfo{}
+	for _, info := range m.InstanceTypes {
+		if slices.Contains(instanceTypes, info.InstanceType) {
+			filtered = append(filtered, info)
+		}
+	}
+
+	return filtered, nil
+}
+
 // DescribeSpotPriceHistory returns the mock spot price data.
 func (m *MockEC2Client) DescribeSpotPriceHistory(
 	ctx context.Context,
@@ -437,18 +550,27 @@ func (m *MockSavingsPlansClient) DescribeSavingsPlanRates(
 type MockPricingClient struct {
 	mu sync.RWMutex
 
-	// OnDemandPrices maps "region:instanceType:os" to price
+	// OnDemandPrices maps "region:instanceType:os" (shared tenancy) or
+	// "region:instanceType:os:tenancy" (dedicated/host) to price
 	OnDemandPrices map[string]*OnDemandPrice
 
+	// EBSPrices maps "region:volumeType" to EBS volume pricing
+	EBSPrices map[string]EBSPrice
+
+	// LoadEBSPricingError can be set to simulate Pricing API errors
+	LoadEBSPricingError error
+
 	// CallCounts tracks method call counts
 	GetOnDemandPriceCallCount  int
 	GetOnDemandPricesCallCount int
+	LoadEBSPricingCallCount    int
 }
 
 // NewMockPricingClient creates a new MockPricingClient.
 func NewMockPricingClient() *MockPricingClient {
 	return &MockPricingClient{
 		OnDemandPrices: make(map[string]*OnDemandPrice),
+		EBSPrices:      make(map[string]EBSPrice),
 	}
 }
 
@@ -500,17 +622,32 @@ func (m *MockPricingClient) LoadAllPricing(
 	_ context.Context,
 	regions []string,
 	operatingSystems []string,
+	tenancies []string,
 ) (map[string]float64, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	result := make(map[string]float64)
 
-	// Filter cached prices by requested regions/OS
+	// Shared tenancy is always loaded, matching RealPricingClient
+	requestedTenancies := map[string]bool{PricingTenancyShared: true}
+	for _, t := range tenancies {
+		requestedTenancies[pricingAPITenancy(t)] = true
+	}
+
+	// Filter cached prices by requested regions/OS/tenancy
 	for key, price := range m.OnDemandPrices {
-		// Key format: "region:instanceType:os"
+		// Key format: "region:instanceType:os" (shared) or "region:instanceType:os:tenancy"
 		parts := splitCacheKey(key)
-		if len(parts) != 3 {
+		tenancy := PricingTenancyShared
+		switch len(parts) {
+		case 3:
+		case 4:
+			tenancy = parts[3]
+		default:
+			continue
+		}
+		if !requestedTenancies[tenancy] {
 			continue
 		}
 
@@ -541,6 +678,31 @@ func (m *MockPricingClient) LoadAllPricing(
 	return result, nil
 }
 
+// LoadEBSPricing returns the mock EBS prices for the requested regions.
+func (m *MockPricingClient) LoadEBSPricing(_ context.Context, regions []string) (map[string]EBSPrice, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.LoadEBSPricingCallCount++
+
+	if m.LoadEBSPricingError != nil {
+		return nil, m.LoadEBSPricingError
+	}
+
+	requested := make(map[string]bool, len(regions))
+	for _, r := range regions {
+		requested[r] = true
+	}
+
+	result := make(map[string]EBSPrice)
+	for key, price := range m.EBSPrices {
+		if requested[price.Region] {
+			result[key] = price
+		}
+	}
+
+	return result, nil
+}
+
 // splitCacheKey splits a cache key into its components.
 func splitCacheKey(key string) []string {
 	// Simple split by colon
@@ -579,3 +741,110 @@ func (m *MockPricingClient) SetOnDemandPrice(
 		Tenancy:         "Shared",
 	}
 }
+
+// SetOnDemandPriceForTenancy sets a mock on-demand price for a dedicated or host
+// tenancy (helper for tests). tenancy is an EC2 value ("dedicated", "host").
+func (m *MockPricingClient) SetOnDemandPriceForTenancy(
+	region string,
+	instanceType string,
+	operatingSystem string,
+	tenancy string,
+	pricePerHour float64,
+) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pricingTenancy := pricingAPITenancy(tenancy)
+	m.OnDemandPrices[onDemandPriceKey(region, instanceType, operatingSystem, pricingTenancy)] = &OnDemandPrice{
+		InstanceType:    instanceType,
+		Region:          region,
+		PricePerHour:    pricePerHour,
+		OperatingSystem: operatingSystem,
+		Tenancy:         pricingTenancy,
+	}
+}
+
+// MockOrganizationsClient is a mock implementation of OrganizationsClient for testing.
+type MockOrganizationsClient struct {
+	mu sync.RWMutex
+
+	// Accounts is the mock list of organization accounts
+	Accounts []OrganizationAccount
+
+	// Parents maps an account or OU ID to the ID of its parent OU or root.
+	// ListChildren finds children here; IDs starting with "ou-" are OUs.
+	Parents map[string]string
+
+	// Tags maps an account ID to its tags
+	Tags map[string]map[string]string
+
+	// Error injection for testing error paths
+	ListAccountsError error
+	ListChildrenError error
+	ListTagsError     error
+
+	// CallCounts tracks method call counts
+	ListAccountsCallCount int
+	ListChildrenCallCount int
+	ListTagsCallCount     int
+}
+
+// NewMockOrganizationsClient creates a new MockOrganizationsClient.
+func NewMockOrganizationsClient() *MockOrganizationsClient {
+	return &MockOrganizationsClient{
+		Accounts: []OrganizationAccount{},
+		Parents:  make(map[string]string),
+		Tags:     make(map[string]map[string]string),
+	}
+}
+
+// ListAccounts returns the mock organization accounts.
+func (m *MockOrganizationsClient) ListAccounts(ctx context.Context) ([]OrganizationAccount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ListAccountsCallCount++
+
+	if m.ListAccountsError != nil {
+		return nil, m.ListAccountsError
+	}
+
+	return append([]OrganizationAccount(nil), m.Accounts...), nil
+}
+
+// ListChildren returns the mock accounts or OUs whose parent is parentID, sorted by ID.
+func (m *MockOrganizationsClient) ListChildren(ctx context.Context, parentID, childType string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ListChildrenCallCount++
+
+	if m.ListChildrenError != nil {
+		return nil, m.ListChildrenError
+	}
+
+	var children []string
+	for id, parent := range m.Parents {
+		isOU := strings.HasPrefix(id, "ou-")
+		if parent == parentID && isOU == (childType == OrganizationChildTypeOrganizationalUnit) {
+			children = append(children, id)
+		}
+	}
+	slices.Sort(children)
+	return children, nil
+}
+
+// ListTags returns the mock tags of an account.
+func (m *MockOrganizationsClient) ListTags(ctx context.Context, accountID string) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ListTagsCallCount++
+
+	if m.ListTagsError != nil {
+		return nil, m.ListTagsError
+	}
+
+	tags := make(map[string]string, len(m.Tags[accountID]))
+	for key, value := range m.Tags[accountID] {
+		tags[key] = value
+	}
+	return tags, nil
+}
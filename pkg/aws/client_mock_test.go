@@ -749,6 +749,120 @@ func TestMockEC2Client_DescribeReservedInstances_ErrorInjection(t *testing.T) {
 	}
 }
 
+// TestMockEC2Client_DescribeCapacityReservations tests region filtering and error injection.
+func TestMockEC2Client_DescribeCapacityReservations(t *testing.T) {
+	mockEC2 := NewMockEC2Client()
+	mockEC2.CapacityReservations = []CapacityReservation{
+		{CapacityReservationID: "cr-west", Region: "us-west-2"},
+		{CapacityReservationID: "cr-east", Region: "us-east-1"},
+	}
+
+	ctx := context.Background()
+	result, err := mockEC2.DescribeCapacityReservations(ctx, []string{"us-west-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 || result[0].CapacityReservationID != "cr-west" {
+		t.Errorf("expected only cr-west, got %v", result)
+	}
+
+	result, err = mockEC2.DescribeCapacityReservations(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Errorf("expected 2 reservations without a region filter, got %d", len(result))
+	}
+
+	mockEC2.DescribeCapacityReservationsError = errors.New("mock error")
+	result, err = mockEC2.DescribeCapacityReservations(ctx, []string{"us-west-2"})
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+	if result != nil {
+		t.Errorf("expected nil result, got %v", result)
+	}
+	if mockEC2.DescribeCapacityReservationsCallCount != 3 {
+		t.Errorf("expected 3 calls, got %d", mockEC2.DescribeCapacityReservationsCallCount)
+	}
+}
+
+// TestMockEC2Client_DescribeVolumes tests region filtering and error injection.
+func TestMockEC2Client_DescribeVolumes(t *testing.T) {
+	mockEC2 := NewMockEC2Client()
+	mockEC2.Volumes = []Volume{
+		{VolumeID: "vol-west", Region: "us-west-2"},
+		{VolumeID: "vol-east", Region: "us-east-1"},
+	}
+
+	ctx := context.Background()
+	result, err := mockEC2.DescribeVolumes(ctx, []string{"us-west-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 || result[0].VolumeID != "vol-west" {
+		t.Errorf("expected only vol-west, got %v", result)
+	}
+
+	mockEC2.DescribeVolumesError = errors.New("mock error")
+	if _, err := mockEC2.DescribeVolumes(ctx, nil); err == nil {
+		t.Error("expected error, got nil")
+	}
+	if mockEC2.DescribeVolumesCallCount != 2 {
+		t.Errorf("expected 2 calls, got %d", mockEC2.DescribeVolumesCallCount)
+	}
+}
+
+// TestMockEC2Client_DescribeInstanceTypes tests instance type filtering and error injection.
+func TestMockEC2Client_DescribeInstanceTypes(t *testing.T) {
+	mockEC2 := NewMockEC2Client()
+	mockEC2.InstanceTypes = []InstanceTypeInfo{
+		{InstanceType: "m5.xlarge", VCPUs: 4, MemoryMiB: 16384},
+		{InstanceType: "c5.xlarge", VCPUs: 4, MemoryMiB: 8192},
+	}
+
+	ctx := context.Background()
+	result, err := mockEC2.DescribeInstanceTypes(ctx, []string{"m5.xlarge", "r5.xlarge"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 || result[0].InstanceType != "m5.xlarge" {
+		t.Errorf("expected only m5.xlarge, got %v", result)
+	}
+
+	mockEC2.DescribeInstanceTypesError = errors.New("mock error")
+	if _, err := mockEC2.DescribeInstanceTypes(ctx, []string{"m5.xlarge"}); err == nil {
+		t.Error("expected error, got nil")
+	}
+	if mockEC2.DescribeInstanceTypesCallCount != 2 {
+		t.Errorf("expected 2 calls, got %d", mockEC2.DescribeInstanceTypesCallCount)
+	}
+}
+
+// TestMockPricingClient_LoadEBSPricing tests region filtering and error injection.
+func TestMockPricingClient_LoadEBSPricing(t *testing.T) {
+	mockPricing := NewMockPricingClient()
+	mockPricing.EBSPrices["us-west-2:gp3"] = EBSPrice{VolumeType: "gp3", Region: "us-west-2", PerGBMonth: 0.08}
+	mockPricing.EBSPrices["us-east-1:gp3"] = EBSPrice{VolumeType: "gp3", Region: "us-east-1", PerGBMonth: 0.08}
+
+	ctx := context.Background()
+	prices, err := mockPricing.LoadEBSPricing(ctx, []string{"us-west-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prices) != 1 || prices["us-west-2:gp3"].PerGBMonth != 0.08 {
+		t.Errorf("expected only us-west-2:gp3, got %v", prices)
+	}
+
+	mockPricing.LoadEBSPricingError = errors.New("mock error")
+	if _, err := mockPricing.LoadEBSPricing(ctx, []string{"us-west-2"}); err == nil {
+		t.Error("expected error, got nil")
+	}
+	if mockPricing.LoadEBSPricingCallCount != 2 {
+		t.Errorf("expected 2 calls, got %d", mockPricing.LoadEBSPricingCallCount)
+	}
+}
+
 // TestMockSavingsPlansClient_DescribeSavingsPlans_ErrorInjection tests error injection.
 func TestMockSavingsPlansClient_DescribeSavingsPlans_ErrorInjection(t *testing.T) {
 	mockSP := NewMockSavingsPlansClient()
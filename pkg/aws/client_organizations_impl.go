@@ -0,0 +1,155 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/organizations/types"
+)
+
+// organizationsRegions maps each partition to the region that serves its AWS
+// Organizations endpoint. Organizations is a global service with a single endpoint
+// per partition, and requests must be signed for that endpoint's region.
+var organizationsRegions = map[string]string{
+	"aws":        "us-east-1",
+	"aws-us-gov": "us-gov-west-1",
+	"aws-cn":     "cn-northwest-1",
+}
+
+// OrganizationsRegion returns the region that serves the AWS Organizations API in
+// the given partition, defaulting to the commercial partition's region.
+func OrganizationsRegion(partition string) string {
+	if region, ok := organizationsRegions[partition]; ok {
+		return region
+	}
+	return organizationsRegions["aws"]
+}
+
+// rolePartition returns the partition of a role ARN, or "aws" if the ARN is empty
+// or invalid.
+func rolePartition(roleARN string) string {
+	if parsed, err := arn.Parse(roleARN); err == nil {
+		return parsed.Partition
+	}
+	return "aws"
+}
+
+// RealOrganizationsClient is a production implementation of OrganizationsClient
+// that makes actual API calls to AWS Organizations.
+type RealOrganizationsClient struct {
+	client *organizations.Client
+}
+
+// NewRealOrganizationsClient creates an Organizations client using the given
+// credentials, which must belong to the organization's management account or a
+// delegated administrator. The region selects the partition's endpoint (see
+// OrganizationsRegion). Pass an empty endpointURL for the AWS endpoint.
+func NewRealOrganizationsClient(
+	ctx context.Context,
+	credsProvider aws.CredentialsProvider,
+	region string,
+	endpointURL string,
+) (*RealOrganizationsClient, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx,
+		awsconfig.WithRegion(region),
+		awsconfig.WithCredentialsProvider(credsProvider),
+	)
+	if err != nil { // coverage:ignore - AWS SDK config loading errors are difficult to trigger in unit tests
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	orgOpts := []func(*organizations.Options){}
+	if endpointURL != "" {
+		// Override endpoint for LocalStack testing
+		orgOpts = append(orgOpts, func(o *organizations.Options) {
+			o.BaseEndpoint = aws.String(endpointURL)
+		})
+	}
+
+	return &RealOrganizationsClient{
+		client: organizations.NewFromConfig(cfg, orgOpts...),
+	}, nil
+}
+
+// ListAccounts returns every account in the organization, following pagination.
+func (c *RealOrganizationsClient) ListAccounts(ctx context.Context) ([]OrganizationAccount, error) {
+	var accounts []OrganizationAccount
+	paginator := organizations.NewListAccountsPaginator(c.client, &organizations.ListAccountsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list accounts: %w", err)
+		}
+
+		for _, account := range output.Accounts {
+			accounts = append(accounts, OrganizationAccount{
+				AccountID: aws.ToString(account.Id),
+				Name:      aws.ToString(account.Name),
+				Status:    string(account.Status),
+			})
+		}
+	}
+	return accounts, nil
+}
+
+// ListChildren returns the IDs of the accounts or organizational units directly in
+// an organizational unit or root, following pagination.
+func (c *RealOrganizationsClient) ListChildren(
+	ctx context.Context,
+	parentID string,
+	childType string,
+) ([]string, error) {
+	var children []string
+	paginator := organizations.NewListChildrenPaginator(c.client, &organizations.ListChildrenInput{
+		ParentId:  aws.String(parentID),
+		ChildType: types.ChildType(childType),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list children of %s: %w", parentID, err)
+		}
+
+		for _, child := range output.Children {
+			children = append(children, aws.ToString(child.Id))
+		}
+	}
+	return children, nil
+}
+
+// ListTags returns the tags attached to an account, following pagination.
+func (c *RealOrganizationsClient) ListTags(ctx context.Context, accountID string) (map[string]string, error) {
+	tags := make(map[string]string)
+	paginator := organizations.NewListTagsForResourcePaginator(c.client, &organizations.ListTagsForResourceInput{
+		ResourceId: aws.String(accountID),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags of %s: %w", accountID, err)
+		}
+
+		for _, tag := range output.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	}
+	return tags, nil
+}
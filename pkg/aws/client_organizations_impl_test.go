@@ -0,0 +1,179 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/organizations/types"
+)
+
+// newTestOrganizationsServer serves canned Organizations API responses: two pages
+// of accounts, one child account, and an AccessDenied error for tags. Requests must
+// be signed for Organizations in the given region.
+func newTestOrganizationsServer(t *testing.T, region string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/"+region+"/organizations/aws4_request") {
+			t.Errorf("request not signed for organizations: %q", r.Header.Get("Authorization"))
+		}
+
+		var input map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "AWSOrganizationsV20161128.ListAccounts":
+			if input["NextToken"] == "" {
+				_, _ = w.Write([]byte(`{"Accounts":[{"Id":"111111111111","Name":"management","Status":"ACTIVE"}],` +
+					`"NextToken":"page2"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"Accounts":[{"Id":"222222222222","Name":"closed","Status":"SUSPENDED"}]}`))
+		case "AWSOrganizationsV20161128.ListChildren":
+			if input["ParentId"] != "ou-ab12-cd34ef56" || input["ChildType"] != OrganizationChildTypeAccount {
+				t.Errorf("unexpected ListChildren input: %v", input)
+			}
+			_, _ = w.Write([]byte(`{"Children":[{"Id":"111111111111","Type":"ACCOUNT"}]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"AccessDeniedException","message":"not allowed"}`))
+		}
+	}))
+}
+
+var testOrganizationsCredentials = credentials.StaticCredentialsProvider{
+	Value: aws.Credentials{AccessKeyID: "test", SecretAccessKey: "test"},
+}
+
+func TestRealOrganizationsClient(t *testing.T) {
+	server := newTestOrganizationsServer(t, "us-east-1")
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := NewRealOrganizationsClient(ctx, testOrganizationsCredentials, "us-east-1", server.URL)
+	if err != nil {
+		t.Fatalf("NewRealOrganizationsClient() unexpected error: %v", err)
+	}
+
+	accounts, err := client.ListAccounts(ctx)
+	if err != nil {
+		t.Fatalf("ListAccounts() unexpected error: %v", err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("expected 2 accounts across pages, got %d", len(accounts))
+	}
+	want := OrganizationAccount{AccountID: "222222222222", Name: "closed", Status: "SUSPENDED"}
+	if accounts[1] != want {
+		t.Errorf("accounts[1] = %+v, want %+v", accounts[1], want)
+	}
+
+	children, err := client.ListChildren(ctx, "ou-ab12-cd34ef56", OrganizationChildTypeAccount)
+	if err != nil {
+		t.Fatalf("ListChildren() unexpected error: %v", err)
+	}
+	if len(children) != 1 || children[0] != "111111111111" {
+		t.Errorf("ListChildren() = %v, want [111111111111]", children)
+	}
+
+	_, err = client.ListTags(ctx, "111111111111")
+	var accessDenied *types.AccessDeniedException
+	if !errors.As(err, &accessDenied) {
+		t.Errorf("ListTags() error = %v, want AccessDeniedException", err)
+	}
+}
+
+func TestRealOrganizationsClientPartitions(t *testing.T) {
+	tests := []struct {
+		name         string
+		roleARN      string
+		wantRegion   string
+		wantEndpoint string
+	}{
+		{
+			name:         "commercial",
+			roleARN:      "arn:aws:iam::111111111111:role/lumina",
+			wantRegion:   "us-east-1",
+			wantEndpoint: "https://organizations.us-east-1.amazonaws.com",
+		},
+		{
+			name:         "GovCloud",
+			roleARN:      "arn:aws-us-gov:iam::111111111111:role/lumina",
+			wantRegion:   "us-gov-west-1",
+			wantEndpoint: "https://organizations.us-gov-west-1.amazonaws.com",
+		},
+		{
+			name:         "China",
+			roleARN:      "arn:aws-cn:iam::111111111111:role/lumina",
+			wantRegion:   "cn-northwest-1",
+			wantEndpoint: "https://organizations.cn-northwest-1.amazonaws.com.cn",
+		},
+		{
+			name:         "no role",
+			roleARN:      "",
+			wantRegion:   "us-east-1",
+			wantEndpoint: "https://organizations.us-east-1.amazonaws.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			region := OrganizationsRegion(rolePartition(tt.roleARN))
+			if region != tt.wantRegion {
+				t.Fatalf("OrganizationsRegion() = %q, want %q", region, tt.wantRegion)
+			}
+
+			client, err := NewRealOrganizationsClient(ctx, testOrganizationsCredentials, region, "")
+			if err != nil {
+				t.Fatalf("NewRealOrganizationsClient() unexpected error: %v", err)
+			}
+			options := client.client.Options()
+			endpoint, err := options.EndpointResolverV2.ResolveEndpoint(ctx, organizations.EndpointParameters{
+				Region: aws.String(options.Region),
+			})
+			if err != nil {
+				t.Fatalf("ResolveEndpoint() unexpected error: %v", err)
+			}
+			if endpoint.URI.String() != tt.wantEndpoint {
+				t.Errorf("endpoint = %q, want %q", endpoint.URI.String(), tt.wantEndpoint)
+			}
+		})
+	}
+}
+
+func TestRealOrganizationsClientSignsForPartitionRegion(t *testing.T) {
+	server := newTestOrganizationsServer(t, "us-gov-west-1")
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := NewRealOrganizationsClient(ctx, testOrganizationsCredentials, "us-gov-west-1", server.URL)
+	if err != nil {
+		t.Fatalf("NewRealOrganizationsClient() unexpected error: %v", err)
+	}
+	if _, err := client.ListAccounts(ctx); err != nil {
+		t.Fatalf("ListAccounts() unexpected error: %v", err)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -60,6 +61,11 @@ const (
 	PricingRegionUSEast1 = "us-east-1"
 	// PricingRegionAPSouth1 is the secondary AWS Pricing API region.
 	PricingRegionAPSouth1 = "ap-south-1"
+
+	// pricingPaginationDelay is the delay between pagination requests to avoid
+	// AWS throttling. 200ms gives us max 5 requests/second per worker, or 15
+	// req/sec total with 3 workers. In practice, API latency keeps us under 10 req/sec.
+	pricingPaginationDelay = 200 * time.Millisecond
 )
 
 // NewRealPricingClient creates a new Pricing client with the specified credential provider.
@@ -140,7 +146,7 @@ func (c *RealPricingClient) GetOnDemandPrice(
 	operatingSystem string,
 ) (*OnDemandPrice, error) {
 	// Check cache first
-	cacheKey := fmt.Sprintf("%s:%s:%s:Shared", region, instanceType, operatingSystem)
+	cacheKey := fmt.Sprintf("%s:%s:%s:%s", region, instanceType, operatingSystem, PricingTenancyShared)
 	c.cacheMutex.RLock()
 	if cached, exists := c.cache[cacheKey]; exists && time.Now().Before(cached.expiresAt) {
 		c.cacheMutex.RUnlock()
@@ -179,7 +185,7 @@ func (c *RealPricingClient) GetOnDemandPrice(
 			{
 				Type:  pricingtypes.FilterTypeTermMatch,
 				Field: aws.String("tenancy"),
-				Value: aws.String("Shared"),
+				Value: aws.String(PricingTenancyShared),
 			},
 			{
 				Type:  pricingtypes.FilterTypeTermMatch,
@@ -207,7 +213,7 @@ func (c *RealPricingClient) GetOnDemandPrice(
 	}
 
 	// Parse the pricing JSON document
-	price, err := parsePricingDocument(output.PriceList[0], region, instanceType, operatingSystem)
+	price, err := parsePricingDocument(output.PriceList[0], region, instanceType, operatingSystem, PricingTenancyShared)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse pricing data: %w", err)
 	}
@@ -251,6 +257,11 @@ func (c *RealPricingClient) GetOnDemandPrices(
 // regions and operating systems. This is the most efficient way to populate pricing
 // data at startup.
 //
+// Shared tenancy is always loaded. tenancies lists additional EC2 tenancy values
+// ("dedicated", "host") to load, typically those of running instances; their
+// prices are returned under keys suffixed with the Pricing API tenancy
+// (e.g., "us-west-2:m5.xlarge:Linux:Dedicated").
+//
 // The function makes paginated API calls to fetch all EC2 pricing data, which takes
 // approximately 3-10 seconds for 5 regions depending on network latency and AWS API
 // response times.
@@ -263,61 +274,222 @@ func (c *RealPricingClient) GetOnDemandPrices(
 //
 // Example usage:
 //
-//	prices, err := client.LoadAllPricing(ctx, []string{"us-west-2", "us-east-1"}, []string{"Linux"})
-//	// Returns ~1200 prices (600 instance types × 2 regions × 1 OS)
+//	prices, err := client.LoadAllPricing(ctx, []string{"us-west-2", "us-east-1"}, []string{"Linux"}, nil)
+//	// Returns ~1200 prices (600 instance types × 2 regions × 1 OS × shared tenancy)
 func (c *RealPricingClient) LoadAllPricing(
 	ctx context.Context,
 	regions []string,
 	operatingSystems []string,
+	tenancies []string,
 ) (map[string]float64, error) {
-	// Parallel loading: Each region+OS combination is independent and can be queried concurrently
-	// However, we must rate-limit to avoid AWS API throttling (10 req/sec limit)
+	// Convert EC2 tenancy values to Pricing API values, de-duplicating so that
+	// "default" and "shared" don't trigger the same query twice.
+	pricingTenancies := make([]string, 0, len(tenancies)+1)
+	seenTenancies := make(map[string]bool)
+	for _, t := range append([]string{TenancyDefault}, tenancies...) {
+		pt := pricingAPITenancy(t)
+		if !seenTenancies[pt] {
+			seenTenancies[pt] = true
+			pricingTenancies = append(pricingTenancies, pt)
+		}
+	}
+
+	// Parallel loading: Each region+OS+tenancy combination is independent and can be queried
+	// concurrently. However, we must rate-limit to avoid AWS API throttling (10 req/sec limit)
 	allPrices := make(map[string]float64)
 	var mu sync.Mutex // Protect allPrices map
 	var wg sync.WaitGroup
-	errors := make(chan error, len(regions)*len(operatingSystems))
+	errors := make(chan error, len(regions)*len(operatingSystems)*len(pricingTenancies))
 
 	// Rate limiting: AWS Pricing API allows ~10 requests/second
-	// We'll be conservative and allow max 3 concurrent region+OS workers
-	// Each worker makes multiple paginated requests (~6-8 pages per region/OS)
+	// We'll be conservative and allow max 3 concurrent region+OS+tenancy workers
+	// Each worker makes multiple paginated requests (~6-8 pages per region/OS/tenancy)
 	// with a 200ms delay between pages to avoid throttling.
 	// This gives us ~15 req/sec theoretical max (3 workers * 5 req/sec each),
 	// but in practice we stay well under 10 req/sec due to API response latency.
 	semaphore := make(chan struct{}, 3)
 
-	// Delay between pagination requests to avoid AWS throttling.
-	// 200ms gives us max 5 requests/second per worker, or 15 req/sec total
-	// with 3 workers. In practice, API latency keeps us under 10 req/sec.
-	const paginationDelay = 200 * time.Millisecond
-
-	// Iterate through each region and OS combination in parallel
+	// Iterate through each region, OS, and tenancy combination in parallel
 	for _, region := range regions {
 		for _, os := range operatingSystems {
+			for _, tenancy := range pricingTenancies {
+				wg.Add(1)
+				go func(reg, operatingSystem, pricingTenancy string) {
+					defer wg.Done()
+
+					// Acquire semaphore slot (blocks if 3 workers already running)
+					semaphore <- struct{}{}
+					defer func() { <-semaphore }() // Release slot when done
+
+					// Convert region code to location name for AWS Pricing API
+					location, err := regionToLocation(reg)
+					if err != nil {
+						// Skip unsupported regions rather than failing entirely
+						return
+					}
+
+					// Query AWS Pricing API for all EC2 instance pricing in this region/OS/tenancy
+					// We use pagination to fetch all results (typically 100 prices per page)
+					var nextToken *string
+					pageCount := 0
+
+					for {
+						pageCount++
+
+						// Build the GetProducts request
+						input := &pricing.GetProductsInput{
+							ServiceCode: aws.String("AmazonEC2"),
+							Filters: []pricingtypes.Filter{
+								{
+									Type:  pricingtypes.FilterTypeTermMatch,
+									Field: aws.String("location"),
+									Value: aws.String(location),
+								},
+								{
+									Type:  pricingtypes.FilterTypeTermMatch,
+									Field: aws.String("operatingSystem"),
+									Value: aws.String(operatingSystem),
+								},
+								{
+									Type:  pricingtypes.FilterTypeTermMatch,
+									Field: aws.String("tenancy"),
+									Value: aws.String(pricingTenancy),
+								},
+								{
+									Type:  pricingtypes.FilterTypeTermMatch,
+									Field: aws.String("capacitystatus"),
+									Value: aws.String("Used"),
+								},
+								{
+									Type:  pricingtypes.FilterTypeTermMatch,
+									Field: aws.String("preInstalledSw"),
+									Value: aws.String("NA"),
+								},
+							},
+							MaxResults: aws.Int32(100), // Maximum allowed per page
+							NextToken:  nextToken,
+						}
+
+						// Make the API call
+						output, err := c.client.GetProducts(ctx, input)
+						if err != nil {
+							errors <- fmt.Errorf("failed to query pricing API for %s/%s/%s (page %d): %w",
+								reg, operatingSystem, pricingTenancy, pageCount, err)
+							return
+						}
+
+						// Parse each pricing document in this page
+						for _, priceDoc := range output.PriceList {
+							// Extract instance type from the document
+							// We need to parse enough of the JSON to get the instance type
+							var productInfo struct {
+								Product struct {
+									Attributes struct {
+										InstanceType string `json:"instanceType"`
+									} `json:"attributes"`
+								} `json:"product"`
+							}
+
+							if err := json.Unmarshal([]byte(priceDoc), &productInfo); err != nil {
+								// Skip malformed documents
+								continue
+							}
+
+							instanceType := productInfo.Product.Attributes.InstanceType
+							if instanceType == "" {
+								// Skip if no instance type found
+								continue
+							}
+
+							// Parse the full pricing document to get the hourly rate
+							price, err := parsePricingDocument(priceDoc, reg, instanceType, operatingSystem, pricingTenancy)
+							if err != nil {
+								// Skip pricing entries we can't parse
+								continue
+							}
+
+							// Store in results map (thread-safe)
+							key := onDemandPriceKey(reg, instanceType, operatingSystem, pricingTenancy)
+							mu.Lock()
+							allPrices[key] = price.PricePerHour
+							mu.Unlock()
+
+							// Also store in internal cache (thread-safe)
+							cacheKey := fmt.Sprintf("%s:%s:%s:%s", reg, instanceType, operatingSystem, pricingTenancy)
+							c.cacheMutex.Lock()
+							c.cache[cacheKey] = &cachedPrice{
+								price:     price,
+								expiresAt: time.Now().Add(c.cacheTTL),
+							}
+							c.cacheMutex.Unlock()
+						}
+
+						// Check if there are more pages
+						if output.NextToken == nil {
+							break // No more pages
+						}
+						nextToken = output.NextToken
+
+						// Add delay before next pagination request to avoid AWS throttling.
+						// This prevents "ThrottlingException: Rate exceeded" errors that occur
+						// when making rapid-fire paginated requests (typically on page 4+).
+						// The delay is applied after checking NextToken to avoid unnecessary
+						// delay after the final page.
+						time.Sleep(pricingPaginationDelay)
+					}
+				}(region, os, tenancy)
+			}
+		}
+	}
+
+	// Wait for all goroutines to complete
+	wg.Wait()
+	close(errors)
+
+	// Check for errors
+	if len(errors) > 0 {
+		// Return the first error encountered
+		return nil, <-errors
+	}
+
+	return allPrices, nil
+}
+
+// ebsProductFamilies are the Pricing API product families EBS volume charges are
+// listed under: provisioned storage, provisioned IOPS, and provisioned throughput.
+var ebsProductFamilies = []string{"Storage", "System Operation", "Provisioned Throughput"}
+
+// LoadEBSPricing loads EBS volume pricing for every volume type in the specified
+// regions. Each region's storage, IOPS, and throughput prices are separate products,
+// so one query is made per region and product family, rate-limited like
+// LoadAllPricing.
+//
+// Returns a map of "region:volumeType" -> price (e.g., "us-west-2:gp3").
+func (c *RealPricingClient) LoadEBSPricing(ctx context.Context, regions []string) (map[string]EBSPrice, error) {
+	allPrices := make(map[string]EBSPrice)
+	var mu sync.Mutex // Protect allPrices map
+	var wg sync.WaitGroup
+	errors := make(chan error, len(regions)*len(ebsProductFamilies))
+	semaphore := make(chan struct{}, 3)
+
+	for _, region := range regions {
+		location, err := regionToLocation(region)
+		if err != nil {
+			// Skip unsupported regions rather than failing entirely
+			continue
+		}
+
+		for _, family := range ebsProductFamilies {
 			wg.Add(1)
-			go func(reg, operatingSystem string) {
+			go func(reg, productFamily string) {
 				defer wg.Done()
 
-				// Acquire semaphore slot (blocks if 3 workers already running)
 				semaphore <- struct{}{}
-				defer func() { <-semaphore }() // Release slot when done
-
-				// Convert region code to location name for AWS Pricing API
-				location, err := regionToLocation(reg)
-				if err != nil {
-					// Skip unsupported regions rather than failing entirely
-					return
-				}
+				defer func() { <-semaphore }()
 
-				// Query AWS Pricing API for all EC2 instance pricing in this region/OS
-				// We use pagination to fetch all results (typically 100 prices per page)
 				var nextToken *string
-				pageCount := 0
-
 				for {
-					pageCount++
-
-					// Build the GetProducts request
-					input := &pricing.GetProductsInput{
+					output, err := c.client.GetProducts(ctx, &pricing.GetProductsInput{
 						ServiceCode: aws.String("AmazonEC2"),
 						Filters: []pricingtypes.Filter{
 							{
@@ -327,105 +499,47 @@ func (c *RealPricingClient) LoadAllPricing(
 							},
 							{
 								Type:  pricingtypes.FilterTypeTermMatch,
-								Field: aws.String("operatingSystem"),
-								Value: aws.String(operatingSystem),
-							},
-							{
-								Type:  pricingtypes.FilterTypeTermMatch,
-								Field: aws.String("tenancy"),
-								Value: aws.String("Shared"),
-							},
-							{
-								Type:  pricingtypes.FilterTypeTermMatch,
-								Field: aws.String("capacitystatus"),
-								Value: aws.String("Used"),
-							},
-							{
-								Type:  pricingtypes.FilterTypeTermMatch,
-								Field: aws.String("preInstalledSw"),
-								Value: aws.String("NA"),
+								Field: aws.String("productFamily"),
+								Value: aws.String(productFamily),
 							},
 						},
-						MaxResults: aws.Int32(100), // Maximum allowed per page
+						MaxResults: aws.Int32(100),
 						NextToken:  nextToken,
-					}
-
-					// Make the API call
-					output, err := c.client.GetProducts(ctx, input)
+					})
 					if err != nil {
-						errors <- fmt.Errorf("failed to query pricing API for %s/%s (page %d): %w",
-							reg, operatingSystem, pageCount, err)
+						errors <- fmt.Errorf("failed to query EBS pricing for %s/%s: %w", reg, productFamily, err)
 						return
 					}
 
-					// Parse each pricing document in this page
+					mu.Lock()
 					for _, priceDoc := range output.PriceList {
-						// Extract instance type from the document
-						// We need to parse enough of the JSON to get the instance type
-						var productInfo struct {
-							Product struct {
-								Attributes struct {
-									InstanceType string `json:"instanceType"`
-								} `json:"attributes"`
-							} `json:"product"`
-						}
-
-						if err := json.Unmarshal([]byte(priceDoc), &productInfo); err != nil {
-							// Skip malformed documents
-							continue
-						}
-
-						instanceType := productInfo.Product.Attributes.InstanceType
-						if instanceType == "" {
-							// Skip if no instance type found
-							continue
-						}
-
-						// Parse the full pricing document to get the hourly rate
-						price, err := parsePricingDocument(priceDoc, reg, instanceType, operatingSystem)
+						dimension, err := parseEBSPricingDocument(priceDoc)
 						if err != nil {
-							// Skip pricing entries we can't parse
+							// Skip products that aren't EBS volume charges
 							continue
 						}
-
-						// Store in results map (thread-safe)
-						key := fmt.Sprintf("%s:%s:%s", reg, instanceType, operatingSystem)
-						mu.Lock()
-						allPrices[key] = price.PricePerHour
-						mu.Unlock()
-
-						// Also store in internal cache (thread-safe)
-						cacheKey := fmt.Sprintf("%s:%s:%s:Shared", reg, instanceType, operatingSystem)
-						c.cacheMutex.Lock()
-						c.cache[cacheKey] = &cachedPrice{
-							price:     price,
-							expiresAt: time.Now().Add(c.cacheTTL),
-						}
-						c.cacheMutex.Unlock()
+						key := fmt.Sprintf("%s:%s", reg, dimension.volumeType)
+						price := allPrices[key]
+						price.VolumeType = dimension.volumeType
+						price.Region = reg
+						price.apply(dimension)
+						allPrices[key] = price
 					}
+					mu.Unlock()
 
-					// Check if there are more pages
 					if output.NextToken == nil {
-						break // No more pages
+						break
 					}
 					nextToken = output.NextToken
-
-					// Add delay before next pagination request to avoid AWS throttling.
-					// This prevents "ThrottlingException: Rate exceeded" errors that occur
-					// when making rapid-fire paginated requests (typically on page 4+).
-					// The delay is applied after checking NextToken to avoid unnecessary
-					// delay after the final page.
-					time.Sleep(paginationDelay)
+					time.Sleep(pricingPaginationDelay)
 				}
-			}(region, os)
+			}(region, family)
 		}
 	}
 
-	// Wait for all goroutines to complete
 	wg.Wait()
 	close(errors)
 
-	// Check for errors
 	if len(errors) > 0 {
 		// Return the first error encountered
 		return nil, <-errors
@@ -434,6 +548,85 @@ func (c *RealPricingClient) LoadAllPricing(
 	return allPrices, nil
 }
 
+// ebsPriceDimension is one EBS volume charge from a Pricing API document.
+type ebsPriceDimension struct {
+	volumeType string
+	usageType  string
+	unit       string
+	usd        float64
+}
+
+// apply sets the price component the dimension is a charge for, based on its unit.
+// io2 IOPS above 32,000 are billed at cheaper tiers listed as separate products
+// (usage types ending in ".tier2" and ".tier3"); only the first tier is kept.
+func (p *EBSPrice) apply(d ebsPriceDimension) {
+	switch strings.ToLower(d.unit) {
+	case "gb-mo":
+		p.PerGBMonth = d.usd
+	case "iops-mo":
+		if !strings.Contains(strings.ToLower(d.usageType), ".tier") {
+			p.PerIOPSMonth = d.usd
+		}
+	case "mibps-mo":
+		p.PerMiBpsMonth = d.usd
+	case "gibps-mo":
+		p.PerMiBpsMonth = d.usd / 1024
+	}
+}
+
+// parseEBSPricingDocument extracts the volume type and monthly price from an EBS
+// Pricing API document. Products without a volume type (e.g., snapshots and I/O
+// requests) and non-monthly charges are rejected.
+func parseEBSPricingDocument(doc string) (ebsPriceDimension, error) {
+	var pricingDoc struct {
+		Product struct {
+			Attributes struct {
+				VolumeAPIName string `json:"volumeApiName"`
+				UsageType     string `json:"usagetype"`
+			} `json:"attributes"`
+		} `json:"product"`
+		Terms struct {
+			OnDemand map[string]struct {
+				PriceDimensions map[string]struct {
+					PricePerUnit struct {
+						USD string `json:"USD"`
+					} `json:"pricePerUnit"`
+					Unit string `json:"unit"`
+				} `json:"priceDimensions"`
+			} `json:"OnDemand"`
+		} `json:"terms"`
+	}
+
+	if err := json.Unmarshal([]byte(doc), &pricingDoc); err != nil {
+		return ebsPriceDimension{}, fmt.Errorf("failed to parse pricing JSON: %w", err)
+	}
+
+	attributes := pricingDoc.Product.Attributes
+	if attributes.VolumeAPIName == "" {
+		return ebsPriceDimension{}, fmt.Errorf("pricing document has no volume type")
+	}
+
+	for _, onDemandTerm := range pricingDoc.Terms.OnDemand {
+		for _, dimension := range onDemandTerm.PriceDimensions {
+			if !strings.HasSuffix(strings.ToLower(dimension.Unit), "-mo") {
+				continue
+			}
+			var usd float64
+			if _, err := fmt.Sscanf(dimension.PricePerUnit.USD, "%f", &usd); err != nil {
+				return ebsPriceDimension{}, fmt.Errorf("failed to parse price %q: %w", dimension.PricePerUnit.USD, err)
+			}
+			return ebsPriceDimension{
+				volumeType: attributes.VolumeAPIName,
+				usageType:  attributes.UsageType,
+				unit:       dimension.Unit,
+				usd:        usd,
+			}, nil
+		}
+	}
+
+	return ebsPriceDimension{}, fmt.Errorf("no monthly price found in pricing document")
+}
+
 // parsePricingDocument parses an AWS Pricing API JSON document into an OnDemandPrice.
 //
 // The AWS Pricing API returns complex nested JSON documents with the following structure:
@@ -471,6 +664,7 @@ func parsePricingDocument(
 	region string,
 	instanceType string,
 	operatingSystem string,
+	tenancy string,
 ) (*OnDemandPrice, error) {
 	// Parse the JSON document
 	var pricingDoc struct {
@@ -520,10 +714,42 @@ func parsePricingDocument(
 		Region:          region,
 		PricePerHour:    pricePerHour,
 		OperatingSystem: operatingSystem,
-		Tenancy:         "Shared",
+		Tenancy:         tenancy,
 	}, nil
 }
 
+// pricingAPITenancy converts an EC2 tenancy value to the "tenancy" attribute used by
+// the AWS Pricing API. EC2 reports "default" for shared hardware, which the Pricing
+// API calls "Shared".
+//
+// Examples:
+//   - "default"   → "Shared"
+//   - "dedicated" → "Dedicated"
+//   - "host"      → "Host"
+func pricingAPITenancy(ec2Tenancy string) string {
+	switch strings.ToLower(ec2Tenancy) {
+	case "", TenancyDefault, TenancyShared:
+		return PricingTenancyShared
+	case TenancyDedicated:
+		return PricingTenancyDedicated
+	case TenancyHost:
+		return PricingTenancyHost
+	default:
+		// Unknown tenancy - pass through and let the API filter decide
+		return ec2Tenancy
+	}
+}
+
+// onDemandPriceKey builds a LoadAllPricing result key. Shared tenancy prices use
+// "region:instanceType:os" so existing consumers (and test data) keep working;
+// other tenancies append the tenancy, e.g. "us-west-2:m5.xlarge:Linux:Dedicated".
+func onDemandPriceKey(region, instanceType, operatingSystem, pricingTenancy string) string {
+	if pricingTenancy == PricingTenancyShared {
+		return fmt.Sprintf("%s:%s:%s", region, instanceType, operatingSystem)
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", region, instanceType, operatingSystem, pricingTenancy)
+}
+
 // regionToLocation converts an AWS region code to a location name used by the Pricing API.
 //
 // AWS Pricing API uses human-readable location names instead of region codes.
@@ -327,7 +327,7 @@ func TestParsePricingDocument(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			price, err := parsePricingDocument(tt.doc, tt.region, tt.instanceType, tt.operatingSystem)
+			price, err := parsePricingDocument(tt.doc, tt.region, tt.instanceType, tt.operatingSystem, PricingTenancyShared)
 
 			if tt.expectError {
 				if err == nil {
@@ -363,6 +363,93 @@ func TestParsePricingDocument(t *testing.T) {
 	}
 }
 
+// ebsPricingDocument returns a minimal EBS Pricing API document.
+func ebsPricingDocument(volumeType, usageType, unit, usd string) string {
+	return `{
+		"product": {"attributes": {"volumeApiName": "` + volumeType + `", "usagetype": "` + usageType + `"}},
+		"terms": {"OnDemand": {"SKU.TERM": {"priceDimensions": {"SKU.TERM.DIM": {
+			"pricePerUnit": {"USD": "` + usd + `"},
+			"unit": "` + unit + `"
+		}}}}}
+	}`
+}
+
+// TestParseEBSPricingDocument tests extracting EBS charges and combining them into an EBSPrice.
+func TestParseEBSPricingDocument(t *testing.T) {
+	docs := []string{
+		ebsPricingDocument("gp3", "USW2-EBS:VolumeUsage.gp3", "GB-Mo", "0.0800000000"),
+		ebsPricingDocument("gp3", "USW2-EBS:VolumeP-IOPS.gp3", "IOPS-Mo", "0.0050000000"),
+		ebsPricingDocument("gp3", "USW2-EBS:VolumeP-Throughput.gp3", "GiBps-mo", "40.9600000000"),
+		ebsPricingDocument("io2", "USW2-EBS:VolumeP-IOPS.io2", "IOPS-Mo", "0.0650000000"),
+		ebsPricingDocument("io2", "USW2-EBS:VolumeP-IOPS.io2.tier2", "IOPS-Mo", "0.0455000000"),
+	}
+
+	prices := map[string]EBSPrice{}
+	for _, doc := range docs {
+		dimension, err := parseEBSPricingDocument(doc)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		price := prices[dimension.volumeType]
+		price.apply(dimension)
+		prices[dimension.volumeType] = price
+	}
+
+	gp3 := prices["gp3"]
+	if gp3.PerGBMonth != 0.08 || gp3.PerIOPSMonth != 0.005 || gp3.PerMiBpsMonth != 0.04 {
+		t.Errorf("unexpected gp3 price: %+v", gp3)
+	}
+	// The tier 2 price must not replace the first tier's
+	if io2 := prices["io2"]; io2.PerIOPSMonth != 0.065 {
+		t.Errorf("expected io2 first-tier IOPS price 0.065, got %v", io2.PerIOPSMonth)
+	}
+
+	rejected := map[string]string{
+		"no volume type": ebsPricingDocument("", "USW2-EBS:SnapshotUsage", "GB-Mo", "0.05"),
+		"not monthly":    ebsPricingDocument("standard", "USW2-EBS:VolumeIOUsage", "IOs", "0.00000005"),
+		"bad price":      ebsPricingDocument("gp2", "USW2-EBS:VolumeUsage.gp2", "GB-Mo", "free"),
+		"invalid JSON":   "{",
+	}
+	for name, doc := range rejected {
+		if _, err := parseEBSPricingDocument(doc); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+// TestPricingAPITenancy tests conversion of EC2 tenancy values to Pricing API values.
+func TestPricingAPITenancy(t *testing.T) {
+	tests := []struct {
+		ec2Tenancy string
+		expected   string
+	}{
+		{"", PricingTenancyShared},
+		{TenancyDefault, PricingTenancyShared},
+		{TenancyShared, PricingTenancyShared},
+		{TenancyDedicated, PricingTenancyDedicated},
+		{"Dedicated", PricingTenancyDedicated},
+		{TenancyHost, PricingTenancyHost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ec2Tenancy, func(t *testing.T) {
+			if got := pricingAPITenancy(tt.ec2Tenancy); got != tt.expected {
+				t.Errorf("pricingAPITenancy(%q) = %q, want %q", tt.ec2Tenancy, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestOnDemandPriceKey tests that only non-shared tenancies are included in result keys.
+func TestOnDemandPriceKey(t *testing.T) {
+	if got := onDemandPriceKey("us-west-2", "m5.xlarge", "Linux", PricingTenancyShared); got != "us-west-2:m5.xlarge:Linux" {
+		t.Errorf("unexpected shared key: %s", got)
+	}
+	if got := onDemandPriceKey("us-west-2", "m5.xlarge", "Linux", PricingTenancyDedicated); got != "us-west-2:m5.xlarge:Linux:Dedicated" {
+		t.Errorf("unexpected dedicated key: %s", got)
+	}
+}
+
 // TestBrokenPricingClient tests the error-returning fallback client.
 func TestBrokenPricingClient(t *testing.T) {
 	ctx := context.Background()
@@ -386,6 +473,15 @@ func TestBrokenPricingClient(t *testing.T) {
 	if prices != nil {
 		t.Errorf("expected nil prices, got %v", prices)
 	}
+
+	// Test LoadEBSPricing
+	ebsPrices, err := client.LoadEBSPricing(ctx, []string{"us-west-2"})
+	if err != expectedErr {
+		t.Errorf("expected error %v, got %v", expectedErr, err)
+	}
+	if ebsPrices != nil {
+		t.Errorf("expected nil EBS prices, got %v", ebsPrices)
+	}
 }
 
 // TestRealPricingClientCaching tests that pricing results are cached properly.
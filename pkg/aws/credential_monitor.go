@@ -92,7 +92,7 @@ type CredentialMonitor struct {
 	accounts      []config.AWSAccount // AWS accounts to monitor
 	checkInterval time.Duration       // How often to check credentials
 
-	mu            sync.RWMutex              // Protects accountStatus map
+	mu            sync.RWMutex              // Protects accounts and accountStatus
 	accountStatus map[string]*AccountStatus // key: accountID
 
 	ctx    context.Context    // Context for lifecycle management
@@ -144,7 +144,7 @@ func NewCredentialMonitor(
 // then continue checking on the configured interval until Stop() is called.
 func (m *CredentialMonitor) Start() {
 	m.logger.Info("Starting credential monitor",
-		"accounts", len(m.accounts),
+		"accounts", len(m.getAccounts()),
 		"checkInterval", m.checkInterval)
 
 	go m.monitorLoop()
@@ -182,13 +182,45 @@ func (m *CredentialMonitor) monitorLoop() {
 // This runs in the background and updates the cached status for each account.
 // This method is exported for testing purposes.
 func (m *CredentialMonitor) CheckAllAccounts() {
-	m.logger.V(1).Info("Running credential checks", "accounts", len(m.accounts))
+	accounts := m.getAccounts()
+	m.logger.V(1).Info("Running credential checks", "accounts", len(accounts))
 
-	for _, account := range m.accounts {
+	for _, account := range accounts {
 		m.checkAccount(account)
 	}
 }
 
+// SetAccounts replaces the monitored accounts, e.g. after account discovery finds
+// a different set. Cached status and metrics of accounts no longer monitored are
+// dropped; new accounts are checked on the next interval.
+func (m *CredentialMonitor) SetAccounts(accounts []config.AWSAccount) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	monitored := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		monitored[account.AccountID] = true
+	}
+	for accountID, status := range m.accountStatus {
+		if monitored[accountID] {
+			continue
+		}
+		delete(m.accountStatus, accountID)
+		labels := []string{status.AccountID, status.AccountName}
+		credentialCheckDuration.DeleteLabelValues(labels...)
+		credentialLastCheckTimestamp.DeleteLabelValues(labels...)
+		credentialHealthy.DeleteLabelValues(labels...)
+	}
+	m.accounts = accounts
+}
+
+// getAccounts returns the currently monitored accounts.
+func (m *CredentialMonitor) getAccounts() []config.AWSAccount {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.accounts
+}
+
 // checkAccount validates a single account and updates its status.
 func (m *CredentialMonitor) checkAccount(account config.AWSAccount) {
 	start := time.Now()
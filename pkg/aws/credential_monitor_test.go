@@ -298,6 +298,35 @@ func TestCredentialMonitorGetAccountStatus(t *testing.T) {
 	}
 }
 
+func TestCredentialMonitorSetAccounts(t *testing.T) {
+	validator := &mockValidator{}
+	monitor := NewCredentialMonitor(validator, []config.AWSAccount{
+		{AccountID: "123", Name: "kept"},
+		{AccountID: "456", Name: "removed"},
+	}, 10*time.Minute)
+	monitor.CheckAllAccounts()
+
+	monitor.SetAccounts([]config.AWSAccount{
+		{AccountID: "123", Name: "kept"},
+		{AccountID: "789", Name: "added"},
+	})
+
+	if monitor.GetAccountStatus("123") == nil {
+		t.Error("expected status of kept account to be retained")
+	}
+	if monitor.GetAccountStatus("456") != nil {
+		t.Error("expected status of removed account to be dropped")
+	}
+
+	monitor.CheckAllAccounts()
+	if monitor.GetAccountStatus("789") == nil {
+		t.Error("expected added account to be checked")
+	}
+	if got := validator.getCallCount(); got != 4 {
+		t.Errorf("expected 4 validation calls, got %d", got)
+	}
+}
+
 func TestCredentialMonitorGetAccountStatusNotFound(t *testing.T) {
 	validator := &mockValidator{}
 	accounts := []config.AWSAccount{}
@@ -27,9 +27,13 @@ import (
 
 // Platform constants for operating system types.
 // These are normalized, lowercase values used throughout the codebase.
+// They also match the lowercased AWS Pricing API "operatingSystem" values
+// ("Linux", "Windows", "RHEL", "SUSE") used as on-demand pricing keys.
 const (
 	PlatformLinux   = "linux"
 	PlatformWindows = "windows"
+	PlatformRHEL    = "rhel"
+	PlatformSUSE    = "suse"
 )
 
 // ProductDescription constants for AWS spot pricing and Reserved Instance queries.
@@ -47,6 +51,16 @@ const (
 	LifecycleSpot     = "spot"
 )
 
+// Savings Plan types, as returned by DescribeSavingsPlans. Only EC2 Instance and
+// Compute Savings Plans discount EC2 instances; SageMaker and Database Savings
+// Plans cover other services.
+const (
+	SavingsPlanTypeEC2Instance = "EC2Instance"
+	SavingsPlanTypeCompute     = "Compute"
+	SavingsPlanTypeSageMaker   = "SageMaker"
+	SavingsPlanTypeDatabase    = "Database"
+)
+
 // Kubernetes EC2 tag constants.
 // EKS automatically adds these tags to EC2 instances that are part of a Kubernetes cluster.
 const (
@@ -65,6 +79,13 @@ const (
 	TenancyShared    = "shared"    // Savings Plans: Shared/default tenancy
 )
 
+// Pricing API tenancy values, as used in the "tenancy" product attribute.
+const (
+	PricingTenancyShared    = "Shared"
+	PricingTenancyDedicated = "Dedicated"
+	PricingTenancyHost      = "Host"
+)
+
 // AccountConfig represents configuration for accessing an AWS account.
 // Supports both direct credentials and AssumeRole-based access.
 type AccountConfig struct {
@@ -130,7 +151,7 @@ type Instance struct {
 	// PrivateIPAddress is the private IP address
 	PrivateIPAddress string
 
-	// Platform is the OS platform (e.g., "linux", "windows")
+	// Platform is the OS platform (e.g., "linux", "windows", "rhel", "suse")
 	Platform string
 
 	// Tenancy indicates whether the instance runs on shared or dedicated hardware
@@ -139,6 +160,10 @@ type Instance struct {
 
 	// SpotInstanceRequestID is the spot instance request ID if this is a spot instance
 	SpotInstanceRequestID string
+
+	// CapacityReservationID is the On-Demand Capacity Reservation the instance
+	// runs in, if any
+	CapacityReservationID string
 }
 
 // GetClusterName extracts the Kubernetes cluster name from EC2 tags.
@@ -192,6 +217,10 @@ type ReservedInstance struct {
 	// Platform is the operating system ("Linux/UNIX", "Windows", etc.)
 	Platform string
 
+	// Tenancy is the RI tenancy ("default", "dedicated")
+	// Empty is treated as "default" (shared hardware).
+	Tenancy string
+
 	// AccountID is the AWS account that owns this RI
 	AccountID string
 
@@ -207,7 +236,7 @@ type SavingsPlan struct {
 	// SavingsPlanID is the short ID
 	SavingsPlanID string
 
-	// SavingsPlanType is either "EC2Instance" or "Compute"
+	// SavingsPlanType is "EC2Instance", "Compute", "SageMaker" or "Database"
 	SavingsPlanType string
 
 	// State is the current state (e.g., "active", "retired")
@@ -240,6 +269,140 @@ type SavingsPlan struct {
 	EC2InstanceFamily string
 }
 
+// AppliesToEC2 reports whether the Savings Plan can discount EC2 instances, i.e.
+// whether it's an EC2 Instance or Compute Savings Plan.
+func (sp SavingsPlan) AppliesToEC2() bool {
+	return sp.SavingsPlanType == SavingsPlanTypeEC2Instance || sp.SavingsPlanType == SavingsPlanTypeCompute
+}
+
+// CapacityReservation represents an EC2 On-Demand Capacity Reservation. A
+// reservation is billed at on-demand rates for its full instance count, whether or
+// not instances run in it.
+type CapacityReservation struct {
+	// CapacityReservationID is the unique identifier (e.g., "cr-0abc123")
+	CapacityReservationID string
+
+	// InstanceType is the instance type capacity is reserved for
+	InstanceType string
+
+	// AvailabilityZone is the AZ capacity is reserved in
+	AvailabilityZone string
+
+	// Region is the AWS region
+	Region string
+
+	// Platform is the operating system ("Linux/UNIX", "Windows", etc.)
+	Platform string
+
+	// Tenancy is the reservation tenancy ("default", "dedicated")
+	Tenancy string
+
+	// TotalInstanceCount is the number of instances capacity is reserved for
+	TotalInstanceCount int32
+
+	// AvailableInstanceCount is the number of instances that can still be launched
+	// into the reservation, as reported by AWS
+	AvailableInstanceCount int32
+
+	// InstanceMatchCriteria is "open" (matching instances run in the reservation
+	// automatically) or "targeted" (instances must target it explicitly)
+	InstanceMatchCriteria string
+
+	// State is the reservation state (e.g., "active", "expired")
+	State string
+
+	// Start is when the reservation started
+	Start time.Time
+
+	// End is when the reservation ends. Zero for reservations without an end date.
+	End time.Time
+
+	// AccountID is the AWS account that owns this reservation
+	AccountID string
+
+	// AccountName is the friendly name of the AWS account
+	AccountName string
+}
+
+// EBS volume types (the EC2 API's VolumeType values).
+const (
+	VolumeTypeGP2      = "gp2"
+	VolumeTypeGP3      = "gp3"
+	VolumeTypeIO1      = "io1"
+	VolumeTypeIO2      = "io2"
+	VolumeTypeST1      = "st1"
+	VolumeTypeSC1      = "sc1"
+	VolumeTypeStandard = "standard"
+)
+
+// Volume represents an EBS volume. Root and data volumes of a node, including
+// volumes backing Kubernetes persistent volumes, are attached to its instance.
+type Volume struct {
+	// VolumeID is the unique identifier (e.g., "vol-0abc123")
+	VolumeID string
+
+	// VolumeType is the volume type ("gp2", "gp3", "io1", "io2", "st1", "sc1", "standard")
+	VolumeType string
+
+	// SizeGiB is the provisioned size in GiB
+	SizeGiB int32
+
+	// IOPS is the volume's IOPS. For io1, io2, and gp3 this is what was
+	// provisioned; for gp2 it's the baseline that comes with the volume's size.
+	IOPS int32
+
+	// Throughput is the provisioned throughput in MiB/s (gp3 only)
+	Throughput int32
+
+	// AvailabilityZone is the AZ the volume is in
+	AvailabilityZone string
+
+	// Region is the AWS region
+	Region string
+
+	// State is the volume state (e.g., "in-use", "available")
+	State string
+
+	// AttachedInstanceIDs are the instances the volume is attached to. Usually
+	// one; io1 and io2 volumes with Multi-Attach can be attached to several.
+	AttachedInstanceIDs []string
+
+	// AccountID is the AWS account that owns this volume
+	AccountID string
+
+	// AccountName is the friendly name of the AWS account
+	AccountName string
+}
+
+// InstanceTypeInfo describes the capacity of an EC2 instance type. Capacity doesn't
+// vary by region, so it's keyed by instance type alone.
+type InstanceTypeInfo struct {
+	// InstanceType is the instance type (e.g., "m5.xlarge")
+	InstanceType string
+
+	// VCPUs is the default number of vCPUs
+	VCPUs int32
+
+	// MemoryMiB is the memory in MiB
+	MemoryMiB int64
+
+	// GPUs is the number of GPUs (0 for types without GPUs)
+	GPUs int32
+
+	// NetworkPerformance is AWS's description of the network bandwidth
+	// (e.g., "Up to 10 Gigabit", "25 Gigabit")
+	NetworkPerformance string
+
+	// NetworkBaselineGbps is the baseline network bandwidth in Gbps, summed over
+	// network cards. Zero if AWS doesn't report it.
+	NetworkBaselineGbps float64
+}
+
+// MemoryGiB returns the instance type's memory in GiB.
+func (t InstanceTypeInfo) MemoryGiB() float64 {
+	return float64(t.MemoryMiB) / 1024
+}
+
 // SpotPrice represents the current spot price for an instance type.
 type SpotPrice struct {
 	// InstanceType is the instance type
@@ -280,6 +443,28 @@ type OnDemandPrice struct {
 	Tenancy string
 }
 
+// EBSPrice is the on-demand price of an EBS volume type in a region. Prices are
+// monthly, as AWS publishes them.
+type EBSPrice struct {
+	// VolumeType is the volume type (e.g., "gp3")
+	VolumeType string
+
+	// Region is the AWS region
+	Region string
+
+	// PerGBMonth is the price of one GB of provisioned storage per month in USD
+	PerGBMonth float64
+
+	// PerIOPSMonth is the price of one provisioned IOPS per month in USD (gp3 IOPS
+	// above the included baseline, io1, and io2). For io2 this is the first tier's
+	// price, which AWS discounts above 32,000 IOPS.
+	PerIOPSMonth float64
+
+	// PerMiBpsMonth is the price of one MiB/s of provisioned throughput per month
+	// in USD (gp3 throughput above the included baseline)
+	PerMiBpsMonth float64
+}
+
 // CostEstimate represents a cost estimate for an instance.
 type CostEstimate struct {
 	// InstanceID is the EC2 instance ID
@@ -345,3 +530,25 @@ type SavingsPlanRate struct {
 	// Tenancy is "shared", "dedicated", or "host"
 	Tenancy string
 }
+
+// OrganizationAccountStatusActive is the Status of accounts that are members of an
+// organization (as opposed to "SUSPENDED" or "PENDING_CLOSURE").
+const OrganizationAccountStatusActive = "ACTIVE"
+
+// Child types of OrganizationsClient.ListChildren.
+const (
+	OrganizationChildTypeAccount            = "ACCOUNT"
+	OrganizationChildTypeOrganizationalUnit = "ORGANIZATIONAL_UNIT"
+)
+
+// OrganizationAccount represents an account listed by AWS Organizations.
+type OrganizationAccount struct {
+	// AccountID is the 12-digit AWS account ID
+	AccountID string
+
+	// Name is the account name set in Organizations
+	Name string
+
+	// Status is the account status (e.g., "ACTIVE", "SUSPENDED")
+	Status string
+}
@@ -0,0 +1,309 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package billing
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CUR line item types that carry EC2 instance usage.
+// Reference: https://docs.aws.amazon.com/cur/latest/userguide/Lineitem-columns.html
+const (
+	lineItemTypeUsage                   = "Usage"
+	lineItemTypeDiscountedUsage         = "DiscountedUsage"
+	lineItemTypeSavingsPlanCoveredUsage = "SavingsPlanCoveredUsage"
+)
+
+// CUR columns read by CURSource. Each column has a legacy CUR name and a
+// CUR 2.0 (Data Exports) name; either is accepted.
+const (
+	colUsageAccountID = iota
+	colUsageStartDate
+	colLineItemType
+	colProductCode
+	colUsageType
+	colUnblendedCost
+	colSavingsPlanEffectiveCost
+	colSavingsPlanARN
+	numColumns
+)
+
+var curColumnNames = [numColumns][2]string{
+	colUsageAccountID:           {"lineItem/UsageAccountId", "line_item_usage_account_id"},
+	colUsageStartDate:           {"lineItem/UsageStartDate", "line_item_usage_start_date"},
+	colLineItemType:             {"lineItem/LineItemType", "line_item_line_item_type"},
+	colProductCode:              {"lineItem/ProductCode", "line_item_product_code"},
+	colUsageType:                {"lineItem/UsageType", "line_item_usage_type"},
+	colUnblendedCost:            {"lineItem/UnblendedCost", "line_item_unblended_cost"},
+	colSavingsPlanEffectiveCost: {"savingsPlan/SavingsPlanEffectiveCost", "savings_plan_savings_plan_effective_cost"},
+	colSavingsPlanARN:           {"savingsPlan/SavingsPlanARN", "savings_plan_savings_plan_a_r_n"},
+}
+
+// requiredCURColumns must be present in every CUR file. The Savings Plan
+// columns only exist when the payer has Savings Plans.
+var requiredCURColumns = []int{
+	colUsageAccountID,
+	colUsageStartDate,
+	colLineItemType,
+	colProductCode,
+	colUsageType,
+	colUnblendedCost,
+}
+
+// curTimeLayouts are the UsageStartDate formats written by CUR and CUR 2.0.
+var curTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02 15:04:05",
+}
+
+// CURSource reads AWS Cost and Usage Report CSV files (optionally gzipped) from a
+// local directory, such as an S3 export synced to a volume.
+//
+// Only EC2 instance usage (BoxUsage, SpotUsage and DedicatedUsage usage types) is
+// counted, since that's what the cost calculator estimates. Each line item's
+// amortized cost is:
+//   - Usage: lineItem/UnblendedCost (on-demand and spot)
+//   - SavingsPlanCoveredUsage: savingsPlan/SavingsPlanEffectiveCost (SP-covered)
+//
+// DiscountedUsage (RI-covered) line items are skipped. The cost calculator
+// treats Reserved Instances as prepaid and estimates RI-covered instances at
+// $0, so counting their amortized reservation cost here would show up as
+// permanent drift in accounts that own RIs.
+//
+// Files are re-read on every call. CUR files are rewritten in place as AWS
+// finalizes the month, so caching them would serve stale numbers.
+type CURSource struct {
+	// Dir is the directory containing CUR files. Subdirectories are searched.
+	Dir string
+}
+
+// NewCURSource creates a CURSource that reads CUR files under dir.
+func NewCURSource(dir string) *CURSource {
+	return &CURSource{Dir: dir}
+}
+
+// LoadDailyCosts implements Source.
+func (s *CURSource) LoadDailyCosts(ctx context.Context, start, end time.Time) ([]DailyCosts, error) {
+	days := make(map[time.Time]*DailyCosts)
+
+	err := filepath.WalkDir(s.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || !isCURFile(path) {
+			return nil
+		}
+		if err := s.loadFile(path, start, end, days); err != nil {
+			return fmt.Errorf("failed to read CUR file %s: %w", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]DailyCosts, 0, len(days))
+	for _, day := range days {
+		result = append(result, *day)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Date.Before(result[j].Date)
+	})
+	return result, nil
+}
+
+// isCURFile reports whether path looks like a CUR CSV file.
+func isCURFile(path string) bool {
+	return strings.HasSuffix(path, ".csv") || strings.HasSuffix(path, ".csv.gz")
+}
+
+// loadFile adds the EC2 instance costs from a single CUR file to days.
+func (s *CURSource) loadFile(path string, start, end time.Time, days map[time.Time]*DailyCosts) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	}
+
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil // empty file
+		}
+		return err
+	}
+	columns, err := curColumnIndexes(header)
+	if err != nil {
+		return err
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := addLineItem(record, columns, start, end, days); err != nil {
+			line, _ := reader.FieldPos(0)
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+// curColumnIndexes maps each CUR column to its index in header (-1 if absent).
+func curColumnIndexes(header []string) ([numColumns]int, error) {
+	var columns [numColumns]int
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.TrimSpace(name)] = i
+	}
+
+	for col, names := range curColumnNames {
+		columns[col] = -1
+		for _, name := range names {
+			if i, ok := positions[name]; ok {
+				columns[col] = i
+				break
+			}
+		}
+	}
+
+	for _, col := range requiredCURColumns {
+		if columns[col] < 0 {
+			return columns, fmt.Errorf("missing required column %q", curColumnNames[col][0])
+		}
+	}
+	return columns, nil
+}
+
+// addLineItem adds a single CUR line item to days if it's EC2 instance usage
+// within [start, end).
+func addLineItem(
+	record []string,
+	columns [numColumns]int,
+	start, end time.Time,
+	days map[time.Time]*DailyCosts,
+) error {
+	field := func(col int) string {
+		if i := columns[col]; i >= 0 && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	if field(colProductCode) != "AmazonEC2" || !isInstanceUsageType(field(colUsageType)) {
+		return nil
+	}
+
+	var costCol int
+	switch field(colLineItemType) {
+	case lineItemTypeUsage:
+		costCol = colUnblendedCost
+	case lineItemTypeSavingsPlanCoveredUsage:
+		costCol = colSavingsPlanEffectiveCost
+	case lineItemTypeDiscountedUsage:
+		// RI-covered usage is estimated at $0 (see CURSource)
+		return nil
+	default:
+		// Fees, negations, credits, taxes, etc. aren't instance usage
+		return nil
+	}
+
+	usageStart, err := parseCURTime(field(colUsageStartDate))
+	if err != nil {
+		return err
+	}
+	if usageStart.Before(start) || !usageStart.Before(end) {
+		return nil
+	}
+
+	amount, err := parseCURCost(field(costCol))
+	if err != nil {
+		return fmt.Errorf("invalid cost in column %q: %w", curColumnNames[costCol][0], err)
+	}
+
+	date := DayStart(usageStart)
+	day, exists := days[date]
+	if !exists {
+		day = newDailyCosts(date)
+		days[date] = day
+	}
+
+	day.Accounts[field(colUsageAccountID)] += amount
+	if costCol == colSavingsPlanEffectiveCost {
+		if arn := field(colSavingsPlanARN); arn != "" {
+			day.SavingsPlans[arn] += amount
+		}
+	}
+	return nil
+}
+
+// isInstanceUsageType reports whether a CUR usage type is EC2 instance-hours
+// (e.g., "USW2-BoxUsage:m5.xlarge", "SpotUsage:c5.large", "DedicatedUsage:m5.large").
+func isInstanceUsageType(usageType string) bool {
+	return strings.Contains(usageType, "BoxUsage") ||
+		strings.Contains(usageType, "SpotUsage") ||
+		strings.Contains(usageType, "DedicatedUsage")
+}
+
+// parseCURTime parses a CUR usage timestamp. Timestamps without a zone are UTC.
+func parseCURTime(value string) (time.Time, error) {
+	for _, layout := range curTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid usage start date %q", value)
+}
+
+// parseCURCost parses a CUR cost column. Empty values are 0.
+func parseCURCost(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
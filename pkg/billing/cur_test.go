@@ -0,0 +1,133 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package billing
+
+import (
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const legacyCURHeader = "lineItem/UsageAccountId,lineItem/UsageStartDate,lineItem/LineItemType," +
+	"lineItem/ProductCode,lineItem/UsageType,lineItem/UnblendedCost," +
+	"savingsPlan/SavingsPlanEffectiveCost,savingsPlan/SavingsPlanARN,reservation/EffectiveCost\n"
+
+// writeFile writes content to dir/name, gzipping it if name ends in .gz.
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	if filepath.Ext(name) == ".gz" {
+		gz := gzip.NewWriter(f)
+		_, err = gz.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		return
+	}
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+}
+
+func TestCURSourceLoadDailyCosts(t *testing.T) {
+	dir := t.TempDir()
+	spARN := "arn:aws:savingsplans::111111111111:savingsplan/sp-1"
+
+	writeFile(t, dir, "2025-01/report-1.csv", legacyCURHeader+
+		// On-demand usage: unblended cost
+		"111111111111,2025-01-15T10:00:00Z,Usage,AmazonEC2,USW2-BoxUsage:m5.xlarge,0.192,,,\n"+
+		// SP-covered usage: SP effective cost, not the on-demand unblended cost
+		"111111111111,2025-01-15T10:00:00Z,SavingsPlanCoveredUsage,AmazonEC2,USW2-BoxUsage:m5.xlarge,0.192,0.138,"+spARN+",\n"+
+		// SP negation and recurring fee lines are ignored
+		"111111111111,2025-01-15T10:00:00Z,SavingsPlanNegation,AmazonEC2,USW2-BoxUsage:m5.xlarge,-0.192,,"+spARN+",\n"+
+		// RI-covered usage is skipped; the estimate counts it at $0
+		"222222222222,2025-01-15T11:00:00Z,DiscountedUsage,AmazonEC2,USW2-BoxUsage:c5.large,0.085,,,0.053\n"+
+		// Spot usage
+		"222222222222,2025-01-16T00:00:00Z,Usage,AmazonEC2,USW2-SpotUsage:c5.large,0.031,,,\n"+
+		// Non-instance EC2 usage and other services are ignored
+		"222222222222,2025-01-15T11:00:00Z,Usage,AmazonEC2,USW2-EBS:VolumeUsage.gp3,0.010,,,\n"+
+		"222222222222,2025-01-15T11:00:00Z,Usage,AmazonS3,USW2-TimedStorage-ByteHrs,0.500,,,\n"+
+		// Outside the requested window
+		"111111111111,2025-01-17T00:00:00Z,Usage,AmazonEC2,USW2-BoxUsage:m5.xlarge,0.192,,,\n")
+
+	// CUR 2.0 column names, gzipped, in a nested directory
+	writeFile(t, dir, "cur2/data/part-0.csv.gz",
+		"line_item_usage_account_id,line_item_usage_start_date,line_item_line_item_type,"+
+			"line_item_product_code,line_item_usage_type,line_item_unblended_cost\n"+
+			"111111111111,2025-01-15 23:00:00,Usage,AmazonEC2,BoxUsage:m5.large,0.096\n")
+
+	// Non-CUR files are skipped
+	writeFile(t, dir, "2025-01/manifest.json", "{}")
+
+	source := NewCURSource(dir)
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	days, err := source.LoadDailyCosts(context.Background(), start, start.Add(48*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, days, 2)
+
+	assert.Equal(t, start, days[0].Date)
+	assert.InDelta(t, 0.192+0.138+0.096, days[0].Accounts["111111111111"], 0.0001)
+	assert.NotContains(t, days[0].Accounts, "222222222222")
+	assert.InDelta(t, 0.138, days[0].SavingsPlans[spARN], 0.0001)
+
+	assert.Equal(t, start.Add(24*time.Hour), days[1].Date)
+	assert.InDelta(t, 0.031, days[1].Accounts["222222222222"], 0.0001)
+	assert.NotContains(t, days[1].Accounts, "111111111111")
+}
+
+func TestCURSourceMissingColumn(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "report.csv", "lineItem/UsageAccountId,lineItem/UnblendedCost\n111111111111,1.0\n")
+
+	_, err := NewCURSource(dir).LoadDailyCosts(context.Background(), time.Time{}, time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lineItem/UsageStartDate")
+}
+
+func TestCURSourceInvalidCost(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "report.csv", legacyCURHeader+
+		"111111111111,2025-01-15T10:00:00Z,Usage,AmazonEC2,BoxUsage:m5.xlarge,abc,,,\n")
+
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	_, err := NewCURSource(dir).LoadDailyCosts(context.Background(), start, start.Add(24*time.Hour))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestCURSourceMissingDirectory(t *testing.T) {
+	_, err := NewCURSource(filepath.Join(t.TempDir(), "missing")).
+		LoadDailyCosts(context.Background(), time.Time{}, time.Now())
+	assert.Error(t, err)
+}
+
+func TestCURSourceEmptyFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "report.csv", "")
+
+	days, err := NewCURSource(dir).LoadDailyCosts(context.Background(), time.Time{}, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, days)
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package billing
+
+import (
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/cost"
+)
+
+// Drift compares an actual cost with Lumina's estimate for the same day.
+type Drift struct {
+	// Actual is the amortized cost from AWS billing data ($)
+	Actual float64
+
+	// Estimated is the cost Lumina estimated for the same window ($)
+	Estimated float64
+}
+
+// Percent returns the estimate's error relative to the actual cost.
+// Positive means Lumina over-estimated. Returns 0 when there's no actual cost.
+func (d Drift) Percent() float64 {
+	if d.Actual == 0 {
+		return 0
+	}
+	return (d.Estimated - d.Actual) / d.Actual * 100
+}
+
+// Report is the drift between actual and estimated costs for one UTC day.
+type Report struct {
+	// Date is the start of the compared day (UTC midnight)
+	Date time.Time
+
+	// Accounts maps account ID to the drift in its EC2 instance costs
+	Accounts map[string]Drift
+
+	// SavingsPlans maps Savings Plan ARN to the drift in commitment consumed
+	SavingsPlans map[string]Drift
+}
+
+// Compare builds a drift report from a day's actual costs and Lumina's estimate
+// for the same day.
+//
+// The report covers the given accounts, since a CUR from an organization's payer
+// account includes every linked account, not just the monitored ones. It covers the
+// Savings Plans in the estimate, which includes every SP Lumina knows about, even
+// unused ones; commitments Lumina can't see have nothing to compare against.
+func Compare(actual DailyCosts, estimated cost.DailyEstimate, accountIDs []string) Report {
+	report := Report{
+		Date:         actual.Date,
+		Accounts:     make(map[string]Drift, len(accountIDs)),
+		SavingsPlans: make(map[string]Drift, len(estimated.SavingsPlanCosts)),
+	}
+
+	for _, accountID := range accountIDs {
+		report.Accounts[accountID] = Drift{
+			Actual:    actual.Accounts[accountID],
+			Estimated: estimated.AccountCosts[accountID],
+		}
+	}
+
+	for arn, amount := range estimated.SavingsPlanCosts {
+		report.SavingsPlans[arn] = Drift{
+			Actual:    actual.SavingsPlans[arn],
+			Estimated: amount,
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package billing
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriftPercent(t *testing.T) {
+	assert.InDelta(t, 10.0, Drift{Actual: 100, Estimated: 110}.Percent(), 0.0001)
+	assert.InDelta(t, -25.0, Drift{Actual: 100, Estimated: 75}.Percent(), 0.0001)
+	assert.Equal(t, 0.0, Drift{Actual: 0, Estimated: 5}.Percent())
+}
+
+func TestCompare(t *testing.T) {
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	actual := DailyCosts{
+		Date: date,
+		Accounts: map[string]float64{
+			"111111111111": 100,
+			"222222222222": 50,
+			"999999999999": 500, // linked account Lumina doesn't monitor
+		},
+		SavingsPlans: map[string]float64{
+			"arn:sp-1":       40,
+			"arn:sp-unknown": 10, // commitment Lumina can't see
+		},
+	}
+	estimated := cost.DailyEstimate{
+		Date: date,
+		AccountCosts: map[string]float64{
+			"111111111111": 90,
+		},
+		SavingsPlanCosts: map[string]float64{
+			"arn:sp-1": 44,
+			"arn:sp-2": 0,
+		},
+	}
+
+	report := Compare(actual, estimated, []string{"111111111111", "222222222222"})
+
+	assert.Equal(t, date, report.Date)
+	assert.Equal(t, map[string]Drift{
+		"111111111111": {Actual: 100, Estimated: 90},
+		"222222222222": {Actual: 50, Estimated: 0}, // no instances seen by Lumina
+	}, report.Accounts)
+	assert.Equal(t, map[string]Drift{
+		"arn:sp-1": {Actual: 40, Estimated: 44},
+		"arn:sp-2": {Actual: 0, Estimated: 0},
+	}, report.SavingsPlans)
+}
+
+// TestCompareRICoveredAccount tests that an account running RI-covered instances
+// shows no drift: the estimate counts them at $0 and CURSource skips their
+// DiscountedUsage line items.
+func TestCompareRICoveredAccount(t *testing.T) {
+	dir := t.TempDir()
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	var cur strings.Builder
+	cur.WriteString(legacyCURHeader)
+	for hour := range 2 {
+		usageStart := day.Add(time.Duration(hour) * time.Hour).Format(time.RFC3339)
+		cur.WriteString("111111111111," + usageStart +
+			",DiscountedUsage,AmazonEC2,USW2-BoxUsage:m5.xlarge,0.192,,,0.121\n")
+		cur.WriteString("111111111111," + usageStart + ",Usage,AmazonEC2,USW2-BoxUsage:c5.large,0.085,,,\n")
+	}
+	writeFile(t, dir, "report.csv", cur.String())
+
+	days, err := NewCURSource(dir).LoadDailyCosts(context.Background(), day, day.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, days, 1)
+
+	estimates := cost.NewDailyEstimates(1)
+	result := cost.CalculationResult{
+		InstanceCosts: map[string]cost.InstanceCost{
+			"i-ri": {InstanceID: "i-ri", AccountID: "111111111111", ShelfPrice: 0.192, EffectiveCost: 0,
+				CoverageType: cost.CoverageReservedInstance},
+			"i-od": {InstanceID: "i-od", AccountID: "111111111111", ShelfPrice: 0.085, EffectiveCost: 0.085,
+				CoverageType: cost.CoverageOnDemand},
+		},
+	}
+	estimates.Record(result, day)
+	estimates.Record(result, day.Add(2*time.Hour))
+	estimated, ok := estimates.Day(day)
+	require.True(t, ok)
+
+	report := Compare(days[0], estimated, []string{"111111111111"})
+	drift := report.Accounts["111111111111"]
+	assert.InDelta(t, 2*0.085, drift.Actual, 0.0001)
+	assert.InDelta(t, 2*0.085, drift.Estimated, 0.0001)
+	assert.InDelta(t, 0.0, drift.Percent(), 0.0001)
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package billing loads actual AWS billing data and compares it with the costs
+// estimated by pkg/cost.
+//
+// The cost calculator replicates AWS's RI and Savings Plan allocation from the
+// instances it can see, so its numbers drift from the bill when the model is wrong
+// (missing prices, unmodeled discounts, instances outside the monitored accounts
+// consuming shared commitments, etc.). This package reads daily amortized EC2
+// instance costs from a billing source and reports the difference per account and
+// per Savings Plan, so that drift is visible rather than silently trusted.
+package billing
+
+import (
+	"context"
+	"time"
+)
+
+// DailyCosts holds the actual amortized EC2 instance costs for one UTC day.
+type DailyCosts struct {
+	// Date is the start of the day (UTC midnight)
+	Date time.Time
+
+	// Accounts maps usage account ID to the amortized cost ($) of EC2 instance usage.
+	// Amortized cost is what the usage cost after Savings Plan discounts, which is
+	// the daily equivalent of ec2_instance_hourly_cost. RI-covered usage is
+	// excluded, since the estimate treats Reserved Instances as prepaid ($0).
+	Accounts map[string]float64
+
+	// SavingsPlans maps Savings Plan ARN to the commitment ($) it applied to EC2
+	// instance usage (savingsPlan/SavingsPlanEffectiveCost).
+	SavingsPlans map[string]float64
+}
+
+// newDailyCosts creates an empty DailyCosts for the given day.
+func newDailyCosts(date time.Time) *DailyCosts {
+	return &DailyCosts{
+		Date:         date,
+		Accounts:     make(map[string]float64),
+		SavingsPlans: make(map[string]float64),
+	}
+}
+
+// Source provides actual daily costs from AWS billing data.
+type Source interface {
+	// LoadDailyCosts returns the actual costs for each UTC day in [start, end),
+	// sorted by date. Days without any billing data are omitted.
+	LoadDailyCosts(ctx context.Context, start, end time.Time) ([]DailyCosts, error)
+}
+
+// DayStart returns the start of the UTC day containing t.
+func DayStart(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -25,13 +25,21 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
+// Serverless service names for ServerlessEstimate.Service.
+const (
+	ServiceFargate = "fargate"
+	ServiceLambda  = "lambda"
+)
+
 // Operating system constants for configuration validation.
 // These are title-case values used in YAML configuration files.
 // They map to the lowercase aws.Platform* constants used internally.
@@ -52,10 +60,16 @@ const (
 	KeyHealthProbeBindAddress    = "healthProbeBindAddress"
 	KeyAccountValidationInterval = "accountValidationInterval"
 
+	// Account discovery configuration keys
+	KeyAccountDiscoveryEnabled         = "accountDiscovery.enabled"
+	KeyAccountDiscoveryRefreshInterval = "accountDiscovery.refreshInterval"
+
 	// Reconciliation configuration keys
 	KeyReconciliationRISP        = "reconciliation.risp"
 	KeyReconciliationEC2         = "reconciliation.ec2"
 	KeyReconciliationSpotPricing = "reconciliation.spotPricing"
+	KeyReconciliationBilling     = "reconciliation.billing"
+	KeyReconciliationSnapshot    = "reconciliation.snapshot"
 
 	// Pricing configuration keys
 	KeyPricingSpotPriceCacheExpiration = "pricing.spotPriceCacheExpiration"
@@ -72,6 +86,50 @@ const (
 	KeyMetricsLabelsHostName         = "metrics.labels.hostName"
 	KeyMetricsNodeNameSourceTagKey   = "metrics.nodeNameSource.tagKey"
 
+	// Cost configuration keys
+	KeyCostSavingsPlanLedger     = "cost.savingsPlanLedger"
+	KeyCostSavingsPlanAllocation = "cost.savingsPlanAllocation"
+
+	// Billing configuration keys
+	KeyBillingCURPath = "billing.curPath"
+
+	// History configuration keys
+	KeyHistoryPath          = "history.path"
+	KeyHistoryResolution    = "history.resolution"
+	KeyHistoryRetentionDays = "history.retentionDays"
+
+	// Snapshot configuration keys
+	KeySnapshotPath   = "snapshot.path"
+	KeySnapshotMaxAge = "snapshot.maxAge"
+
+	// Allocation configuration keys
+	KeyAllocationEnabled   = "allocation.enabled"
+	KeyAllocationCPUWeight = "allocation.cpuWeight"
+
+	// Recommendation configuration keys
+	KeyRecommendationsEnabled  = "recommendations.enabled"
+	KeyRecommendationsLookback = "recommendations.lookback"
+
+	// Serverless configuration keys
+	KeyServerlessFargateEnabled = "serverless.fargate.enabled"
+
+	// Storage configuration keys
+	KeyStorageEnabled = "storage.enabled"
+
+	// Karpenter configuration keys
+	KeyKarpenterEnabled = "karpenter.enabled"
+
+	// Unit cost configuration keys
+	KeyUnitCostsEnabled = "unitCosts.enabled"
+
+	// Expiration forecast configuration keys
+	KeyForecastEnabled = "forecast.enabled"
+	KeyForecastHorizon = "forecast.horizon"
+
+	// Agent mode configuration keys
+	KeyAgentHubURL       = "agent.hubURL"
+	KeyAgentPollInterval = "agent.pollInterval"
+
 	// Test data keys
 	KeyTestDataPricing = "testData.pricing"
 )
@@ -83,6 +141,7 @@ const (
 	EnvMetricsBindAddress            = "LUMINA_METRICS_BIND_ADDRESS"
 	EnvHealthProbeBindAddress        = "LUMINA_HEALTH_PROBE_BIND_ADDRESS"
 	EnvAccountValidationInterval     = "LUMINA_ACCOUNT_VALIDATION_INTERVAL"
+	EnvAccountDiscoveryEnabled       = "LUMINA_ACCOUNT_DISCOVERY_ENABLED"
 	EnvReconciliationRISP            = "LUMINA_RECONCILIATION_RISP"
 	EnvReconciliationEC2             = "LUMINA_RECONCILIATION_EC2"
 	EnvMetricsDisableInstanceMetrics = "LUMINA_METRICS_DISABLE_INSTANCE_METRICS"
@@ -93,6 +152,20 @@ const (
 	EnvMetricsLabelsNodeName         = "LUMINA_METRICS_LABELS_NODE_NAME"
 	EnvMetricsLabelsHostName         = "LUMINA_METRICS_LABELS_HOST_NAME"
 	EnvMetricsNodeNameSourceTagKey   = "LUMINA_METRICS_NODE_NAME_SOURCE_TAG_KEY"
+	EnvCostSavingsPlanLedger         = "LUMINA_COST_SAVINGS_PLAN_LEDGER"
+	EnvCostSavingsPlanAllocation     = "LUMINA_COST_SAVINGS_PLAN_ALLOCATION"
+	EnvBillingCURPath                = "LUMINA_BILLING_CUR_PATH"
+	EnvSnapshotPath                  = "LUMINA_SNAPSHOT_PATH"
+	EnvHistoryPath                   = "LUMINA_HISTORY_PATH"
+	EnvAllocationEnabled             = "LUMINA_ALLOCATION_ENABLED"
+	EnvAllocationCPUWeight           = "LUMINA_ALLOCATION_CPU_WEIGHT"
+	EnvRecommendationsEnabled        = "LUMINA_RECOMMENDATIONS_ENABLED"
+	EnvServerlessFargateEnabled      = "LUMINA_SERVERLESS_FARGATE_ENABLED"
+	EnvStorageEnabled                = "LUMINA_STORAGE_ENABLED"
+	EnvKarpenterEnabled              = "LUMINA_KARPENTER_ENABLED"
+	EnvUnitCostsEnabled              = "LUMINA_UNIT_COSTS_ENABLED"
+	EnvForecastEnabled               = "LUMINA_FORECAST_ENABLED"
+	EnvAgentHubURL                   = "LUMINA_AGENT_HUB_URL"
 	EnvPrefix                        = "LUMINA"
 )
 
@@ -105,10 +178,16 @@ const (
 	DefaultHealthProbeBindAddress    = ":8081"
 	DefaultAccountValidationInterval = "10m"
 
+	// Account discovery defaults
+	// New accounts are rare; hourly keeps Organizations API traffic negligible
+	DefaultAccountDiscoveryRefreshInterval = "1h"
+
 	// Reconciliation defaults
 	DefaultReconciliationRISP        = "1h"
 	DefaultReconciliationEC2         = "5m"
 	DefaultReconciliationSpotPricing = "15s"
+	DefaultReconciliationBilling     = "6h"
+	DefaultReconciliationSnapshot    = "10m"
 
 	// Pricing defaults
 	DefaultSpotPriceCacheExpiration = "1h"
@@ -116,8 +195,58 @@ const (
 	// 1-year typical: ~28% OFF → you pay 72% → 0.72
 	DefaultSPDiscountEC2Instance = 0.72
 	DefaultSPDiscountCompute     = 0.72
+
+	// Cost defaults
+	// Savings Plans are filled one at a time unless global allocation is chosen
+	DefaultSavingsPlanAllocation = "per_plan"
+
+	// History defaults
+	// Hourly samples for two months covers the previous calendar month for chargeback
+	DefaultHistoryResolution    = "1h"
+	DefaultHistoryRetentionDays = 62
+
+	// Snapshot defaults
+	// Older snapshots are ignored; their instance inventory is too out of date to publish
+	DefaultSnapshotMaxAge = "24h"
+
+	// Allocation defaults
+	// Node cost is split evenly between CPU and memory unless configured otherwise
+	DefaultAllocationCPUWeight = 0.5
+
+	// Recommendation defaults
+	// A week of spillover covers weekday/weekend cycles
+	DefaultRecommendationsLookback = "168h"
+	// Typical rate multipliers for newly purchased plans (same for both plan types)
+	DefaultRecommendationOneYearRate   = 0.72
+	DefaultRecommendationThreeYearRate = 0.50
+
+	// Expirations are forecast a quarter ahead, enough lead time to plan renewals
+	DefaultForecastHorizon = "2160h"
+
+	// Agents poll the hub about as often as it recalculates when instances change
+	DefaultAgentPollInterval = "1m"
+
+	// Serverless defaults
+	// Fargate Linux/x86 on-demand prices in us-east-1
+	DefaultFargateVCPUHourPrice     = 0.04048
+	DefaultFargateMemoryGBHourPrice = 0.004445
+	// Compute Savings Plans discount Fargate and Lambda less than EC2 (1-year rates)
+	DefaultSPDiscountFargate = 0.80
+	DefaultSPDiscountLambda  = 0.88
 )
 
+// AccountIDPlaceholder is replaced with each discovered account's ID in
+// AccountDiscoveryConfig.RoleName.
+const AccountIDPlaceholder = "{account_id}"
+
+// minAccountDiscoveryRefreshInterval keeps discovery from hammering the
+// Organizations API, which is throttled organization-wide.
+const minAccountDiscoveryRefreshInterval = time.Minute
+
+// organizationalUnitIDPattern matches Organizations OU IDs (ou-xxxx-xxxxxxxx) and
+// root IDs (r-xxxx).
+var organizationalUnitIDPattern = regexp.MustCompile(`^(ou-[0-9a-z]{4,32}-[a-z0-9]{8,32}|r-[0-9a-z]{4,32})$`)
+
 // Default metric label names.
 // These are the default values used when label customization is not configured.
 const (
@@ -142,6 +271,10 @@ type Config struct {
 	// If not specified, uses the first account in AWSAccounts.
 	DefaultAccount *AWSAccount `yaml:"defaultAccount,omitempty"`
 
+	// AccountDiscovery contains settings for discovering accounts from AWS
+	// Organizations in addition to AWSAccounts.
+	AccountDiscovery AccountDiscoveryConfig `yaml:"accountDiscovery,omitempty"`
+
 	// DefaultRegion is the default AWS region for API calls.
 	// Can be overridden per-account if needed.
 	DefaultRegion string `yaml:"defaultRegion,omitempty"`
@@ -179,6 +312,45 @@ type Config struct {
 	// Metrics contains settings for metrics collection and emission.
 	Metrics MetricsConfig `yaml:"metrics,omitempty"`
 
+	// Cost contains settings for cost calculation.
+	Cost CostConfig `yaml:"cost,omitempty"`
+
+	// Billing contains settings for comparing estimated costs with AWS billing data.
+	Billing BillingConfig `yaml:"billing,omitempty"`
+
+	// History contains settings for recording cost history.
+	History HistoryConfig `yaml:"history,omitempty"`
+
+	// Snapshot contains settings for persisting cache data across restarts.
+	Snapshot SnapshotConfig `yaml:"snapshot,omitempty"`
+
+	// Allocation contains settings for splitting node costs among pods.
+	Allocation AllocationConfig `yaml:"allocation,omitempty"`
+
+	// Recommendations contains settings for Savings Plan purchase recommendations.
+	Recommendations RecommendationsConfig `yaml:"recommendations,omitempty"`
+
+	// Serverless contains settings for Fargate and Lambda usage that shares
+	// Compute Savings Plans with EC2 instances.
+	Serverless ServerlessConfig `yaml:"serverless,omitempty"`
+
+	// Storage contains settings for attributing EBS volume costs to instances.
+	Storage StorageConfig `yaml:"storage,omitempty"`
+
+	// Karpenter contains settings for rolling up costs by Karpenter NodePool.
+	Karpenter KarpenterConfig `yaml:"karpenter,omitempty"`
+
+	// UnitCosts contains settings for per-vCPU and per-GiB costs by instance type.
+	UnitCosts UnitCostsConfig `yaml:"unitCosts,omitempty"`
+
+	// Forecast contains settings for projecting the cost of upcoming Savings Plan
+	// and Reserved Instance expirations.
+	Forecast ForecastConfig `yaml:"forecast,omitempty"`
+
+	// Agent contains settings for running as an agent that fetches its cluster's
+	// instance costs from a hub Lumina instead of calculating them.
+	Agent AgentConfig `yaml:"agent,omitempty"`
+
 	// TestData contains mock data for E2E testing.
 	// When present, the RISP reconciler will use this data instead of making AWS API calls.
 	// This allows testing without requiring a fully functional AWS environment.
@@ -212,6 +384,19 @@ type ReconciliationConfig struct {
 	// Recommended: 15m - Spot prices change hourly, so 15min provides reasonable freshness
 	SpotPricing string `yaml:"spotPricing,omitempty"`
 
+	// Billing is how often to compare estimated costs with AWS billing data.
+	// Only used when billing.curPath is set.
+	// Format: Go duration string (e.g., "6h", "12h", "24h")
+	// Default: 6h
+	// Recommended: 6h - AWS refreshes Cost and Usage Reports up to a few times a day
+	Billing string `yaml:"billing,omitempty"`
+
+	// Snapshot is how often to write the cache snapshot.
+	// Only used when snapshot.path is set.
+	// Format: Go duration string (e.g., "10m", "30m", "1h")
+	// Default: 10m
+	Snapshot string `yaml:"snapshot,omitempty"`
+
 	// Cost reconciliation is event-driven (no configurable interval needed).
 	// Cost calculations trigger automatically when EC2, RISP, or Pricing caches update.
 	// A 1-second debouncer prevents redundant calculations when multiple caches update simultaneously.
@@ -309,6 +494,357 @@ type MetricsConfig struct {
 	NodeNameSource NodeNameSourceConfig `yaml:"nodeNameSource,omitempty"`
 }
 
+// CostConfig contains settings for cost calculation.
+type CostConfig struct {
+	// SavingsPlanLedger enables cumulative within-hour Savings Plan accounting.
+	// AWS applies each Savings Plan's hourly commitment to all eligible usage within
+	// a clock hour, so a short burst above the commitment can be absorbed by commitment
+	// that was idle earlier in the hour. When enabled, the controller tracks commitment
+	// consumed so far in the current billing hour and emits it alongside the
+	// instantaneous savings_plan_* metrics.
+	// Default: false (only instantaneous rates are reported)
+	SavingsPlanLedger bool `yaml:"savingsPlanLedger,omitempty"`
+
+	// DiscountSharingDisabledAccounts lists the accounts that have Reserved Instance
+	// and Savings Plans discount sharing turned off in the management account's billing
+	// preferences. AWS applies RIs and SPs to the purchasing account's usage first and
+	// then shares them across the organization; these accounts' RIs and SPs only
+	// cover their own usage, and they receive no discounts from other accounts.
+	// AWS doesn't expose this preference through an API, so it must be listed here.
+	// Default: [] (every account shares, which is the AWS default)
+	DiscountSharingDisabledAccounts []string `yaml:"discountSharingDisabledAccounts,omitempty"`
+
+	// SavingsPlanAllocation selects how Savings Plans of the same type share usage
+	// that several of them can cover:
+	//   - "per_plan": plans are filled one at a time, each covering its eligible
+	//     usage by savings percentage
+	//   - "global": every (instance, plan) pair across the plans is ranked by savings
+	//     percentage and the highest are covered first, as AWS applies all plans of a
+	//     type together. Usage goes to the plan that discounts it most.
+	// EC2 Instance Savings Plans are applied before Compute Savings Plans either way.
+	// Default: "per_plan"
+	SavingsPlanAllocation string `yaml:"savingsPlanAllocation,omitempty"`
+}
+
+// BillingConfig contains settings for reconciling estimated costs against actual
+// AWS billing data.
+type BillingConfig struct {
+	// CURPath is a local directory containing AWS Cost and Usage Report CSV files
+	// (plain or gzipped, legacy CUR or CUR 2.0 column names), e.g. an S3 export
+	// synced to a volume. Subdirectories are searched.
+	// When set, the controller compares its daily estimated EC2 instance costs per
+	// account and per Savings Plan with the amortized costs in the report, and emits
+	// billing_* drift metrics.
+	// Default: "" (billing reconciliation disabled)
+	CURPath string `yaml:"curPath,omitempty"`
+}
+
+// HistoryConfig contains settings for the local cost history store.
+type HistoryConfig struct {
+	// Path is the directory cost history is written to. Every calculated
+	// per-instance cost is accumulated into intervals of Resolution and appended to
+	// daily segment files there, which the /api/v1/history/costs endpoint queries.
+	// The directory is created if needed and should be on a persistent volume.
+	// Default: "" (history disabled)
+	Path string `yaml:"path,omitempty"`
+
+	// Resolution is the length of each recorded interval, at most 24h. Shorter
+	// intervals allow finer query ranges but take more disk space.
+	// Format: Go duration string (e.g., "15m", "1h")
+	// Default: 1h
+	Resolution string `yaml:"resolution,omitempty"`
+
+	// RetentionDays is how many days of history to keep, including the current day.
+	// Default: 62 (enough for the previous calendar month)
+	RetentionDays int `yaml:"retentionDays,omitempty"`
+}
+
+// AccountDiscoveryConfig contains settings for discovering AWS accounts from
+// AWS Organizations.
+type AccountDiscoveryConfig struct {
+	// Enabled turns on account discovery. The controller periodically lists the
+	// organization's active accounts using the DefaultAccount's role, which must
+	// belong to the management account or a delegated administrator and allow
+	// organizations:ListAccounts (plus ListChildren and ListTagsForResource when
+	// filtering). Discovered accounts are monitored alongside AWSAccounts; an
+	// account in both uses its AWSAccounts entry. DefaultAccount is required.
+	// Default: false
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// RoleName is the IAM role assumed in each discovered account, optionally
+	// with a path (e.g., "lumina-readonly" or "lumina/readonly"). The
+	// "{account_id}" placeholder is replaced with the account ID.
+	// Required when Enabled is true.
+	RoleName string `yaml:"roleName,omitempty"`
+
+	// RefreshInterval is how often the account list is refreshed. Accounts that
+	// join the organization or start matching the filters are picked up, and
+	// accounts that leave are dropped, without a restart. At least 1m.
+	// Format: Go duration string (e.g., "30m", "1h")
+	// Default: 1h
+	RefreshInterval string `yaml:"refreshInterval,omitempty"`
+
+	// IncludeOUs limits discovery to accounts under these organizational units
+	// (or roots), at any depth (e.g., ["ou-ab12-cd34ef56"]).
+	// Default: all accounts
+	IncludeOUs []string `yaml:"includeOUs,omitempty"`
+
+	// ExcludeOUs skips accounts under these organizational units, at any depth.
+	// Takes precedence over IncludeOUs.
+	ExcludeOUs []string `yaml:"excludeOUs,omitempty"`
+
+	// IncludeTags limits discovery to accounts with at least one of these tags.
+	// Each entry is "key=value", or "key" to match any value.
+	// Default: all accounts
+	IncludeTags []string `yaml:"includeTags,omitempty"`
+
+	// ExcludeTags skips accounts with any of these tags ("key=value" or "key").
+	// Takes precedence over IncludeTags.
+	ExcludeTags []string `yaml:"excludeTags,omitempty"`
+}
+
+// RoleARN returns the ARN of the role to assume in a discovered account, in the
+// given partition (e.g., "aws").
+func (d *AccountDiscoveryConfig) RoleARN(partition, accountID string) string {
+	roleName := strings.ReplaceAll(d.RoleName, AccountIDPlaceholder, accountID)
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, accountID, roleName)
+}
+
+// Validate checks that the account discovery configuration is valid.
+func (d *AccountDiscoveryConfig) Validate() error {
+	if strings.TrimSpace(d.RoleName) == "" {
+		return fmt.Errorf("role name is required")
+	}
+	if arn := d.RoleARN("aws", "123456789012"); !isValidIAMRoleARN(arn) {
+		return fmt.Errorf("invalid role name %q: results in invalid role ARN %q", d.RoleName, arn)
+	}
+
+	if d.RefreshInterval != "" {
+		interval, err := time.ParseDuration(d.RefreshInterval)
+		if err != nil {
+			return fmt.Errorf("invalid refresh interval %q: %w", d.RefreshInterval, err)
+		}
+		if interval < minAccountDiscoveryRefreshInterval {
+			return fmt.Errorf("invalid refresh interval %q, must be at least %s",
+				d.RefreshInterval, minAccountDiscoveryRefreshInterval)
+		}
+	}
+
+	for _, id := range append(slices.Clone(d.IncludeOUs), d.ExcludeOUs...) {
+		if !organizationalUnitIDPattern.MatchString(id) {
+			return fmt.Errorf("invalid organizational unit ID %q: must be an OU ID (ou-...) or root ID (r-...)", id)
+		}
+	}
+
+	for _, filter := range append(slices.Clone(d.IncludeTags), d.ExcludeTags...) {
+		if key, _, _ := strings.Cut(filter, "="); strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid tag filter %q: must be \"key=value\" or \"key\"", filter)
+		}
+	}
+
+	return nil
+}
+
+// SnapshotConfig contains settings for persisting cache data across restarts.
+type SnapshotConfig struct {
+	// Path is the file the EC2, RI/SP, and pricing caches (including Savings Plan
+	// rates) are periodically saved to, and restored from at startup. A restored
+	// snapshot lets cost metrics be published immediately instead of after the
+	// initial AWS data load, which takes several minutes for pricing. The caches
+	// are then refreshed from AWS in the background.
+	// The directory must exist and be writable, e.g. a persistent volume.
+	// Default: "" (snapshots disabled)
+	Path string `yaml:"path,omitempty"`
+
+	// MaxAge is the oldest snapshot that is restored at startup. Older snapshots
+	// are ignored and the controller waits for fresh AWS data instead.
+	// Format: Go duration string (e.g., "6h", "24h")
+	// Default: 24h
+	MaxAge string `yaml:"maxAge,omitempty"`
+}
+
+// AllocationConfig contains settings for allocating node costs to pods and namespaces.
+type AllocationConfig struct {
+	// Enabled turns on per-pod cost allocation. The controller watches pods and
+	// divides each node's effective hourly cost among the pods scheduled on it by
+	// their CPU and memory requests, emitting pod_hourly_cost and
+	// namespace_hourly_cost metrics. Only available in Kubernetes mode.
+	// Default: false (requires list/watch permission on pods)
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// CPUWeight is the fraction of a node's cost attributed to CPU (0-1). The rest
+	// is attributed to memory. Each share is divided by the node's allocatable
+	// capacity, and capacity no pod requested is reported as idle.
+	// Default: 0.5
+	CPUWeight *float64 `yaml:"cpuWeight,omitempty"`
+}
+
+// RecommendationsConfig contains settings for Savings Plan purchase recommendations.
+type RecommendationsConfig struct {
+	// Enabled turns on recommendations. The controller records hourly on-demand
+	// spillover (cost not covered by RIs or Savings Plans) and recommends Compute and
+	// EC2 Instance Savings Plan commitments that would have saved the most over the
+	// lookback window. Results are exported as savings_plan_recommended_* metrics and
+	// at /debug/recommendations.
+	// Default: false
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// Lookback is how much spillover history recommendations are based on, at least
+	// 24h. History is kept in memory, so it restarts with the controller.
+	// Format: Go duration string (e.g., "168h", "720h")
+	// Default: 168h (7 days)
+	Lookback string `yaml:"lookback,omitempty"`
+
+	// OneYearDiscounts are the rate multipliers (what you pay) assumed for newly
+	// purchased 1-year plans.
+	// Default: 0.72 for both plan types
+	OneYearDiscounts *SavingsPlanDiscounts `yaml:"oneYearDiscounts,omitempty"`
+
+	// ThreeYearDiscounts are the rate multipliers assumed for newly purchased
+	// 3-year plans.
+	// Default: 0.50 for both plan types
+	ThreeYearDiscounts *SavingsPlanDiscounts `yaml:"threeYearDiscounts,omitempty"`
+}
+
+// ServerlessConfig contains settings for Fargate and Lambda usage. Compute Savings
+// Plans discount this usage too, so commitment it consumes isn't available to EC2
+// instances. Without it, Compute Savings Plans appear to cover more instances (and
+// to be more utilized by them) than they actually do.
+type ServerlessConfig struct {
+	// Fargate contains settings for EKS Fargate pods in this cluster.
+	Fargate FargateConfig `yaml:"fargate,omitempty"`
+
+	// Estimates are hourly on-demand costs of Fargate or Lambda usage the
+	// controller can't observe, such as ECS tasks or Lambda functions.
+	Estimates []ServerlessEstimate `yaml:"estimates,omitempty"`
+
+	// Discounts are the Compute Savings Plan rate multipliers (what you pay) for
+	// Fargate and Lambda usage. Savings Plan rates aren't loaded for these services,
+	// so these are always used.
+	// Default: fargate 0.80, lambda 0.88 (typical 1-year rates)
+	Discounts *ServerlessDiscounts `yaml:"discounts,omitempty"`
+}
+
+// FargateConfig contains settings for costing EKS Fargate pods.
+type FargateConfig struct {
+	// Enabled turns on Fargate usage. The controller watches pods, prices each pod
+	// running on Fargate by the vCPU and memory Fargate provisioned for it, and
+	// applies Compute Savings Plans to it. Only available in Kubernetes mode.
+	// Default: false (requires list/watch permission on pods)
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// AccountID is the AWS account the cluster's Fargate pods are billed to.
+	// Default: the default account
+	AccountID string `yaml:"accountId,omitempty"`
+
+	// Region is the AWS region the cluster runs in.
+	// Default: defaultRegion
+	Region string `yaml:"region,omitempty"`
+
+	// VCPUHourPrice is the on-demand price of one Fargate vCPU per hour.
+	// Default: 0.04048 (Linux/x86 in us-east-1)
+	VCPUHourPrice float64 `yaml:"vcpuHourPrice,omitempty"`
+
+	// MemoryGBHourPrice is the on-demand price of one GB of Fargate memory per hour.
+	// Default: 0.004445 (Linux/x86 in us-east-1)
+	MemoryGBHourPrice float64 `yaml:"memoryGBHourPrice,omitempty"`
+}
+
+// ServerlessEstimate is a fixed estimate of Fargate or Lambda usage.
+type ServerlessEstimate struct {
+	// Name identifies the estimate (e.g., "ecs-batch"). Must be unique.
+	Name string `yaml:"name"`
+
+	// Service is "fargate" or "lambda"
+	Service string `yaml:"service"`
+
+	// AccountID is the AWS account the usage is billed to
+	AccountID string `yaml:"accountId,omitempty"`
+
+	// Region is the AWS region of the usage
+	Region string `yaml:"region,omitempty"`
+
+	// HourlyCost is the usage's average cost at on-demand rates ($/hour). For Lambda,
+	// only duration charges are covered by Savings Plans, so exclude request charges.
+	HourlyCost float64 `yaml:"hourlyCost"`
+}
+
+// ServerlessDiscounts defines Compute Savings Plan rate multipliers for Fargate and
+// Lambda. Values are MULTIPLIERS (what you pay), not discount percentages.
+type ServerlessDiscounts struct {
+	// Fargate is the rate multiplier for Fargate usage.
+	// Default: 0.80 (~20% discount off on-demand, 1-year commitment)
+	Fargate float64 `yaml:"fargate,omitempty"`
+
+	// Lambda is the rate multiplier for Lambda duration.
+	// Default: 0.88 (~12% discount off on-demand, 1-year commitment)
+	Lambda float64 `yaml:"lambda,omitempty"`
+}
+
+// StorageConfig contains settings for EBS volume costs.
+type StorageConfig struct {
+	// Enabled turns on EBS volume costs. The controller lists EBS volumes along with
+	// EC2 instances, loads gp2/gp3/io1/io2/st1/sc1 pricing from the Pricing API, and
+	// attributes each volume's storage, provisioned IOPS, and provisioned throughput
+	// cost to the instance it's attached to. Costs are emitted as
+	// ec2_instance_storage_hourly_cost and ec2_instance_total_hourly_cost.
+	// Default: false (requires the ec2:DescribeVolumes permission)
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// KarpenterConfig contains settings for Karpenter NodePool cost rollups.
+type KarpenterConfig struct {
+	// Enabled watches Karpenter NodeClaims and reads the karpenter.sh/nodepool and
+	// karpenter.sh/capacity-type labels of nodes and NodeClaims, and emits each
+	// NodePool's effective and shelf cost, commitment coverage, and spot share.
+	// Kubernetes mode only; requires the karpenter.sh/v1 NodeClaim API.
+	// Default: false
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// UnitCostsConfig contains settings for cost-per-resource-unit metrics.
+type UnitCostsConfig struct {
+	// Enabled loads the vCPU, memory, GPU, and network capacity of each running
+	// instance type and emits its effective cost per vCPU-hour, GiB-hour, and
+	// GPU-hour by region and coverage type, so families can be compared after
+	// Savings Plan and Reserved Instance discounts.
+	// Default: false (requires the ec2:DescribeInstanceTypes permission)
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// ForecastConfig contains settings for Savings Plan and Reserved Instance
+// expiration forecasting.
+type ForecastConfig struct {
+	// Enabled re-runs the cost calculation with every commitment that expires
+	// within the horizon removed in order of expiry, and emits the projected
+	// increase in hourly cost and on-demand spillover per expiring commitment.
+	// The forecast is reused until the commitments change, for up to an hour.
+	// Default: false
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// Horizon is how far ahead expirations are forecast.
+	// Format: Go duration string (e.g., "720h", "2160h")
+	// Default: 2160h (90 days)
+	Horizon string `yaml:"horizon,omitempty"`
+}
+
+// AgentConfig contains settings for agent mode. In a multi-cluster deployment, one
+// hub Lumina collects AWS data and calculates costs for the whole organization, and
+// an agent in each cluster watches its nodes and pods and fetches their instances'
+// costs from the hub.
+type AgentConfig struct {
+	// HubURL is the base URL of the hub's metrics server, e.g.
+	// "http://lumina.lumina-system.svc:8080". Setting it runs Lumina as an agent:
+	// no AWS accounts are needed, and no AWS APIs are called.
+	// Default: "" (calculate costs locally)
+	HubURL string `yaml:"hubURL,omitempty"`
+
+	// PollInterval is how often to fetch instance costs from the hub.
+	// Format: Go duration string (e.g., "30s", "1m", "5m")
+	// Default: 1m
+	PollInterval string `yaml:"pollInterval,omitempty"`
+}
+
 // MetricLabelsConfig allows customizing metric label names.
 // This enables matching organizational conventions and avoiding conflicts
 // with externally-added labels (e.g., from Prometheus relabeling).
@@ -457,9 +993,16 @@ func Load(path string) (*Config, error) {
 	v.SetDefault(KeyMetricsBindAddress, DefaultMetricsBindAddress)
 	v.SetDefault(KeyHealthProbeBindAddress, DefaultHealthProbeBindAddress)
 	v.SetDefault(KeyAccountValidationInterval, DefaultAccountValidationInterval)
+	v.SetDefault(KeyAccountDiscoveryEnabled, false)
+	v.SetDefault(KeyAccountDiscoveryRefreshInterval, DefaultAccountDiscoveryRefreshInterval)
 	v.SetDefault(KeyReconciliationRISP, DefaultReconciliationRISP)
 	v.SetDefault(KeyReconciliationEC2, DefaultReconciliationEC2)
 	v.SetDefault(KeyReconciliationSpotPricing, DefaultReconciliationSpotPricing)
+	v.SetDefault(KeyReconciliationBilling, DefaultReconciliationBilling)
+	v.SetDefault(KeyReconciliationSnapshot, DefaultReconciliationSnapshot)
+	v.SetDefault(KeySnapshotMaxAge, DefaultSnapshotMaxAge)
+	v.SetDefault(KeyHistoryResolution, DefaultHistoryResolution)
+	v.SetDefault(KeyHistoryRetentionDays, DefaultHistoryRetentionDays)
 	v.SetDefault(KeyPricingSpotPriceCacheExpiration, DefaultSpotPriceCacheExpiration)
 	// Cost reconciliation is event-driven (no default interval needed)
 
@@ -479,6 +1022,35 @@ func Load(path string) (*Config, error) {
 	v.SetDefault(KeyMetricsLabelsHostName, DefaultLabelHostName)
 	v.SetDefault(KeyMetricsNodeNameSourceTagKey, DefaultNodeNameTagKey)
 
+	// Cumulative SP accounting is opt-in
+	v.SetDefault(KeyCostSavingsPlanLedger, false)
+	v.SetDefault(KeyCostSavingsPlanAllocation, DefaultSavingsPlanAllocation)
+
+	// Pod cost allocation is opt-in (it needs a pod watch)
+	v.SetDefault(KeyAllocationEnabled, false)
+	v.SetDefault(KeyRecommendationsEnabled, false)
+	v.SetDefault(KeyRecommendationsLookback, DefaultRecommendationsLookback)
+
+	// Fargate usage is opt-in (it needs a pod watch, shared with allocation)
+	v.SetDefault(KeyServerlessFargateEnabled, false)
+
+	// EBS volume costs are opt-in (they need the ec2:DescribeVolumes permission)
+	v.SetDefault(KeyStorageEnabled, false)
+
+	// NodePool rollups are opt-in (they need the Karpenter NodeClaim CRD)
+	v.SetDefault(KeyKarpenterEnabled, false)
+
+	// Unit costs are opt-in (they need the ec2:DescribeInstanceTypes permission)
+	v.SetDefault(KeyUnitCostsEnabled, false)
+
+	// Expiration forecasts are opt-in (each expiry is another cost calculation)
+	v.SetDefault(KeyForecastEnabled, false)
+	v.SetDefault(KeyForecastHorizon, DefaultForecastHorizon)
+
+	// Agent mode is opt-in; by default costs are calculated locally
+	v.SetDefault(KeyAgentHubURL, "")
+	v.SetDefault(KeyAgentPollInterval, DefaultAgentPollInterval)
+
 	// Enable environment variable overrides with LUMINA_ prefix
 	// Manually bind each config key to its environment variable
 	// Viper's automatic mapping doesn't handle camelCase to SCREAMING_SNAKE_CASE well
@@ -488,6 +1060,7 @@ func Load(path string) (*Config, error) {
 	_ = v.BindEnv(KeyMetricsBindAddress, EnvMetricsBindAddress)
 	_ = v.BindEnv(KeyHealthProbeBindAddress, EnvHealthProbeBindAddress)
 	_ = v.BindEnv(KeyAccountValidationInterval, EnvAccountValidationInterval)
+	_ = v.BindEnv(KeyAccountDiscoveryEnabled, EnvAccountDiscoveryEnabled)
 	_ = v.BindEnv(KeyReconciliationRISP, EnvReconciliationRISP)
 	_ = v.BindEnv(KeyReconciliationEC2, EnvReconciliationEC2)
 	_ = v.BindEnv(KeyMetricsDisableInstanceMetrics, EnvMetricsDisableInstanceMetrics)
@@ -498,6 +1071,20 @@ func Load(path string) (*Config, error) {
 	_ = v.BindEnv(KeyMetricsLabelsNodeName, EnvMetricsLabelsNodeName)
 	_ = v.BindEnv(KeyMetricsLabelsHostName, EnvMetricsLabelsHostName)
 	_ = v.BindEnv(KeyMetricsNodeNameSourceTagKey, EnvMetricsNodeNameSourceTagKey)
+	_ = v.BindEnv(KeyCostSavingsPlanLedger, EnvCostSavingsPlanLedger)
+	_ = v.BindEnv(KeyCostSavingsPlanAllocation, EnvCostSavingsPlanAllocation)
+	_ = v.BindEnv(KeyBillingCURPath, EnvBillingCURPath)
+	_ = v.BindEnv(KeySnapshotPath, EnvSnapshotPath)
+	_ = v.BindEnv(KeyHistoryPath, EnvHistoryPath)
+	_ = v.BindEnv(KeyAllocationEnabled, EnvAllocationEnabled)
+	_ = v.BindEnv(KeyAllocationCPUWeight, EnvAllocationCPUWeight)
+	_ = v.BindEnv(KeyRecommendationsEnabled, EnvRecommendationsEnabled)
+	_ = v.BindEnv(KeyServerlessFargateEnabled, EnvServerlessFargateEnabled)
+	_ = v.BindEnv(KeyStorageEnabled, EnvStorageEnabled)
+	_ = v.BindEnv(KeyKarpenterEnabled, EnvKarpenterEnabled)
+	_ = v.BindEnv(KeyUnitCostsEnabled, EnvUnitCostsEnabled)
+	_ = v.BindEnv(KeyForecastEnabled, EnvForecastEnabled)
+	_ = v.BindEnv(KeyAgentHubURL, EnvAgentHubURL)
 
 	// Read configuration file
 	if err := v.ReadInConfig(); err != nil {
@@ -545,8 +1132,9 @@ func Load(path string) (*Config, error) {
 
 // Validate checks that the configuration is valid and returns an error if not.
 func (c *Config) Validate() error {
-	// Check that at least one AWS account is configured
-	if len(c.AWSAccounts) == 0 {
+	// Check that at least one AWS account is configured, unless accounts are discovered
+	// or an agent gets its costs from the hub
+	if len(c.AWSAccounts) == 0 && !c.AccountDiscovery.Enabled && !c.IsAgent() {
 		return fmt.Errorf("at least one AWS account must be configured")
 	}
 
@@ -572,6 +1160,30 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate account discovery. Organizations is queried with the default account's
+	// role, so it must be explicit rather than whichever account happens to be first.
+	if c.AccountDiscovery.Enabled {
+		if c.DefaultAccount == nil {
+			return fmt.Errorf("account discovery requires defaultAccount to be configured")
+		}
+		if err := c.AccountDiscovery.Validate(); err != nil {
+			return fmt.Errorf("invalid account discovery configuration: %w", err)
+		}
+	}
+
+	for _, accountID := range c.Cost.DiscountSharingDisabledAccounts {
+		if !isValidAccountID(accountID) {
+			return fmt.Errorf("invalid discount sharing account ID %q: must be 12 digits", accountID)
+		}
+	}
+
+	switch c.Cost.SavingsPlanAllocation {
+	case "", "per_plan", "global":
+	default:
+		return fmt.Errorf("invalid Savings Plan allocation %q, must be one of: per_plan, global",
+			c.Cost.SavingsPlanAllocation)
+	}
+
 	// Validate log level
 	validLogLevels := map[string]bool{
 		"debug": true,
@@ -611,6 +1223,16 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("invalid SpotPricing reconciliation interval %q: %w", c.Reconciliation.SpotPricing, err)
 		}
 	}
+	if c.Reconciliation.Billing != "" {
+		if _, err := time.ParseDuration(c.Reconciliation.Billing); err != nil {
+			return fmt.Errorf("invalid Billing reconciliation interval %q: %w", c.Reconciliation.Billing, err)
+		}
+	}
+	if c.Reconciliation.Snapshot != "" {
+		if _, err := time.ParseDuration(c.Reconciliation.Snapshot); err != nil {
+			return fmt.Errorf("invalid Snapshot reconciliation interval %q: %w", c.Reconciliation.Snapshot, err)
+		}
+	}
 	// Cost reconciliation is event-driven (no interval validation needed)
 
 	// Validate spot price cache expiration
@@ -646,6 +1268,123 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate snapshot max age
+	if c.Snapshot.MaxAge != "" {
+		if _, err := time.ParseDuration(c.Snapshot.MaxAge); err != nil {
+			return fmt.Errorf("invalid snapshot max age %q: %w", c.Snapshot.MaxAge, err)
+		}
+	}
+
+	// Validate history settings
+	if c.History.Resolution != "" {
+		resolution, err := time.ParseDuration(c.History.Resolution)
+		if err != nil {
+			return fmt.Errorf("invalid history resolution %q: %w", c.History.Resolution, err)
+		}
+		if resolution <= 0 || resolution > 24*time.Hour {
+			return fmt.Errorf("invalid history resolution %q, must be between 0 and 24h", c.History.Resolution)
+		}
+	}
+	if c.History.RetentionDays < 0 {
+		return fmt.Errorf("invalid history retention %d days, must not be negative", c.History.RetentionDays)
+	}
+
+	// Validate allocation weighting if specified
+	if w := c.Allocation.CPUWeight; w != nil && (*w < 0 || *w > 1) {
+		return fmt.Errorf("invalid allocation CPU weight %f, must be between 0 and 1", *w)
+	}
+
+	// Validate recommendation settings
+	if c.Recommendations.Lookback != "" {
+		lookback, err := time.ParseDuration(c.Recommendations.Lookback)
+		if err != nil {
+			return fmt.Errorf("invalid recommendations lookback %q: %w", c.Recommendations.Lookback, err)
+		}
+		if lookback < 24*time.Hour {
+			return fmt.Errorf("invalid recommendations lookback %q, must be at least 24h", c.Recommendations.Lookback)
+		}
+	}
+	for _, term := range []struct {
+		name      string
+		discounts *SavingsPlanDiscounts
+	}{
+		{"oneYear", c.Recommendations.OneYearDiscounts},
+		{"threeYear", c.Recommendations.ThreeYearDiscounts},
+	} {
+		if term.discounts == nil {
+			continue
+		}
+		if d := term.discounts.EC2Instance; d < 0 || d > 1 {
+			return fmt.Errorf("invalid recommendations %s EC2Instance discount %f, must be between 0 and 1", term.name, d)
+		}
+		if d := term.discounts.Compute; d < 0 || d > 1 {
+			return fmt.Errorf("invalid recommendations %s Compute discount %f, must be between 0 and 1", term.name, d)
+		}
+	}
+
+	// Validate forecast horizon if specified
+	if c.Forecast.Horizon != "" {
+		horizon, err := time.ParseDuration(c.Forecast.Horizon)
+		if err != nil {
+			return fmt.Errorf("invalid forecast horizon %q: %w", c.Forecast.Horizon, err)
+		}
+		if horizon <= 0 {
+			return fmt.Errorf("invalid forecast horizon %q, must be positive", c.Forecast.Horizon)
+		}
+	}
+
+	// Validate agent settings
+	if c.Agent.HubURL != "" {
+		hubURL, err := url.Parse(c.Agent.HubURL)
+		if err != nil {
+			return fmt.Errorf("invalid agent hub URL %q: %w", c.Agent.HubURL, err)
+		}
+		if (hubURL.Scheme != "http" && hubURL.Scheme != "https") || hubURL.Host == "" {
+			return fmt.Errorf("invalid agent hub URL %q, must be an http or https URL", c.Agent.HubURL)
+		}
+	}
+	if c.Agent.PollInterval != "" {
+		interval, err := time.ParseDuration(c.Agent.PollInterval)
+		if err != nil {
+			return fmt.Errorf("invalid agent poll interval %q: %w", c.Agent.PollInterval, err)
+		}
+		if interval <= 0 {
+			return fmt.Errorf("invalid agent poll interval %q, must be positive", c.Agent.PollInterval)
+		}
+	}
+
+	// Validate serverless settings
+	fargate := c.Serverless.Fargate
+	if fargate.AccountID != "" && !isValidAccountID(fargate.AccountID) {
+		return fmt.Errorf("invalid Fargate account ID %q: must be 12 digits", fargate.AccountID)
+	}
+	if fargate.VCPUHourPrice < 0 || fargate.MemoryGBHourPrice < 0 {
+		return fmt.Errorf("invalid Fargate prices, must not be negative")
+	}
+	if d := c.Serverless.Discounts; d != nil && (d.Fargate < 0 || d.Fargate > 1 || d.Lambda < 0 || d.Lambda > 1) {
+		return fmt.Errorf("invalid serverless discounts (fargate %f, lambda %f), must be between 0 and 1",
+			d.Fargate, d.Lambda)
+	}
+	estimateNames := make(map[string]bool, len(c.Serverless.Estimates))
+	for i, estimate := range c.Serverless.Estimates {
+		if estimate.Name == "" || estimateNames[estimate.Name] {
+			return fmt.Errorf("serverless estimate %d: name is required and must be unique", i)
+		}
+		estimateNames[estimate.Name] = true
+		if estimate.Service != ServiceFargate && estimate.Service != ServiceLambda {
+			return fmt.Errorf("serverless estimate %q: invalid service %q, must be %q or %q",
+				estimate.Name, estimate.Service, ServiceFargate, ServiceLambda)
+		}
+		if estimate.AccountID != "" && !isValidAccountID(estimate.AccountID) {
+			return fmt.Errorf("serverless estimate %q: invalid account ID %q: must be 12 digits",
+				estimate.Name, estimate.AccountID)
+		}
+		if estimate.HourlyCost < 0 {
+			return fmt.Errorf("serverless estimate %q: invalid hourly cost %f, must not be negative",
+				estimate.Name, estimate.HourlyCost)
+		}
+	}
+
 	return nil
 }
 
@@ -721,6 +1460,57 @@ func (c *Config) GetAccountValidationInterval() time.Duration {
 	return duration
 }
 
+// GetAccountDiscoveryRefreshInterval returns the parsed account discovery refresh interval.
+// Returns 1 hour if not configured (the default value).
+func (c *Config) GetAccountDiscoveryRefreshInterval() time.Duration {
+	if c.AccountDiscovery.RefreshInterval == "" {
+		return time.Hour
+	}
+	duration, err := time.ParseDuration(c.AccountDiscovery.RefreshInterval)
+	if err != nil {
+		// Should never happen since Validate() checks this
+		return time.Hour
+	}
+	return duration
+}
+
+// GetHistoryResolution returns the parsed history resolution.
+// Returns 1 hour if not configured (the default value).
+func (c *Config) GetHistoryResolution() time.Duration {
+	if c.History.Resolution == "" {
+		return time.Hour
+	}
+	duration, err := time.ParseDuration(c.History.Resolution)
+	if err != nil {
+		// Should never happen since Validate() checks this
+		return time.Hour
+	}
+	return duration
+}
+
+// GetHistoryRetentionDays returns how many days of cost history to keep.
+// Returns 62 if not configured.
+func (c *Config) GetHistoryRetentionDays() int {
+	if c.History.RetentionDays > 0 {
+		return c.History.RetentionDays
+	}
+	return DefaultHistoryRetentionDays
+}
+
+// GetSnapshotMaxAge returns the parsed snapshot max age.
+// Returns 24 hours if not configured (the default value).
+func (c *Config) GetSnapshotMaxAge() time.Duration {
+	if c.Snapshot.MaxAge == "" {
+		return 24 * time.Hour
+	}
+	duration, err := time.ParseDuration(c.Snapshot.MaxAge)
+	if err != nil {
+		// Should never happen since Validate() checks this
+		return 24 * time.Hour
+	}
+	return duration
+}
+
 // GetDefaultAccount returns the default account to use for non-account-specific
 // AWS API calls (e.g., pricing data). If DefaultAccount is not explicitly configured,
 // returns the first account in AWSAccounts.
@@ -754,6 +1544,143 @@ func (c *Config) GetComputeDiscount() float64 {
 	return 0.72 // Default 1-year rate: ~28% OFF → pay 72%
 }
 
+// GetAllocationCPUWeight returns the fraction of node cost attributed to CPU requests
+// when allocating costs to pods. Returns 0.5 if not configured.
+func (c *Config) GetAllocationCPUWeight() float64 {
+	if c.Allocation.CPUWeight != nil {
+		return *c.Allocation.CPUWeight
+	}
+	return DefaultAllocationCPUWeight
+}
+
+// GetRecommendationsLookback returns the parsed recommendations lookback window.
+// Returns 7 days if not configured.
+func (c *Config) GetRecommendationsLookback() time.Duration {
+	duration, err := time.ParseDuration(c.Recommendations.Lookback)
+	if err != nil {
+		// Unset, or invalid (Validate() rejects that)
+		return 7 * 24 * time.Hour
+	}
+	return duration
+}
+
+// GetForecastHorizon returns the parsed expiration forecast horizon.
+// Returns 90 days if not configured.
+func (c *Config) GetForecastHorizon() time.Duration {
+	duration, err := time.ParseDuration(c.Forecast.Horizon)
+	if err != nil {
+		// Unset, or invalid (Validate() rejects that)
+		return 90 * 24 * time.Hour
+	}
+	return duration
+}
+
+// IsAgent returns whether Lumina runs as an agent that fetches instance costs from
+// a hub (config agent.hubURL).
+func (c *Config) IsAgent() bool {
+	return c.Agent.HubURL != ""
+}
+
+// GetAgentPollInterval returns the parsed interval between fetches from the hub.
+// Returns 1 minute if not configured.
+func (c *Config) GetAgentPollInterval() time.Duration {
+	duration, err := time.ParseDuration(c.Agent.PollInterval)
+	if err != nil {
+		// Unset, or invalid (Validate() rejects that)
+		return time.Minute
+	}
+	return duration
+}
+
+// GetRecommendationDiscounts returns the rate multipliers assumed for newly purchased
+// Savings Plans of the given term (1 or 3 years). Unset plan types default to 0.72
+// for 1-year and 0.50 for 3-year plans.
+func (c *Config) GetRecommendationDiscounts(termYears int) SavingsPlanDiscounts {
+	configured := c.Recommendations.OneYearDiscounts
+	defaultRate := DefaultRecommendationOneYearRate
+	if termYears == 3 {
+		configured = c.Recommendations.ThreeYearDiscounts
+		defaultRate = DefaultRecommendationThreeYearRate
+	}
+
+	discounts := SavingsPlanDiscounts{EC2Instance: defaultRate, Compute: defaultRate}
+	if configured != nil && configured.EC2Instance > 0 {
+		discounts.EC2Instance = configured.EC2Instance
+	}
+	if configured != nil && configured.Compute > 0 {
+		discounts.Compute = configured.Compute
+	}
+	return discounts
+}
+
+// GetSharingDisabledAccounts returns the set of accounts with RI and Savings Plans
+// discount sharing turned off, or nil if every account shares.
+func (c *Config) GetSharingDisabledAccounts() map[string]bool {
+	if len(c.Cost.DiscountSharingDisabledAccounts) == 0 {
+		return nil
+	}
+	accounts := make(map[string]bool, len(c.Cost.DiscountSharingDisabledAccounts))
+	for _, accountID := range c.Cost.DiscountSharingDisabledAccounts {
+		accounts[accountID] = true
+	}
+	return accounts
+}
+
+// GetFargateAccountID returns the AWS account Fargate pods are billed to: the
+// configured account, or else the default account (the first configured account
+// if no default account is set).
+func (c *Config) GetFargateAccountID() string {
+	if c.Serverless.Fargate.AccountID != "" {
+		return c.Serverless.Fargate.AccountID
+	}
+	if c.DefaultAccount != nil {
+		return c.DefaultAccount.AccountID
+	}
+	if len(c.AWSAccounts) > 0 {
+		return c.AWSAccounts[0].AccountID
+	}
+	return ""
+}
+
+// GetFargateRegion returns the AWS region Fargate pods run in.
+// Returns defaultRegion if not configured.
+func (c *Config) GetFargateRegion() string {
+	if c.Serverless.Fargate.Region != "" {
+		return c.Serverless.Fargate.Region
+	}
+	return c.DefaultRegion
+}
+
+// GetFargatePrices returns the on-demand Fargate prices per vCPU-hour and per
+// GB-hour. Unset prices default to Linux/x86 prices in us-east-1.
+func (c *Config) GetFargatePrices() (vCPUHour, memoryGBHour float64) {
+	vCPUHour, memoryGBHour = DefaultFargateVCPUHourPrice, DefaultFargateMemoryGBHourPrice
+	if c.Serverless.Fargate.VCPUHourPrice > 0 {
+		vCPUHour = c.Serverless.Fargate.VCPUHourPrice
+	}
+	if c.Serverless.Fargate.MemoryGBHourPrice > 0 {
+		memoryGBHour = c.Serverless.Fargate.MemoryGBHourPrice
+	}
+	return vCPUHour, memoryGBHour
+}
+
+// GetServerlessDiscount returns the Compute Savings Plan rate multiplier for a
+// serverless service ("fargate" or "lambda"). Returns 0.80 for Fargate and 0.88 for
+// Lambda if not configured.
+func (c *Config) GetServerlessDiscount(service string) float64 {
+	discounts := c.Serverless.Discounts
+	if service == ServiceLambda {
+		if discounts != nil && discounts.Lambda > 0 {
+			return discounts.Lambda
+		}
+		return DefaultSPDiscountLambda
+	}
+	if discounts != nil && discounts.Fargate > 0 {
+		return discounts.Fargate
+	}
+	return DefaultSPDiscountFargate
+}
+
 // GetOperatingSystems returns the configured operating systems for pricing data.
 // Returns ["Linux", "Windows"] if not specified in config.
 func (c *Config) GetOperatingSystems() []string {
@@ -17,8 +17,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoad(t *testing.T) {
@@ -169,6 +171,17 @@ logLevel: invalid`,
 			wantErr: true,
 			errMsg:  "invalid log level",
 		},
+		{
+			name: "invalid Savings Plan allocation",
+			yaml: `awsAccounts:
+  - accountId: "123456789012"
+    name: "Test"
+    assumeRoleArn: "arn:aws:iam::123456789012:role/test-role"
+cost:
+  savingsPlanAllocation: greedy`,
+			wantErr: true,
+			errMsg:  "invalid Savings Plan allocation",
+		},
 		{
 			name: "invalid YAML syntax",
 			yaml: `awsAccounts:
@@ -283,6 +296,69 @@ func TestApplyDefaults(t *testing.T) {
 	if cfg.AccountValidationInterval != "10m" {
 		t.Errorf("AccountValidationInterval = %q, want '10m'", cfg.AccountValidationInterval)
 	}
+	if cfg.Cost.SavingsPlanLedger {
+		t.Errorf("Cost.SavingsPlanLedger = true, want false")
+	}
+	if cfg.Cost.SavingsPlanAllocation != "per_plan" {
+		t.Errorf("Cost.SavingsPlanAllocation = %q, want 'per_plan'", cfg.Cost.SavingsPlanAllocation)
+	}
+	if cfg.Reconciliation.Billing != "6h" {
+		t.Errorf("Reconciliation.Billing = %q, want '6h'", cfg.Reconciliation.Billing)
+	}
+	if cfg.Billing.CURPath != "" {
+		t.Errorf("Billing.CURPath = %q, want empty", cfg.Billing.CURPath)
+	}
+	if cfg.Allocation.Enabled {
+		t.Errorf("Allocation.Enabled = true, want false")
+	}
+	if cfg.Storage.Enabled {
+		t.Errorf("Storage.Enabled = true, want false")
+	}
+	if cfg.Karpenter.Enabled {
+		t.Errorf("Karpenter.Enabled = true, want false")
+	}
+	if cfg.UnitCosts.Enabled {
+		t.Errorf("UnitCosts.Enabled = true, want false")
+	}
+	if got := cfg.GetAllocationCPUWeight(); got != 0.5 {
+		t.Errorf("GetAllocationCPUWeight() = %v, want 0.5", got)
+	}
+	if cfg.Snapshot.Path != "" {
+		t.Errorf("Snapshot.Path = %q, want empty", cfg.Snapshot.Path)
+	}
+	if cfg.Reconciliation.Snapshot != "10m" {
+		t.Errorf("Reconciliation.Snapshot = %q, want '10m'", cfg.Reconciliation.Snapshot)
+	}
+	if got := cfg.GetSnapshotMaxAge(); got != 24*time.Hour {
+		t.Errorf("GetSnapshotMaxAge() = %v, want 24h", got)
+	}
+	if cfg.History.Path != "" {
+		t.Errorf("History.Path = %q, want empty", cfg.History.Path)
+	}
+	if got := cfg.GetHistoryResolution(); got != time.Hour {
+		t.Errorf("GetHistoryResolution() = %v, want 1h", got)
+	}
+	if cfg.History.RetentionDays != 62 {
+		t.Errorf("History.RetentionDays = %d, want 62", cfg.History.RetentionDays)
+	}
+	if cfg.Recommendations.Enabled {
+		t.Error("Recommendations.Enabled = true, want false")
+	}
+	if got := cfg.GetRecommendationsLookback(); got != 7*24*time.Hour {
+		t.Errorf("GetRecommendationsLookback() = %v, want 168h", got)
+	}
+	if cfg.Forecast.Enabled {
+		t.Error("Forecast.Enabled = true, want false")
+	}
+	if got := cfg.GetForecastHorizon(); got != 90*24*time.Hour {
+		t.Errorf("GetForecastHorizon() = %v, want 2160h", got)
+	}
+	if cfg.IsAgent() {
+		t.Error("IsAgent() = true, want false")
+	}
+	if got := cfg.GetAgentPollInterval(); got != time.Minute {
+		t.Errorf("GetAgentPollInterval() = %v, want 1m", got)
+	}
 }
 
 func TestEnvOverrides(t *testing.T) {
@@ -309,6 +385,17 @@ accountValidationInterval: "5m"`
 		"LUMINA_METRICS_BIND_ADDRESS":        os.Getenv("LUMINA_METRICS_BIND_ADDRESS"),
 		"LUMINA_HEALTH_PROBE_BIND_ADDRESS":   os.Getenv("LUMINA_HEALTH_PROBE_BIND_ADDRESS"),
 		"LUMINA_ACCOUNT_VALIDATION_INTERVAL": os.Getenv("LUMINA_ACCOUNT_VALIDATION_INTERVAL"),
+		"LUMINA_COST_SAVINGS_PLAN_LEDGER":    os.Getenv("LUMINA_COST_SAVINGS_PLAN_LEDGER"),
+		"LUMINA_ALLOCATION_ENABLED":          os.Getenv("LUMINA_ALLOCATION_ENABLED"),
+		"LUMINA_ALLOCATION_CPU_WEIGHT":       os.Getenv("LUMINA_ALLOCATION_CPU_WEIGHT"),
+		"LUMINA_SNAPSHOT_PATH":               os.Getenv("LUMINA_SNAPSHOT_PATH"),
+		"LUMINA_HISTORY_PATH":                os.Getenv("LUMINA_HISTORY_PATH"),
+		"LUMINA_RECOMMENDATIONS_ENABLED":     os.Getenv("LUMINA_RECOMMENDATIONS_ENABLED"),
+		"LUMINA_STORAGE_ENABLED":             os.Getenv("LUMINA_STORAGE_ENABLED"),
+		"LUMINA_KARPENTER_ENABLED":           os.Getenv("LUMINA_KARPENTER_ENABLED"),
+		"LUMINA_UNIT_COSTS_ENABLED":          os.Getenv("LUMINA_UNIT_COSTS_ENABLED"),
+		"LUMINA_FORECAST_ENABLED":            os.Getenv("LUMINA_FORECAST_ENABLED"),
+		"LUMINA_AGENT_HUB_URL":               os.Getenv("LUMINA_AGENT_HUB_URL"),
 	}
 	defer func() {
 		// Restore original environment
@@ -326,6 +413,17 @@ accountValidationInterval: "5m"`
 	_ = os.Setenv("LUMINA_METRICS_BIND_ADDRESS", ":9090")
 	_ = os.Setenv("LUMINA_HEALTH_PROBE_BIND_ADDRESS", ":9091")
 	_ = os.Setenv("LUMINA_ACCOUNT_VALIDATION_INTERVAL", "10m")
+	_ = os.Setenv("LUMINA_COST_SAVINGS_PLAN_LEDGER", "true")
+	_ = os.Setenv("LUMINA_ALLOCATION_ENABLED", "true")
+	_ = os.Setenv("LUMINA_ALLOCATION_CPU_WEIGHT", "0.7")
+	_ = os.Setenv("LUMINA_SNAPSHOT_PATH", "/var/lib/lumina/snapshot.json.gz")
+	_ = os.Setenv("LUMINA_HISTORY_PATH", "/var/lib/lumina/history")
+	_ = os.Setenv("LUMINA_RECOMMENDATIONS_ENABLED", "true")
+	_ = os.Setenv("LUMINA_STORAGE_ENABLED", "true")
+	_ = os.Setenv("LUMINA_KARPENTER_ENABLED", "true")
+	_ = os.Setenv("LUMINA_UNIT_COSTS_ENABLED", "true")
+	_ = os.Setenv("LUMINA_FORECAST_ENABLED", "true")
+	_ = os.Setenv("LUMINA_AGENT_HUB_URL", "http://lumina-hub:8080")
 
 	cfg, err := Load(configPath)
 	if err != nil {
@@ -348,6 +446,39 @@ accountValidationInterval: "5m"`
 	if cfg.AccountValidationInterval != "10m" {
 		t.Errorf("AccountValidationInterval = %q, want '10m' (from env)", cfg.AccountValidationInterval)
 	}
+	if !cfg.Cost.SavingsPlanLedger {
+		t.Errorf("Cost.SavingsPlanLedger = false, want true (from env)")
+	}
+	if !cfg.Allocation.Enabled {
+		t.Errorf("Allocation.Enabled = false, want true (from env)")
+	}
+	if !cfg.Storage.Enabled {
+		t.Errorf("Storage.Enabled = false, want true (from env)")
+	}
+	if !cfg.Karpenter.Enabled {
+		t.Errorf("Karpenter.Enabled = false, want true (from env)")
+	}
+	if !cfg.UnitCosts.Enabled {
+		t.Errorf("UnitCosts.Enabled = false, want true (from env)")
+	}
+	if !cfg.Forecast.Enabled {
+		t.Errorf("Forecast.Enabled = false, want true (from env)")
+	}
+	if cfg.Agent.HubURL != "http://lumina-hub:8080" {
+		t.Errorf("Agent.HubURL = %q, want 'http://lumina-hub:8080' (from env)", cfg.Agent.HubURL)
+	}
+	if got := cfg.GetAllocationCPUWeight(); got != 0.7 {
+		t.Errorf("GetAllocationCPUWeight() = %v, want 0.7 (from env)", got)
+	}
+	if cfg.Snapshot.Path != "/var/lib/lumina/snapshot.json.gz" {
+		t.Errorf("Snapshot.Path = %q, want '/var/lib/lumina/snapshot.json.gz' (from env)", cfg.Snapshot.Path)
+	}
+	if cfg.History.Path != "/var/lib/lumina/history" {
+		t.Errorf("History.Path = %q, want '/var/lib/lumina/history' (from env)", cfg.History.Path)
+	}
+	if !cfg.Recommendations.Enabled {
+		t.Error("Recommendations.Enabled = false, want true (from env)")
+	}
 }
 
 func TestValidAccountID(t *testing.T) {
@@ -599,6 +730,22 @@ func TestReconciliationIntervalValidation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid EC2 reconciliation interval",
 		},
+		{
+			name: "invalid Billing interval",
+			reconciliation: ReconciliationConfig{
+				Billing: "daily",
+			},
+			wantErr: true,
+			errMsg:  "invalid Billing reconciliation interval",
+		},
+		{
+			name: "invalid Snapshot interval",
+			reconciliation: ReconciliationConfig{
+				Snapshot: "often",
+			},
+			wantErr: true,
+			errMsg:  "invalid Snapshot reconciliation interval",
+		},
 		{
 			name: "negative RISP interval",
 			reconciliation: ReconciliationConfig{
@@ -638,6 +785,549 @@ func TestReconciliationIntervalValidation(t *testing.T) {
 	}
 }
 
+// TestAllocationCPUWeightValidation tests validation of the allocation CPU weight.
+func TestAllocationCPUWeightValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		weight  *float64
+		wantErr bool
+	}{
+		{name: "unset", weight: nil, wantErr: false},
+		{name: "memory only", weight: ptr(0.0), wantErr: false},
+		{name: "cpu only", weight: ptr(1.0), wantErr: false},
+		{name: "negative", weight: ptr(-0.1), wantErr: true},
+		{name: "above one", weight: ptr(1.5), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				AWSAccounts: []AWSAccount{
+					{
+						AccountID:     "123456789012",
+						Name:          "test-account",
+						AssumeRoleARN: "arn:aws:iam::123456789012:role/test-role",
+					},
+				},
+				Allocation: AllocationConfig{CPUWeight: tt.weight},
+			}
+			err := cfg.Validate()
+			if tt.wantErr && (err == nil || !strings.Contains(err.Error(), "invalid allocation CPU weight")) {
+				t.Errorf("Validate() error = %v, want invalid allocation CPU weight", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSnapshotMaxAge(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxAge  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "unset", maxAge: "", want: 24 * time.Hour},
+		{name: "custom", maxAge: "6h", want: 6 * time.Hour},
+		{name: "invalid", maxAge: "one day", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				AWSAccounts: []AWSAccount{
+					{
+						AccountID:     "123456789012",
+						Name:          "test-account",
+						AssumeRoleARN: "arn:aws:iam::123456789012:role/test-role",
+					},
+				},
+				Snapshot: SnapshotConfig{MaxAge: tt.maxAge},
+			}
+			err := cfg.Validate()
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "invalid snapshot max age") {
+					t.Errorf("Validate() error = %v, want invalid snapshot max age", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+			if got := cfg.GetSnapshotMaxAge(); got != tt.want {
+				t.Errorf("GetSnapshotMaxAge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryValidation(t *testing.T) {
+	tests := []struct {
+		name           string
+		history        HistoryConfig
+		wantErr        string
+		wantResolution time.Duration
+		wantRetention  int
+	}{
+		{name: "unset", wantResolution: time.Hour, wantRetention: 62},
+		{
+			name:           "custom",
+			history:        HistoryConfig{Resolution: "15m", RetentionDays: 400},
+			wantResolution: 15 * time.Minute,
+			wantRetention:  400,
+		},
+		{name: "invalid resolution", history: HistoryConfig{Resolution: "hourly"}, wantErr: "invalid history resolution"},
+		{name: "resolution too long", history: HistoryConfig{Resolution: "48h"}, wantErr: "invalid history resolution"},
+		{name: "negative retention", history: HistoryConfig{RetentionDays: -1}, wantErr: "invalid history retention"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				AWSAccounts: []AWSAccount{
+					{
+						AccountID:     "123456789012",
+						Name:          "test-account",
+						AssumeRoleARN: "arn:aws:iam::123456789012:role/test-role",
+					},
+				},
+				History: tt.history,
+			}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+			if got := cfg.GetHistoryResolution(); got != tt.wantResolution {
+				t.Errorf("GetHistoryResolution() = %v, want %v", got, tt.wantResolution)
+			}
+			if got := cfg.GetHistoryRetentionDays(); got != tt.wantRetention {
+				t.Errorf("GetHistoryRetentionDays() = %d, want %d", got, tt.wantRetention)
+			}
+		})
+	}
+}
+
+func TestRecommendationsValidation(t *testing.T) {
+	tests := []struct {
+		name            string
+		recommendations RecommendationsConfig
+		wantErr         string
+		wantLookback    time.Duration
+		wantOneYear     SavingsPlanDiscounts
+		wantThreeYear   SavingsPlanDiscounts
+	}{
+		{
+			name:          "unset",
+			wantLookback:  168 * time.Hour,
+			wantOneYear:   SavingsPlanDiscounts{EC2Instance: 0.72, Compute: 0.72},
+			wantThreeYear: SavingsPlanDiscounts{EC2Instance: 0.50, Compute: 0.50},
+		},
+		{
+			name: "custom",
+			recommendations: RecommendationsConfig{
+				Lookback:           "720h",
+				OneYearDiscounts:   &SavingsPlanDiscounts{EC2Instance: 0.65},
+				ThreeYearDiscounts: &SavingsPlanDiscounts{EC2Instance: 0.45, Compute: 0.52},
+			},
+			wantLookback:  720 * time.Hour,
+			wantOneYear:   SavingsPlanDiscounts{EC2Instance: 0.65, Compute: 0.72},
+			wantThreeYear: SavingsPlanDiscounts{EC2Instance: 0.45, Compute: 0.52},
+		},
+		{
+			name:            "invalid lookback",
+			recommendations: RecommendationsConfig{Lookback: "weekly"},
+			wantErr:         "invalid recommendations lookback",
+		},
+		{
+			name:            "lookback too short",
+			recommendations: RecommendationsConfig{Lookback: "12h"},
+			wantErr:         "must be at least 24h",
+		},
+		{
+			name:            "invalid discount",
+			recommendations: RecommendationsConfig{ThreeYearDiscounts: &SavingsPlanDiscounts{Compute: 1.5}},
+			wantErr:         "invalid recommendations threeYear Compute discount",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				AWSAccounts: []AWSAccount{
+					{
+						AccountID:     "123456789012",
+						Name:          "test-account",
+						AssumeRoleARN: "arn:aws:iam::123456789012:role/test-role",
+					},
+				},
+				Recommendations: tt.recommendations,
+			}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+			if got := cfg.GetRecommendationsLookback(); got != tt.wantLookback {
+				t.Errorf("GetRecommendationsLookback() = %v, want %v", got, tt.wantLookback)
+			}
+			if got := cfg.GetRecommendationDiscounts(1); got != tt.wantOneYear {
+				t.Errorf("GetRecommendationDiscounts(1) = %+v, want %+v", got, tt.wantOneYear)
+			}
+			if got := cfg.GetRecommendationDiscounts(3); got != tt.wantThreeYear {
+				t.Errorf("GetRecommendationDiscounts(3) = %+v, want %+v", got, tt.wantThreeYear)
+			}
+		})
+	}
+}
+
+func TestForecastValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		horizon     string
+		wantErr     string
+		wantHorizon time.Duration
+	}{
+		{name: "unset", wantHorizon: 2160 * time.Hour},
+		{name: "custom", horizon: "720h", wantHorizon: 720 * time.Hour},
+		{name: "invalid", horizon: "quarterly", wantErr: "invalid forecast horizon"},
+		{name: "not positive", horizon: "0s", wantErr: "must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				AWSAccounts: []AWSAccount{
+					{
+						AccountID:     "123456789012",
+						Name:          "test-account",
+						AssumeRoleARN: "arn:aws:iam::123456789012:role/test-role",
+					},
+				},
+				Forecast: ForecastConfig{Horizon: tt.horizon},
+			}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+			if got := cfg.GetForecastHorizon(); got != tt.wantHorizon {
+				t.Errorf("GetForecastHorizon() = %v, want %v", got, tt.wantHorizon)
+			}
+		})
+	}
+}
+
+func TestAgentValidation(t *testing.T) {
+	tests := []struct {
+		name         string
+		agent        AgentConfig
+		wantErr      string
+		wantInterval time.Duration
+	}{
+		{name: "agent without accounts", agent: AgentConfig{HubURL: "http://lumina-hub:8080"},
+			wantInterval: time.Minute},
+		{name: "custom poll interval", agent: AgentConfig{HubURL: "https://lumina.example.com/", PollInterval: "30s"},
+			wantInterval: 30 * time.Second},
+		{name: "no accounts and no hub", wantErr: "at least one AWS account"},
+		{name: "relative URL", agent: AgentConfig{HubURL: "lumina-hub:8080"}, wantErr: "must be an http or https URL"},
+		{name: "unsupported scheme", agent: AgentConfig{HubURL: "grpc://lumina-hub:8080"},
+			wantErr: "must be an http or https URL"},
+		{name: "invalid poll interval", agent: AgentConfig{HubURL: "http://lumina-hub:8080", PollInterval: "often"},
+			wantErr: "invalid agent poll interval"},
+		{name: "poll interval not positive", agent: AgentConfig{HubURL: "http://lumina-hub:8080", PollInterval: "-1m"},
+			wantErr: "must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Agent: tt.agent}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+			if got := cfg.GetAgentPollInterval(); got != tt.wantInterval {
+				t.Errorf("GetAgentPollInterval() = %v, want %v", got, tt.wantInterval)
+			}
+		})
+	}
+}
+
+func TestDiscountSharingValidation(t *testing.T) {
+	tests := []struct {
+		name         string
+		accounts     []string
+		wantErr      string
+		wantDisabled map[string]bool
+	}{
+		{name: "every account shares"},
+		{name: "sharing disabled", accounts: []string{"210987654321", "123456789012"},
+			wantDisabled: map[string]bool{"210987654321": true, "123456789012": true}},
+		{name: "invalid account", accounts: []string{"12345"}, wantErr: "invalid discount sharing account ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				AWSAccounts: []AWSAccount{
+					{
+						AccountID:     "123456789012",
+						Name:          "test-account",
+						AssumeRoleARN: "arn:aws:iam::123456789012:role/test-role",
+					},
+				},
+				Cost: CostConfig{DiscountSharingDisabledAccounts: tt.accounts},
+			}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+			if got := cfg.GetSharingDisabledAccounts(); !reflect.DeepEqual(got, tt.wantDisabled) {
+				t.Errorf("GetSharingDisabledAccounts() = %v, want %v", got, tt.wantDisabled)
+			}
+		})
+	}
+}
+
+func TestServerlessValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		serverless ServerlessConfig
+		wantErr    string
+	}{
+		{
+			name: "valid",
+			serverless: ServerlessConfig{
+				Fargate:   FargateConfig{Enabled: true, AccountID: "210987654321", VCPUHourPrice: 0.05},
+				Estimates: []ServerlessEstimate{{Name: "functions", Service: ServiceLambda, HourlyCost: 2}},
+				Discounts: &ServerlessDiscounts{Lambda: 0.83},
+			},
+		},
+		{
+			name:       "invalid Fargate account",
+			serverless: ServerlessConfig{Fargate: FargateConfig{AccountID: "12345"}},
+			wantErr:    "invalid Fargate account ID",
+		},
+		{
+			name:       "negative Fargate price",
+			serverless: ServerlessConfig{Fargate: FargateConfig{MemoryGBHourPrice: -1}},
+			wantErr:    "invalid Fargate prices",
+		},
+		{
+			name:       "invalid discount",
+			serverless: ServerlessConfig{Discounts: &ServerlessDiscounts{Fargate: 1.2}},
+			wantErr:    "invalid serverless discounts",
+		},
+		{
+			name: "duplicate estimate name",
+			serverless: ServerlessConfig{Estimates: []ServerlessEstimate{
+				{Name: "ecs", Service: ServiceFargate},
+				{Name: "ecs", Service: ServiceLambda},
+			}},
+			wantErr: "must be unique",
+		},
+		{
+			name:       "invalid estimate service",
+			serverless: ServerlessConfig{Estimates: []ServerlessEstimate{{Name: "batch", Service: "ec2"}}},
+			wantErr:    `invalid service "ec2"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				AWSAccounts: []AWSAccount{
+					{
+						AccountID:     "123456789012",
+						Name:          "test-account",
+						AssumeRoleARN: "arn:aws:iam::123456789012:role/test-role",
+					},
+				},
+				Serverless: tt.serverless,
+			}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestServerlessGetters(t *testing.T) {
+	cfg := &Config{
+		DefaultRegion: "us-west-2",
+		AWSAccounts:   []AWSAccount{{AccountID: "123456789012"}},
+	}
+	if got := cfg.GetFargateAccountID(); got != "123456789012" {
+		t.Errorf("GetFargateAccountID() = %q, want the first account", got)
+	}
+	if got := cfg.GetFargateRegion(); got != "us-west-2" {
+		t.Errorf("GetFargateRegion() = %q, want defaultRegion", got)
+	}
+	vCPU, memory := cfg.GetFargatePrices()
+	if vCPU != DefaultFargateVCPUHourPrice || memory != DefaultFargateMemoryGBHourPrice {
+		t.Errorf("GetFargatePrices() = %v, %v, want defaults", vCPU, memory)
+	}
+	if got := cfg.GetServerlessDiscount(ServiceLambda); got != 0.88 {
+		t.Errorf("GetServerlessDiscount(lambda) = %v, want 0.88", got)
+	}
+
+	cfg.DefaultAccount = &AWSAccount{AccountID: "111111111111"}
+	cfg.Serverless = ServerlessConfig{
+		Fargate:   FargateConfig{Region: "eu-west-1", MemoryGBHourPrice: 0.005},
+		Discounts: &ServerlessDiscounts{Fargate: 0.6},
+	}
+	if got := cfg.GetFargateAccountID(); got != "111111111111" {
+		t.Errorf("GetFargateAccountID() = %q, want the default account", got)
+	}
+	if got := cfg.GetFargateRegion(); got != "eu-west-1" {
+		t.Errorf("GetFargateRegion() = %q, want eu-west-1", got)
+	}
+	if vCPU, memory := cfg.GetFargatePrices(); vCPU != DefaultFargateVCPUHourPrice || memory != 0.005 {
+		t.Errorf("GetFargatePrices() = %v, %v, want default vCPU and configured memory price", vCPU, memory)
+	}
+	if got := cfg.GetServerlessDiscount(ServiceFargate); got != 0.6 {
+		t.Errorf("GetServerlessDiscount(fargate) = %v, want 0.6", got)
+	}
+}
+
+func TestAccountDiscoveryValidation(t *testing.T) {
+	defaultAccount := &AWSAccount{
+		AccountID:     "111111111111",
+		Name:          "management",
+		AssumeRoleARN: "arn:aws:iam::111111111111:role/lumina-org-reader",
+	}
+
+	tests := []struct {
+		name           string
+		defaultAccount *AWSAccount
+		discovery      AccountDiscoveryConfig
+		wantErr        string
+		wantInterval   time.Duration
+	}{
+		{
+			name:           "no static accounts",
+			defaultAccount: defaultAccount,
+			discovery:      AccountDiscoveryConfig{Enabled: true, RoleName: "lumina-readonly"},
+			wantInterval:   time.Hour,
+		},
+		{
+			name:           "filters",
+			defaultAccount: defaultAccount,
+			discovery: AccountDiscoveryConfig{
+				Enabled:         true,
+				RoleName:        "lumina/readonly-{account_id}",
+				RefreshInterval: "15m",
+				IncludeOUs:      []string{"ou-ab12-cd34ef56", "r-ab12"},
+				ExcludeTags:     []string{"lumina=skip", "sandbox"},
+			},
+			wantInterval: 15 * time.Minute,
+		},
+		{
+			name:      "missing default account",
+			discovery: AccountDiscoveryConfig{Enabled: true, RoleName: "lumina-readonly"},
+			wantErr:   "requires defaultAccount",
+		},
+		{
+			name:           "missing role name",
+			defaultAccount: defaultAccount,
+			discovery:      AccountDiscoveryConfig{Enabled: true},
+			wantErr:        "role name is required",
+		},
+		{
+			name:           "invalid role name",
+			defaultAccount: defaultAccount,
+			discovery:      AccountDiscoveryConfig{Enabled: true, RoleName: "lumina readonly"},
+			wantErr:        "invalid role name",
+		},
+		{
+			name:           "refresh too often",
+			defaultAccount: defaultAccount,
+			discovery:      AccountDiscoveryConfig{Enabled: true, RoleName: "lumina-readonly", RefreshInterval: "30s"},
+			wantErr:        "must be at least 1m0s",
+		},
+		{
+			name:           "invalid OU",
+			defaultAccount: defaultAccount,
+			discovery:      AccountDiscoveryConfig{Enabled: true, RoleName: "lumina-readonly", ExcludeOUs: []string{"Sandbox"}},
+			wantErr:        "invalid organizational unit ID",
+		},
+		{
+			name:           "invalid tag filter",
+			defaultAccount: defaultAccount,
+			discovery:      AccountDiscoveryConfig{Enabled: true, RoleName: "lumina-readonly", IncludeTags: []string{"=prod"}},
+			wantErr:        "invalid tag filter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{DefaultAccount: tt.defaultAccount, AccountDiscovery: tt.discovery}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+			if got := cfg.GetAccountDiscoveryRefreshInterval(); got != tt.wantInterval {
+				t.Errorf("GetAccountDiscoveryRefreshInterval() = %v, want %v", got, tt.wantInterval)
+			}
+		})
+	}
+}
+
+func TestAccountDiscoveryRoleARN(t *testing.T) {
+	discovery := AccountDiscoveryConfig{RoleName: "lumina/readonly-{account_id}"}
+	want := "arn:aws-us-gov:iam::222222222222:role/lumina/readonly-222222222222"
+	if got := discovery.RoleARN("aws-us-gov", "222222222222"); got != want {
+		t.Errorf("RoleARN() = %q, want %q", got, want)
+	}
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+
 // TestLoadWithPricingData tests that pricing data is correctly loaded when using
 // flat map keys with colons and periods (which mapstructure would normally treat as delimiters).
 func TestLoadWithPricingData(t *testing.T) {
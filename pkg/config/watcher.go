@@ -0,0 +1,210 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DefaultReloadCheckInterval is how often the configuration file is checked for
+// changes. Kubernetes updates mounted ConfigMaps about once a minute, so checking
+// more often than that only shortens the worst-case delay slightly.
+const DefaultReloadCheckInterval = 10 * time.Second
+
+// Provider supplies the configuration currently in effect.
+// Implementations must be safe for concurrent use.
+type Provider interface {
+	// Current returns the current configuration. Callers must not modify it.
+	Current() *Config
+}
+
+// ChangeFunc is called with the previous and new configuration after a reload.
+type ChangeFunc func(previous, current *Config)
+
+// Watcher reloads the configuration file when its contents change.
+//
+// Kubernetes updates a mounted ConfigMap by swapping a symlink rather than
+// writing the file in place, so instead of relying on filesystem events the
+// watcher periodically hashes the file and reloads it when the hash changes.
+// A reloaded configuration goes through Load, so it is validated before it
+// replaces the current one; an invalid file is logged and the previous
+// configuration stays in effect until the file changes again.
+type Watcher struct {
+	path     string
+	interval time.Duration
+	current  atomic.Pointer[Config]
+
+	mu        sync.Mutex   // Protects hash and listeners, and serializes reloads
+	hash      [32]byte     // Hash of the file contents last loaded (or rejected)
+	listeners []ChangeFunc // Called after the configuration changes
+
+	ctx    context.Context    // Context for lifecycle management
+	cancel context.CancelFunc // Cancel function for stopping the watcher
+	logger logr.Logger
+}
+
+// NewWatcher creates a watcher for the configuration file at path. cfg is the
+// configuration already loaded from it, which Current returns until a reload.
+func NewWatcher(path string, cfg *Config, interval time.Duration) *Watcher {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := &Watcher{
+		path:     path,
+		interval: interval,
+		ctx:      ctx,
+		cancel:   cancel,
+		logger:   ctrl.Log.WithName("config-watcher"),
+	}
+	w.current.Store(cfg)
+
+	// If the file can't be read now, the first successful read counts as a change
+	if data, err := os.ReadFile(path); err == nil {
+		w.hash = sha256.Sum256(data)
+	}
+	return w
+}
+
+// Current returns the configuration currently in effect.
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// Accounts returns the AWS accounts of the current configuration, so the watcher
+// can supply monitored accounts when account discovery is disabled.
+func (w *Watcher) Accounts() []AWSAccount {
+	return w.Current().AWSAccounts
+}
+
+// OnChange registers a function to call after a reload changes the configuration.
+// Functions are called synchronously from the reload, in registration order.
+func (w *Watcher) OnChange(fn ChangeFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.listeners = append(w.listeners, fn)
+}
+
+// Start begins checking the file for changes on the configured interval.
+// This method is non-blocking.
+func (w *Watcher) Start() {
+	w.logger.Info("Watching configuration file for changes",
+		"path", w.path,
+		"checkInterval", w.interval)
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := w.CheckForChanges(); err != nil {
+					w.logger.Error(err, "Configuration reload failed, keeping previous configuration",
+						"path", w.path)
+				}
+			case <-w.ctx.Done():
+				w.logger.Info("Configuration watcher stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops checking the file for changes.
+func (w *Watcher) Stop() {
+	w.cancel()
+}
+
+// CheckForChanges reloads the configuration if the file changed since it was
+// last read, and reports whether a new configuration took effect. An error means
+// the file changed but couldn't be loaded; the current configuration is unchanged
+// and the same contents aren't retried.
+// This method is exported for testing.
+func (w *Watcher) CheckForChanges() (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read config file %s: %w", w.path, err)
+	}
+	hash := sha256.Sum256(data)
+	if hash == w.hash {
+		return false, nil
+	}
+	w.hash = hash
+
+	cfg, err := Load(w.path)
+	if err != nil {
+		return false, err
+	}
+
+	previous := w.current.Swap(cfg)
+	w.logger.Info("Configuration reloaded",
+		"path", w.path,
+		"accounts", len(cfg.AWSAccounts))
+	for _, fn := range slices.Clone(w.listeners) {
+		fn(previous, cfg)
+	}
+	return true, nil
+}
+
+// restartOnlySettings are read once at startup to create long-lived components
+// (the AWS client, HTTP servers, stores), so a reload doesn't change them.
+var restartOnlySettings = []struct {
+	name  string
+	value func(*Config) any
+}{
+	{"defaultAccount", func(c *Config) any { return c.DefaultAccount }},
+	{"defaultRegion", func(c *Config) any { return c.DefaultRegion }},
+	{"metricsBindAddress", func(c *Config) any { return c.MetricsBindAddress }},
+	{"healthProbeBindAddress", func(c *Config) any { return c.HealthProbeBindAddress }},
+	{"accountValidationInterval", func(c *Config) any { return c.AccountValidationInterval }},
+	{"accountDiscovery.enabled", func(c *Config) any { return c.AccountDiscovery.Enabled }},
+	{"cost.savingsPlanLedger", func(c *Config) any { return c.Cost.SavingsPlanLedger }},
+	{"billing", func(c *Config) any { return c.Billing }},
+	{"history", func(c *Config) any { return c.History }},
+	{"snapshot.path", func(c *Config) any { return c.Snapshot.Path }},
+	{"allocation.enabled", func(c *Config) any { return c.Allocation.Enabled }},
+	{"serverless.fargate.enabled", func(c *Config) any { return c.Serverless.Fargate.Enabled }},
+	{"storage.enabled", func(c *Config) any { return c.Storage.Enabled }},
+	{"karpenter.enabled", func(c *Config) any { return c.Karpenter.Enabled }},
+	{"unitCosts.enabled", func(c *Config) any { return c.UnitCosts.Enabled }},
+	{"forecast.enabled", func(c *Config) any { return c.Forecast.Enabled }},
+	{"agent.hubURL", func(c *Config) any { return c.Agent.HubURL }},
+	{"recommendations", func(c *Config) any { return c.Recommendations }},
+}
+
+// RestartRequired returns the settings that differ between previous and current
+// but only take effect after a restart.
+func RestartRequired(previous, current *Config) []string {
+	var changed []string
+	for _, setting := range restartOnlySettings {
+		if !reflect.DeepEqual(setting.value(previous), setting.value(current)) {
+			changed = append(changed, setting.name)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// watcherTestConfig ends with the account list, so tests can append accounts.
+const watcherTestConfig = `regions: [us-west-2]
+awsAccounts:
+  - accountId: "123456789012"
+    name: "Production"
+    assumeRoleArn: "arn:aws:iam::123456789012:role/lumina"
+`
+
+// writeConfigFile writes a config file, failing the test on error.
+func writeConfigFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+}
+
+// newTestWatcher writes watcherTestConfig to a temporary file and watches it.
+func newTestWatcher(t *testing.T) (*Watcher, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, watcherTestConfig)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	return NewWatcher(path, cfg, time.Minute), path
+}
+
+func TestWatcherReload(t *testing.T) {
+	watcher, path := newTestWatcher(t)
+	initial := watcher.Current()
+
+	var calls int
+	var previous, current *Config
+	watcher.OnChange(func(p, c *Config) {
+		calls++
+		previous, current = p, c
+	})
+
+	// Unchanged contents don't reload
+	changed, err := watcher.CheckForChanges()
+	if err != nil || changed {
+		t.Fatalf("CheckForChanges() = %v, %v; want false, nil", changed, err)
+	}
+
+	writeConfigFile(t, path, watcherTestConfig+`  - accountId: "987654321098"
+    name: "Staging"
+    assumeRoleArn: "arn:aws:iam::987654321098:role/lumina"
+`)
+	changed, err = watcher.CheckForChanges()
+	if err != nil || !changed {
+		t.Fatalf("CheckForChanges() = %v, %v; want true, nil", changed, err)
+	}
+
+	if calls != 1 || previous != initial || current != watcher.Current() {
+		t.Fatalf("expected one notification with the old and new config, got %d", calls)
+	}
+	if got := len(watcher.Accounts()); got != 2 {
+		t.Errorf("expected 2 accounts after reload, got %d", got)
+	}
+	if len(initial.AWSAccounts) != 1 {
+		t.Errorf("reload modified the previous config: %d accounts", len(initial.AWSAccounts))
+	}
+}
+
+func TestWatcherInvalidConfig(t *testing.T) {
+	watcher, path := newTestWatcher(t)
+	initial := watcher.Current()
+
+	var calls int
+	watcher.OnChange(func(_, _ *Config) { calls++ })
+
+	// An account with a malformed ID fails validation
+	writeConfigFile(t, path, `awsAccounts:
+  - accountId: "12345"
+    name: "Production"
+    assumeRoleArn: "arn:aws:iam::123456789012:role/lumina"
+`)
+	if _, err := watcher.CheckForChanges(); err == nil {
+		t.Fatal("expected error for invalid config")
+	}
+	if watcher.Current() != initial || calls != 0 {
+		t.Fatal("invalid config replaced the current config")
+	}
+
+	// The same invalid contents aren't reloaded (or reported) again
+	if changed, err := watcher.CheckForChanges(); changed || err != nil {
+		t.Errorf("CheckForChanges() = %v, %v; want false, nil", changed, err)
+	}
+
+	// Fixing the file applies it
+	writeConfigFile(t, path, "logLevel: debug\n"+watcherTestConfig)
+	if changed, err := watcher.CheckForChanges(); !changed || err != nil {
+		t.Fatalf("CheckForChanges() = %v, %v; want true, nil", changed, err)
+	}
+	if watcher.Current().LogLevel != "debug" || calls != 1 {
+		t.Errorf("fixed config not applied: logLevel=%q calls=%d", watcher.Current().LogLevel, calls)
+	}
+}
+
+func TestWatcherMissingFile(t *testing.T) {
+	watcher, path := newTestWatcher(t)
+	initial := watcher.Current()
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("failed to remove config file: %v", err)
+	}
+	if _, err := watcher.CheckForChanges(); err == nil {
+		t.Error("expected error for missing config file")
+	}
+	if watcher.Current() != initial {
+		t.Error("missing file replaced the current config")
+	}
+}
+
+func TestRestartRequired(t *testing.T) {
+	previous := &Config{
+		DefaultRegion: "us-west-2",
+		Regions:       []string{"us-west-2"},
+		History:       HistoryConfig{Path: "/data/history"},
+	}
+
+	current := *previous
+	current.Regions = []string{"us-west-2", "us-east-1"}
+	current.Reconciliation.EC2 = "1m"
+	if got := RestartRequired(previous, &current); len(got) != 0 {
+		t.Errorf("RestartRequired() = %v, want none for reloadable settings", got)
+	}
+
+	current.DefaultRegion = "eu-west-1"
+	current.History.RetentionDays = 7
+	want := []string{"defaultRegion", "history"}
+	if got := RestartRequired(previous, &current); !slices.Equal(got, want) {
+		t.Errorf("RestartRequired() = %v, want %v", got, want)
+	}
+}
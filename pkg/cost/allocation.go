@@ -0,0 +1,134 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+// IdleNamespace is the namespace name used to report node capacity that no pod
+// requested. It can't collide with a real namespace because Kubernetes namespace
+// names can't contain underscores.
+const IdleNamespace = "__idle__"
+
+// PodRequests describes the resources a pod requested on its node.
+type PodRequests struct {
+	// Namespace and Name identify the pod
+	Namespace string
+	Name      string
+
+	// CPUCores is the pod's total CPU request in cores (e.g., 0.5 for "500m")
+	CPUCores float64
+
+	// MemoryBytes is the pod's total memory request in bytes
+	MemoryBytes float64
+}
+
+// NodeCapacity describes the resources a node offers to pods (its allocatable
+// capacity, not its raw capacity).
+type NodeCapacity struct {
+	CPUCores    float64
+	MemoryBytes float64
+}
+
+// PodCost is a pod's share of its node's hourly cost.
+type PodCost struct {
+	Namespace  string
+	Name       string
+	HourlyCost float64 // $/hour
+}
+
+// NodeAllocation is one node's hourly cost split among its pods.
+// The pod costs and IdleCost always add up to the node's hourly cost.
+type NodeAllocation struct {
+	// NodeName and InstanceID identify the node
+	NodeName   string
+	InstanceID string
+
+	// Pods holds each pod's share, in the order the pods were given
+	Pods []PodCost
+
+	// IdleCost is the share of capacity no pod requested ($/hour)
+	IdleCost float64
+}
+
+// AllocateNodeCost divides a node's hourly cost among the pods scheduled on it.
+//
+// The cost is first split into a CPU share (cpuWeight) and a memory share
+// (1 - cpuWeight). Each share is priced per unit of the node's allocatable capacity,
+// and each pod pays for the units it requested. Whatever is left over is idle.
+//
+// Example: a $1.00/hour node with 4 cores and 16 GiB, cpuWeight 0.5, running a pod
+// that requests 1 core and 8 GiB. The pod pays 0.50 × 1/4 + 0.50 × 8/16 = $0.375/hour
+// and $0.625/hour is idle.
+//
+// If the pods request more than the node's allocatable capacity (e.g., the capacity
+// isn't known), the requests are used as the capacity instead, so the pods never pay
+// more than the node costs. Pods without requests (BestEffort) are allocated nothing.
+func AllocateNodeCost(
+	nodeName, instanceID string,
+	hourlyCost float64,
+	capacity NodeCapacity,
+	pods []PodRequests,
+	cpuWeight float64,
+) NodeAllocation {
+	var requestedCPU, requestedMemory float64
+	for _, pod := range pods {
+		requestedCPU += pod.CPUCores
+		requestedMemory += pod.MemoryBytes
+	}
+
+	cpuPrice := unitPrice(hourlyCost*cpuWeight, max(capacity.CPUCores, requestedCPU))
+	memoryPrice := unitPrice(hourlyCost*(1-cpuWeight), max(capacity.MemoryBytes, requestedMemory))
+
+	allocation := NodeAllocation{
+		NodeName:   nodeName,
+		InstanceID: instanceID,
+		Pods:       make([]PodCost, 0, len(pods)),
+		IdleCost:   hourlyCost,
+	}
+	for _, pod := range pods {
+		podCost := pod.CPUCores*cpuPrice + pod.MemoryBytes*memoryPrice
+		allocation.Pods = append(allocation.Pods, PodCost{
+			Namespace:  pod.Namespace,
+			Name:       pod.Name,
+			HourlyCost: podCost,
+		})
+		allocation.IdleCost -= podCost
+	}
+
+	// Guard against rounding leaving a tiny negative idle cost
+	if allocation.IdleCost < 0 {
+		allocation.IdleCost = 0
+	}
+	return allocation
+}
+
+// NamespaceCosts sums pod costs by namespace across all nodes. Idle capacity is
+// reported under IdleNamespace.
+func NamespaceCosts(allocations []NodeAllocation) map[string]float64 {
+	costs := make(map[string]float64)
+	for _, allocation := range allocations {
+		for _, pod := range allocation.Pods {
+			costs[pod.Namespace] += pod.HourlyCost
+		}
+		costs[IdleNamespace] += allocation.IdleCost
+	}
+	return costs
+}
+
+// unitPrice returns the cost of one unit of a resource, or 0 if there are no units.
+func unitPrice(cost, units float64) float64 {
+	if units <= 0 {
+		return 0
+	}
+	return cost / units
+}
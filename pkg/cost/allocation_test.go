@@ -0,0 +1,131 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gib = 1024 * 1024 * 1024
+
+// TestAllocateNodeCost tests splitting a node's cost by CPU and memory requests.
+func TestAllocateNodeCost(t *testing.T) {
+	capacity := NodeCapacity{CPUCores: 4, MemoryBytes: 16 * gib}
+	pods := []PodRequests{
+		{Namespace: "web", Name: "frontend", CPUCores: 1, MemoryBytes: 8 * gib},
+		{Namespace: "batch", Name: "worker", CPUCores: 2, MemoryBytes: 0},
+		{Namespace: "web", Name: "besteffort"},
+	}
+
+	tests := []struct {
+		name      string
+		cpuWeight float64
+		want      []float64 // per-pod cost, in order
+		wantIdle  float64
+	}{
+		{
+			// CPU share $0.50 = $0.125/core, memory share $0.50 = $0.03125/GiB
+			name:      "even split",
+			cpuWeight: 0.5,
+			want:      []float64{0.375, 0.25, 0},
+			wantIdle:  0.375,
+		},
+		{
+			name:      "cpu only",
+			cpuWeight: 1,
+			want:      []float64{0.25, 0.50, 0},
+			wantIdle:  0.25,
+		},
+		{
+			name:      "memory only",
+			cpuWeight: 0,
+			want:      []float64{0.50, 0, 0},
+			wantIdle:  0.50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocation := AllocateNodeCost("node-1", "i-001", 1.00, capacity, pods, tt.cpuWeight)
+
+			assert.Equal(t, "node-1", allocation.NodeName)
+			assert.Equal(t, "i-001", allocation.InstanceID)
+			require.Len(t, allocation.Pods, len(pods))
+			total := allocation.IdleCost
+			for i, pod := range allocation.Pods {
+				assert.Equal(t, pods[i].Name, pod.Name)
+				assert.InDelta(t, tt.want[i], pod.HourlyCost, 0.0001, "pod %s", pod.Name)
+				total += pod.HourlyCost
+			}
+			assert.InDelta(t, tt.wantIdle, allocation.IdleCost, 0.0001)
+			assert.InDelta(t, 1.00, total, 0.0001, "pods and idle should add up to the node cost")
+		})
+	}
+}
+
+// TestAllocateNodeCostOverCommitted tests that requests beyond the node's capacity
+// are scaled down so the pods never pay more than the node costs.
+func TestAllocateNodeCostOverCommitted(t *testing.T) {
+	pods := []PodRequests{
+		{Namespace: "a", Name: "pod-1", CPUCores: 3, MemoryBytes: 4 * gib},
+		{Namespace: "b", Name: "pod-2", CPUCores: 3, MemoryBytes: 4 * gib},
+	}
+
+	// Capacity unknown (e.g., node status not populated yet)
+	allocation := AllocateNodeCost("node-1", "i-001", 2.00, NodeCapacity{}, pods, 0.5)
+
+	assert.InDelta(t, 1.00, allocation.Pods[0].HourlyCost, 0.0001)
+	assert.InDelta(t, 1.00, allocation.Pods[1].HourlyCost, 0.0001)
+	assert.Equal(t, 0.0, allocation.IdleCost)
+}
+
+// TestAllocateNodeCostEmptyNode tests that a node without pods is entirely idle.
+func TestAllocateNodeCostEmptyNode(t *testing.T) {
+	allocation := AllocateNodeCost("node-1", "i-001", 0.80, NodeCapacity{CPUCores: 2, MemoryBytes: 8 * gib}, nil, 0.5)
+
+	assert.Empty(t, allocation.Pods)
+	assert.InDelta(t, 0.80, allocation.IdleCost, 0.0001)
+}
+
+// TestNamespaceCosts tests summing allocations by namespace.
+func TestNamespaceCosts(t *testing.T) {
+	allocations := []NodeAllocation{
+		{
+			NodeName: "node-1",
+			Pods: []PodCost{
+				{Namespace: "web", Name: "frontend-1", HourlyCost: 0.30},
+				{Namespace: "batch", Name: "worker-1", HourlyCost: 0.20},
+			},
+			IdleCost: 0.50,
+		},
+		{
+			NodeName: "node-2",
+			Pods: []PodCost{
+				{Namespace: "web", Name: "frontend-2", HourlyCost: 0.40},
+			},
+			IdleCost: 0.10,
+		},
+	}
+
+	costs := NamespaceCosts(allocations)
+
+	assert.Len(t, costs, 3)
+	assert.InDelta(t, 0.70, costs["web"], 0.0001)
+	assert.InDelta(t, 0.20, costs["batch"], 0.0001)
+	assert.InDelta(t, 0.60, costs[IdleNamespace], 0.0001)
+}
@@ -15,6 +15,8 @@
 package cost
 
 import (
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -78,12 +80,13 @@ func NewCalculator(pricingCache PricingCacheReader, config ConfigReader) *Calcul
 //
 // Algorithm steps:
 //  1. Initialize all instances with shelf prices (on-demand rates)
-//  2. Apply Reserved Instances (RIs) - exact instance type + AZ matches
+//  2. Apply Reserved Instances (RIs) - exact type + AZ, or family + region for size-flexible RIs
 //  3. Apply EC2 Instance Savings Plans - specific family + region
-//  4. Apply Compute Savings Plans - any family, any region
+//  4. Apply Compute Savings Plans - any family, any region, plus Fargate and Lambda usage
 //  5. Calculate remaining on-demand costs
 //  6. Calculate Savings Plans utilization metrics
-//  7. Calculate aggregate costs and savings
+//  7. Attribute EBS volume costs to instances (separate from compute cost)
+//  8. Calculate aggregate costs and savings
 //
 // The function returns a complete CalculationResult with per-instance costs
 // and SP utilization metrics.
@@ -91,38 +94,68 @@ func NewCalculator(pricingCache PricingCacheReader, config ConfigReader) *Calcul
 // Reference: AWS Savings Plans documentation
 // https://docs.aws.amazon.com/savingsplans/latest/userguide/sp-applying.html
 func (c *Calculator) Calculate(input CalculationInput) CalculationResult {
+	return c.calculate(input, nil)
+}
+
+// calculate implements Calculate, reporting allocation decisions to trace (see Explain).
+func (c *Calculator) calculate(input CalculationInput, trace *explainer) CalculationResult {
+	if input.Now.IsZero() {
+		input.Now = time.Now()
+	}
+
 	// Initialize result structure
 	result := CalculationResult{
-		InstanceCosts:          make(map[string]InstanceCost),
-		SavingsPlanUtilization: make(map[string]SavingsPlanUtilization),
-		CalculatedAt:           time.Now(),
+		InstanceCosts:                  make(map[string]InstanceCost),
+		SavingsPlanUtilization:         make(map[string]SavingsPlanUtilization),
+		ReservedInstanceUtilization:    make(map[string]ReservedInstanceUtilization),
+		ServerlessCosts:                make(map[string]ServerlessCost),
+		CalculatedAt:                   input.Now,
+		CapacityReservationUtilization: make(map[string]CapacityReservationUtilization),
 	}
 
 	// Step 1: Initialize cost objects for all instances with shelf prices
 	// Convert map to use pointers for efficient updates
 	costsPtrs := make(map[string]*InstanceCost)
 	c.initializeInstanceCosts(input, costsPtrs)
+	serverlessPtrs := make(map[string]*ServerlessCost)
+	c.initializeServerlessCosts(input, serverlessPtrs)
 
-	// Step 2: Initialize SP utilization tracking
+	// Step 2: Initialize SP and RI utilization tracking
 	spUtilPtrs := make(map[string]*SavingsPlanUtilization)
 	c.initializeSPUtilization(input, spUtilPtrs)
+	riUtilPtrs := make(map[string]*ReservedInstanceUtilization)
+	c.initializeRIUtilization(input, riUtilPtrs)
 
 	// Step 3: Apply Reserved Instances (highest priority)
-	// RIs apply before any Savings Plans
-	applyReservedInstances(input.Instances, input.ReservedInstances, costsPtrs)
+	// RIs apply before any Savings Plans. RIs and SPs both cover their owner
+	// account's usage first, then other accounts' that share discounts.
+	sharing := discountSharing(input.SharingDisabledAccounts)
+	applyReservedInstances(input.Instances, input.ReservedInstances, costsPtrs, riUtilPtrs, sharing, trace)
+	c.calculateRIUtilization(input, riUtilPtrs)
+
+	// Step 3.5: Value unused On-Demand Capacity Reservations. Reservations don't
+	// change instance costs, so this doesn't affect the steps that follow.
+	calculateCapacityReservationUtilization(input, result.CapacityReservationUtilization)
 
 	// Step 4: Apply Savings Plans
 	// This handles both EC2 Instance SPs and Compute SPs in priority order
-	applySavingsPlans(c, input.Instances, input.SavingsPlans, costsPtrs, spUtilPtrs)
+	applySavingsPlans(c, input.Instances, input.SavingsPlans, costsPtrs, serverlessPtrs, spUtilPtrs, sharing,
+		input.SavingsPlanAllocation, trace)
 
-	// Step 4.5: Validate Savings Plans math invariants
-	// This runtime check ensures the algorithm calculated costs correctly
-	c.validateSavingsPlansInvariants(input.SavingsPlans, costsPtrs, spUtilPtrs)
+	// Step 4.5: Audit Savings Plans math invariants
+	// This runtime check records any place the algorithm calculated costs incorrectly
+	result.InvariantViolations = c.validateSavingsPlansInvariants(input.SavingsPlans, costsPtrs, serverlessPtrs,
+		spUtilPtrs)
 
 	// Step 5: Apply spot pricing for spot instances
 	// Spot instances use current market rates, not on-demand rates
 	c.applySpotPricing(input, costsPtrs)
 
+	// Step 5.5: Attribute EBS volume costs to the instances they're attached to.
+	// Storage isn't discounted by RIs or Savings Plans.
+	applyStorageCosts(input, costsPtrs)
+	result.IncludesStorage = input.Volumes != nil
+
 	// Step 6: Convert pointer maps back to value maps for result
 	for id, costPtr := range costsPtrs {
 		result.InstanceCosts[id] = *costPtr
@@ -130,6 +163,12 @@ func (c *Calculator) Calculate(input CalculationInput) CalculationResult {
 	for arn, utilPtr := range spUtilPtrs {
 		result.SavingsPlanUtilization[arn] = *utilPtr
 	}
+	for id, utilPtr := range riUtilPtrs {
+		result.ReservedInstanceUtilization[id] = *utilPtr
+	}
+	for id, costPtr := range serverlessPtrs {
+		result.ServerlessCosts[id] = *costPtr
+	}
 
 	// Step 7: Calculate aggregate metrics
 	c.calculateAggregates(&result)
@@ -147,9 +186,11 @@ func (c *Calculator) Calculate(input CalculationInput) CalculationResult {
 //   - All coverage amounts = 0 (will be set by RI/SP application)
 func (c *Calculator) initializeInstanceCosts(input CalculationInput, costs map[string]*InstanceCost) {
 	for _, inst := range input.Instances {
-		// Look up on-demand price for this instance type + region
-		priceKey := inst.InstanceType + ":" + inst.Region
-		shelfPrice := input.OnDemandPrices[priceKey]
+		// Look up on-demand price for this instance type + region + OS + tenancy
+		// Windows and licensed Linux (RHEL, SUSE) are priced higher than plain Linux,
+		// so the OS must match the one used for SP rate and spot price lookups.
+		// Dedicated instances carry a premium over shared tenancy.
+		shelfPrice, accuracy := lookupShelfPrice(input.OnDemandPrices, inst)
 
 		if shelfPrice <= 0 {
 			// If we don't have pricing data, skip this instance
@@ -160,35 +201,61 @@ func (c *Calculator) initializeInstanceCosts(input CalculationInput, costs map[s
 		// Initialize cost object with shelf price
 		// EffectiveCost starts at shelf price and will be reduced by discounts
 		costs[inst.InstanceID] = &InstanceCost{
-			InstanceID:          inst.InstanceID,
-			InstanceType:        inst.InstanceType,
-			Region:              inst.Region,
-			AccountID:           inst.AccountID,
-			AccountName:         inst.AccountName,
-			AvailabilityZone:    inst.AvailabilityZone,
-			ShelfPrice:          shelfPrice,
-			EffectiveCost:       shelfPrice,       // Will be reduced by RIs/SPs
-			CoverageType:        CoverageOnDemand, // May change to RI/SP
-			PricingAccuracy:     PricingAccurate,  // On-demand pricing from AWS Pricing API is accurate
-			RICoverage:          0,
-			SavingsPlanCoverage: 0,
-			SavingsPlanARN:      "",
-			OnDemandCost:        shelfPrice, // Will be reduced as coverage applied
-			SpotPrice:           0,
-			IsSpot:              inst.State == "running" && inst.Lifecycle == lifecycleSpot,
-			Lifecycle:           inst.Lifecycle, // Capture lifecycle for metrics
+			InstanceID:            inst.InstanceID,
+			InstanceType:          inst.InstanceType,
+			Region:                inst.Region,
+			AccountID:             inst.AccountID,
+			AccountName:           inst.AccountName,
+			AvailabilityZone:      inst.AvailabilityZone,
+			ShelfPrice:            shelfPrice,
+			EffectiveCost:         shelfPrice,       // Will be reduced by RIs/SPs
+			CoverageType:          CoverageOnDemand, // May change to RI/SP
+			PricingAccuracy:       accuracy,         // Estimated if the OS/tenancy price was missing
+			RICoverage:            0,
+			SavingsPlanCoverage:   0,
+			OnDemandCost:          shelfPrice, // Will be reduced as coverage applied
+			SpotPrice:             0,
+			IsSpot:                inst.State == "running" && inst.Lifecycle == lifecycleSpot,
+			Lifecycle:             inst.Lifecycle,             // Capture lifecycle for metrics
+			CapacityReservationID: inst.CapacityReservationID, // Marker only, doesn't change the cost
+		}
+	}
+}
+
+// initializeServerlessCosts creates initial cost objects for Fargate and Lambda
+// usage at on-demand rates. Usage without a cost is skipped.
+func (c *Calculator) initializeServerlessCosts(input CalculationInput, costs map[string]*ServerlessCost) {
+	for _, usage := range input.ServerlessUsage {
+		if usage.OnDemandCost <= 0 {
+			continue
+		}
+		costs[usage.ID] = &ServerlessCost{
+			ID:              usage.ID,
+			Service:         usage.Service,
+			AccountID:       usage.AccountID,
+			Region:          usage.Region,
+			ShelfPrice:      usage.OnDemandCost,
+			SavingsPlanRate: usage.SavingsPlanRate,
+			EffectiveCost:   usage.OnDemandCost,
+			CoverageType:    CoverageOnDemand,
 		}
 	}
 }
 
-// initializeSPUtilization creates initial utilization tracking objects for all
-// Savings Plans. These will be updated as the algorithm applies SP coverage to instances.
+// initializeSPUtilization creates initial utilization tracking objects for all EC2
+// Instance and Compute Savings Plans. These will be updated as the algorithm applies
+// SP coverage to instances. Other types (e.g., SageMaker) never cover instances, so
+// tracking them would show them as unused.
 func (c *Calculator) initializeSPUtilization(input CalculationInput, utilization map[string]*SavingsPlanUtilization) {
 	for _, sp := range input.SavingsPlans {
+		if !sp.AppliesToEC2() {
+			continue
+		}
+
 		// Calculate hours remaining until SP expires
 		remainingHours := 0.0
 		if !sp.End.IsZero() {
-			remainingHours = time.Until(sp.End).Hours()
+			remainingHours = sp.End.Sub(input.Now).Hours()
 			if remainingHours < 0 {
 				remainingHours = 0 // SP already expired
 			}
@@ -212,6 +279,126 @@ func (c *Calculator) initializeSPUtilization(input CalculationInput, utilization
 	}
 }
 
+// initializeRIUtilization creates initial utilization tracking objects for all
+// Reserved Instances. UtilizedInstances is filled in as RIs are applied.
+func (c *Calculator) initializeRIUtilization(input CalculationInput, utilization map[string]*ReservedInstanceUtilization) {
+	for _, ri := range input.ReservedInstances {
+		utilization[ri.ReservedInstanceID] = &ReservedInstanceUtilization{
+			ReservedInstanceID: ri.ReservedInstanceID,
+			AccountID:          ri.AccountID,
+			AccountName:        ri.AccountName,
+			InstanceType:       ri.InstanceType,
+			Region:             ri.Region,
+			AvailabilityZone:   ri.AvailabilityZone,
+			InstanceCount:      ri.InstanceCount,
+		}
+	}
+}
+
+// calculateRIUtilization sets each Reserved Instance's utilization percentage and
+// the on-demand value of its unused instances, once all RIs have been applied.
+//
+// The unused value uses the shelf price of the RI's own instance type, OS and
+// tenancy (falling back like lookupShelfPrice), since an unused RI has no
+// instance to take a price from.
+func (c *Calculator) calculateRIUtilization(input CalculationInput, utilization map[string]*ReservedInstanceUtilization) {
+	for _, ri := range input.ReservedInstances {
+		util := utilization[ri.ReservedInstanceID]
+		if util == nil || util.InstanceCount <= 0 {
+			continue
+		}
+
+		util.UtilizationPercent = util.UtilizedInstances / float64(util.InstanceCount) * 100
+
+		unused := float64(util.InstanceCount) - util.UtilizedInstances
+		if unused <= riUnitEpsilon {
+			util.UnusedHourlyCost = 0
+			continue
+		}
+		shelfPrice, _ := lookupShelfPrice(input.OnDemandPrices, aws.Instance{
+			InstanceType: ri.InstanceType,
+			Region:       ri.Region,
+			Platform:     riOperatingSystem(ri.Platform),
+			Tenancy:      ri.Tenancy,
+		})
+		util.UnusedHourlyCost = unused * shelfPrice
+	}
+}
+
+// lookupShelfPrice returns the on-demand price for an instance and whether it is
+// accurate. The instance's own OS and tenancy price is preferred. When it isn't
+// loaded (e.g., RHEL not in pricing.operatingSystems, or a dedicated instance that
+// appeared after the last pricing load), the shared tenancy price and then the
+// Linux price are used instead and reported as estimated, since both under-estimate
+// the real shelf price.
+//
+// Returns 0 if no usable price was found.
+func lookupShelfPrice(prices map[string]float64, inst aws.Instance) (float64, PricingAccuracy) {
+	operatingSystem := PricingOperatingSystem(inst.Platform)
+	tenancy := PricingTenancy(inst.Tenancy)
+
+	accuracy := PricingAccurate // On-demand pricing from AWS Pricing API is accurate
+	for _, os := range uniqueStrings(operatingSystem, aws.PlatformLinux) {
+		for _, t := range uniqueStrings(tenancy, aws.TenancyDefault) {
+			if price := prices[OnDemandPriceKey(inst.InstanceType, inst.Region, os, t)]; price > 0 {
+				return price, accuracy
+			}
+			accuracy = PricingEstimated
+		}
+	}
+	return 0, accuracy
+}
+
+// uniqueStrings returns the given values with duplicates removed, preserving order.
+func uniqueStrings(values ...string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !slices.Contains(result, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// OnDemandPriceKey builds the CalculationInput.OnDemandPrices key for an instance
+// type, region, operating system (as returned by PricingOperatingSystem), and
+// tenancy. Shared ("default") tenancy prices have no tenancy segment.
+//
+// Examples:
+//   - OnDemandPriceKey("m5.xlarge", "us-west-2", "linux", "default")   → "m5.xlarge:us-west-2:linux"
+//   - OnDemandPriceKey("m5.xlarge", "us-west-2", "linux", "dedicated") → "m5.xlarge:us-west-2:linux:dedicated"
+func OnDemandPriceKey(instanceType, region, operatingSystem, tenancy string) string {
+	key := instanceType + ":" + region + ":" + operatingSystem
+	if t := PricingTenancy(tenancy); t != aws.TenancyDefault {
+		key += ":" + t
+	}
+	return strings.ToLower(key)
+}
+
+// PricingTenancy converts an EC2 Tenancy value to the tenancy used for on-demand
+// pricing lookups ("default", "dedicated", or "host"). Empty values and the
+// Savings Plans "shared" spelling are treated as "default".
+func PricingTenancy(tenancy string) string {
+	normalized := strings.ToLower(strings.TrimSpace(tenancy))
+	if normalized == "" || normalized == aws.TenancyShared {
+		return aws.TenancyDefault
+	}
+	return normalized
+}
+
+// PricingOperatingSystem converts an EC2 Platform value to the operating system
+// used for on-demand pricing lookups. The result matches the lowercased AWS Pricing
+// API "operatingSystem" values ("linux", "windows", "rhel", "suse").
+//
+// Empty Platform values are treated as Linux (EC2 omits the platform for Linux).
+func PricingOperatingSystem(platform string) string {
+	normalized := strings.ToLower(strings.TrimSpace(platform))
+	if normalized == "" {
+		return aws.PlatformLinux
+	}
+	return normalized
+}
+
 // platformToProductDescription converts an EC2 Platform value to an AWS ProductDescription.
 // This matches the behavior in spot_pricing_reconciler.go for consistency.
 func platformToProductDescription(platform string) string {
@@ -267,9 +454,11 @@ func (c *Calculator) applySpotPricing(input CalculationInput, costs map[string]*
 		cost.EffectiveCost = spotPrice
 		cost.SpotPrice = spotPrice
 		cost.CoverageType = CoverageSpot
-		cost.OnDemandCost = 0        // Not paying on-demand rate
-		cost.RICoverage = 0          // Reset any incorrectly applied RI coverage
-		cost.SavingsPlanCoverage = 0 // Reset any incorrectly applied SP coverage
+		cost.OnDemandCost = 0               // Not paying on-demand rate
+		cost.RICoverage = 0                 // Reset any incorrectly applied RI coverage
+		cost.RIContributions = nil          // Reset any incorrectly applied RI contributions
+		cost.SavingsPlanCoverage = 0        // Reset any incorrectly applied SP coverage
+		cost.SavingsPlanContributions = nil // Reset any incorrectly applied SP contributions
 
 		// Set pricing accuracy based on whether we have actual spot pricing data
 		if spotPrice > 0 {
@@ -288,15 +477,18 @@ func (c *Calculator) applySpotPricing(input CalculationInput, costs map[string]*
 func (c *Calculator) calculateAggregates(result *CalculationResult) {
 	totalEstimatedCost := 0.0
 	totalShelfPrice := 0.0
+	totalStorageCost := 0.0
 
 	for _, cost := range result.InstanceCosts {
 		totalEstimatedCost += cost.EffectiveCost
 		totalShelfPrice += cost.ShelfPrice
+		totalStorageCost += cost.StorageCost
 	}
 
 	result.TotalEstimatedCost = totalEstimatedCost
 	result.TotalShelfPrice = totalShelfPrice
 	result.TotalSavings = totalShelfPrice - totalEstimatedCost
+	result.TotalStorageCost = totalStorageCost
 }
 
 // validateSavingsPlansInvariants performs runtime validation of critical math invariants
@@ -316,21 +508,33 @@ func (c *Calculator) calculateAggregates(result *CalculationResult) {
 //  3. Coverage Bounds:
 //     Total coverage (RI + SP) must not exceed shelf price for any instance.
 //
-// This function panics if any invariant is violated, since that indicates a critical
-// bug in the cost calculation logic that should never happen in production.
+//  4. Contribution Sums:
+//     Each instance's SP contributions add up to its SP coverage, and each Savings
+//     Plan's contributions (plus the serverless usage it covers) add up to its
+//     current utilization.
+//
+// Every violation is returned, Savings Plans in input order and then instances by ID.
+// A violation indicates a bug in the cost calculation logic or inconsistent input
+// (e.g., the same instance listed twice by overlapping account configurations).
 //
 // Design rationale:
-//   - Fail fast: Better to panic than emit bad metrics to production dashboards
+//   - Audit, don't crash: the caller decides what to do with a result that has
+//     violations (the CostReconciler keeps publishing the last good result), so
+//     bad input can't take down the controller
 //   - Defensive programming: Validates assumptions that "should always be true"
 //   - Debugging aid: Makes it obvious when algorithm changes break invariants
 //   - No performance impact: Only runs once per reconciliation loop (~1 minute)
 func (c *Calculator) validateSavingsPlansInvariants(
 	savingsPlans []aws.SavingsPlan,
 	costs map[string]*InstanceCost,
+	serverless map[string]*ServerlessCost,
 	utilization map[string]*SavingsPlanUtilization,
-) {
+) []InvariantViolation {
 	const epsilon = 1e-6 // Tolerance for floating-point comparison
 
+	var violations []InvariantViolation
+	instanceIDs := slices.Sorted(maps.Keys(costs))
+
 	// INVARIANT 1: Validate SP commitment balance for each Savings Plan
 	//
 	// The math MUST always work out to:
@@ -349,7 +553,7 @@ func (c *Calculator) validateSavingsPlansInvariants(
 	for _, sp := range savingsPlans {
 		util, exists := utilization[sp.SavingsPlanARN]
 		if !exists {
-			// SP wasn't tracked - should never happen, but don't panic in production
+			// SP wasn't tracked (not an EC2 Instance or Compute SP)
 			continue
 		}
 
@@ -371,12 +575,13 @@ func (c *Calculator) validateSavingsPlansInvariants(
 			//   Current Utilization: $152/hour (bug: over-allocated!)
 			//   Remaining Capacity: $0
 			//   calculatedCommitment = $152 + $0 = $152 ≠ $150
-			panic(invariantViolation{
-				description: "Savings Plan commitment balance violation",
-				spARN:       sp.SavingsPlanARN,
-				expected:    sp.Commitment,
-				actual:      calculatedCommitment,
-				details: map[string]interface{}{
+			violations = append(violations, InvariantViolation{
+				Invariant:      InvariantCommitmentBalance,
+				Description:    "Savings Plan commitment balance violation",
+				SavingsPlanARN: sp.SavingsPlanARN,
+				Expected:       sp.Commitment,
+				Actual:         calculatedCommitment,
+				Details: map[string]interface{}{
 					"utilization": util.CurrentUtilizationRate,
 					"remaining":   util.RemainingCapacity,
 				},
@@ -390,7 +595,8 @@ func (c *Calculator) validateSavingsPlansInvariants(
 	// instances with RI coverage going negative when Compute SP tried to apply.
 	//
 	// If this happens, it means the coverage limiting logic failed.
-	for instanceID, cost := range costs {
+	for _, instanceID := range instanceIDs {
+		cost := costs[instanceID]
 		if cost.EffectiveCost < -epsilon {
 			// CRITICAL BUG: Instance has negative cost!
 			//
@@ -401,19 +607,20 @@ func (c *Calculator) validateSavingsPlansInvariants(
 			//   3. Coverage limiting fails: EffectiveCost = $0 - $0.13 = -$0.13
 			//
 			// This should be impossible after our fix, but if it happens, we want
-			// to know immediately (hence the panic).
-			panic(invariantViolation{
-				description: "Instance has negative effective cost",
-				spARN:       cost.SavingsPlanARN,
-				expected:    0.0,
-				actual:      cost.EffectiveCost,
-				details: map[string]interface{}{
-					"instance_id":           instanceID,
-					"instance_type":         cost.InstanceType,
-					"shelf_price":           cost.ShelfPrice,
-					"ri_coverage":           cost.RICoverage,
-					"savings_plan_coverage": cost.SavingsPlanCoverage,
-					"coverage_type":         cost.CoverageType,
+			// to know immediately.
+			violations = append(violations, InvariantViolation{
+				Invariant:   InvariantNonNegativeCost,
+				Description: "Instance has negative effective cost",
+				InstanceID:  instanceID,
+				Expected:    0.0,
+				Actual:      cost.EffectiveCost,
+				Details: map[string]interface{}{
+					"instance_type":              cost.InstanceType,
+					"shelf_price":                cost.ShelfPrice,
+					"ri_coverage":                cost.RICoverage,
+					"savings_plan_coverage":      cost.SavingsPlanCoverage,
+					"savings_plan_contributions": cost.SavingsPlanContributions,
+					"coverage_type":              cost.CoverageType,
 				},
 			})
 		}
@@ -429,7 +636,8 @@ func (c *Calculator) validateSavingsPlansInvariants(
 	//   RI coverage: $0.100/hour
 	//   SP coverage: $0.150/hour
 	//   Total: $0.250/hour > $0.192/hour ← BUG!
-	for instanceID, cost := range costs {
+	for _, instanceID := range instanceIDs {
+		cost := costs[instanceID]
 		totalCoverage := cost.RICoverage + cost.SavingsPlanCoverage
 
 		// Allow small floating-point error
@@ -442,38 +650,76 @@ func (c *Calculator) validateSavingsPlansInvariants(
 			//   - RI allocation (applyReservedInstances)
 			//   - SP allocation (applySavingsPlans)
 			//   - Coverage limiting (the fix we added)
-			panic(invariantViolation{
-				description: "Total coverage exceeds shelf price",
-				spARN:       cost.SavingsPlanARN,
-				expected:    cost.ShelfPrice,
-				actual:      totalCoverage,
-				details: map[string]interface{}{
-					"instance_id":           instanceID,
-					"instance_type":         cost.InstanceType,
-					"shelf_price":           cost.ShelfPrice,
-					"ri_coverage":           cost.RICoverage,
-					"savings_plan_coverage": cost.SavingsPlanCoverage,
-					"effective_cost":        cost.EffectiveCost,
+			violations = append(violations, InvariantViolation{
+				Invariant:   InvariantCoverageBounds,
+				Description: "Total coverage exceeds shelf price",
+				InstanceID:  instanceID,
+				Expected:    cost.ShelfPrice,
+				Actual:      totalCoverage,
+				Details: map[string]interface{}{
+					"instance_type":              cost.InstanceType,
+					"shelf_price":                cost.ShelfPrice,
+					"ri_coverage":                cost.RICoverage,
+					"savings_plan_coverage":      cost.SavingsPlanCoverage,
+					"savings_plan_contributions": cost.SavingsPlanContributions,
+					"effective_cost":             cost.EffectiveCost,
 				},
 			})
 		}
 	}
-}
 
-// invariantViolation represents a critical math invariant violation in the cost
-// calculation algorithm. This should NEVER happen in production.
-//
-// When this occurs, it indicates a bug in the algorithm logic (not bad input data).
-// The panic includes detailed context to help debug what went wrong.
-type invariantViolation struct {
-	description string                 // Human-readable description of what failed
-	spARN       string                 // Savings Plan ARN (if relevant)
-	expected    float64                // Expected value
-	actual      float64                // Actual value
-	details     map[string]interface{} // Additional debugging context
-}
+	// INVARIANT 4: Validate contribution sums
+	//
+	// An instance can draw from several Savings Plans (split coverage), so the
+	// coverage recorded on each side must agree: an instance's contributions add up
+	// to its SavingsPlanCoverage, and the contributions charged to a plan add up to
+	// its CurrentUtilizationRate.
+	//
+	// Example violation:
+	//   Instance contributions: SP-A $0.03 + SP-B $0.02 = $0.05/hour
+	//   Instance SavingsPlanCoverage: $0.04/hour ← BUG!
+	planCoverage := make(map[string]float64)
+	for _, instanceID := range instanceIDs {
+		cost := costs[instanceID]
+		var contributed float64
+		for _, contribution := range cost.SavingsPlanContributions {
+			contributed += contribution.Coverage
+			planCoverage[contribution.SavingsPlanARN] += contribution.Coverage
+		}
+		if diff := contributed - cost.SavingsPlanCoverage; diff > epsilon || diff < -epsilon {
+			violations = append(violations, InvariantViolation{
+				Invariant:   InvariantContributionSum,
+				Description: "Instance Savings Plan contributions don't sum to its coverage",
+				InstanceID:  instanceID,
+				Expected:    cost.SavingsPlanCoverage,
+				Actual:      contributed,
+				Details: map[string]interface{}{
+					"savings_plan_contributions": cost.SavingsPlanContributions,
+				},
+			})
+		}
+	}
+	for _, usage := range serverless {
+		for _, contribution := range usage.SavingsPlanContributions {
+			planCoverage[contribution.SavingsPlanARN] += contribution.Coverage
+		}
+	}
+	for _, sp := range savingsPlans {
+		util, exists := utilization[sp.SavingsPlanARN]
+		if !exists {
+			continue
+		}
+		covered := planCoverage[sp.SavingsPlanARN]
+		if diff := covered - util.CurrentUtilizationRate; diff > epsilon || diff < -epsilon {
+			violations = append(violations, InvariantViolation{
+				Invariant:      InvariantContributionSum,
+				Description:    "Savings Plan contributions don't sum to its utilization",
+				SavingsPlanARN: sp.SavingsPlanARN,
+				Expected:       util.CurrentUtilizationRate,
+				Actual:         covered,
+			})
+		}
+	}
 
-// Error implements the error interface for invariantViolation.
-func (v invariantViolation) Error() string {
-	return v.description
+	return violations
 }
@@ -156,7 +156,8 @@ func TestCalculatorComprehensiveScenarios(t *testing.T) {
 				//   - i-006: Fully covered, pays $1.44 (commitment: $3.00 - $1.44 = $1.56)
 				//   - i-007: Fully covered, pays $1.44 (commitment: $1.56 - $1.44 = $0.12)
 				//   - i-008: Partially covered (only $0.12 left):
-				//            SP contributes $0.12, instance pays $2.00 - $0.12 = $1.88
+				//            SP contributes $0.12, covering $0.12 / $1.44 = 1/12 of the instance,
+				//            instance pays $0.12 + 11/12 × $2.00 = $1.95
 				//   - i-009 to i-015: On-demand, pay $2.00 each
 				newTestComputeSP("sp-001", 3.00),
 			},
@@ -172,8 +173,8 @@ func TestCalculatorComprehensiveScenarios(t *testing.T) {
 				"i-006": {ShelfPrice: 2.00, EffectiveCost: 1.44, CoverageType: CoverageComputeSavingsPlan, SPCoverage: 1.44},
 				"i-007": {ShelfPrice: 2.00, EffectiveCost: 1.44, CoverageType: CoverageComputeSavingsPlan, SPCoverage: 1.44},
 				// i-008 gets partial SP coverage (commitment exhausted, only $0.12 remains)
-				// SP contributes $0.12, instance pays remaining $1.88
-				"i-008": {ShelfPrice: 2.00, EffectiveCost: 1.88, CoverageType: CoverageComputeSavingsPlan, SPCoverage: 0.12},
+				// SP contributes $0.12, instance pays $0.12 + the uncovered 11/12 at on-demand = $1.95
+				"i-008": {ShelfPrice: 2.00, EffectiveCost: 1.95, CoverageType: CoverageComputeSavingsPlan, SPCoverage: 0.12},
 				// Remaining 7 instances are on-demand (no SP commitment left)
 				"i-009": {ShelfPrice: 2.00, EffectiveCost: 2.00, CoverageType: CoverageOnDemand, OnDemandCost: 2.00},
 				"i-010": {ShelfPrice: 2.00, EffectiveCost: 2.00, CoverageType: CoverageOnDemand, OnDemandCost: 2.00},
@@ -187,17 +188,17 @@ func TestCalculatorComprehensiveScenarios(t *testing.T) {
 			// Total effective cost (what you actually pay):
 			//   - 5 RI instances: 5 * $0 = $0
 			//   - 2 full SP instances: 2 * $1.44 = $2.88
-			//   - 1 partial SP instance: $1.88
+			//   - 1 partial SP instance: $1.95
 			//   - 7 on-demand instances: 7 * $2.00 = $14.00
-			//   Total: $0 + $2.88 + $1.88 + $14.00 = $18.76
-			expectedTotalEstimatedCost: 18.76,
+			//   Total: $0 + $2.88 + $1.95 + $14.00 = $18.83
+			expectedTotalEstimatedCost: 18.83,
 			// Total savings (ShelfPrice - EffectiveCost):
 			//   - RI savings: 5 * ($2.00 - $0) = $10.00
 			//   - Full SP savings: 2 * ($2.00 - $1.44) = 2 * $0.56 = $1.12
-			//   - Partial SP savings: $2.00 - $1.88 = $0.12
+			//   - Partial SP savings: $2.00 - $1.95 = $0.05
 			//   - On-demand savings: 7 * $0 = $0
-			//   Total: $10.00 + $1.12 + $0.12 + $0 = $11.24
-			expectedTotalSavings: 11.24,
+			//   Total: $10.00 + $1.12 + $0.05 + $0 = $11.17
+			expectedTotalSavings: 11.17,
 			// Total RI coverage (what RIs contribute): 5 * $2.00 = $10.00
 			expectedTotalRICoverage: 10.00,
 			// Total SP coverage (SP commitment consumed):
@@ -316,10 +317,10 @@ func TestCalculatorComprehensiveScenarios(t *testing.T) {
 
 			// Build pricing maps with simple pricing
 			onDemandPrices := map[string]float64{
-				"m5.2xlarge:us-west-2": 2.00,
-				"m5.xlarge:us-west-2":  1.00,
-				"c5.xlarge:us-west-2":  1.00,
-				"t3.medium:us-west-2":  0.50,
+				"m5.2xlarge:us-west-2:linux": 2.00,
+				"m5.xlarge:us-west-2:linux":  1.00,
+				"c5.xlarge:us-west-2:linux":  1.00,
+				"t3.medium:us-west-2:linux":  0.50,
 			}
 
 			pricingCache := &mockPricingCache{
@@ -508,7 +509,7 @@ func TestCalculatorMultipleSavingsPlansCommitmentAccounting(t *testing.T) {
 		SavingsPlans:      savingsPlans,
 		PricingCache:      &mockPricingCache{},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 1.00, // $1.00/hr on-demand
+			"m5.xlarge:us-west-2:linux": 1.00, // $1.00/hr on-demand
 		},
 	}
 
@@ -563,18 +564,17 @@ func TestCalculatorMultipleSavingsPlansCommitmentAccounting(t *testing.T) {
 	// Verify expected coverage
 	// With $10/hr total SP commitment and 28% discount (0.72 multiplier):
 	//   - SP rate: $1.00 OD × 0.72 = $0.72/hr per instance
-	//   - Theoretical coverage: $10 / $0.72 = 13.88 instances with one SP
-	//   - Actual with 5 SPs: ~17 instances (15 fully + 2 partially)
+	//   - Coverage: $10 / $0.72 = 13.89 instances, however the commitment is split
+	//     across SPs (13 fully + 1 partially)
 	//
-	// Why more coverage than theoretical? With 5 separate SPs of varying sizes,
-	// the allocation algorithm can pack instances more efficiently:
-	//   - Smaller SPs can fill partial coverage gaps
-	//   - Multiple SPs distribute commitment across instances
+	// When an SP runs out partway through an instance, the next SP covers the rest
+	// of it (split coverage), so the 5 SPs cover exactly as much usage as one SP
+	// with the same total commitment would.
 	//
 	// Key insight: You have $10/hr of SP COMMITMENT (what you pay AWS),
-	// and with realistic 28% discounts, you CONSUME all $10/hr covering 17 instances.
-	// The remaining 3 instances pay on-demand rates.
-	assert.InDelta(t, 17, spCoveredCount, 1, "~17 instances should be SP-covered with 5 SPs")
+	// and with realistic 28% discounts, you CONSUME all $10/hr covering 13.89 instances.
+	// The remaining 6.11 instances' worth of usage pays on-demand rates.
+	assert.Equal(t, 14, spCoveredCount, "14 instances should be SP-covered with 5 SPs")
 	assert.InDelta(t, 10.00, totalSPUtilization, 0.50,
 		"Should consume ~$10/hr of SP commitment (out of $10/hr available)")
 
@@ -589,15 +589,13 @@ func TestCalculatorMultipleSavingsPlansCommitmentAccounting(t *testing.T) {
 	assert.InDelta(t, expectedShelfPrice, result.TotalShelfPrice, 0.01, "TotalShelfPrice mismatch")
 
 	// TotalEstimatedCost with realistic 28% discount and 5 SPs:
-	//   - ~17 SP-covered instances: 17 × $0.72 = ~$12.24
-	//   - ~3 on-demand instances: 3 × $1.00 = ~$3.00
-	//   - Total: ~$15.24
-	// But test shows $12.28 for SP-covered, so actual ~$15.28
-	assert.Greater(t, result.TotalEstimatedCost, 12.00, "TotalEstimatedCost should be > $12")
-	assert.Less(t, result.TotalEstimatedCost, 16.00, "TotalEstimatedCost should be < $16")
+	//   - 13.89 instances at the SP rate: the $10.00 commitment
+	//   - 6.11 instances at on-demand: 6.11 × $1.00 = $6.11
+	//   - Total: $16.11
+	assert.InDelta(t, 16.11, result.TotalEstimatedCost, 0.01, "TotalEstimatedCost mismatch")
 
 	// TotalSavings = ShelfPrice - EstimatedCost
-	// Expected: $20 - $15.28 = ~$4.72 (28% of ~17 instances)
+	// Expected: $20 - $16.11 = $3.89 (28% of 13.89 instances)
 	expectedSavings := expectedShelfPrice - result.TotalEstimatedCost
 	assert.InDelta(t, expectedSavings, result.TotalSavings, 0.01, "TotalSavings mismatch")
 
@@ -612,13 +610,15 @@ func TestCalculatorMultipleSavingsPlansCommitmentAccounting(t *testing.T) {
 			"SP %s should not be over-utilized", sp.SavingsPlanARN)
 	}
 
-	// Verify each instance is covered by exactly ONE Savings Plan
+	// Verify each SP-covered instance records the Savings Plans covering it
 	spARNs := make(map[string]bool)
 	for _, cost := range result.InstanceCosts {
 		if cost.CoverageType == CoverageComputeSavingsPlan {
-			assert.NotEmpty(t, cost.SavingsPlanARN,
-				"SP-covered instance %s should have SP ARN", cost.InstanceID)
-			spARNs[cost.SavingsPlanARN] = true
+			assert.NotEmpty(t, cost.SavingsPlanContributions,
+				"SP-covered instance %s should have SP contributions", cost.InstanceID)
+			for _, contribution := range cost.SavingsPlanContributions {
+				spARNs[contribution.SavingsPlanARN] = true
+			}
 		}
 	}
 
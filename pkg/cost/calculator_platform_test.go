@@ -94,3 +94,178 @@ func TestPlatformToProductDescription(t *testing.T) {
 		})
 	}
 }
+
+// TestPricingOperatingSystem verifies the Platform → on-demand pricing OS mapping.
+func TestPricingOperatingSystem(t *testing.T) {
+	assert.Equal(t, "linux", PricingOperatingSystem(""))
+	assert.Equal(t, "linux", PricingOperatingSystem("linux"))
+	assert.Equal(t, "windows", PricingOperatingSystem(" Windows "))
+	assert.Equal(t, "rhel", PricingOperatingSystem(aws.PlatformRHEL))
+	assert.Equal(t, "suse", PricingOperatingSystem(aws.PlatformSUSE))
+
+	assert.Equal(t, "m5.xlarge:us-west-2:windows", OnDemandPriceKey("m5.xlarge", "us-west-2", "Windows", ""))
+}
+
+// TestCalculatorOperatingSystemShelfPrice verifies that shelf prices are looked up
+// by the instance's OS, and that a missing OS price falls back to the Linux price
+// with estimated accuracy.
+func TestCalculatorOperatingSystemShelfPrice(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	newInstance := func(id, platform string) aws.Instance {
+		return aws.Instance{
+			InstanceID:       id,
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			Lifecycle:        "on-demand",
+			Platform:         platform,
+			LaunchTime:       testBaseTime(),
+		}
+	}
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newInstance("i-linux", aws.PlatformLinux),
+			newInstance("i-empty", ""),
+			newInstance("i-windows", aws.PlatformWindows),
+			newInstance("i-rhel", aws.PlatformRHEL),
+		},
+		OnDemandPrices: map[string]float64{
+			"m5.xlarge:us-west-2:linux":   0.192,
+			"m5.xlarge:us-west-2:windows": 0.376,
+			// No RHEL price loaded
+		},
+	}
+
+	result := calc.Calculate(input)
+
+	assert.Equal(t, 0.192, result.InstanceCosts["i-linux"].ShelfPrice)
+	assert.Equal(t, PricingAccurate, result.InstanceCosts["i-linux"].PricingAccuracy)
+
+	assert.Equal(t, 0.192, result.InstanceCosts["i-empty"].ShelfPrice)
+	assert.Equal(t, PricingAccurate, result.InstanceCosts["i-empty"].PricingAccuracy)
+
+	assert.Equal(t, 0.376, result.InstanceCosts["i-windows"].ShelfPrice)
+	assert.Equal(t, 0.376, result.InstanceCosts["i-windows"].EffectiveCost)
+	assert.Equal(t, PricingAccurate, result.InstanceCosts["i-windows"].PricingAccuracy)
+
+	assert.Equal(t, 0.192, result.InstanceCosts["i-rhel"].ShelfPrice, "Falls back to Linux price")
+	assert.Equal(t, PricingEstimated, result.InstanceCosts["i-rhel"].PricingAccuracy)
+}
+
+// TestPricingTenancy verifies the Tenancy → on-demand pricing tenancy mapping and
+// that only non-default tenancies add a key segment.
+func TestPricingTenancy(t *testing.T) {
+	assert.Equal(t, aws.TenancyDefault, PricingTenancy(""))
+	assert.Equal(t, aws.TenancyDefault, PricingTenancy("default"))
+	assert.Equal(t, aws.TenancyDefault, PricingTenancy("Shared"))
+	assert.Equal(t, aws.TenancyDedicated, PricingTenancy(" Dedicated "))
+	assert.Equal(t, aws.TenancyHost, PricingTenancy("host"))
+
+	assert.Equal(t, "m5.xlarge:us-west-2:linux", OnDemandPriceKey("m5.xlarge", "us-west-2", "linux", "default"))
+	assert.Equal(t, "m5.xlarge:us-west-2:linux:dedicated", OnDemandPriceKey("m5.xlarge", "us-west-2", "linux", "dedicated"))
+}
+
+// TestCalculatorTenancyShelfPrice verifies that dedicated instances are priced at the
+// dedicated rate, and fall back to the shared rate with estimated accuracy when the
+// dedicated price isn't loaded.
+func TestCalculatorTenancyShelfPrice(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	newInstance := func(id, platform, tenancy string) aws.Instance {
+		return aws.Instance{
+			InstanceID:       id,
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			Lifecycle:        "on-demand",
+			Platform:         platform,
+			Tenancy:          tenancy,
+			LaunchTime:       testBaseTime(),
+		}
+	}
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newInstance("i-shared", aws.PlatformLinux, aws.TenancyDefault),
+			newInstance("i-dedicated", aws.PlatformLinux, aws.TenancyDedicated),
+			newInstance("i-windows-dedicated", aws.PlatformWindows, aws.TenancyDedicated),
+			newInstance("i-host", aws.PlatformLinux, aws.TenancyHost),
+		},
+		OnDemandPrices: map[string]float64{
+			"m5.xlarge:us-west-2:linux":           0.192,
+			"m5.xlarge:us-west-2:linux:dedicated": 0.211,
+			"m5.xlarge:us-west-2:windows":         0.376,
+			// No Windows dedicated or host prices loaded
+		},
+	}
+
+	result := calc.Calculate(input)
+
+	assert.Equal(t, 0.192, result.InstanceCosts["i-shared"].ShelfPrice)
+	assert.Equal(t, PricingAccurate, result.InstanceCosts["i-shared"].PricingAccuracy)
+
+	assert.Equal(t, 0.211, result.InstanceCosts["i-dedicated"].ShelfPrice)
+	assert.Equal(t, 0.211, result.InstanceCosts["i-dedicated"].EffectiveCost)
+	assert.Equal(t, PricingAccurate, result.InstanceCosts["i-dedicated"].PricingAccuracy)
+
+	assert.Equal(t, 0.376, result.InstanceCosts["i-windows-dedicated"].ShelfPrice,
+		"Falls back to the shared price for the same OS")
+	assert.Equal(t, PricingEstimated, result.InstanceCosts["i-windows-dedicated"].PricingAccuracy)
+
+	assert.Equal(t, 0.192, result.InstanceCosts["i-host"].ShelfPrice, "Falls back to the shared price")
+	assert.Equal(t, PricingEstimated, result.InstanceCosts["i-host"].PricingAccuracy)
+}
+
+// TestCalculatorEstimatedShelfPriceStaysEstimated verifies that an accurate SP rate
+// doesn't mark an instance accurate when its shelf price was a Linux fallback.
+func TestCalculatorEstimatedShelfPriceStaysEstimated(t *testing.T) {
+	spARN := "arn:aws:savingsplans::123456789012:savingsplan/sp-compute"
+	calc := NewCalculator(&mockPricingCache{
+		spRates: map[string]float64{
+			spARN + ",m5.xlarge,us-west-2,default,rhel": 0.150,
+		},
+	}, nil)
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			{
+				InstanceID:       "i-rhel",
+				InstanceType:     "m5.xlarge",
+				Region:           "us-west-2",
+				AccountID:        "123456789012",
+				AvailabilityZone: "us-west-2a",
+				State:            "running",
+				Lifecycle:        "on-demand",
+				Platform:         aws.PlatformRHEL,
+				Tenancy:          aws.TenancyDefault,
+				LaunchTime:       testBaseTime(),
+			},
+		},
+		SavingsPlans: []aws.SavingsPlan{
+			{
+				SavingsPlanARN:  spARN,
+				SavingsPlanType: "Compute",
+				Region:          "all",
+				InstanceFamily:  "all",
+				Commitment:      1.00,
+				AccountID:       "123456789012",
+			},
+		},
+		OnDemandPrices: map[string]float64{
+			"m5.xlarge:us-west-2:linux": 0.192,
+		},
+	}
+
+	result := calc.Calculate(input)
+
+	cost := result.InstanceCosts["i-rhel"]
+	assert.Equal(t, CoverageComputeSavingsPlan, cost.CoverageType)
+	assert.Equal(t, 0.150, cost.EffectiveCost)
+	assert.Equal(t, PricingEstimated, cost.PricingAccuracy)
+}
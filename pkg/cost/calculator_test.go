@@ -22,6 +22,7 @@ import (
 
 	"github.com/nextdoor/lumina/pkg/aws"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // testBaseTime returns a fixed time for test determinism
@@ -62,8 +63,8 @@ func TestCalculatorBasicFlow(t *testing.T) {
 		SavingsPlans:      []aws.SavingsPlan{},
 		PricingCache:      &mockPricingCache{},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2":  1.00,
-			"c5.2xlarge:us-west-2": 2.00,
+			"m5.xlarge:us-west-2:linux":  1.00,
+			"c5.2xlarge:us-west-2:linux": 2.00,
 		},
 	}
 
@@ -133,7 +134,7 @@ func TestCalculatorWithReservedInstances(t *testing.T) {
 		},
 		SavingsPlans: []aws.SavingsPlan{},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 1.00,
+			"m5.xlarge:us-west-2:linux": 1.00,
 		},
 	}
 
@@ -193,8 +194,8 @@ func TestCalculatorWithEC2InstanceSavingsPlan(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2":  1.00,
-			"m5.2xlarge:us-west-2": 2.00,
+			"m5.xlarge:us-west-2:linux":  1.00,
+			"m5.2xlarge:us-west-2:linux": 2.00,
 		},
 	}
 
@@ -214,18 +215,23 @@ func TestCalculatorWithEC2InstanceSavingsPlan(t *testing.T) {
 	assert.InDelta(t, 0.72, cost1.EffectiveCost, 0.01)       // Pays SP rate
 	assert.InDelta(t, 0.72, cost1.SavingsPlanCoverage, 0.01) // SP commitment consumed
 	assert.Equal(t, CoverageEC2InstanceSavingsPlan, cost1.CoverageType)
-	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-001", cost1.SavingsPlanARN)
+	require.Len(t, cost1.SavingsPlanContributions, 1)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-001",
+		cost1.SavingsPlanContributions[0].SavingsPlanARN)
 
 	// Check second instance (m5.2xlarge) - should get partial SP coverage
 	// Commitment: $1.00 total, $0.72 used by first instance, $0.28 remaining
-	// SP contributes $0.28, instance pays $2.00 - $0.28 = $1.72
+	// SP covers $0.28 / $1.44 = 19.4% of the instance, and the rest is on-demand:
+	// instance pays $0.28 + 80.6% × $2.00 = $1.89
 	cost2 := result.InstanceCosts["i-002"]
 	assert.Equal(t, "i-002", cost2.InstanceID)
 	assert.Equal(t, 2.00, cost2.ShelfPrice)
-	assert.InDelta(t, 1.72, cost2.EffectiveCost, 0.01)       // Pays mostly on-demand
+	assert.InDelta(t, 1.89, cost2.EffectiveCost, 0.01)       // Pays mostly on-demand
 	assert.InDelta(t, 0.28, cost2.SavingsPlanCoverage, 0.01) // SP commitment consumed (remaining)
 	assert.Equal(t, CoverageEC2InstanceSavingsPlan, cost2.CoverageType)
-	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-001", cost2.SavingsPlanARN)
+	require.Len(t, cost2.SavingsPlanContributions, 1)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-001",
+		cost2.SavingsPlanContributions[0].SavingsPlanARN)
 
 	// Check SP utilization metrics
 	spUtil := result.SavingsPlanUtilization["arn:aws:savingsplans::123456789012:savingsplan/sp-001"]
@@ -236,8 +242,8 @@ func TestCalculatorWithEC2InstanceSavingsPlan(t *testing.T) {
 
 	// Check aggregate totals
 	assert.InDelta(t, 3.00, result.TotalShelfPrice, 0.01)    // $1.00 + $2.00
-	assert.InDelta(t, 2.44, result.TotalEstimatedCost, 0.01) // $0.72 + $1.72
-	assert.InDelta(t, 0.56, result.TotalSavings, 0.01)       // $3.00 - $2.44
+	assert.InDelta(t, 2.61, result.TotalEstimatedCost, 0.01) // $0.72 + $1.89
+	assert.InDelta(t, 0.39, result.TotalSavings, 0.01)       // $3.00 - $2.61
 }
 
 // TestCalculatorSpotPricing tests that spot instances use spot market prices.
@@ -266,7 +272,7 @@ func TestCalculatorSpotPricing(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 1.00,
+			"m5.xlarge:us-west-2:linux": 1.00,
 		},
 	}
 
@@ -363,10 +369,10 @@ func TestCalculatorPriorityOrder(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2":  1.00,
-			"m5.2xlarge:us-west-2": 2.00,
-			"c5.xlarge:us-west-2":  1.00,
-			"r5.xlarge:us-west-2":  1.50,
+			"m5.xlarge:us-west-2:linux":  1.00,
+			"m5.2xlarge:us-west-2:linux": 2.00,
+			"c5.xlarge:us-west-2:linux":  1.00,
+			"r5.xlarge:us-west-2:linux":  1.50,
 		},
 	}
 
@@ -433,7 +439,7 @@ func TestCalculatorSPUtilizationMetrics(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 1.00,
+			"m5.xlarge:us-west-2:linux": 1.00,
 		},
 	}
 
@@ -443,10 +449,11 @@ func TestCalculatorSPUtilizationMetrics(t *testing.T) {
 	// With 28% EC2 Instance SP discount (1-year commitment):
 	//   - m5.xlarge: $1.00 OD → $0.72 SP rate
 	//   - Commitment: $0.50 (NOT enough to fully cover, only partial coverage)
-	//   - SP contributes: $0.50, instance pays remaining $0.22 on-demand
+	//   - SP contributes: $0.50, covering $0.50 / $0.72 = 69.4% of the instance
+	//   - Instance pays $0.50 + the remaining 30.6% at on-demand = $0.81
 	cost := result.InstanceCosts["i-001"]
 	assert.Equal(t, 1.00, cost.ShelfPrice)
-	assert.InDelta(t, 0.50, cost.EffectiveCost, 0.01)       // Pays $0.50 (SP exhausted)
+	assert.InDelta(t, 0.81, cost.EffectiveCost, 0.01)       // Pays $0.81 (SP exhausted)
 	assert.InDelta(t, 0.50, cost.SavingsPlanCoverage, 0.01) // SP commitment consumed (all of it)
 	assert.Equal(t, CoverageEC2InstanceSavingsPlan, cost.CoverageType)
 
@@ -456,6 +463,7 @@ func TestCalculatorSPUtilizationMetrics(t *testing.T) {
 	assert.InDelta(t, 0.50, spUtil.CurrentUtilizationRate, 0.01) // Instance uses full $0.50 commitment
 	assert.InDelta(t, 0.00, spUtil.RemainingCapacity, 0.01)      // $0.50 - $0.50 = $0 remaining (exhausted)
 	assert.InDelta(t, 100.0, spUtil.UtilizationPercent, 1.0)     // 100% utilized (commitment exhausted)
+	assert.InDelta(t, 0.22, spUtil.UnmetDemandRate, 0.01)        // $0.72 SP rate - $0.50 covered
 	assert.Greater(t, spUtil.RemainingHours, 0.0)
 	assert.InDelta(t, 365*24, spUtil.RemainingHours, 24) // Within 24 hours of 1 year
 	assert.Equal(t, endTime, spUtil.EndTime)
@@ -508,7 +516,7 @@ func TestCalculatorLaunchTimeStability(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 1.00,
+			"m5.xlarge:us-west-2:linux": 1.00,
 		},
 	}
 
@@ -566,7 +574,7 @@ func TestCalculatorMultipleAccounts(t *testing.T) {
 		},
 		SavingsPlans: []aws.SavingsPlan{},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 1.00,
+			"m5.xlarge:us-west-2:linux": 1.00,
 		},
 	}
 
@@ -640,8 +648,8 @@ func TestCalculatorRIAndSPInteraction(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 1.00,
-			"c5.xlarge:us-west-2": 1.00,
+			"m5.xlarge:us-west-2:linux": 1.00,
+			"c5.xlarge:us-west-2:linux": 1.00,
 		},
 	}
 
@@ -717,7 +725,7 @@ func TestCalculatorPartialRIAndSPOverlap(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 1.00,
+			"m5.xlarge:us-west-2:linux": 1.00,
 		},
 	}
 
@@ -737,27 +745,35 @@ func TestCalculatorPartialRIAndSPOverlap(t *testing.T) {
 	assert.LessOrEqual(t, cost.SavingsPlanCoverage, cost.ShelfPrice,
 		"SP coverage exceeded shelf price - should be capped")
 
-	// Calculate expected values based on SIMPLIFIED model (only ONE SP applies)
+	// Calculate expected values with split coverage (each SP covers what it can)
 	// 1. EC2 Instance SP (28% discount): rate = $1.00 * 0.72 = $0.72
 	//    Commitment $0.10 < rate $0.72, so PARTIAL coverage
-	//    Contributes $0.10, EffectiveCost = $1.00 - $0.10 = $0.90
-	//    SavingsPlanCoverage = $0.10 (SP commitment consumed)
+	//    Contributes $0.10, covering $0.10 / $0.72 = 13.9% of the instance
 	//
-	// 2. Compute SP DOES NOT APPLY (instance already has SP coverage)
-	//    Our simplified model prevents multiple SPs from applying to same instance
+	// 2. Compute SP covers the remaining 86.1% of the instance
+	//    Contributes 86.1% of its $0.72 rate = $0.62
 	//
 	// Final state:
-	// - EffectiveCost: $0.90 (partially covered, $0.80 spillover at on-demand)
-	// - SavingsPlanCoverage: $0.10 (what EC2 SP contributed)
+	// - EffectiveCost: $0.72 (the SP rate, paid by two SPs)
+	// - SavingsPlanCoverage: $0.72 ($0.10 + $0.62)
 
-	expectedSPCoverage := 0.10    // EC2 Instance SP contributed its full commitment
-	expectedEffectiveCost := 0.90 // $1.00 - $0.10 = $0.90
+	expectedSPCoverage := 0.72    // Both SPs together pay the full SP rate
+	expectedEffectiveCost := 0.72 // Nothing left at on-demand rates
 
 	assert.InDelta(t, expectedEffectiveCost, cost.EffectiveCost, 0.001,
-		"EffectiveCost should be $0.90 (partial EC2 SP coverage)")
+		"EffectiveCost should be $0.72 (split EC2 and Compute SP coverage)")
 
 	assert.InDelta(t, expectedSPCoverage, cost.SavingsPlanCoverage, 0.001,
-		"SP coverage should be $0.10 (only EC2 SP applies, Compute SP blocked)")
+		"SP coverage should be $0.72 (EC2 SP first, Compute SP for the rest)")
+
+	require.Len(t, cost.SavingsPlanContributions, 2)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-ec2-small",
+		cost.SavingsPlanContributions[0].SavingsPlanARN)
+	assert.InDelta(t, 0.10, cost.SavingsPlanContributions[0].Coverage, 0.001)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-compute-large",
+		cost.SavingsPlanContributions[1].SavingsPlanARN)
+	assert.InDelta(t, 0.62, cost.SavingsPlanContributions[1].Coverage, 0.001)
+	assert.Empty(t, result.InvariantViolations)
 }
 
 // TestCalculatorNegativeCostPrevention is a comprehensive test ensuring no combination
@@ -941,7 +957,7 @@ func TestCalculatorInvariantValidation(t *testing.T) {
 				},
 			},
 			OnDemandPrices: map[string]float64{
-				"m5.xlarge:us-west-2": 1.00,
+				"m5.xlarge:us-west-2:linux": 1.00,
 			},
 		}
 
@@ -976,7 +992,7 @@ func TestCalculatorInvariantValidation(t *testing.T) {
 					ReservedInstances: []aws.ReservedInstance{},
 					SavingsPlans:      []aws.SavingsPlan{},
 					OnDemandPrices: map[string]float64{
-						"m5.xlarge:us-west-2": 1.00,
+						"m5.xlarge:us-west-2:linux": 1.00,
 					},
 				},
 			},
@@ -1006,7 +1022,7 @@ func TestCalculatorInvariantValidation(t *testing.T) {
 					},
 					SavingsPlans: []aws.SavingsPlan{},
 					OnDemandPrices: map[string]float64{
-						"m5.xlarge:us-west-2": 1.00,
+						"m5.xlarge:us-west-2:linux": 1.00,
 					},
 				},
 			},
@@ -1045,7 +1061,7 @@ func TestCalculatorInvariantValidation(t *testing.T) {
 						},
 					},
 					OnDemandPrices: map[string]float64{
-						"m5.xlarge:us-west-2": 1.00,
+						"m5.xlarge:us-west-2:linux": 1.00,
 					},
 				},
 			},
@@ -1063,6 +1079,8 @@ func TestCalculatorInvariantValidation(t *testing.T) {
 
 // TestCalculatorInvariantViolationDetection tests that the validation DOES catch bugs.
 // This is critical - if the validation doesn't catch bugs, it's useless.
+// Violations are returned rather than panicking, so each one is checked for the
+// instance or Savings Plan it names.
 //
 // NOTE: These tests verify the validation logic itself, not the cost calculation.
 // We're testing that validateSavingsPlansInvariants() correctly detects violations.
@@ -1086,16 +1104,22 @@ func TestCalculatorInvariantViolationDetection(t *testing.T) {
 				EffectiveCost:       -0.20, // BUG: Negative cost!
 				RICoverage:          1.00,
 				SavingsPlanCoverage: 0.20,
-				CoverageType:        CoverageReservedInstance,
+				SavingsPlanContributions: []SavingsPlanContribution{
+					{SavingsPlanARN: "arn:aws:savingsplans::123456789012:savingsplan/sp-001", Coverage: 0.20},
+				},
+				CoverageType: CoverageReservedInstance,
 			},
 		}
 
 		utilization := map[string]*SavingsPlanUtilization{}
 
-		// The validation should panic on negative cost
-		assert.Panics(t, func() {
-			calc.validateSavingsPlansInvariants([]aws.SavingsPlan{}, costs, utilization)
-		}, "Should panic on negative effective cost")
+		// The validation should report the negative cost
+		violations := calc.validateSavingsPlansInvariants([]aws.SavingsPlan{}, costs, nil, utilization)
+		// Its coverage also exceeds its shelf price, which is reported after
+		require.Len(t, violations, 2, "Should report negative effective cost")
+		assert.Equal(t, InvariantNonNegativeCost, violations[0].Invariant)
+		assert.Equal(t, "i-negative", violations[0].InstanceID)
+		assert.Equal(t, -0.20, violations[0].Actual)
 	})
 
 	t.Run("Coverage Exceeds Shelf Price", func(t *testing.T) {
@@ -1111,17 +1135,23 @@ func TestCalculatorInvariantViolationDetection(t *testing.T) {
 				EffectiveCost:       0.00,
 				RICoverage:          0.70, // RI coverage
 				SavingsPlanCoverage: 0.50, // SP coverage
-				CoverageType:        CoverageReservedInstance,
+				SavingsPlanContributions: []SavingsPlanContribution{
+					{SavingsPlanARN: "arn:aws:savingsplans::123456789012:savingsplan/sp-001", Coverage: 0.50},
+				},
+				CoverageType: CoverageReservedInstance,
 				// Total: 0.70 + 0.50 = 1.20 > 1.00 ← BUG!
 			},
 		}
 
 		utilization := map[string]*SavingsPlanUtilization{}
 
-		// The validation should panic on over-coverage
-		assert.Panics(t, func() {
-			calc.validateSavingsPlansInvariants([]aws.SavingsPlan{}, costs, utilization)
-		}, "Should panic when total coverage exceeds shelf price")
+		// The validation should report the over-coverage
+		violations := calc.validateSavingsPlansInvariants([]aws.SavingsPlan{}, costs, nil, utilization)
+		require.Len(t, violations, 1, "Should report total coverage exceeding shelf price")
+		assert.Equal(t, InvariantCoverageBounds, violations[0].Invariant)
+		assert.Equal(t, "i-overcovered", violations[0].InstanceID)
+		assert.InDelta(t, 1.20, violations[0].Actual, 1e-9)
+		assert.Equal(t, 1.00, violations[0].Expected)
 	})
 
 	t.Run("SP Commitment Balance Violation", func(t *testing.T) {
@@ -1135,7 +1165,17 @@ func TestCalculatorInvariantViolationDetection(t *testing.T) {
 			AccountID:       "123456789012",
 		}
 
-		costs := map[string]*InstanceCost{}
+		costs := map[string]*InstanceCost{
+			"i-covered": {
+				InstanceID:          "i-covered",
+				ShelfPrice:          1.00,
+				EffectiveCost:       0.80,
+				SavingsPlanCoverage: 0.80,
+				SavingsPlanContributions: []SavingsPlanContribution{
+					{SavingsPlanARN: sp.SavingsPlanARN, Coverage: 0.80},
+				},
+			},
+		}
 
 		utilization := map[string]*SavingsPlanUtilization{
 			sp.SavingsPlanARN: {
@@ -1147,10 +1187,12 @@ func TestCalculatorInvariantViolationDetection(t *testing.T) {
 			},
 		}
 
-		// The validation should panic on commitment imbalance
-		assert.Panics(t, func() {
-			calc.validateSavingsPlansInvariants([]aws.SavingsPlan{sp}, costs, utilization)
-		}, "Should panic when SP utilization + remaining ≠ commitment")
+		// The validation should report the commitment imbalance
+		violations := calc.validateSavingsPlansInvariants([]aws.SavingsPlan{sp}, costs, nil, utilization)
+		require.Len(t, violations, 1, "Should report SP utilization + remaining ≠ commitment")
+		assert.Equal(t, InvariantCommitmentBalance, violations[0].Invariant)
+		assert.Equal(t, sp.SavingsPlanARN, violations[0].SavingsPlanARN)
+		assert.Empty(t, violations[0].InstanceID)
 	})
 
 	t.Run("Valid State Does Not Panic", func(t *testing.T) {
@@ -1166,7 +1208,10 @@ func TestCalculatorInvariantViolationDetection(t *testing.T) {
 				EffectiveCost:       0.28, // Positive, reasonable cost (EC2 Instance SP rate)
 				RICoverage:          0.0,
 				SavingsPlanCoverage: 0.28, // Coverage < shelf price ✓
-				CoverageType:        CoverageEC2InstanceSavingsPlan,
+				SavingsPlanContributions: []SavingsPlanContribution{
+					{SavingsPlanARN: "arn:aws:savingsplans::123456789012:savingsplan/sp-valid", Coverage: 0.28},
+				},
+				CoverageType: CoverageEC2InstanceSavingsPlan,
 			},
 		}
 
@@ -1189,11 +1234,114 @@ func TestCalculatorInvariantViolationDetection(t *testing.T) {
 			},
 		}
 
-		// Valid state should NOT panic
-		assert.NotPanics(t, func() {
-			calc.validateSavingsPlansInvariants([]aws.SavingsPlan{sp}, costs, utilization)
-		}, "Valid state should not trigger invariant violation")
+		// Valid state should have no violations
+		assert.Empty(t, calc.validateSavingsPlansInvariants([]aws.SavingsPlan{sp}, costs, nil, utilization),
+			"Valid state should not trigger invariant violation")
+	})
+
+	t.Run("Every Violation Is Reported", func(t *testing.T) {
+		costs := map[string]*InstanceCost{
+			"i-b": {InstanceID: "i-b", ShelfPrice: 1.00, EffectiveCost: -0.50, RICoverage: 1.50},
+			"i-a": {
+				InstanceID: "i-a", ShelfPrice: 1.00, EffectiveCost: 0.00, SavingsPlanCoverage: 1.20,
+				SavingsPlanContributions: []SavingsPlanContribution{{SavingsPlanARN: "sp-a", Coverage: 1.20}},
+			},
+		}
+
+		violations := calc.validateSavingsPlansInvariants(nil, costs, nil, map[string]*SavingsPlanUtilization{})
+
+		// Negative costs first, then coverage bounds, instances by ID within each
+		require.Len(t, violations, 3)
+		assert.Equal(t, InvariantNonNegativeCost, violations[0].Invariant)
+		assert.Equal(t, "i-b", violations[0].InstanceID)
+		assert.Equal(t, InvariantCoverageBounds, violations[1].Invariant)
+		assert.Equal(t, "i-a", violations[1].InstanceID)
+		assert.Equal(t, InvariantCoverageBounds, violations[2].Invariant)
+		assert.Equal(t, "i-b", violations[2].InstanceID)
 	})
+
+	t.Run("Contributions Don't Sum", func(t *testing.T) {
+		sp := aws.SavingsPlan{
+			SavingsPlanARN:  "arn:aws:savingsplans::123456789012:savingsplan/sp-split",
+			SavingsPlanType: "EC2Instance",
+			Commitment:      0.50,
+		}
+
+		// The instance records $0.30 of coverage, but its contributions only add up
+		// to $0.20, which is also less than the SP's utilization
+		costs := map[string]*InstanceCost{
+			"i-split": {
+				InstanceID:          "i-split",
+				ShelfPrice:          1.00,
+				EffectiveCost:       0.80,
+				SavingsPlanCoverage: 0.30,
+				SavingsPlanContributions: []SavingsPlanContribution{
+					{SavingsPlanARN: sp.SavingsPlanARN, Coverage: 0.20},
+				},
+			},
+		}
+		utilization := map[string]*SavingsPlanUtilization{
+			sp.SavingsPlanARN: {
+				SavingsPlanARN:         sp.SavingsPlanARN,
+				HourlyCommitment:       sp.Commitment,
+				CurrentUtilizationRate: 0.30,
+				RemainingCapacity:      0.20,
+			},
+		}
+
+		violations := calc.validateSavingsPlansInvariants([]aws.SavingsPlan{sp}, costs, nil, utilization)
+
+		// The instance's sum first, then the SP's
+		require.Len(t, violations, 2)
+		assert.Equal(t, InvariantContributionSum, violations[0].Invariant)
+		assert.Equal(t, "i-split", violations[0].InstanceID)
+		assert.InDelta(t, 0.20, violations[0].Actual, 1e-9)
+		assert.Equal(t, InvariantContributionSum, violations[1].Invariant)
+		assert.Equal(t, sp.SavingsPlanARN, violations[1].SavingsPlanARN)
+		assert.InDelta(t, 0.30, violations[1].Expected, 1e-9)
+	})
+}
+
+// TestCalculatorDuplicateInstance tests that an instance listed twice, as
+// overlapping account configurations can do, doesn't crash the calculation or get
+// covered twice: Savings Plans only cover what's still billed at on-demand rates.
+func TestCalculatorDuplicateInstance(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	instance := aws.Instance{
+		InstanceID:       "i-duplicate",
+		InstanceType:     "m5.xlarge",
+		Region:           "us-west-2",
+		AccountID:        "123456789012",
+		AvailabilityZone: "us-west-2a",
+		State:            "running",
+		LaunchTime:       testBaseTime(),
+	}
+	input := CalculationInput{
+		Instances: []aws.Instance{instance, instance},
+		SavingsPlans: []aws.SavingsPlan{
+			{
+				SavingsPlanARN:  "arn:aws:savingsplans::123456789012:savingsplan/sp-ec2",
+				SavingsPlanType: "EC2Instance",
+				Region:          "us-west-2",
+				InstanceFamily:  "m5",
+				Commitment:      10.00,
+				AccountID:       "123456789012",
+			},
+		},
+		OnDemandPrices: map[string]float64{"m5.xlarge:us-west-2:linux": 1.00},
+	}
+
+	var result CalculationResult
+	require.NotPanics(t, func() { result = calc.Calculate(input) })
+
+	assert.Empty(t, result.InvariantViolations)
+	cost := result.InstanceCosts["i-duplicate"]
+	require.Len(t, cost.SavingsPlanContributions, 1)
+	assert.InDelta(t, 0.72, cost.SavingsPlanCoverage, 1e-9)
+	assert.InDelta(t, 0.72, cost.EffectiveCost, 1e-9)
+	assert.InDelta(t, 0.72,
+		result.SavingsPlanUtilization["arn:aws:savingsplans::123456789012:savingsplan/sp-ec2"].CurrentUtilizationRate, 1e-9)
 }
 
 // TestCalculatorMultipleSavingsPlansOnSameInstance tests the scenario where
@@ -1242,7 +1390,7 @@ func TestCalculatorMultipleSavingsPlansOnSameInstance(t *testing.T) {
 		SavingsPlans:      savingsPlans,
 		PricingCache:      &mockPricingCache{},
 		OnDemandPrices: map[string]float64{
-			"m5.large:us-west-2": 0.10, // $0.10/hour shelf price
+			"m5.large:us-west-2:linux": 0.10, // $0.10/hour shelf price
 		},
 	}
 
@@ -1270,9 +1418,11 @@ func TestCalculatorMultipleSavingsPlansOnSameInstance(t *testing.T) {
 
 	// Verify SavingsPlanCoverage tracks SP commitment consumed (not discount):
 	// For a fully covered instance: SavingsPlanCoverage == EffectiveCost (both = SP rate)
-	// Our simplified model: only ONE SP applies, so this should hold
+	// The first SP runs out partway through the instance and the second covers the
+	// rest (split coverage), so together they pay the full SP rate
 	assert.InDelta(t, cost.EffectiveCost, cost.SavingsPlanCoverage, 0.001,
 		"SavingsPlanCoverage should equal SP commitment consumed (EffectiveCost for fully covered)")
+	assert.Len(t, cost.SavingsPlanContributions, 2, "Two SPs should split the instance")
 
 	// Verify SP utilization is tracked correctly
 	// At least one SP should have utilization (the first one that matched)
@@ -1323,7 +1473,7 @@ func TestCalculatorMultipleComputeSPsMatchCommitment(t *testing.T) {
 			LaunchTime:       baseTime,
 		})
 	}
-	onDemandPrices["m5.xlarge:us-west-2"] = 0.192 // $0.192/hour shelf price
+	onDemandPrices["m5.xlarge:us-west-2:linux"] = 0.192 // $0.192/hour shelf price
 
 	// Create 5 Compute Savings Plans (matching production scenario)
 	// Total commitment: $258/hour (similar to production)
@@ -1415,8 +1565,8 @@ func TestCalculatorMultipleComputeSPsMatchCommitment(t *testing.T) {
 	// Verify each instance is covered by at most one Compute SP
 	for _, cost := range result.InstanceCosts {
 		if cost.CoverageType == CoverageComputeSavingsPlan {
-			// Instance should have exactly one SP ARN
-			assert.NotEmpty(t, cost.SavingsPlanARN, "SP-covered instance should have SP ARN")
+			// Instance should have at least one SP contribution
+			assert.NotEmpty(t, cost.SavingsPlanContributions, "SP-covered instance should have SP contributions")
 
 			// EffectiveCost should equal the SP rate (what you pay)
 			// This is approximately 72% discount for Compute SPs (varies by instance type)
@@ -1527,8 +1677,8 @@ func TestCalculatorDifferentSPRatesFromCache(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 0.100, // $0.10/hour on-demand
-			"m5.xlarge:us-east-1": 0.100, // $0.10/hour on-demand
+			"m5.xlarge:us-west-2:linux": 0.100, // $0.10/hour on-demand
+			"m5.xlarge:us-east-1:linux": 0.100, // $0.10/hour on-demand
 		},
 	}
 
@@ -1538,21 +1688,27 @@ func TestCalculatorDifferentSPRatesFromCache(t *testing.T) {
 	cost1 := result.InstanceCosts["i-sp001-covered"]
 	assert.Equal(t, CoverageComputeSavingsPlan, cost1.CoverageType, "Instance should be covered by Compute SP")
 	assert.InDelta(t, 0.050, cost1.EffectiveCost, 0.001, "Instance should use SP-001 rate of $0.050")
-	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-001", cost1.SavingsPlanARN,
+	require.Len(t, cost1.SavingsPlanContributions, 1)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-001",
+		cost1.SavingsPlanContributions[0].SavingsPlanARN,
 		"Instance should be attributed to SP-001")
 	assert.Equal(t, PricingAccurate, cost1.PricingAccuracy, "Pricing should be accurate (Tier 1 from cache)")
 
 	cost2 := result.InstanceCosts["i-sp002-covered"]
 	assert.Equal(t, CoverageComputeSavingsPlan, cost2.CoverageType, "Instance should be covered by Compute SP")
 	assert.InDelta(t, 0.070, cost2.EffectiveCost, 0.001, "Instance should use SP-002 rate of $0.070 (higher than SP-001)")
-	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-002", cost2.SavingsPlanARN,
+	require.Len(t, cost2.SavingsPlanContributions, 1)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-002",
+		cost2.SavingsPlanContributions[0].SavingsPlanARN,
 		"Instance should be attributed to SP-002")
 	assert.Equal(t, PricingAccurate, cost2.PricingAccuracy, "Pricing should be accurate (Tier 1 from cache)")
 
 	cost3 := result.InstanceCosts["i-sp003-covered"]
 	assert.Equal(t, CoverageComputeSavingsPlan, cost3.CoverageType, "Instance should be covered by Compute SP")
 	assert.InDelta(t, 0.055, cost3.EffectiveCost, 0.001, "Instance should use SP-003 rate of $0.055")
-	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-003", cost3.SavingsPlanARN,
+	require.Len(t, cost3.SavingsPlanContributions, 1)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-003",
+		cost3.SavingsPlanContributions[0].SavingsPlanARN,
 		"Instance should be attributed to SP-003")
 	assert.Equal(t, PricingAccurate, cost3.PricingAccuracy, "Pricing should be accurate (Tier 1 from cache)")
 
@@ -1641,7 +1797,7 @@ func TestCalculatorEC2InstanceSPRatesFromCache(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 0.100, // $0.10/hour on-demand
+			"m5.xlarge:us-west-2:linux": 0.100, // $0.10/hour on-demand
 		},
 	}
 
@@ -1651,7 +1807,9 @@ func TestCalculatorEC2InstanceSPRatesFromCache(t *testing.T) {
 	cost1 := result.InstanceCosts["i-m5-xlarge-1"]
 	assert.Equal(t, CoverageEC2InstanceSavingsPlan, cost1.CoverageType, "Instance should be covered by EC2 Instance SP")
 	assert.InDelta(t, 0.045, cost1.EffectiveCost, 0.001, "Instance should use SP-EC2-001 rate of $0.045")
-	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-ec2-001", cost1.SavingsPlanARN,
+	require.Len(t, cost1.SavingsPlanContributions, 1)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-ec2-001",
+		cost1.SavingsPlanContributions[0].SavingsPlanARN,
 		"Instance should be attributed to SP-EC2-001 (better rate)")
 	assert.Equal(t, PricingAccurate, cost1.PricingAccuracy, "Pricing should be accurate (Tier 1 from cache)")
 
@@ -1659,7 +1817,9 @@ func TestCalculatorEC2InstanceSPRatesFromCache(t *testing.T) {
 	cost2 := result.InstanceCosts["i-m5-xlarge-2"]
 	assert.Equal(t, CoverageEC2InstanceSavingsPlan, cost2.CoverageType, "Instance should be covered by EC2 Instance SP")
 	assert.InDelta(t, 0.060, cost2.EffectiveCost, 0.001, "Instance should use SP-EC2-002 rate of $0.060")
-	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-ec2-002", cost2.SavingsPlanARN,
+	require.Len(t, cost2.SavingsPlanContributions, 1)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-ec2-002",
+		cost2.SavingsPlanContributions[0].SavingsPlanARN,
 		"Instance should be attributed to SP-EC2-002 (worse rate)")
 	assert.Equal(t, PricingAccurate, cost2.PricingAccuracy, "Pricing should be accurate (Tier 1 from cache)")
 
@@ -1736,7 +1896,7 @@ func TestCalculatorMixedTierPricingAccuracy(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 0.100, // $0.10/hour on-demand
+			"m5.xlarge:us-west-2:linux": 0.100, // $0.10/hour on-demand
 		},
 	}
 
@@ -1747,7 +1907,9 @@ func TestCalculatorMixedTierPricingAccuracy(t *testing.T) {
 	assert.InDelta(t, 0.050, cost1.EffectiveCost, 0.001, "Should use cached rate of $0.050")
 	assert.Equal(t, PricingAccurate, cost1.PricingAccuracy,
 		"CRITICAL: Pricing should be marked as ACCURATE (Tier 1 cache lookup)")
-	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-001", cost1.SavingsPlanARN)
+	require.Len(t, cost1.SavingsPlanContributions, 1)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-001",
+		cost1.SavingsPlanContributions[0].SavingsPlanARN)
 
 	// Verify Tier 2 instance has estimated pricing
 	cost2 := result.InstanceCosts["i-tier2"]
@@ -1755,7 +1917,9 @@ func TestCalculatorMixedTierPricingAccuracy(t *testing.T) {
 		"Should use Tier 2 discount (0.72 * $0.10 = $0.072)")
 	assert.Equal(t, PricingEstimated, cost2.PricingAccuracy,
 		"CRITICAL: Pricing should be marked as ESTIMATED (Tier 2 discount fallback)")
-	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-002", cost2.SavingsPlanARN)
+	require.Len(t, cost2.SavingsPlanContributions, 1)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/sp-002",
+		cost2.SavingsPlanContributions[0].SavingsPlanARN)
 
 	// Verify costs are different (Tier 1 vs Tier 2 rates)
 	assert.NotEqual(t, cost1.EffectiveCost, cost2.EffectiveCost,
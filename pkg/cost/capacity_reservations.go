@@ -0,0 +1,73 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"github.com/nextdoor/lumina/pkg/aws"
+)
+
+// CapacityReservationInstance returns an instance with the instance type, region,
+// OS and tenancy of a Capacity Reservation, for looking up the on-demand price its
+// unused capacity is billed at.
+func CapacityReservationInstance(cr aws.CapacityReservation) aws.Instance {
+	return aws.Instance{
+		InstanceType:     cr.InstanceType,
+		Region:           cr.Region,
+		AvailabilityZone: cr.AvailabilityZone,
+		Platform:         riOperatingSystem(cr.Platform),
+		Tenancy:          cr.Tenancy,
+	}
+}
+
+// calculateCapacityReservationUtilization counts the running instances in each
+// Capacity Reservation and values the rest of its capacity at on-demand rates.
+//
+// Instances report the reservation they run in (for "open" reservations, AWS picks
+// it), so no matching is done here. Regional RIs and Savings Plans can discount
+// unused capacity on the bill; that isn't modelled, so UnusedHourlyCost is an upper
+// bound.
+func calculateCapacityReservationUtilization(
+	input CalculationInput,
+	utilization map[string]CapacityReservationUtilization,
+) {
+	if len(input.CapacityReservations) == 0 {
+		return
+	}
+
+	used := make(map[string]int32)
+	for _, inst := range input.Instances {
+		if inst.CapacityReservationID != "" && inst.State == "running" {
+			used[inst.CapacityReservationID]++
+		}
+	}
+
+	for _, cr := range input.CapacityReservations {
+		util := CapacityReservationUtilization{
+			CapacityReservationID: cr.CapacityReservationID,
+			AccountID:             cr.AccountID,
+			AccountName:           cr.AccountName,
+			InstanceType:          cr.InstanceType,
+			Region:                cr.Region,
+			AvailabilityZone:      cr.AvailabilityZone,
+			TotalInstanceCount:    cr.TotalInstanceCount,
+			UsedInstances:         min(used[cr.CapacityReservationID], cr.TotalInstanceCount),
+		}
+		if unused := util.TotalInstanceCount - util.UsedInstances; unused > 0 {
+			shelfPrice, _ := lookupShelfPrice(input.OnDemandPrices, CapacityReservationInstance(cr))
+			util.UnusedHourlyCost = float64(unused) * shelfPrice
+		}
+		utilization[cr.CapacityReservationID] = util
+	}
+}
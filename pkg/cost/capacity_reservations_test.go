@@ -0,0 +1,155 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCalculatorCapacityReservations tests that instances running in a Capacity
+// Reservation are marked, and that unused reserved capacity is valued at the
+// on-demand price of the reservation's instance type, OS and tenancy.
+func TestCalculatorCapacityReservations(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	instance := func(id, reservationID, state string) aws.Instance {
+		return aws.Instance{
+			InstanceID:            id,
+			InstanceType:          "m5.xlarge",
+			Region:                "us-west-2",
+			AccountID:             "123456789012",
+			AvailabilityZone:      "us-west-2a",
+			State:                 state,
+			LaunchTime:            testBaseTime(),
+			CapacityReservationID: reservationID,
+		}
+	}
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			instance("i-001", "cr-partial", "running"),
+			instance("i-002", "cr-full", "running"),
+			instance("i-003", "", "running"),
+			instance("i-004", "cr-partial", "stopped"),
+		},
+		CapacityReservations: []aws.CapacityReservation{
+			{
+				CapacityReservationID: "cr-partial",
+				InstanceType:          "m5.xlarge",
+				Region:                "us-west-2",
+				AvailabilityZone:      "us-west-2a",
+				Platform:              aws.ProductDescriptionLinuxUnix,
+				Tenancy:               "default",
+				TotalInstanceCount:    3,
+				AccountID:             "123456789012",
+			},
+			{
+				CapacityReservationID: "cr-full",
+				InstanceType:          "m5.xlarge",
+				Region:                "us-west-2",
+				AvailabilityZone:      "us-west-2a",
+				TotalInstanceCount:    1,
+			},
+			{
+				CapacityReservationID: "cr-windows",
+				InstanceType:          "c5.large",
+				Region:                "us-west-2",
+				AvailabilityZone:      "us-west-2b",
+				Platform:              aws.ProductDescriptionWindows,
+				TotalInstanceCount:    2,
+			},
+			{
+				// No price is known for this instance type
+				CapacityReservationID: "cr-unpriced",
+				InstanceType:          "x2iedn.32xlarge",
+				Region:                "us-west-2",
+				TotalInstanceCount:    1,
+			},
+		},
+		PricingCache: &mockPricingCache{},
+		OnDemandPrices: map[string]float64{
+			"m5.xlarge:us-west-2:linux":  0.192,
+			"c5.large:us-west-2:windows": 0.177,
+		},
+	}
+
+	result := calc.Calculate(input)
+
+	assert.Equal(t, "cr-partial", result.InstanceCosts["i-001"].CapacityReservationID)
+	assert.Equal(t, "", result.InstanceCosts["i-003"].CapacityReservationID)
+	assert.Equal(t, 0.192, result.InstanceCosts["i-001"].EffectiveCost, "reservations don't change instance costs")
+
+	require.Len(t, result.CapacityReservationUtilization, 4)
+
+	partial := result.CapacityReservationUtilization["cr-partial"]
+	assert.Equal(t, int32(1), partial.UsedInstances, "stopped instances don't use the reservation")
+	assert.InDelta(t, 2*0.192, partial.UnusedHourlyCost, 0.0001)
+
+	full := result.CapacityReservationUtilization["cr-full"]
+	assert.Equal(t, int32(1), full.UsedInstances)
+	assert.Equal(t, 0.0, full.UnusedHourlyCost)
+
+	windows := result.CapacityReservationUtilization["cr-windows"]
+	assert.InDelta(t, 2*0.177, windows.UnusedHourlyCost, 0.0001)
+
+	assert.Equal(t, 0.0, result.CapacityReservationUtilization["cr-unpriced"].UnusedHourlyCost)
+
+	// Unused reservations aren't part of the instance totals
+	assert.InDelta(t, 4*0.192, result.TotalEstimatedCost, 0.0001)
+}
+
+// TestCalculatorIgnoresNonEC2SavingsPlans tests that SageMaker and Database Savings
+// Plans neither cover instances nor show up in Savings Plan utilization.
+func TestCalculatorIgnoresNonEC2SavingsPlans(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	input := CalculationInput{
+		Instances: []aws.Instance{{
+			InstanceID:       "i-001",
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			LaunchTime:       testBaseTime(),
+		}},
+		SavingsPlans: []aws.SavingsPlan{
+			{
+				SavingsPlanARN:  "arn:aws:savingsplans::123456789012:savingsplan/sagemaker",
+				SavingsPlanType: aws.SavingsPlanTypeSageMaker,
+				Region:          "all",
+				Commitment:      10.00,
+			},
+			{
+				SavingsPlanARN:  "arn:aws:savingsplans::123456789012:savingsplan/database",
+				SavingsPlanType: aws.SavingsPlanTypeDatabase,
+				Region:          "us-west-2",
+				Commitment:      5.00,
+			},
+		},
+		PricingCache:   &mockPricingCache{},
+		OnDemandPrices: map[string]float64{"m5.xlarge:us-west-2:linux": 0.192},
+	}
+
+	result := calc.Calculate(input)
+
+	assert.Empty(t, result.SavingsPlanUtilization)
+	assert.Equal(t, CoverageOnDemand, result.InstanceCosts["i-001"].CoverageType)
+	assert.Equal(t, 0.192, result.InstanceCosts["i-001"].EffectiveCost)
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// DailyEstimates turns the Calculator's $/hour results into $ totals per UTC day,
+// so they can be compared with daily billing data (see pkg/billing).
+//
+// Each recorded result is assumed to hold from the time it was recorded until the
+// next one, the same way SavingsPlanLedger treats results within an hour. Only the
+// most recent retentionDays days are kept.
+//
+// The totals only cover time the controller was running. ObservedSeconds says how
+// much of the day that was, so partial days (e.g., after a restart) can be skipped.
+//
+// Thread-safety: All methods are safe for concurrent access.
+type DailyEstimates struct {
+	mu sync.Mutex
+
+	// retentionDays is how many days of totals to keep, including the current one
+	retentionDays int
+
+	// lastRecorded is when the most recent result was recorded
+	lastRecorded time.Time
+
+	// current is the most recent result; its rates apply from lastRecorded onwards
+	current *CalculationResult
+
+	// days holds the totals keyed by the start of the UTC day
+	days map[time.Time]*DailyEstimate
+}
+
+// DailyEstimate holds Lumina's estimated costs for one UTC day.
+type DailyEstimate struct {
+	// Date is the start of the day (UTC midnight)
+	Date time.Time
+
+	// AccountCosts maps account ID to the estimated EC2 instance cost ($),
+	// the sum of InstanceCost.EffectiveCost over the day
+	AccountCosts map[string]float64
+
+	// SavingsPlanCosts maps Savings Plan ARN to the estimated commitment consumed ($),
+	// the sum of SavingsPlanUtilization.CurrentUtilizationRate over the day.
+	// Every Savings Plan seen during the day is present, even if unused.
+	SavingsPlanCosts map[string]float64
+
+	// ObservedSeconds is how much of the day the estimate covers (at most 86400)
+	ObservedSeconds float64
+}
+
+// NewDailyEstimates creates an empty accumulator that keeps retentionDays days of
+// estimates. A retentionDays below 1 keeps only the current day.
+func NewDailyEstimates(retentionDays int) *DailyEstimates {
+	if retentionDays < 1 {
+		retentionDays = 1
+	}
+	return &DailyEstimates{
+		retentionDays: retentionDays,
+		days:          make(map[time.Time]*DailyEstimate),
+	}
+}
+
+// Record adds the previous result's costs up to now, then makes result the
+// current one. Calls with a now earlier than the previous call add nothing.
+func (d *DailyEstimates) Record(result CalculationResult, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.current != nil {
+		// Split the interval at each midnight so every day gets its own share
+		for d.lastRecorded.Before(now) {
+			end := now
+			if nextDay := utcDayStart(d.lastRecorded).Add(24 * time.Hour); end.After(nextDay) {
+				end = nextDay
+			}
+			d.add(utcDayStart(d.lastRecorded), end.Sub(d.lastRecorded).Seconds())
+			d.lastRecorded = end
+		}
+	}
+
+	d.current = &result
+	if now.After(d.lastRecorded) {
+		d.lastRecorded = now
+	}
+	d.prune(now)
+}
+
+// Day returns a copy of the estimate for the UTC day containing date.
+// Returns (estimate, false) if nothing was recorded for that day.
+func (d *DailyEstimates) Day(date time.Time) (DailyEstimate, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	day, exists := d.days[utcDayStart(date)]
+	if !exists {
+		return DailyEstimate{}, false
+	}
+	return DailyEstimate{
+		Date:             day.Date,
+		AccountCosts:     maps.Clone(day.AccountCosts),
+		SavingsPlanCosts: maps.Clone(day.SavingsPlanCosts),
+		ObservedSeconds:  day.ObservedSeconds,
+	}, true
+}
+
+// add accumulates the current result's costs for the given number of seconds of date.
+func (d *DailyEstimates) add(date time.Time, seconds float64) {
+	if seconds <= 0 {
+		return
+	}
+	hours := seconds / 3600
+
+	day, exists := d.days[date]
+	if !exists {
+		day = &DailyEstimate{
+			Date:             date,
+			AccountCosts:     make(map[string]float64),
+			SavingsPlanCosts: make(map[string]float64),
+		}
+		d.days[date] = day
+	}
+
+	for _, ic := range d.current.InstanceCosts {
+		day.AccountCosts[ic.AccountID] += ic.EffectiveCost * hours
+	}
+	for arn, util := range d.current.SavingsPlanUtilization {
+		day.SavingsPlanCosts[arn] += util.CurrentUtilizationRate * hours
+	}
+	day.ObservedSeconds += seconds
+}
+
+// prune drops days that fall outside the retention window ending at now.
+func (d *DailyEstimates) prune(now time.Time) {
+	oldest := utcDayStart(now).AddDate(0, 0, -(d.retentionDays - 1))
+	for date := range d.days {
+		if date.Before(oldest) {
+			delete(d.days, date)
+		}
+	}
+}
+
+// utcDayStart returns the start of the UTC day containing t.
+func utcDayStart(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// estimatesResult builds a CalculationResult with two instances in different
+// accounts and one Savings Plan consuming spRate $/hour.
+func estimatesResult(spRate float64) CalculationResult {
+	return CalculationResult{
+		InstanceCosts: map[string]InstanceCost{
+			"i-001": {InstanceID: "i-001", AccountID: "111111111111", EffectiveCost: 1.00},
+			"i-002": {InstanceID: "i-002", AccountID: "222222222222", EffectiveCost: 0.50},
+		},
+		SavingsPlanUtilization: map[string]SavingsPlanUtilization{
+			"arn:sp-1": {SavingsPlanARN: "arn:sp-1", CurrentUtilizationRate: spRate},
+		},
+	}
+}
+
+// TestDailyEstimatesAccumulates tests that costs are summed over the time each
+// result was in effect.
+func TestDailyEstimatesAccumulates(t *testing.T) {
+	estimates := NewDailyEstimates(3)
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	estimates.Record(estimatesResult(0.40), day.Add(6*time.Hour))
+	estimates.Record(estimatesResult(0.80), day.Add(8*time.Hour))  // 2h at $0.40/h SP
+	estimates.Record(estimatesResult(0.80), day.Add(12*time.Hour)) // 4h at $0.80/h SP
+
+	estimate, ok := estimates.Day(day.Add(13 * time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, day, estimate.Date)
+	assert.InDelta(t, 6.0, estimate.AccountCosts["111111111111"], 0.0001)
+	assert.InDelta(t, 3.0, estimate.AccountCosts["222222222222"], 0.0001)
+	assert.InDelta(t, 0.80+3.20, estimate.SavingsPlanCosts["arn:sp-1"], 0.0001)
+	assert.InDelta(t, 6*3600, estimate.ObservedSeconds, 0.001)
+
+	// Day returns a copy
+	estimate.AccountCosts["111111111111"] = 0
+	again, _ := estimates.Day(day)
+	assert.InDelta(t, 6.0, again.AccountCosts["111111111111"], 0.0001)
+}
+
+// TestDailyEstimatesSplitsAtMidnight tests that an interval spanning midnight is
+// split between the two days.
+func TestDailyEstimatesSplitsAtMidnight(t *testing.T) {
+	estimates := NewDailyEstimates(3)
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	estimates.Record(estimatesResult(0), day.Add(22*time.Hour))
+	estimates.Record(estimatesResult(0), day.Add(27*time.Hour))
+
+	first, ok := estimates.Day(day)
+	require.True(t, ok)
+	assert.InDelta(t, 2.0, first.AccountCosts["111111111111"], 0.0001)
+	assert.InDelta(t, 2*3600, first.ObservedSeconds, 0.001)
+
+	second, ok := estimates.Day(day.Add(24 * time.Hour))
+	require.True(t, ok)
+	assert.InDelta(t, 3.0, second.AccountCosts["111111111111"], 0.0001)
+	assert.InDelta(t, 3*3600, second.ObservedSeconds, 0.001)
+
+	// Unused Savings Plans are still reported
+	assert.Contains(t, second.SavingsPlanCosts, "arn:sp-1")
+}
+
+// TestDailyEstimatesRetention tests that days older than the retention window
+// are dropped.
+func TestDailyEstimatesRetention(t *testing.T) {
+	estimates := NewDailyEstimates(2)
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	estimates.Record(estimatesResult(0), day.Add(12*time.Hour))
+	estimates.Record(estimatesResult(0), day.Add(36*time.Hour))
+	estimates.Record(estimatesResult(0), day.Add(60*time.Hour))
+
+	_, ok := estimates.Day(day)
+	assert.False(t, ok, "day outside retention should be dropped")
+
+	estimate, ok := estimates.Day(day.Add(24 * time.Hour))
+	require.True(t, ok)
+	assert.InDelta(t, 86400, estimate.ObservedSeconds, 0.001)
+}
+
+// TestDailyEstimatesClockSkew tests that going back in time adds nothing.
+func TestDailyEstimatesClockSkew(t *testing.T) {
+	estimates := NewDailyEstimates(1)
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	estimates.Record(estimatesResult(0), day.Add(2*time.Hour))
+	estimates.Record(estimatesResult(0), day.Add(3*time.Hour))
+	estimates.Record(estimatesResult(0), day.Add(1*time.Hour))
+
+	estimate, ok := estimates.Day(day)
+	require.True(t, ok)
+	assert.InDelta(t, 3600, estimate.ObservedSeconds, 0.001)
+}
@@ -0,0 +1,303 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import "github.com/nextdoor/lumina/pkg/aws"
+
+// RejectionReason is why a Reserved Instance or Savings Plan didn't cover an instance.
+type RejectionReason string
+
+const (
+	// RejectedSpot indicates a spot instance, which RIs and SPs never cover
+	RejectedSpot RejectionReason = "spot_instance"
+
+	// RejectedInstanceType indicates the instance's type (or family, for size-flexible
+	// RIs and EC2 Instance SPs) doesn't match
+	RejectedInstanceType RejectionReason = "instance_type_mismatch"
+
+	// RejectedLocation indicates the instance's Availability Zone (zonal RIs) or
+	// region doesn't match
+	RejectedLocation RejectionReason = "location_mismatch"
+
+	// RejectedPlatform indicates an RI can't cover the instance's platform or
+	// tenancy
+	RejectedPlatform RejectionReason = "platform_or_tenancy_mismatch"
+
+	// RejectedAccount indicates the instance's account isn't covered in this
+	// allocation pass: the owner pass covers only the owner account, and the shared
+	// pass only accounts that share discounts
+	RejectedAccount RejectionReason = "account_not_eligible"
+
+	// RejectedOwnerNotSharing indicates the owner account has discount sharing
+	// turned off, so the commitment skips the shared pass
+	RejectedOwnerNotSharing RejectionReason = "owner_not_sharing"
+
+	// RejectedRICovered indicates Reserved Instances already cover the instance
+	RejectedRICovered RejectionReason = "covered_by_reserved_instance"
+
+	// RejectedSPCovered indicates Savings Plans already cover all of the instance
+	RejectedSPCovered RejectionReason = "covered_by_savings_plan"
+
+	// RejectedNoRate indicates no Savings Plan rate could be determined for the instance
+	RejectedNoRate RejectionReason = "no_rate"
+
+	// RejectedCapacityExhausted indicates the RI's capacity or the SP's commitment ran
+	// out before reaching the instance
+	RejectedCapacityExhausted RejectionReason = "capacity_exhausted"
+)
+
+// Explanation records how a calculation arrived at one instance's cost.
+type Explanation struct {
+	// Instance is the instance as it was calculated
+	Instance aws.Instance
+
+	// Cost is the instance's calculated cost: the final breakdown of the decisions below
+	Cost InstanceCost
+
+	// ReservedInstances lists each Reserved Instance considered for the instance, once
+	// per allocation pass, in the order they were applied
+	ReservedInstances []ReservedInstanceDecision
+
+	// SavingsPlans lists each Savings Plan considered for the instance, once per
+	// allocation pass, in the order they were applied
+	SavingsPlans []SavingsPlanDecision
+}
+
+// ReservedInstanceDecision records whether a Reserved Instance covered an instance in
+// one allocation pass.
+type ReservedInstanceDecision struct {
+	ReservedInstanceID string
+	AccountID          string
+	InstanceType       string
+	SizeFlexible       bool
+
+	// Shared is true for the pass that shares the RI with accounts other than its owner
+	Shared bool
+
+	// Rank is the instance's position (from 1) in the order the RI covers eligible
+	// instances; 0 if the instance wasn't eligible
+	Rank int
+
+	// RemainingCapacity is how many of the RI's instances were left when it reached
+	// the instance or, if it never did, when the RI was considered
+	RemainingCapacity float64
+
+	// NormalizedUnits and Coverage are what the RI applied to the instance (see
+	// RIContribution)
+	NormalizedUnits float64
+	Coverage        float64
+
+	// Rejection is why the RI didn't cover the instance; empty if it did
+	Rejection RejectionReason
+}
+
+// SavingsPlanDecision records whether a Savings Plan covered an instance in one
+// allocation pass.
+type SavingsPlanDecision struct {
+	SavingsPlanARN  string
+	SavingsPlanType string
+	AccountID       string
+
+	// Shared is true for the pass that shares the SP with accounts other than its owner
+	Shared bool
+
+	// Rank is the instance's position (from 1) in the SP's savings percentage order,
+	// out of Eligible instances and serverless usage; 0 if it wasn't eligible. With
+	// SavingsPlanAllocationGlobal, the order ranks the eligible usage of all SPs of
+	// the type together.
+	Rank     int
+	Eligible int
+
+	// SavingsPercent, SPRate and RateAccurate are the savings the SP offered the
+	// instance, which decide its rank (see sortBySavings)
+	SavingsPercent float64
+	SPRate         float64
+	RateAccurate   bool
+
+	// RemainingCommitment is the commitment left ($/hour) when the SP reached the
+	// instance or, if it never did, when the SP was considered
+	RemainingCommitment float64
+
+	// Coverage is the commitment the SP applied to the instance ($/hour)
+	Coverage float64
+
+	// Rejection is why the SP didn't cover the instance; empty if it did
+	Rejection RejectionReason
+}
+
+// Explain runs the calculation on input and records every Reserved Instance and
+// Savings Plan allocation decision involving instanceID. It returns false if the
+// calculation has no cost for the instance.
+func (c *Calculator) Explain(input CalculationInput, instanceID string) (Explanation, bool) {
+	trace := &explainer{instanceID: instanceID}
+	result := c.calculate(input, trace)
+
+	instanceCost, ok := result.InstanceCosts[instanceID]
+	if !ok {
+		return Explanation{}, false
+	}
+	trace.explanation.Cost = instanceCost
+	for _, inst := range input.Instances {
+		if inst.InstanceID == instanceID {
+			trace.explanation.Instance = inst
+			break
+		}
+	}
+	return trace.explanation, true
+}
+
+// explainer records the allocation decisions involving one instance. Allocation
+// reports every decision to it; a nil *explainer ignores them, so normal calculations
+// record nothing.
+//
+// Each RI or SP decision starts out as RejectedCapacityExhausted, and is updated as
+// the commitment rejects, ranks or covers the instance. Only the last RI decision, and
+// each SP's last decision, is ever updated.
+type explainer struct {
+	instanceID  string
+	explanation Explanation
+}
+
+// considerReservedInstance starts the decision for ri in an allocation pass, with
+// skipped set if the RI isn't applied in the pass at all.
+func (e *explainer) considerReservedInstance(
+	ri *aws.ReservedInstance, shared bool, remaining float64, skipped RejectionReason,
+) {
+	if e == nil {
+		return
+	}
+	if skipped == "" {
+		skipped = RejectedCapacityExhausted
+	}
+	e.explanation.ReservedInstances = append(e.explanation.ReservedInstances, ReservedInstanceDecision{
+		ReservedInstanceID: ri.ReservedInstanceID,
+		AccountID:          ri.AccountID,
+		InstanceType:       ri.InstanceType,
+		SizeFlexible:       isSizeFlexibleRI(ri),
+		Shared:             shared,
+		RemainingCapacity:  remaining,
+		Rejection:          skipped,
+	})
+}
+
+// rejectReservedInstance records why the current RI can't cover instanceID.
+func (e *explainer) rejectReservedInstance(instanceID string, reason RejectionReason) {
+	if decision := e.reservedInstance(instanceID); decision != nil {
+		decision.Rejection = reason
+	}
+}
+
+// rankReservedInstance records the position of the explained instance among the
+// current RI's eligible instances, in the order it covers them.
+func (e *explainer) rankReservedInstance(eligible []*aws.Instance) {
+	if e == nil {
+		return // Skip the loop in normal calculations
+	}
+	for i, inst := range eligible {
+		if decision := e.reservedInstance(inst.InstanceID); decision != nil {
+			decision.Rank = i + 1
+		}
+	}
+}
+
+// coverReservedInstance records the current RI covering instanceID, with remaining
+// of its instances left before it did.
+func (e *explainer) coverReservedInstance(instanceID string, remaining float64, contribution RIContribution) {
+	if decision := e.reservedInstance(instanceID); decision != nil {
+		decision.RemainingCapacity = remaining
+		decision.NormalizedUnits = contribution.NormalizedUnits
+		decision.Coverage = contribution.Coverage
+		decision.Rejection = ""
+	}
+}
+
+// reservedInstance returns the current RI decision if instanceID is the explained
+// instance, or nil.
+func (e *explainer) reservedInstance(instanceID string) *ReservedInstanceDecision {
+	if e == nil || instanceID != e.instanceID || len(e.explanation.ReservedInstances) == 0 {
+		return nil
+	}
+	return &e.explanation.ReservedInstances[len(e.explanation.ReservedInstances)-1]
+}
+
+// considerSavingsPlan starts the decision for sp in an allocation pass, with skipped
+// set if the SP isn't applied in the pass at all.
+func (e *explainer) considerSavingsPlan(sp *aws.SavingsPlan, shared bool, remaining float64, skipped RejectionReason) {
+	if e == nil {
+		return
+	}
+	if skipped == "" {
+		skipped = RejectedCapacityExhausted
+	}
+	e.explanation.SavingsPlans = append(e.explanation.SavingsPlans, SavingsPlanDecision{
+		SavingsPlanARN:      sp.SavingsPlanARN,
+		SavingsPlanType:     sp.SavingsPlanType,
+		AccountID:           sp.AccountID,
+		Shared:              shared,
+		RemainingCommitment: remaining,
+		Rejection:           skipped,
+	})
+}
+
+// rejectSavingsPlan records why the SP spARN can't cover instanceID.
+func (e *explainer) rejectSavingsPlan(instanceID, spARN string, reason RejectionReason) {
+	if decision := e.savingsPlan(instanceID, spARN); decision != nil {
+		decision.Rejection = reason
+	}
+}
+
+// rankSavingsPlan records the savings each SP offers the explained instance and its
+// position among the eligible usage, in the order it is covered.
+func (e *explainer) rankSavingsPlan(eligible []instanceWithSavings) {
+	if e == nil {
+		return // Skip the loop in normal calculations
+	}
+	for i, item := range eligible {
+		if item.Instance == nil {
+			continue
+		}
+		if decision := e.savingsPlan(item.Instance.InstanceID, item.SavingsPlan.SavingsPlanARN); decision != nil {
+			decision.Rank = i + 1
+			decision.Eligible = len(eligible)
+			decision.SavingsPercent = item.SavingsPercent
+			decision.SPRate = item.SPRate
+			decision.RateAccurate = item.IsAccurate
+		}
+	}
+}
+
+// coverSavingsPlan records the SP spARN covering instanceID with coverage of its
+// commitment, with remaining commitment left before it did.
+func (e *explainer) coverSavingsPlan(instanceID, spARN string, remaining, coverage float64) {
+	if decision := e.savingsPlan(instanceID, spARN); decision != nil {
+		decision.RemainingCommitment = remaining
+		decision.Coverage = coverage
+		decision.Rejection = ""
+	}
+}
+
+// savingsPlan returns the last decision for the SP spARN if instanceID is the
+// explained instance, or nil.
+func (e *explainer) savingsPlan(instanceID, spARN string) *SavingsPlanDecision {
+	if e == nil || instanceID != e.instanceID {
+		return nil
+	}
+	for i := len(e.explanation.SavingsPlans) - 1; i >= 0; i-- {
+		if e.explanation.SavingsPlans[i].SavingsPlanARN == spARN {
+			return &e.explanation.SavingsPlans[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newExplainTestInput creates two payer instances competing for one payer RI and a
+// Savings Plan too small to cover the second, plus a member instance and a spot
+// instance the RI and SP don't reach. Estimated SP rates are 0.72 * $0.192.
+func newExplainTestInput() CalculationInput {
+	ri := newRegionalTestRI("ri-payer", "m5.xlarge", 1)
+	ri.AccountID = payerAccount
+	otherFamily := newRegionalTestRI("ri-c5", "c5.xlarge", 1)
+	otherFamily.AccountID = payerAccount
+
+	spot := newSharingTestInstance("i-spot", payerAccount, 4*time.Hour)
+	spot.Lifecycle = lifecycleSpot
+
+	return CalculationInput{
+		Instances: []aws.Instance{
+			newSharingTestInstance("i-001", payerAccount, 1*time.Hour),
+			newSharingTestInstance("i-002", payerAccount, 2*time.Hour),
+			newSharingTestInstance("i-003", memberAccount, 3*time.Hour),
+			spot,
+		},
+		ReservedInstances: []aws.ReservedInstance{ri, otherFamily},
+		SavingsPlans:      []aws.SavingsPlan{newSharingTestSP("sp-payer", payerAccount, 0.10)},
+		OnDemandPrices:    sizeFlexTestPrices(),
+		PricingCache:      &mockPricingCache{},
+	}
+}
+
+// TestExplain verifies the decisions recorded for an instance partially covered by a
+// Savings Plan after losing a Reserved Instance to an older instance.
+func TestExplain(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+	input := newExplainTestInput()
+
+	explanation, ok := calc.Explain(input, "i-002")
+	require.True(t, ok)
+	assert.Equal(t, "i-002", explanation.Instance.InstanceID)
+	assert.Equal(t, calc.Calculate(input).InstanceCosts["i-002"], explanation.Cost)
+	assert.InDelta(t, 0.10, explanation.Cost.SavingsPlanCoverage, 1e-9)
+
+	assert.Equal(t, []ReservedInstanceDecision{
+		{
+			// The older i-001 got the RI's only instance
+			ReservedInstanceID: "ri-payer", AccountID: payerAccount, InstanceType: "m5.xlarge", SizeFlexible: true,
+			Rank: 2, RemainingCapacity: 1, Rejection: RejectedCapacityExhausted,
+		},
+		{
+			ReservedInstanceID: "ri-c5", AccountID: payerAccount, InstanceType: "c5.xlarge", SizeFlexible: true,
+			RemainingCapacity: 1, Rejection: RejectedInstanceType,
+		},
+		{
+			ReservedInstanceID: "ri-payer", AccountID: payerAccount, InstanceType: "m5.xlarge", SizeFlexible: true,
+			Shared: true, Rejection: RejectedCapacityExhausted,
+		},
+		{
+			ReservedInstanceID: "ri-c5", AccountID: payerAccount, InstanceType: "c5.xlarge", SizeFlexible: true,
+			Shared: true, RemainingCapacity: 1, Rejection: RejectedInstanceType,
+		},
+	}, explanation.ReservedInstances)
+
+	require.Len(t, explanation.SavingsPlans, 2)
+	owner := explanation.SavingsPlans[0]
+	assert.Equal(t, "sp-payer", owner.SavingsPlanARN)
+	assert.False(t, owner.Shared)
+	assert.Equal(t, 1, owner.Rank)
+	assert.Equal(t, 1, owner.Eligible)
+	assert.InDelta(t, 0.28, owner.SavingsPercent, 1e-9)
+	assert.InDelta(t, 0.192*0.72, owner.SPRate, 1e-9)
+	assert.False(t, owner.RateAccurate)
+	assert.InDelta(t, 0.10, owner.RemainingCommitment, 1e-9)
+	assert.InDelta(t, 0.10, owner.Coverage, 1e-9)
+	assert.Empty(t, owner.Rejection)
+
+	shared := explanation.SavingsPlans[1]
+	assert.True(t, shared.Shared)
+	assert.Equal(t, RejectedAccount, shared.Rejection)
+}
+
+// TestExplainRejections verifies the reasons recorded for instances the RIs and SPs
+// didn't cover.
+func TestExplainRejections(t *testing.T) {
+	rejections := func(explanation Explanation) ([]RejectionReason, []RejectionReason) {
+		var ris, sps []RejectionReason
+		for _, decision := range explanation.ReservedInstances {
+			ris = append(ris, decision.Rejection)
+		}
+		for _, decision := range explanation.SavingsPlans {
+			sps = append(sps, decision.Rejection)
+		}
+		return ris, sps
+	}
+
+	tests := []struct {
+		name       string
+		instanceID string
+		disabled   map[string]bool
+		wantRIs    []RejectionReason
+		wantSPs    []RejectionReason
+	}{
+		{
+			name:       "covered by RI",
+			instanceID: "i-001",
+			wantRIs:    []RejectionReason{"", RejectedInstanceType, RejectedCapacityExhausted, RejectedInstanceType},
+			wantSPs:    []RejectionReason{RejectedRICovered, RejectedAccount},
+		},
+		{
+			name:       "other account",
+			instanceID: "i-003",
+			wantRIs: []RejectionReason{
+				RejectedAccount, RejectedInstanceType, RejectedCapacityExhausted, RejectedInstanceType,
+			},
+			// The SP ranks the instance in the shared pass, but has no commitment left
+			wantSPs: []RejectionReason{RejectedAccount, RejectedCapacityExhausted},
+		},
+		{
+			name:       "owner not sharing",
+			instanceID: "i-003",
+			disabled:   map[string]bool{payerAccount: true},
+			wantRIs: []RejectionReason{
+				RejectedAccount, RejectedInstanceType, RejectedCapacityExhausted, RejectedOwnerNotSharing,
+			},
+			wantSPs: []RejectionReason{RejectedAccount, RejectedOwnerNotSharing},
+		},
+		{
+			name:       "spot",
+			instanceID: "i-spot",
+			wantRIs:    []RejectionReason{RejectedSpot, RejectedSpot, RejectedCapacityExhausted, RejectedSpot},
+			wantSPs:    []RejectionReason{RejectedSpot, RejectedSpot},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := newExplainTestInput()
+			input.SharingDisabledAccounts = tt.disabled
+
+			explanation, ok := NewCalculator(nil, nil).Explain(input, tt.instanceID)
+			require.True(t, ok)
+			ris, sps := rejections(explanation)
+			assert.Equal(t, tt.wantRIs, ris)
+			assert.Equal(t, tt.wantSPs, sps)
+		})
+	}
+
+	_, ok := NewCalculator(nil, nil).Explain(newExplainTestInput(), "i-missing")
+	assert.False(t, ok)
+}
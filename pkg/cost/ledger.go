@@ -0,0 +1,235 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// SavingsPlanLedger accumulates Savings Plan usage over the current AWS billing hour.
+//
+// AWS applies each Savings Plan's hourly commitment to the total eligible usage within
+// a clock hour (UTC), not to the instantaneous rate. If instances churn within the hour
+// (e.g., spot replacements or Karpenter consolidation), a burst above the commitment
+// can be absorbed by idle commitment earlier or later in the same hour. The Calculator's
+// rate-based utilization reports that burst as spillover; the ledger doesn't.
+//
+// The ledger treats each CalculationResult as the state of the fleet from the time it
+// is recorded until the next one, and integrates each SP's demand (covered usage plus
+// unmet demand, at SP rates) and covered instance-seconds over that interval. At the
+// top of each hour the totals reset, matching AWS's hourly commitment.
+//
+// Unlike the Calculator, the ledger is stateful. After a controller restart, the first
+// billing hour only includes the time observed since startup (see ObservedSeconds).
+//
+// Thread-safety: All methods are safe for concurrent access.
+type SavingsPlanLedger struct {
+	mu sync.Mutex
+
+	// hourStart is the start of the billing hour being accumulated
+	hourStart time.Time
+
+	// lastRecorded is when the most recent result was recorded
+	lastRecorded time.Time
+
+	// current is the most recent result; its rates apply from lastRecorded onwards
+	current *CalculationResult
+
+	// accumulators holds the per-SP totals for the current billing hour
+	accumulators map[string]*ledgerAccumulator
+}
+
+// ledgerAccumulator holds the running totals for one Savings Plan within a billing hour.
+type ledgerAccumulator struct {
+	demand          float64 // $ of eligible usage at SP rates (covered + unmet)
+	instanceSeconds float64 // seconds of instance time covered by the SP
+	observedSeconds float64 // seconds of the hour the SP was observed
+}
+
+// SavingsPlanLedgerEntry is the cumulative usage of one Savings Plan within the
+// current billing hour.
+type SavingsPlanLedgerEntry struct {
+	// SavingsPlanARN is the unique identifier for this Savings Plan
+	SavingsPlanARN string
+
+	// AccountID is the AWS account that owns this Savings Plan
+	AccountID string
+
+	// AccountName is the friendly name of the AWS account
+	AccountName string
+
+	// Type is the Savings Plan type: "EC2Instance" or "Compute"
+	Type string
+
+	// HourStart is the start of the billing hour (UTC)
+	HourStart time.Time
+
+	// HourlyCommitment is the $ commitment available for the whole billing hour
+	HourlyCommitment float64
+
+	// CommitmentUsed is the commitment consumed so far this billing hour ($).
+	// This is the eligible usage at SP rates, capped at HourlyCommitment.
+	CommitmentUsed float64
+
+	// Spillover is the eligible usage so far this billing hour ($ at SP rates) that
+	// exceeds the commitment and is billed at on-demand rates.
+	Spillover float64
+
+	// ProjectedUtilizationPercent is the utilization of the commitment at the end of
+	// the billing hour, assuming the current instances keep running until then.
+	// Capped at 100%; see ProjectedSpillover for usage beyond the commitment.
+	ProjectedUtilizationPercent float64
+
+	// ProjectedSpillover is the Spillover expected at the end of the billing hour,
+	// assuming the current instances keep running until then ($ at SP rates).
+	ProjectedSpillover float64
+
+	// InstanceSeconds is the instance time covered by this SP so far this billing hour
+	InstanceSeconds float64
+
+	// ObservedSeconds is how much of the billing hour the ledger has observed.
+	// Less than the elapsed time when the controller started mid-hour.
+	ObservedSeconds float64
+}
+
+// NewSavingsPlanLedger creates an empty ledger. The billing hour starts at the first
+// recorded result.
+func NewSavingsPlanLedger() *SavingsPlanLedger {
+	return &SavingsPlanLedger{
+		accumulators: make(map[string]*ledgerAccumulator),
+	}
+}
+
+// Record advances the ledger to now using the previously recorded result, then makes
+// result the fleet state going forward. Returns the ledger entries for every Savings
+// Plan in result, as of now.
+//
+// Calls with a now earlier than the previous call don't accumulate any usage.
+func (l *SavingsPlanLedger) Record(result CalculationResult, now time.Time) map[string]SavingsPlanLedgerEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.advance(now)
+	l.current = &result
+	if now.After(l.lastRecorded) {
+		l.lastRecorded = now
+	}
+
+	return l.snapshot(now)
+}
+
+// advance accumulates the current result's usage from lastRecorded until now,
+// resetting the totals at each billing hour boundary along the way.
+func (l *SavingsPlanLedger) advance(now time.Time) {
+	if l.current == nil {
+		// First observation: start the billing hour, nothing to accumulate yet
+		l.hourStart = billingHourStart(now)
+		l.lastRecorded = now
+		return
+	}
+
+	for l.lastRecorded.Before(now) {
+		hourEnd := l.hourStart.Add(time.Hour)
+		end := now
+		if end.After(hourEnd) {
+			end = hourEnd
+		}
+
+		l.accumulate(end.Sub(l.lastRecorded).Seconds())
+		l.lastRecorded = end
+
+		// AWS resets Savings Plan commitment at the top of every hour
+		if !end.Before(hourEnd) {
+			l.hourStart = hourEnd
+			l.accumulators = make(map[string]*ledgerAccumulator)
+		}
+	}
+}
+
+// accumulate adds the current result's usage over the given number of seconds.
+func (l *SavingsPlanLedger) accumulate(seconds float64) {
+	if seconds <= 0 {
+		return
+	}
+	hours := seconds / 3600
+
+	for arn, util := range l.current.SavingsPlanUtilization {
+		acc := l.accumulator(arn)
+		acc.demand += (util.CurrentUtilizationRate + util.UnmetDemandRate) * hours
+		acc.observedSeconds += seconds
+	}
+
+	for _, ic := range l.current.InstanceCosts {
+		for _, contribution := range ic.SavingsPlanContributions {
+			if contribution.Coverage > 0 {
+				l.accumulator(contribution.SavingsPlanARN).instanceSeconds += seconds
+			}
+		}
+	}
+}
+
+// accumulator returns the running totals for a Savings Plan, creating them if needed.
+func (l *SavingsPlanLedger) accumulator(arn string) *ledgerAccumulator {
+	acc, exists := l.accumulators[arn]
+	if !exists {
+		acc = &ledgerAccumulator{}
+		l.accumulators[arn] = acc
+	}
+	return acc
+}
+
+// snapshot builds ledger entries for every Savings Plan in the current result.
+func (l *SavingsPlanLedger) snapshot(now time.Time) map[string]SavingsPlanLedgerEntry {
+	entries := make(map[string]SavingsPlanLedgerEntry, len(l.current.SavingsPlanUtilization))
+
+	// Fraction of the billing hour left for the current instances to keep running
+	remainingHours := l.hourStart.Add(time.Hour).Sub(now).Hours()
+	remainingHours = math.Max(0, math.Min(1, remainingHours))
+
+	for arn, util := range l.current.SavingsPlanUtilization {
+		acc := l.accumulator(arn)
+		commitment := util.HourlyCommitment
+
+		projectedDemand := acc.demand + (util.CurrentUtilizationRate+util.UnmetDemandRate)*remainingHours
+
+		entry := SavingsPlanLedgerEntry{
+			SavingsPlanARN:     arn,
+			AccountID:          util.AccountID,
+			AccountName:        util.AccountName,
+			Type:               util.Type,
+			HourStart:          l.hourStart,
+			HourlyCommitment:   commitment,
+			CommitmentUsed:     math.Min(acc.demand, commitment),
+			Spillover:          math.Max(0, acc.demand-commitment),
+			ProjectedSpillover: math.Max(0, projectedDemand-commitment),
+			InstanceSeconds:    acc.instanceSeconds,
+			ObservedSeconds:    acc.observedSeconds,
+		}
+		if commitment > 0 {
+			entry.ProjectedUtilizationPercent = math.Min(projectedDemand, commitment) / commitment * 100
+		}
+		entries[arn] = entry
+	}
+
+	return entries
+}
+
+// billingHourStart returns the start of the AWS billing hour containing t.
+// AWS bills Savings Plans in clock hours (UTC).
+func billingHourStart(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour)
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ledgerTestSPARN = "arn:aws:savingsplans::123456789012:savingsplan/sp-ledger"
+
+// ledgerResult builds a CalculationResult with a single $1.00/hour Savings Plan that
+// is consuming covered $/hour of commitment, has unmet $/hour of spillover, and
+// covers the given number of instances.
+func ledgerResult(covered, unmet float64, instances int) CalculationResult {
+	result := CalculationResult{
+		InstanceCosts: make(map[string]InstanceCost),
+		SavingsPlanUtilization: map[string]SavingsPlanUtilization{
+			ledgerTestSPARN: {
+				SavingsPlanARN:         ledgerTestSPARN,
+				AccountID:              "123456789012",
+				AccountName:            "test",
+				Type:                   "Compute",
+				HourlyCommitment:       1.00,
+				CurrentUtilizationRate: covered,
+				UnmetDemandRate:        unmet,
+			},
+		},
+	}
+	for i := range instances {
+		id := string(rune('a' + i))
+		result.InstanceCosts[id] = InstanceCost{
+			InstanceID:          id,
+			SavingsPlanCoverage: covered / float64(instances),
+			SavingsPlanContributions: []SavingsPlanContribution{
+				{SavingsPlanARN: ledgerTestSPARN, Coverage: covered / float64(instances)},
+			},
+		}
+	}
+	return result
+}
+
+// TestSavingsPlanLedgerFirstRecord tests that the first observation only starts the
+// billing hour and doesn't accumulate any usage.
+func TestSavingsPlanLedgerFirstRecord(t *testing.T) {
+	ledger := NewSavingsPlanLedger()
+	start := time.Date(2025, 1, 15, 10, 15, 0, 0, time.UTC)
+
+	entries := ledger.Record(ledgerResult(0.50, 0, 1), start)
+	require.Contains(t, entries, ledgerTestSPARN)
+
+	entry := entries[ledgerTestSPARN]
+	assert.Equal(t, time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), entry.HourStart)
+	assert.Equal(t, 1.00, entry.HourlyCommitment)
+	assert.Equal(t, 0.0, entry.CommitmentUsed)
+	assert.Equal(t, 0.0, entry.Spillover)
+	assert.Equal(t, 0.0, entry.ObservedSeconds)
+	assert.Equal(t, "Compute", entry.Type)
+	assert.Equal(t, "123456789012", entry.AccountID)
+
+	// 45 minutes left at $0.50/hour → $0.375 of the $1.00 commitment
+	assert.InDelta(t, 37.5, entry.ProjectedUtilizationPercent, 0.001)
+}
+
+// TestSavingsPlanLedgerBurstAbsorbedWithinHour tests the case the ledger exists for:
+// a burst that exceeds the commitment at the instantaneous rate is absorbed by
+// commitment left idle earlier in the same hour.
+func TestSavingsPlanLedgerBurstAbsorbedWithinHour(t *testing.T) {
+	ledger := NewSavingsPlanLedger()
+	hour := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	// 10:00-10:30: one instance using $0.40/hour of a $1.00/hour commitment
+	ledger.Record(ledgerResult(0.40, 0, 1), hour)
+
+	// 10:30-10:45: burst to $1.60/hour of demand ($0.60/hour spilling over at the
+	// instantaneous rate)
+	entries := ledger.Record(ledgerResult(1.00, 0.60, 4), hour.Add(30*time.Minute))
+	entry := entries[ledgerTestSPARN]
+	assert.InDelta(t, 0.20, entry.CommitmentUsed, 0.0001) // $0.40 × 0.5h
+	assert.Equal(t, 0.0, entry.Spillover)
+	assert.InDelta(t, 1800, entry.InstanceSeconds, 0.001)
+	assert.InDelta(t, 1800, entry.ObservedSeconds, 0.001)
+
+	// 10:45-11:00: back to one instance
+	entries = ledger.Record(ledgerResult(0.40, 0, 1), hour.Add(45*time.Minute))
+	entry = entries[ledgerTestSPARN]
+
+	// $0.20 + $1.60 × 0.25h = $0.60 used, nothing billed at on-demand
+	assert.InDelta(t, 0.60, entry.CommitmentUsed, 0.0001)
+	assert.Equal(t, 0.0, entry.Spillover)
+	assert.InDelta(t, 1800+4*900, entry.InstanceSeconds, 0.001)
+	assert.InDelta(t, 2700, entry.ObservedSeconds, 0.001)
+
+	// Projected: $0.60 + $0.40 × 0.25h = $0.70 of $1.00
+	assert.InDelta(t, 70.0, entry.ProjectedUtilizationPercent, 0.001)
+	assert.Equal(t, 0.0, entry.ProjectedSpillover)
+}
+
+// TestSavingsPlanLedgerSpillover tests that demand beyond the hourly commitment is
+// reported as spillover.
+func TestSavingsPlanLedgerSpillover(t *testing.T) {
+	ledger := NewSavingsPlanLedger()
+	hour := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	// $1.00/hour covered + $1.00/hour unmet for 45 minutes = $1.50 of demand
+	ledger.Record(ledgerResult(1.00, 1.00, 2), hour)
+	entries := ledger.Record(ledgerResult(1.00, 1.00, 2), hour.Add(45*time.Minute))
+
+	entry := entries[ledgerTestSPARN]
+	assert.InDelta(t, 1.00, entry.CommitmentUsed, 0.0001)
+	assert.InDelta(t, 0.50, entry.Spillover, 0.0001)
+	assert.InDelta(t, 100.0, entry.ProjectedUtilizationPercent, 0.0001)
+	assert.InDelta(t, 1.00, entry.ProjectedSpillover, 0.0001) // $2.00 total - $1.00
+}
+
+// TestSavingsPlanLedgerHourlyReset tests that the totals reset at the top of each
+// billing hour, and that usage spanning a boundary is split between the hours.
+func TestSavingsPlanLedgerHourlyReset(t *testing.T) {
+	ledger := NewSavingsPlanLedger()
+	hour := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	ledger.Record(ledgerResult(0.80, 0, 1), hour.Add(50*time.Minute))
+
+	// 10:50-11:10 at $0.80/hour: only 11:00-11:10 counts towards the new hour
+	entries := ledger.Record(ledgerResult(0.80, 0, 1), hour.Add(70*time.Minute))
+	entry := entries[ledgerTestSPARN]
+	assert.Equal(t, hour.Add(time.Hour), entry.HourStart)
+	assert.InDelta(t, 0.80/6, entry.CommitmentUsed, 0.0001)
+	assert.InDelta(t, 600, entry.InstanceSeconds, 0.001)
+	assert.InDelta(t, 600, entry.ObservedSeconds, 0.001)
+
+	// A gap spanning several hours still ends up in the latest hour only
+	entries = ledger.Record(ledgerResult(0.80, 0, 1), hour.Add(4*time.Hour+30*time.Minute))
+	entry = entries[ledgerTestSPARN]
+	assert.Equal(t, hour.Add(4*time.Hour), entry.HourStart)
+	assert.InDelta(t, 0.40, entry.CommitmentUsed, 0.0001)
+	assert.InDelta(t, 1800, entry.ObservedSeconds, 0.001)
+}
+
+// TestSavingsPlanLedgerClockSkew tests that an observation earlier than the previous
+// one doesn't subtract or double-count usage.
+func TestSavingsPlanLedgerClockSkew(t *testing.T) {
+	ledger := NewSavingsPlanLedger()
+	hour := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	ledger.Record(ledgerResult(0.60, 0, 1), hour)
+	ledger.Record(ledgerResult(0.60, 0, 1), hour.Add(20*time.Minute))
+
+	entries := ledger.Record(ledgerResult(0.60, 0, 1), hour.Add(10*time.Minute))
+	assert.InDelta(t, 0.20, entries[ledgerTestSPARN].CommitmentUsed, 0.0001)
+
+	entries = ledger.Record(ledgerResult(0.60, 0, 1), hour.Add(30*time.Minute))
+	assert.InDelta(t, 0.30, entries[ledgerTestSPARN].CommitmentUsed, 0.0001)
+}
+
+// TestSavingsPlanLedgerRemovedPlan tests that entries are only returned for Savings
+// Plans in the latest result.
+func TestSavingsPlanLedgerRemovedPlan(t *testing.T) {
+	ledger := NewSavingsPlanLedger()
+	hour := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	ledger.Record(ledgerResult(0.60, 0, 1), hour)
+	entries := ledger.Record(CalculationResult{}, hour.Add(10*time.Minute))
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+// Karpenter capacity types (karpenter.sh/capacity-type label values).
+const (
+	CapacityTypeSpot     = "spot"
+	CapacityTypeOnDemand = "on-demand"
+	CapacityTypeReserved = "reserved"
+)
+
+// NodePoolMember identifies the Karpenter NodePool that provisioned an instance.
+type NodePoolMember struct {
+	// NodePool is the NodePool's name
+	NodePool string
+
+	// CapacityType is the instance's Karpenter capacity type (CapacityType*
+	// constants). If empty, it's derived from the instance's lifecycle.
+	CapacityType string
+}
+
+// NodePoolCost is the combined cost of the instances in a Karpenter NodePool.
+type NodePoolCost struct {
+	// NodePool is the NodePool's name
+	NodePool string
+
+	// EffectiveCost is the sum of the instances' EffectiveCost ($/hour)
+	EffectiveCost float64
+
+	// ShelfPrice is the sum of the instances' on-demand ShelfPrice ($/hour)
+	ShelfPrice float64
+
+	// CommitmentShelfPrice is the ShelfPrice of instances covered by a Reserved
+	// Instance or Savings Plan ($/hour). Partially covered instances count in full.
+	CommitmentShelfPrice float64
+
+	// SpotShelfPrice is the ShelfPrice of spot instances ($/hour)
+	SpotShelfPrice float64
+
+	// NodeCounts is the number of instances by capacity type
+	NodeCounts map[string]int
+}
+
+// CommitmentCoveragePercent returns the share of the NodePool's shelf price covered
+// by Reserved Instances and Savings Plans (0-100).
+func (c NodePoolCost) CommitmentCoveragePercent() float64 {
+	return percentOf(c.CommitmentShelfPrice, c.ShelfPrice)
+}
+
+// SpotPercent returns the share of the NodePool's shelf price running on spot
+// instances (0-100).
+func (c NodePoolCost) SpotPercent() float64 {
+	return percentOf(c.SpotShelfPrice, c.ShelfPrice)
+}
+
+// NodePoolCosts rolls up instance costs by Karpenter NodePool. members maps EC2
+// instance IDs to their NodePool; instances without one aren't included.
+//
+// Shares are weighted by shelf price rather than instance count, so a NodePool
+// mixing large on-demand and small spot instances reports how much of its capacity
+// (in on-demand dollars) is discounted.
+func NodePoolCosts(costs map[string]InstanceCost, members map[string]NodePoolMember) map[string]NodePoolCost {
+	result := make(map[string]NodePoolCost)
+	for instanceID, member := range members {
+		ic, exists := costs[instanceID]
+		if !exists || member.NodePool == "" {
+			continue
+		}
+
+		pool, exists := result[member.NodePool]
+		if !exists {
+			pool = NodePoolCost{NodePool: member.NodePool, NodeCounts: make(map[string]int)}
+		}
+
+		pool.EffectiveCost += ic.EffectiveCost
+		pool.ShelfPrice += ic.ShelfPrice
+		switch {
+		case ic.IsSpot:
+			pool.SpotShelfPrice += ic.ShelfPrice
+		case ic.CoverageType == CoverageReservedInstance,
+			ic.CoverageType == CoverageEC2InstanceSavingsPlan,
+			ic.CoverageType == CoverageComputeSavingsPlan:
+			pool.CommitmentShelfPrice += ic.ShelfPrice
+		}
+
+		capacityType := member.CapacityType
+		if capacityType == "" {
+			capacityType = CapacityTypeOnDemand
+			if ic.IsSpot {
+				capacityType = CapacityTypeSpot
+			}
+		}
+		pool.NodeCounts[capacityType]++
+
+		result[member.NodePool] = pool
+	}
+	return result
+}
+
+// percentOf returns part as a percentage of total, or 0 if total is 0.
+func percentOf(part, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return part / total * 100
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNodePoolCosts tests rolling up instance costs by Karpenter NodePool.
+func TestNodePoolCosts(t *testing.T) {
+	costs := map[string]InstanceCost{
+		"i-ri":       {ShelfPrice: 1.00, EffectiveCost: 0, CoverageType: CoverageReservedInstance},
+		"i-sp":       {ShelfPrice: 1.00, EffectiveCost: 0.72, CoverageType: CoverageComputeSavingsPlan},
+		"i-ondemand": {ShelfPrice: 2.00, EffectiveCost: 2.00, CoverageType: CoverageOnDemand},
+		"i-spot":     {ShelfPrice: 1.00, EffectiveCost: 0.30, CoverageType: CoverageSpot, IsSpot: true},
+		"i-batch":    {ShelfPrice: 0.50, EffectiveCost: 0.50, CoverageType: CoverageOnDemand},
+		"i-other":    {ShelfPrice: 9.00, EffectiveCost: 9.00, CoverageType: CoverageOnDemand},
+	}
+	members := map[string]NodePoolMember{
+		"i-ri":       {NodePool: "default", CapacityType: CapacityTypeOnDemand},
+		"i-sp":       {NodePool: "default", CapacityType: CapacityTypeOnDemand},
+		"i-ondemand": {NodePool: "default", CapacityType: CapacityTypeOnDemand},
+		"i-spot":     {NodePool: "default"}, // Capacity type from lifecycle
+		"i-batch":    {NodePool: "batch", CapacityType: CapacityTypeReserved},
+		"i-gone":     {NodePool: "batch"}, // No cost (terminated)
+	}
+
+	pools := NodePoolCosts(costs, members)
+	require.Len(t, pools, 2, "i-other isn't in a NodePool")
+
+	pool := pools["default"]
+	assert.Equal(t, "default", pool.NodePool)
+	assert.InDelta(t, 3.02, pool.EffectiveCost, 0.0001)
+	assert.InDelta(t, 5.00, pool.ShelfPrice, 0.0001)
+	assert.InDelta(t, 40.0, pool.CommitmentCoveragePercent(), 0.0001)
+	assert.InDelta(t, 20.0, pool.SpotPercent(), 0.0001)
+	assert.Equal(t, map[string]int{CapacityTypeOnDemand: 3, CapacityTypeSpot: 1}, pool.NodeCounts)
+
+	batch := pools["batch"]
+	assert.Equal(t, map[string]int{CapacityTypeReserved: 1}, batch.NodeCounts)
+	assert.Equal(t, 0.0, batch.CommitmentCoveragePercent())
+	assert.Equal(t, 0.0, NodePoolCost{}.SpotPercent())
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"strconv"
+	"strings"
+)
+
+// fixedNormalizationFactors maps the named (non-"Nxlarge") instance sizes to their
+// AWS normalization factor. Sizes of the form "<N>xlarge" are computed as N * 8.
+//
+// Reference: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/apply_ri.html
+var fixedNormalizationFactors = map[string]float64{
+	"nano":   0.25,
+	"micro":  0.5,
+	"small":  1,
+	"medium": 2,
+	"large":  4,
+	"xlarge": 8,
+}
+
+// normalizationFactor returns the AWS normalization factor for an instance type,
+// which is the unit used to apply size-flexible Regional Reserved Instances across
+// sizes within the same instance family.
+//
+// Examples:
+//   - "m5.large"   → 4
+//   - "m5.xlarge"  → 8
+//   - "m5.4xlarge" → 32
+//
+// Returns (0, false) for sizes that have no fixed factor. Bare metal sizes fall into
+// this bucket because their factor depends on the family's largest virtualized size,
+// which we don't track; those instances only receive exact-match RI coverage.
+func normalizationFactor(instanceType string) (float64, bool) {
+	idx := strings.Index(instanceType, ".")
+	if idx < 0 || idx == len(instanceType)-1 {
+		return 0, false
+	}
+	size := instanceType[idx+1:]
+
+	if factor, ok := fixedNormalizationFactors[size]; ok {
+		return factor, true
+	}
+
+	// "<N>xlarge" sizes scale linearly from xlarge (8 units)
+	if multiplier, found := strings.CutSuffix(size, "xlarge"); found {
+		n, err := strconv.Atoi(multiplier)
+		if err != nil || n <= 0 {
+			return 0, false
+		}
+		return float64(n) * fixedNormalizationFactors["xlarge"], true
+	}
+
+	return 0, false
+}
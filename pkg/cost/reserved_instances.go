@@ -16,6 +16,7 @@ package cost
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/nextdoor/lumina/pkg/aws"
 )
@@ -24,173 +25,466 @@ import (
 // RIs are applied BEFORE any Savings Plans according to AWS billing rules.
 //
 // Reserved Instances match based on:
-//   - Instance Type (exact match, e.g., "m5.xlarge")
+//   - Instance Type (exact match, e.g., "m5.xlarge"), OR instance family for
+//     size-flexible Regional RIs (see below)
 //   - Availability Zone (for zonal RIs) OR Region (for regional RIs)
-//   - Account ID (RIs apply within the same account, or shared in organizations)
+//   - Account ID: an RI applies to its own account's instances first, then to other
+//     accounts' instances unless discount sharing is turned off (see sharing.go)
 //
-// When an RI is applied, the instance's cost is set to $0 because RIs are pre-paid.
-// The instance is marked as RI-covered so Savings Plans don't apply to it.
+// When an RI fully covers an instance, the instance's cost is set to $0 because RIs
+// are pre-paid. Fully RI-covered instances are skipped by Savings Plans.
+//
+// Size flexibility:
+// Regional RIs for Linux/UNIX with default tenancy are size-flexible within their
+// instance family. Each RI contributes InstanceCount * normalizationFactor(type) units,
+// which are spent across same-family instances by their own normalization factor.
+// For example, one m5.4xlarge RI (32 units) covers 4x m5.xlarge (8 units each), or
+// half of an m5.8xlarge (64 units). Partially covered instances pay on-demand rates
+// for the uncovered share, which Savings Plans may then cover.
 //
 // Algorithm:
-//  1. For each Reserved Instance:
-//     a. Find all uncovered instances matching instance type + AZ/region + account
-//     b. Sort eligible instances by launch time (oldest first) for stable assignment
-//     c. Apply RI coverage to oldest instances first until RI capacity exhausted
-//  2. Move to next RI
+//  1. Order RIs: zonal RIs first (AWS applies them before regional RIs), preserving
+//     input order otherwise
+//  2. For each allocation pass (owner account, then shared) and each Reserved Instance:
+//     a. Find all instances in the pass's accounts it can still cover (exact type, or
+//     same family if size-flexible)
+//     b. Sort eligible instances for stable assignment (oldest first; size-flexible RIs
+//     prefer exact size matches, then smallest sizes)
+//     c. Apply RI coverage until the RI's remaining capacity is exhausted
+//
+// Every decision involving the instance trace explains is reported to it.
 //
-// Reference: AWS Savings Plans documentation
-// https://docs.aws.amazon.com/savingsplans/latest/userguide/sp-applying.html
+// Reference: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/apply_ri.html
 func applyReservedInstances(
 	instances []aws.Instance,
 	reservedInstances []aws.ReservedInstance,
 	costs map[string]*InstanceCost,
+	utilization map[string]*ReservedInstanceUtilization,
+	sharing discountSharing,
+	trace *explainer,
 ) {
-	// Track which RIs have been utilized (matched to an instance)
-	// Key: RI unique identifier (account_id:instance_type:availability_zone)
-	utilizedRIs := make(map[string]int)
-
-	// Process each Reserved Instance
-	for _, ri := range reservedInstances {
-		// STEP 1: Find all eligible instances for this RI
-		//
-		// Build a list of instances that match this RI's criteria and aren't
-		// already covered. We'll sort them to ensure stable, deterministic assignment.
-		var eligible []*aws.Instance
-		for idx := range instances {
-			inst := &instances[idx]
-
-			// Skip spot instances - Reserved Instances don't apply to spot per AWS docs
-			// Spot instances always pay the spot market rate, they cannot use RIs or SPs
-			// Reference: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/using-spot-instances.html
-			if inst.Lifecycle == lifecycleSpot {
-				continue
-			}
+	// STEP 1: Zonal RIs are applied before regional RIs so that a size-flexible
+	// regional RI doesn't consume an instance a zonal RI was purchased for.
+	ordered := make([]*aws.ReservedInstance, 0, len(reservedInstances))
+	for idx := range reservedInstances {
+		ordered = append(ordered, &reservedInstances[idx])
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return !isRegionalRI(ordered[i]) && isRegionalRI(ordered[j])
+	})
 
-			// Skip if instance doesn't match RI criteria
-			if !matchesReservedInstance(inst, &ri) {
+	// STEP 2: Apply each RI to its own account's instances, then what's left of it to
+	// other accounts' instances, recording how many of its instances were used
+	remaining := make([]float64, len(ordered))
+	for i, ri := range ordered {
+		remaining[i] = float64(ri.InstanceCount)
+	}
+	for _, shared := range allocationPasses {
+		for i, ri := range ordered {
+			var skipped RejectionReason
+			switch {
+			case remaining[i] <= riUnitEpsilon:
+				skipped = RejectedCapacityExhausted
+			case shared && !sharing.shares(ri.AccountID):
+				skipped = RejectedOwnerNotSharing
+			}
+			trace.considerReservedInstance(ri, shared, remaining[i], skipped)
+			if skipped != "" {
 				continue
 			}
+			inScope := func(accountID string) bool {
+				return sharing.eligible(ri.AccountID, accountID, shared)
+			}
 
-			// Get the cost object for this instance
-			cost, exists := costs[inst.InstanceID]
-			if !exists {
-				continue
+			var utilized float64
+			if isSizeFlexibleRI(ri) {
+				utilized = applySizeFlexibleRI(instances, ri, remaining[i], inScope, costs, trace)
+			} else {
+				utilized = applyExactMatchRI(instances, ri, int(remaining[i]), inScope, costs, trace)
 			}
+			remaining[i] -= utilized
 
-			// Skip if instance is already RI-covered
-			if cost.RICoverage > 0 {
-				continue
+			if util, exists := utilization[ri.ReservedInstanceID]; exists {
+				util.UtilizedInstances += utilized
 			}
+		}
+	}
+}
 
-			eligible = append(eligible, inst)
+// applyExactMatchRI applies up to capacity of a Reserved Instance's instances to
+// instances of exactly the same type in the accounts inScope accepts. Each fully
+// covers one instance. Returns the number of instances covered.
+func applyExactMatchRI(
+	instances []aws.Instance,
+	ri *aws.ReservedInstance,
+	capacity int,
+	inScope func(accountID string) bool,
+	costs map[string]*InstanceCost,
+	trace *explainer,
+) float64 {
+	// Find all eligible instances for this RI
+	//
+	// Build a list of instances that match this RI's criteria and aren't
+	// already covered. We'll sort them to ensure stable, deterministic assignment.
+	var eligible []*aws.Instance
+	for idx := range instances {
+		inst := &instances[idx]
+
+		// Skip spot instances - Reserved Instances don't apply to spot per AWS docs
+		// Spot instances always pay the spot market rate, they cannot use RIs or SPs
+		// Reference: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/using-spot-instances.html
+		if inst.Lifecycle == lifecycleSpot {
+			trace.rejectReservedInstance(inst.InstanceID, RejectedSpot)
+			continue
 		}
 
-		// STEP 2: Sort eligible instances by launch time (oldest first)
-		//
-		// This provides stable discount assignment:
-		// - Older instances keep their RI coverage across reconciliation loops
-		// - Newer instances only get coverage if there's unused RI capacity
-		// - Prevents discounts from "jumping" between instances
-		//
-		// Tie-breaker: Instance ID (for complete determinism)
-		sort.Slice(eligible, func(i, j int) bool {
-			if !eligible[i].LaunchTime.Equal(eligible[j].LaunchTime) {
-				return eligible[i].LaunchTime.Before(eligible[j].LaunchTime)
-			}
-			return eligible[i].InstanceID < eligible[j].InstanceID
-		})
-
-		// STEP 3: Apply RI coverage to eligible instances in order
-		//
-		// RIs can cover multiple instances (based on InstanceCount).
-		// Apply coverage to the oldest instances first until RI capacity is exhausted.
-		riCount := ri.InstanceCount
-		appliedCount := 0
-
-		for _, inst := range eligible {
-			if appliedCount >= int(riCount) {
-				break // RI capacity exhausted
-			}
+		// Skip if instance doesn't match RI criteria or isn't in this pass's accounts
+		if mismatch := reservedInstanceMismatch(inst, ri); mismatch != "" {
+			trace.rejectReservedInstance(inst.InstanceID, mismatch)
+			continue
+		}
+		if !inScope(inst.AccountID) {
+			trace.rejectReservedInstance(inst.InstanceID, RejectedAccount)
+			continue
+		}
 
-			cost := costs[inst.InstanceID]
+		// Get the cost object for this instance
+		cost, exists := costs[inst.InstanceID]
+		if !exists {
+			continue
+		}
 
-			// Apply RI coverage
-			cost.RICoverage = cost.ShelfPrice
-			cost.EffectiveCost = 0 // RIs are pre-paid, so effective cost is $0
-			cost.CoverageType = CoverageReservedInstance
+		// Skip if instance already has any RI coverage
+		if cost.RICoverage > 0 {
+			trace.rejectReservedInstance(inst.InstanceID, RejectedRICovered)
+			continue
+		}
+
+		eligible = append(eligible, inst)
+	}
+
+	// Sort eligible instances by launch time (oldest first)
+	//
+	// This provides stable discount assignment:
+	// - Older instances keep their RI coverage across reconciliation loops
+	// - Newer instances only get coverage if there's unused RI capacity
+	// - Prevents discounts from "jumping" between instances
+	//
+	// Tie-breaker: Instance ID (for complete determinism)
+	sortByLaunchTime(eligible)
+	trace.rankReservedInstance(eligible)
+
+	// Apply RI coverage to the oldest instances first until RI capacity is exhausted.
+	// RIs can cover multiple instances (based on InstanceCount).
+	units, _ := normalizationFactor(ri.InstanceType)
+	appliedCount := 0
+
+	for _, inst := range eligible {
+		if appliedCount >= capacity {
+			break // RI capacity exhausted
+		}
+
+		cost := costs[inst.InstanceID]
+
+		// Apply RI coverage
+		cost.RICoverage = cost.ShelfPrice
+		cost.EffectiveCost = 0 // RIs are pre-paid, so effective cost is $0
+		cost.CoverageType = CoverageReservedInstance
+		contribution := RIContribution{
+			ReservedInstanceID: ri.ReservedInstanceID,
+			NormalizedUnits:    units,
+			Coverage:           cost.ShelfPrice,
+		}
+		cost.RIContributions = append(cost.RIContributions, contribution)
+		trace.coverReservedInstance(inst.InstanceID, float64(capacity-appliedCount), contribution)
+
+		appliedCount++
+	}
+
+	return float64(appliedCount)
+}
+
+// applySizeFlexibleRI applies a size-flexible Regional Reserved Instance to instances
+// in the same family, spending normalized units rather than whole instances.
+//
+// Example: an RI for 2x m5.2xlarge provides 2 * 16 = 32 units.
+//   - m5.large  (4 units)  → fully covered, 28 units left
+//   - m5.xlarge (8 units)  → fully covered, 20 units left
+//   - m5.8xlarge (64 units) → 20 of 64 units covered (31.25% of its shelf price)
+//
+// Only capacity of the RI's instances is spent, on instances in the accounts inScope
+// accepts. Returns the units spent as a number of the RI's own instances (20 units of
+// the example above would be 1.25).
+func applySizeFlexibleRI(
+	instances []aws.Instance,
+	ri *aws.ReservedInstance,
+	capacity float64,
+	inScope func(accountID string) bool,
+	costs map[string]*InstanceCost,
+	trace *explainer,
+) float64 {
+	riFactor, ok := normalizationFactor(ri.InstanceType)
+	if !ok {
+		return 0
+	}
+
+	// Find instances this RI can still contribute units to
+	var eligible []*aws.Instance
+	for idx := range instances {
+		inst := &instances[idx]
+
+		// Spot instances never receive RI coverage
+		if inst.Lifecycle == lifecycleSpot {
+			trace.rejectReservedInstance(inst.InstanceID, RejectedSpot)
+			continue
+		}
+
+		if mismatch := sizeFlexibleRIMismatch(inst, ri); mismatch != "" {
+			trace.rejectReservedInstance(inst.InstanceID, mismatch)
+			continue
+		}
+		if !inScope(inst.AccountID) {
+			trace.rejectReservedInstance(inst.InstanceID, RejectedAccount)
+			continue
+		}
+
+		cost, exists := costs[inst.InstanceID]
+		if !exists {
+			continue
+		}
+
+		// Skip fully covered instances; partially covered ones can take more units
+		if uncoveredRIUnits(inst, cost) <= riUnitEpsilon {
+			trace.rejectReservedInstance(inst.InstanceID, RejectedRICovered)
+			continue
+		}
+
+		eligible = append(eligible, inst)
+	}
+
+	// Sort: exact size matches first, then smallest size first (AWS applies
+	// size-flexible discounts from the smallest to the largest size in the family),
+	// then oldest first and instance ID for stable assignment.
+	sort.Slice(eligible, func(i, j int) bool {
+		iExact := eligible[i].InstanceType == ri.InstanceType
+		jExact := eligible[j].InstanceType == ri.InstanceType
+		if iExact != jExact {
+			return iExact
+		}
+
+		iFactor, _ := normalizationFactor(eligible[i].InstanceType)
+		jFactor, _ := normalizationFactor(eligible[j].InstanceType)
+		if iFactor != jFactor {
+			return iFactor < jFactor
+		}
 
-			// Track utilization
-			riKey := riIdentifier(&ri)
-			utilizedRIs[riKey]++
-			appliedCount++
+		if !eligible[i].LaunchTime.Equal(eligible[j].LaunchTime) {
+			return eligible[i].LaunchTime.Before(eligible[j].LaunchTime)
 		}
+		return eligible[i].InstanceID < eligible[j].InstanceID
+	})
+	trace.rankReservedInstance(eligible)
+
+	// Spend the RI's normalized units across eligible instances
+	totalUnits := capacity * riFactor
+	remainingUnits := totalUnits
+
+	for _, inst := range eligible {
+		if remainingUnits <= riUnitEpsilon {
+			break // RI capacity exhausted
+		}
+
+		cost := costs[inst.InstanceID]
+		instanceFactor, _ := normalizationFactor(inst.InstanceType)
+
+		needed := uncoveredRIUnits(inst, cost)
+		applied := needed
+		if applied > remainingUnits {
+			applied = remainingUnits // Partial coverage: RI only has this many units left
+		}
+
+		var coverage float64
+		if needed-applied <= riUnitEpsilon {
+			// Fully covered: take the exact remainder to avoid floating-point dust
+			coverage = cost.ShelfPrice - cost.RICoverage
+			cost.RICoverage = cost.ShelfPrice
+			cost.EffectiveCost = 0
+		} else {
+			coverage = cost.ShelfPrice * (applied / instanceFactor)
+			cost.RICoverage += coverage
+			cost.EffectiveCost = cost.ShelfPrice - cost.RICoverage
+		}
+		cost.CoverageType = CoverageReservedInstance
+		contribution := RIContribution{
+			ReservedInstanceID: ri.ReservedInstanceID,
+			NormalizedUnits:    applied,
+			Coverage:           coverage,
+		}
+		cost.RIContributions = append(cost.RIContributions, contribution)
+		trace.coverReservedInstance(inst.InstanceID, remainingUnits/riFactor, contribution)
+
+		remainingUnits -= applied
 	}
+
+	return (totalUnits - remainingUnits) / riFactor
 }
 
-// matchesReservedInstance checks if an EC2 instance matches the criteria for a
-// Reserved Instance to apply.
+// riUnitEpsilon is the tolerance used when comparing normalized units.
+const riUnitEpsilon = 1e-9
+
+// uncoveredRIUnits returns how many normalized units of an instance are not yet
+// covered by Reserved Instances.
+func uncoveredRIUnits(instance *aws.Instance, cost *InstanceCost) float64 {
+	factor, ok := normalizationFactor(instance.InstanceType)
+	if !ok || cost.RICoverage >= cost.ShelfPrice {
+		return 0
+	}
+
+	covered := 0.0
+	for _, contribution := range cost.RIContributions {
+		covered += contribution.NormalizedUnits
+	}
+	return factor - covered
+}
+
+// sortByLaunchTime sorts instances oldest first, using instance ID as a tie-breaker.
+func sortByLaunchTime(instances []*aws.Instance) {
+	sort.Slice(instances, func(i, j int) bool {
+		if !instances[i].LaunchTime.Equal(instances[j].LaunchTime) {
+			return instances[i].LaunchTime.Before(instances[j].LaunchTime)
+		}
+		return instances[i].InstanceID < instances[j].InstanceID
+	})
+}
+
+// reservedInstanceMismatch checks if an EC2 instance matches the criteria for an
+// exact-match Reserved Instance to apply.
 //
 // Matching rules:
 //   - Instance type must match exactly
-//   - Account ID must match (RIs apply within account)
 //   - For zonal RIs: Availability Zone must match exactly
 //   - For regional RIs: Region must match (any AZ within the region)
+//   - Operating system and tenancy must match the RI's
 //
-// TODO(#46): Regional RI Size Flexibility Not Implemented
-// AWS Regional RIs support instance size flexibility within the same family using
-// normalization factors. For example, a Regional RI for m5.4xlarge (16 units) can
-// be split to cover 4x m5.xlarge (4 units each). This is NOT currently implemented.
-// The current implementation only supports exact instance type matching.
-//
-// Limitations:
-//   - A Regional RI for m5.4xlarge can ONLY match m5.4xlarge instances (not smaller m5 sizes)
-//   - Zonal RIs do not support size flexibility (AWS behavior - correct as-is)
-//   - This may result in underutilization of Regional RIs if exact instance types don't match
+// Zonal RIs never support size flexibility (AWS behavior). Regional RIs that are
+// size-flexible are matched by sizeFlexibleRIMismatch instead. Which accounts an RI
+// applies to is decided by the allocation pass, not here.
 //
-// Returns true if the RI can apply to this instance.
-func matchesReservedInstance(instance *aws.Instance, ri *aws.ReservedInstance) bool {
+// Returns why the RI can't apply to this instance, or "" if it can.
+func reservedInstanceMismatch(instance *aws.Instance, ri *aws.ReservedInstance) RejectionReason {
 	// Instance type must match exactly
-	// TODO(#46): For regional RIs, this should check instance family and use
-	// normalization factors to allow size flexibility
 	if instance.InstanceType != ri.InstanceType {
-		return false
-	}
-
-	// Account must match
-	if instance.AccountID != ri.AccountID {
-		return false
+		return RejectedInstanceType
 	}
 
 	// Check availability zone / region matching
 	// If RI availability zone is "regional" or empty, it's a regional RI
 	// Otherwise it's a zonal RI that must match exact AZ
-	if ri.AvailabilityZone != "regional" && ri.AvailabilityZone != "" {
+	if !isRegionalRI(ri) {
 		// Zonal RI: must match exact AZ
 		if instance.AvailabilityZone != ri.AvailabilityZone {
-			return false
+			return RejectedLocation
 		}
 	} else {
 		// Regional RI: must match region (any AZ in that region)
 		if instance.Region != ri.Region {
-			return false
+			return RejectedLocation
 		}
 	}
 
-	return true
+	// Operating system and tenancy must match (e.g., a Windows RI doesn't cover a
+	// Linux instance, and a dedicated RI doesn't cover a shared-tenancy one)
+	if PricingOperatingSystem(instance.Platform) != riOperatingSystem(ri.Platform) {
+		return RejectedPlatform
+	}
+	if riTenancy(instance.Tenancy) != riTenancy(ri.Tenancy) {
+		return RejectedPlatform
+	}
+
+	return ""
 }
 
-// riIdentifier creates a unique identifier for a Reserved Instance.
-// This is used to track which RIs have been utilized.
+// sizeFlexibleRIMismatch checks if an EC2 instance can receive units from a
+// size-flexible Regional Reserved Instance, returning why not or "" if it can.
 //
-// Format: "account_id:instance_type:scope:availability_zone_or_region"
-// Examples:
-//   - Zonal RI:    "123456789012:m5.xlarge:zonal:us-west-2a"
-//   - Regional RI: "123456789012:m5.xlarge:regional:us-west-2"
-func riIdentifier(ri *aws.ReservedInstance) string {
-	if ri.AvailabilityZone != "regional" && ri.AvailabilityZone != "" {
-		// Zonal RI
-		return ri.AccountID + ":" + ri.InstanceType + ":zonal:" + ri.AvailabilityZone
-	}
-	// Regional RI
-	return ri.AccountID + ":" + ri.InstanceType + ":regional:" + ri.Region
+// Matching rules:
+//   - Instance family must match (e.g., "m5" for an m5.4xlarge RI)
+//   - Instance size must have a known normalization factor
+//   - Region must match
+//   - Instance must run Linux with default (shared) tenancy, like the RI
+func sizeFlexibleRIMismatch(instance *aws.Instance, ri *aws.ReservedInstance) RejectionReason {
+	if extractInstanceFamily(instance.InstanceType) != extractInstanceFamily(ri.InstanceType) {
+		return RejectedInstanceType
+	}
+
+	if _, ok := normalizationFactor(instance.InstanceType); !ok {
+		return RejectedInstanceType
+	}
+
+	if instance.Region != ri.Region {
+		return RejectedLocation
+	}
+
+	platform := strings.ToLower(instance.Platform)
+	if platform != "" && platform != aws.PlatformLinux {
+		return RejectedPlatform
+	}
+
+	if instance.Tenancy != "" && instance.Tenancy != aws.TenancyDefault {
+		return RejectedPlatform
+	}
+	return ""
+}
+
+// riOperatingSystem converts a Reserved Instance product description (e.g.,
+// "Linux/UNIX (Amazon VPC)", "Windows") to the operating system used for on-demand
+// pricing lookups. Empty and unrecognized descriptions are treated as Linux.
+func riOperatingSystem(productDescription string) string {
+	switch {
+	case strings.HasPrefix(productDescription, aws.ProductDescriptionWindows):
+		return aws.PlatformWindows
+	case strings.HasPrefix(productDescription, aws.ProductDescriptionRHEL):
+		return aws.PlatformRHEL
+	case strings.HasPrefix(productDescription, aws.ProductDescriptionSUSE):
+		return aws.PlatformSUSE
+	default:
+		return aws.PlatformLinux
+	}
+}
+
+// riTenancy normalizes an instance or RI tenancy for matching. Empty is treated
+// as default (shared) tenancy.
+func riTenancy(tenancy string) string {
+	normalized := strings.ToLower(strings.TrimSpace(tenancy))
+	if normalized == "" {
+		return aws.TenancyDefault
+	}
+	return normalized
+}
+
+// isRegionalRI returns true if the RI is scoped to a region rather than an AZ.
+// Regional RIs have an empty availability zone (or "regional").
+func isRegionalRI(ri *aws.ReservedInstance) bool {
+	return ri.AvailabilityZone == "regional" || ri.AvailabilityZone == ""
+}
+
+// isSizeFlexibleRI returns true if the RI applies across sizes within its family.
+// Per AWS, only Regional RIs for Linux/UNIX with default tenancy are size-flexible.
+// Empty Platform and Tenancy are treated as Linux/UNIX and default respectively.
+func isSizeFlexibleRI(ri *aws.ReservedInstance) bool {
+	if !isRegionalRI(ri) {
+		return false
+	}
+
+	// "Linux/UNIX" or "Linux/UNIX (Amazon VPC)"; SUSE/RHEL/Windows are not flexible
+	if ri.Platform != "" && !strings.HasPrefix(ri.Platform, aws.ProductDescriptionLinuxUnix) {
+		return false
+	}
+
+	if ri.Tenancy != "" && ri.Tenancy != aws.TenancyDefault {
+		return false
+	}
+
+	_, ok := normalizationFactor(ri.InstanceType)
+	return ok
 }
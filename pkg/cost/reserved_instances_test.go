@@ -0,0 +1,479 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSizeFlexTestInstance creates an on-demand Linux instance for RI size flexibility tests.
+func newSizeFlexTestInstance(id, instanceType string, launchOffset time.Duration) aws.Instance {
+	return aws.Instance{
+		InstanceID:       id,
+		InstanceType:     instanceType,
+		Region:           "us-west-2",
+		AccountID:        "123456789012",
+		AvailabilityZone: "us-west-2a",
+		State:            "running",
+		Lifecycle:        "on-demand",
+		Platform:         aws.PlatformLinux,
+		Tenancy:          aws.TenancyDefault,
+		LaunchTime:       testBaseTime().Add(launchOffset),
+	}
+}
+
+// newRegionalTestRI creates a regional Linux/UNIX RI with default tenancy.
+func newRegionalTestRI(id, instanceType string, count int32) aws.ReservedInstance {
+	return aws.ReservedInstance{
+		ReservedInstanceID: id,
+		InstanceType:       instanceType,
+		InstanceCount:      count,
+		Region:             "us-west-2",
+		AccountID:          "123456789012",
+		Platform:           aws.ProductDescriptionLinuxUnix,
+		Tenancy:            aws.TenancyDefault,
+		State:              "active",
+	}
+}
+
+// sizeFlexTestPrices returns on-demand prices proportional to normalization factors
+// ($0.024 per unit), matching how AWS prices sizes within a family.
+func sizeFlexTestPrices() map[string]float64 {
+	return map[string]float64{
+		"m5.large:us-west-2:linux":   0.096,
+		"m5.xlarge:us-west-2:linux":  0.192,
+		"m5.2xlarge:us-west-2:linux": 0.384,
+		"m5.4xlarge:us-west-2:linux": 0.768,
+		"m5.8xlarge:us-west-2:linux": 1.536,
+		"m5.metal:us-west-2:linux":   4.608,
+		"c5.xlarge:us-west-2:linux":  0.170,
+	}
+}
+
+func TestNormalizationFactor(t *testing.T) {
+	tests := []struct {
+		instanceType string
+		expected     float64
+		ok           bool
+	}{
+		{"t3.nano", 0.25, true},
+		{"t3.micro", 0.5, true},
+		{"t3.small", 1, true},
+		{"t3.medium", 2, true},
+		{"m5.large", 4, true},
+		{"m5.xlarge", 8, true},
+		{"m5.2xlarge", 16, true},
+		{"m5.4xlarge", 32, true},
+		{"m5.24xlarge", 192, true},
+		{"u-6tb1.112xlarge", 896, true},
+		{"m5.metal", 0, false},
+		{"m5", 0, false},
+		{"m5.", 0, false},
+		{"m5.0xlarge", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.instanceType, func(t *testing.T) {
+			factor, ok := normalizationFactor(tt.instanceType)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, factor)
+		})
+	}
+}
+
+// TestRegionalRISizeFlexibility verifies that a regional RI covers smaller instances
+// in the same family using normalization factors.
+func TestRegionalRISizeFlexibility(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	// One m5.4xlarge RI = 32 units, enough for 4x m5.xlarge (8 units each)
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newSizeFlexTestInstance("i-001", "m5.xlarge", 1*time.Hour),
+			newSizeFlexTestInstance("i-002", "m5.xlarge", 2*time.Hour),
+			newSizeFlexTestInstance("i-003", "m5.xlarge", 3*time.Hour),
+			newSizeFlexTestInstance("i-004", "m5.xlarge", 4*time.Hour),
+			newSizeFlexTestInstance("i-005", "m5.xlarge", 5*time.Hour),
+		},
+		ReservedInstances: []aws.ReservedInstance{
+			newRegionalTestRI("ri-4xl", "m5.4xlarge", 1),
+		},
+		OnDemandPrices: sizeFlexTestPrices(),
+	}
+
+	result := calc.Calculate(input)
+
+	for _, id := range []string{"i-001", "i-002", "i-003", "i-004"} {
+		cost := result.InstanceCosts[id]
+		assert.Equal(t, CoverageReservedInstance, cost.CoverageType, id)
+		assert.Equal(t, 0.0, cost.EffectiveCost, id)
+		assert.InDelta(t, 0.192, cost.RICoverage, 1e-9, id)
+		require.Len(t, cost.RIContributions, 1, id)
+		assert.Equal(t, "ri-4xl", cost.RIContributions[0].ReservedInstanceID)
+		assert.Equal(t, 8.0, cost.RIContributions[0].NormalizedUnits)
+	}
+
+	// Newest instance is left on-demand once the RI's 32 units are spent
+	assert.Equal(t, CoverageOnDemand, result.InstanceCosts["i-005"].CoverageType)
+	assert.Equal(t, 0.192, result.InstanceCosts["i-005"].EffectiveCost)
+	assert.Empty(t, result.InstanceCosts["i-005"].RIContributions)
+}
+
+// TestRegionalRIPartialCoverage verifies that a smaller regional RI partially covers
+// a larger instance, with the remainder charged at on-demand rates.
+func TestRegionalRIPartialCoverage(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	// 2x m5.2xlarge RI = 32 units; m5.8xlarge needs 64 units → 50% covered
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newSizeFlexTestInstance("i-big", "m5.8xlarge", 1*time.Hour),
+		},
+		ReservedInstances: []aws.ReservedInstance{
+			newRegionalTestRI("ri-2xl", "m5.2xlarge", 2),
+		},
+		OnDemandPrices: sizeFlexTestPrices(),
+	}
+
+	result := calc.Calculate(input)
+
+	cost := result.InstanceCosts["i-big"]
+	assert.Equal(t, CoverageReservedInstance, cost.CoverageType)
+	assert.InDelta(t, 0.768, cost.RICoverage, 1e-9)
+	assert.InDelta(t, 0.768, cost.EffectiveCost, 1e-9, "Uncovered half pays on-demand")
+	require.Len(t, cost.RIContributions, 1)
+	assert.Equal(t, 32.0, cost.RIContributions[0].NormalizedUnits)
+	assert.InDelta(t, 0.768, cost.RIContributions[0].Coverage, 1e-9)
+}
+
+// TestRegionalRIMultipleRIsCombine verifies that several regional RIs can combine to
+// cover a single instance, each contribution being recorded.
+func TestRegionalRIMultipleRIsCombine(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	// m5.2xlarge (16 units) covered by 2x m5.large (8 units) + 1x m5.xlarge (8 units)
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newSizeFlexTestInstance("i-001", "m5.2xlarge", 1*time.Hour),
+		},
+		ReservedInstances: []aws.ReservedInstance{
+			newRegionalTestRI("ri-large", "m5.large", 2),
+			newRegionalTestRI("ri-xlarge", "m5.xlarge", 1),
+		},
+		OnDemandPrices: sizeFlexTestPrices(),
+	}
+
+	result := calc.Calculate(input)
+
+	cost := result.InstanceCosts["i-001"]
+	assert.Equal(t, 0.0, cost.EffectiveCost)
+	assert.Equal(t, 0.384, cost.RICoverage)
+	require.Len(t, cost.RIContributions, 2)
+	assert.Equal(t, "ri-large", cost.RIContributions[0].ReservedInstanceID)
+	assert.Equal(t, 8.0, cost.RIContributions[0].NormalizedUnits)
+	assert.Equal(t, "ri-xlarge", cost.RIContributions[1].ReservedInstanceID)
+	assert.Equal(t, 8.0, cost.RIContributions[1].NormalizedUnits)
+}
+
+// TestRegionalRIPrefersExactThenSmallest verifies the allocation order for
+// size-flexible RIs: exact size match first, then smallest sizes.
+func TestRegionalRIPrefersExactThenSmallest(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	// m5.2xlarge RI (16 units): exact match takes all 16 units even though the
+	// m5.large launched earlier
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newSizeFlexTestInstance("i-large", "m5.large", 1*time.Hour),
+			newSizeFlexTestInstance("i-2xl", "m5.2xlarge", 2*time.Hour),
+		},
+		ReservedInstances: []aws.ReservedInstance{
+			newRegionalTestRI("ri-2xl", "m5.2xlarge", 1),
+		},
+		OnDemandPrices: sizeFlexTestPrices(),
+	}
+
+	result := calc.Calculate(input)
+	assert.Equal(t, CoverageReservedInstance, result.InstanceCosts["i-2xl"].CoverageType)
+	assert.Equal(t, CoverageOnDemand, result.InstanceCosts["i-large"].CoverageType)
+
+	// Without an exact match, the smallest instance is covered first
+	input.Instances = []aws.Instance{
+		newSizeFlexTestInstance("i-4xl", "m5.4xlarge", 1*time.Hour),
+		newSizeFlexTestInstance("i-large", "m5.large", 2*time.Hour),
+	}
+
+	result = calc.Calculate(input)
+	assert.Equal(t, 0.0, result.InstanceCosts["i-large"].EffectiveCost)
+	require.Len(t, result.InstanceCosts["i-4xl"].RIContributions, 1)
+	assert.Equal(t, 12.0, result.InstanceCosts["i-4xl"].RIContributions[0].NormalizedUnits,
+		"Larger instance receives the 12 units left after the m5.large")
+}
+
+// TestRIWithoutSizeFlexibility verifies that zonal RIs, non-Linux RIs, dedicated
+// tenancy RIs, and non-matching instances keep exact-match behavior.
+func TestRIWithoutSizeFlexibility(t *testing.T) {
+	zonal := newRegionalTestRI("ri-zonal", "m5.2xlarge", 1)
+	zonal.AvailabilityZone = "us-west-2a"
+
+	windows := newRegionalTestRI("ri-windows", "m5.2xlarge", 1)
+	windows.Platform = aws.ProductDescriptionWindows
+
+	dedicated := newRegionalTestRI("ri-dedicated", "m5.2xlarge", 1)
+	dedicated.Tenancy = aws.TenancyDedicated
+
+	windowsInstance := newSizeFlexTestInstance("i-001", "m5.xlarge", time.Hour)
+	windowsInstance.Platform = aws.PlatformWindows
+
+	dedicatedInstance := newSizeFlexTestInstance("i-001", "m5.xlarge", time.Hour)
+	dedicatedInstance.Tenancy = aws.TenancyDedicated
+
+	tests := []struct {
+		name     string
+		ri       aws.ReservedInstance
+		instance aws.Instance
+	}{
+		{"zonal RI", zonal, newSizeFlexTestInstance("i-001", "m5.xlarge", time.Hour)},
+		{"windows RI", windows, newSizeFlexTestInstance("i-001", "m5.xlarge", time.Hour)},
+		{"dedicated RI", dedicated, newSizeFlexTestInstance("i-001", "m5.xlarge", time.Hour)},
+		{"different family", newRegionalTestRI("ri-m5", "m5.2xlarge", 1),
+			newSizeFlexTestInstance("i-001", "c5.xlarge", time.Hour)},
+		{"windows instance", newRegionalTestRI("ri-m5", "m5.2xlarge", 1), windowsInstance},
+		{"dedicated instance", newRegionalTestRI("ri-m5", "m5.2xlarge", 1), dedicatedInstance},
+		{"metal instance", newRegionalTestRI("ri-m5", "m5.2xlarge", 1),
+			newSizeFlexTestInstance("i-001", "m5.metal", time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc := NewCalculator(nil, nil)
+			result := calc.Calculate(CalculationInput{
+				Instances:         []aws.Instance{tt.instance},
+				ReservedInstances: []aws.ReservedInstance{tt.ri},
+				OnDemandPrices:    sizeFlexTestPrices(),
+			})
+
+			cost := result.InstanceCosts["i-001"]
+			assert.Equal(t, CoverageOnDemand, cost.CoverageType)
+			assert.Equal(t, 0.0, cost.RICoverage)
+			assert.Empty(t, cost.RIContributions)
+		})
+	}
+}
+
+// TestExactMatchRIPlatformAndTenancy verifies that RIs which aren't size-flexible
+// only cover instances with the same operating system and tenancy.
+func TestExactMatchRIPlatformAndTenancy(t *testing.T) {
+	windowsRI := newRegionalTestRI("ri-windows", "m5.xlarge", 1)
+	windowsRI.Platform = aws.ProductDescriptionWindows
+
+	dedicatedRI := newRegionalTestRI("ri-dedicated", "m5.xlarge", 1)
+	dedicatedRI.Tenancy = aws.TenancyDedicated
+
+	zonalRI := newRegionalTestRI("ri-zonal", "m5.xlarge", 1)
+	zonalRI.AvailabilityZone = "us-west-2a"
+
+	linuxInstance := newSizeFlexTestInstance("i-001", "m5.xlarge", time.Hour)
+
+	windowsInstance := newSizeFlexTestInstance("i-001", "m5.xlarge", time.Hour)
+	windowsInstance.Platform = aws.PlatformWindows
+
+	dedicatedInstance := newSizeFlexTestInstance("i-001", "m5.xlarge", time.Hour)
+	dedicatedInstance.Tenancy = aws.TenancyDedicated
+
+	tests := []struct {
+		name     string
+		ri       aws.ReservedInstance
+		instance aws.Instance
+		covered  bool
+	}{
+		{"windows RI, windows instance", windowsRI, windowsInstance, true},
+		{"windows RI, linux instance", windowsRI, linuxInstance, false},
+		{"dedicated RI, dedicated instance", dedicatedRI, dedicatedInstance, true},
+		{"dedicated RI, default instance", dedicatedRI, linuxInstance, false},
+		{"zonal linux RI, windows instance", zonalRI, windowsInstance, false},
+		{"zonal linux RI, dedicated instance", zonalRI, dedicatedInstance, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc := NewCalculator(nil, nil)
+			result := calc.Calculate(CalculationInput{
+				Instances:         []aws.Instance{tt.instance},
+				ReservedInstances: []aws.ReservedInstance{tt.ri},
+				OnDemandPrices: map[string]float64{
+					"m5.xlarge:us-west-2:linux":   0.192,
+					"m5.xlarge:us-west-2:windows": 0.376,
+				},
+			})
+
+			cost := result.InstanceCosts["i-001"]
+			if tt.covered {
+				assert.Equal(t, CoverageReservedInstance, cost.CoverageType)
+				assert.Equal(t, 0.0, cost.EffectiveCost)
+				return
+			}
+			assert.Equal(t, CoverageOnDemand, cost.CoverageType)
+			assert.Equal(t, 0.0, cost.RICoverage)
+			assert.Equal(t, RejectedPlatform, reservedInstanceMismatch(&tt.instance, &tt.ri))
+		})
+	}
+}
+
+// TestZonalRIAppliedBeforeRegional verifies that a zonal RI keeps its instance even
+// when a size-flexible regional RI is listed first.
+func TestZonalRIAppliedBeforeRegional(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	zonal := newRegionalTestRI("ri-zonal", "m5.xlarge", 1)
+	zonal.AvailabilityZone = "us-west-2a"
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newSizeFlexTestInstance("i-001", "m5.xlarge", 1*time.Hour),
+			newSizeFlexTestInstance("i-002", "m5.xlarge", 2*time.Hour),
+		},
+		ReservedInstances: []aws.ReservedInstance{
+			newRegionalTestRI("ri-regional", "m5.xlarge", 1),
+			zonal,
+		},
+		OnDemandPrices: sizeFlexTestPrices(),
+	}
+
+	result := calc.Calculate(input)
+
+	require.Len(t, result.InstanceCosts["i-001"].RIContributions, 1)
+	assert.Equal(t, "ri-zonal", result.InstanceCosts["i-001"].RIContributions[0].ReservedInstanceID)
+	require.Len(t, result.InstanceCosts["i-002"].RIContributions, 1)
+	assert.Equal(t, "ri-regional", result.InstanceCosts["i-002"].RIContributions[0].ReservedInstanceID)
+}
+
+// TestPartialRICoverageWithSavingsPlan verifies that a Savings Plan only pays for
+// the share of an instance not already covered by a size-flexible RI.
+func TestPartialRICoverageWithSavingsPlan(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newSizeFlexTestInstance("i-big", "m5.8xlarge", 1*time.Hour),
+		},
+		ReservedInstances: []aws.ReservedInstance{
+			newRegionalTestRI("ri-2xl", "m5.2xlarge", 2), // 32 of 64 units
+		},
+		SavingsPlans: []aws.SavingsPlan{
+			{
+				SavingsPlanARN:  "arn:aws:savingsplans::123456789012:savingsplan/sp-compute",
+				SavingsPlanType: "Compute",
+				Region:          "all",
+				InstanceFamily:  "all",
+				Commitment:      10.00,
+				AccountID:       "123456789012",
+			},
+		},
+		OnDemandPrices: sizeFlexTestPrices(),
+	}
+
+	result := calc.Calculate(input)
+
+	// Uncovered half = $0.768 on-demand; SP rate for it = $0.768 * 0.72 = $0.55296
+	cost := result.InstanceCosts["i-big"]
+	assert.Equal(t, CoverageReservedInstance, cost.CoverageType, "RI remains the primary coverage")
+	assert.InDelta(t, 0.768, cost.RICoverage, 1e-9)
+	assert.InDelta(t, 0.55296, cost.SavingsPlanCoverage, 1e-9)
+	assert.InDelta(t, 0.55296, cost.EffectiveCost, 1e-9)
+	assert.LessOrEqual(t, cost.RICoverage+cost.SavingsPlanCoverage, cost.ShelfPrice)
+
+	util := result.SavingsPlanUtilization["arn:aws:savingsplans::123456789012:savingsplan/sp-compute"]
+	assert.InDelta(t, 0.55296, util.CurrentUtilizationRate, 1e-9)
+}
+
+// TestReservedInstanceUtilization verifies per-RI utilization and the on-demand value
+// of unused capacity, for both exact-match and size-flexible RIs.
+func TestReservedInstanceUtilization(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	// Windows RIs aren't size-flexible, so this one only covers exact m5.xlarge matches
+	windows := newRegionalTestRI("ri-windows", "m5.xlarge", 3)
+	windows.Platform = aws.ProductDescriptionWindows
+	windowsInstance := newSizeFlexTestInstance("i-windows", "m5.xlarge", 1*time.Hour)
+	windowsInstance.Platform = aws.PlatformWindows
+
+	prices := sizeFlexTestPrices()
+	prices["m5.xlarge:us-west-2:windows"] = 0.376
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			// 8 + 4 = 12 of the size-flexible RI's 16 units
+			newSizeFlexTestInstance("i-xl", "m5.xlarge", 1*time.Hour),
+			newSizeFlexTestInstance("i-large", "m5.large", 2*time.Hour),
+			windowsInstance,
+		},
+		ReservedInstances: []aws.ReservedInstance{
+			newRegionalTestRI("ri-flex", "m5.xlarge", 2),
+			windows,
+			newRegionalTestRI("ri-idle", "c5.xlarge", 1),
+		},
+		OnDemandPrices: prices,
+	}
+
+	result := calc.Calculate(input)
+	require.Len(t, result.ReservedInstanceUtilization, 3)
+
+	flex := result.ReservedInstanceUtilization["ri-flex"]
+	assert.Equal(t, "m5.xlarge", flex.InstanceType)
+	assert.Equal(t, int32(2), flex.InstanceCount)
+	assert.InDelta(t, 1.5, flex.UtilizedInstances, 1e-9)
+	assert.InDelta(t, 75, flex.UtilizationPercent, 1e-9)
+	assert.InDelta(t, 0.5*0.192, flex.UnusedHourlyCost, 1e-9)
+
+	// Unused Windows instances are valued at the Windows price
+	win := result.ReservedInstanceUtilization["ri-windows"]
+	assert.InDelta(t, 1, win.UtilizedInstances, 1e-9)
+	assert.InDelta(t, 100.0/3, win.UtilizationPercent, 1e-9)
+	assert.InDelta(t, 2*0.376, win.UnusedHourlyCost, 1e-9)
+
+	idle := result.ReservedInstanceUtilization["ri-idle"]
+	assert.Equal(t, 0.0, idle.UtilizedInstances)
+	assert.Equal(t, 0.0, idle.UtilizationPercent)
+	assert.InDelta(t, 0.170, idle.UnusedHourlyCost, 1e-9)
+}
+
+// TestRIOperatingSystem verifies the mapping of RI product descriptions to pricing OS.
+func TestRIOperatingSystem(t *testing.T) {
+	tests := []struct {
+		productDescription string
+		expected           string
+	}{
+		{"", aws.PlatformLinux},
+		{"Linux/UNIX", aws.PlatformLinux},
+		{"Linux/UNIX (Amazon VPC)", aws.PlatformLinux},
+		{"Windows", aws.PlatformWindows},
+		{"Windows (Amazon VPC)", aws.PlatformWindows},
+		{"Red Hat Enterprise Linux", aws.PlatformRHEL},
+		{"SUSE Linux", aws.PlatformSUSE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.productDescription, func(t *testing.T) {
+			assert.Equal(t, tt.expected, riOperatingSystem(tt.productDescription))
+		})
+	}
+}
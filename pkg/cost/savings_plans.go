@@ -21,24 +21,51 @@ import (
 	"github.com/nextdoor/lumina/pkg/aws"
 )
 
+// SavingsPlanAllocation selects how Savings Plans of the same type share usage that
+// more than one of them can cover.
+type SavingsPlanAllocation string
+
+const (
+	// SavingsPlanAllocationPerPlan fills each plan in turn: each covers its eligible
+	// usage in order of savings percentage before the next plan is applied. This is
+	// the default.
+	SavingsPlanAllocationPerPlan SavingsPlanAllocation = "per_plan"
+
+	// SavingsPlanAllocationGlobal ranks every (usage, plan) pair across all plans of a
+	// type by savings percentage and covers the highest first, the way AWS applies all
+	// plans of a type together. Where plans overlap, usage goes to the plan that
+	// discounts it most.
+	SavingsPlanAllocationGlobal SavingsPlanAllocation = "global"
+)
+
 // applySavingsPlans applies Savings Plans to EC2 instances that aren't already
-// covered by Reserved Instances. This follows AWS's documented allocation algorithm.
+// covered by Reserved Instances, and Compute Savings Plans to Fargate and Lambda
+// usage. This follows AWS's documented allocation algorithm.
 //
 // AWS applies Savings Plans in priority order:
 //  1. EC2 Instance Savings Plans (specific instance family + region)
 //  2. Compute Savings Plans (any instance family, any region)
 //
+// Both are applied to usage in the account that owns the plan before any of their
+// commitment is shared with other accounts (see sharing.go).
+//
 // Within each Savings Plan, AWS applies coverage to instances in order of:
 //  1. Highest savings percentage first (maximize cost reduction)
 //  2. Tie-breaker: Lowest Savings Plans rate (prefer cheaper SP rates)
 //  3. Continue until SP hourly commitment is exhausted
 //
+// With SavingsPlanAllocationGlobal, that order applies across all plans of a type at
+// once rather than within each plan (see applySavingsPlansBySavings).
+//
 // The function operates in a rate-based model:
 //   - Each SP has a fixed $/hour commitment (e.g., $150/hour)
 //   - We calculate instantaneous utilization based on currently running instances
 //   - Remaining capacity = commitment - current utilization rate
 //   - This is stateless (controller restart safe) but may not match AWS's cumulative
-//     billing within an hour if instances scale up/down
+//     billing within an hour if instances scale up/down. SavingsPlanLedger can
+//     accumulate results over the billing hour to model that.
+//
+// Every decision involving the instance trace explains is reported to it.
 //
 // Reference: AWS Savings Plans documentation
 // https://docs.aws.amazon.com/savingsplans/latest/userguide/sp-applying.html
@@ -47,7 +74,11 @@ func applySavingsPlans(
 	instances []aws.Instance,
 	savingsPlans []aws.SavingsPlan,
 	costs map[string]*InstanceCost,
+	serverless map[string]*ServerlessCost,
 	utilization map[string]*SavingsPlanUtilization,
+	sharing discountSharing,
+	allocation SavingsPlanAllocation,
+	trace *explainer,
 ) {
 	// Separate EC2 Instance SPs from Compute SPs
 	// EC2 Instance SPs apply first (more specific, higher priority)
@@ -63,17 +94,106 @@ func applySavingsPlans(
 		}
 	}
 
-	// Step 2: Apply EC2 Instance Savings Plans
-	// These apply to specific instance family + region combinations
-	for _, sp := range ec2InstanceSPs {
-		applyEC2InstanceSavingsPlan(calc, &sp, instances, costs, utilization)
+	// Remember the first (highest-priority) SP each instance or serverless usage was
+	// eligible for, so usage that spills over to on-demand can be attributed to it as
+	// unmet demand.
+	firstEligible := make(map[string]spDemand)
+	recordEligible := func(eligible []instanceWithSavings) {
+		for _, item := range eligible {
+			id := item.id()
+			if _, seen := firstEligible[id]; seen {
+				continue
+			}
+			firstEligible[id] = spDemand{SavingsPlanARN: item.SavingsPlan.SavingsPlanARN, SPRate: item.SPRate}
+		}
+	}
+
+	// Steps 2 and 3 run once for each allocation pass: every plan is applied to usage
+	// in the account that owns it first, and only then is what's left of its
+	// commitment shared with other accounts.
+	for _, shared := range allocationPasses {
+		inScope := func(sp *aws.SavingsPlan) func(accountID string) bool {
+			return func(accountID string) bool {
+				return sharing.eligible(sp.AccountID, accountID, shared)
+			}
+		}
+
+		if allocation == SavingsPlanAllocationGlobal {
+			// Steps 2 and 3, ranking usage across all plans of each type at once
+			for _, plans := range [][]aws.SavingsPlan{ec2InstanceSPs, computeSPs} {
+				var considered []*aws.SavingsPlan
+				for i := range plans {
+					if considerSavingsPlan(trace, &plans[i], shared, sharing, utilization) {
+						considered = append(considered, &plans[i])
+					}
+				}
+				recordEligible(applySavingsPlansBySavings(
+					calc, considered, instances, inScope, costs, serverless, utilization, trace))
+			}
+			continue
+		}
+
+		// Step 2: Apply EC2 Instance Savings Plans
+		// These apply to specific instance family + region combinations
+		for _, sp := range ec2InstanceSPs {
+			if !considerSavingsPlan(trace, &sp, shared, sharing, utilization) {
+				continue
+			}
+			recordEligible(applyEC2InstanceSavingsPlan(calc, &sp, instances, inScope(&sp), costs, utilization, trace))
+		}
+
+		// Step 3: Apply Compute Savings Plans
+		// These apply to any instance family, any region (broader coverage), and to
+		// Fargate and Lambda usage
+		for _, sp := range computeSPs {
+			if !considerSavingsPlan(trace, &sp, shared, sharing, utilization) {
+				continue
+			}
+			recordEligible(
+				applyComputeSavingsPlan(calc, &sp, instances, inScope(&sp), costs, serverless, utilization, trace))
+		}
+	}
+
+	// Step 4: Attribute unmet demand
+	// Eligible usage that isn't fully covered pays on-demand rates for the rest.
+	// Its uncovered usage (at the SP rate) is recorded against the first SP it was
+	// eligible for, whichever plans covered the rest of it.
+	for id, demand := range firstEligible {
+		var unmet float64
+		if cost, isInstance := costs[id]; isInstance {
+			unmet = demand.SPRate * onDemandRemainder(cost) / cost.ShelfPrice
+		} else {
+			usage := serverless[id]
+			unmet = demand.SPRate * serverlessOnDemandRemainder(usage) / usage.ShelfPrice
+		}
+		if unmet > 1e-9 {
+			utilization[demand.SavingsPlanARN].UnmetDemandRate += unmet
+		}
 	}
+}
 
-	// Step 3: Apply Compute Savings Plans
-	// These apply to any instance family, any region (broader coverage)
-	for _, sp := range computeSPs {
-		applyComputeSavingsPlan(calc, &sp, instances, costs, utilization)
+// considerSavingsPlan reports whether sp is applied in the shared or owner allocation
+// pass, and reports the decision to trace.
+func considerSavingsPlan(
+	trace *explainer,
+	sp *aws.SavingsPlan,
+	shared bool,
+	sharing discountSharing,
+	utilization map[string]*SavingsPlanUtilization,
+) bool {
+	var skipped RejectionReason
+	if shared && !sharing.shares(sp.AccountID) {
+		skipped = RejectedOwnerNotSharing
 	}
+	trace.considerSavingsPlan(sp, shared, utilization[sp.SavingsPlanARN].RemainingCapacity, skipped)
+	return skipped == ""
+}
+
+// spDemand is the first SP an instance (or serverless usage) is eligible for, and
+// that SP's rate for all of its usage ($/hour).
+type spDemand struct {
+	SavingsPlanARN string
+	SPRate         float64
 }
 
 // applyEC2InstanceSavingsPlan applies a single EC2 Instance Savings Plan to
@@ -82,29 +202,93 @@ func applySavingsPlans(
 // EC2 Instance SPs match based on:
 //   - Instance Family (e.g., "m5" matches "m5.large", "m5.xlarge", "m5.2xlarge")
 //   - Region (e.g., "us-west-2")
-//   - Instance must NOT already be RI-covered
+//   - Instance must NOT already be fully RI-covered
 //
 // Algorithm:
-//  1. Filter instances: matching family + region, not RI-covered
+//  1. Filter instances: matching family + region, not fully RI-covered
 //  2. Calculate savings percentage for each instance
 //  3. Sort by savings % (descending), then by SP rate (ascending)
 //  4. Apply SP coverage until commitment exhausted
+//
+// Returns the eligible instances in priority order.
 func applyEC2InstanceSavingsPlan(
 	calc *Calculator,
 	sp *aws.SavingsPlan,
 	instances []aws.Instance,
+	inScope func(accountID string) bool,
 	costs map[string]*InstanceCost,
 	utilization map[string]*SavingsPlanUtilization,
-) {
-	// STEP 1: Find all instances eligible for this specific EC2 Instance Savings Plan
+	trace *explainer,
+) []instanceWithSavings {
+	// STEP 1: Find all instances eligible for this specific EC2 Instance Savings Plan,
+	// with the savings it offers each (see ec2InstanceSPEligible)
+	eligible := ec2InstanceSPEligible(calc, sp, instances, inScope, costs, trace)
+
+	// STEP 2: Sort eligible instances to prioritize which ones get coverage first
+	// (see sortBySavings)
+	sortBySavings(eligible)
+	trace.rankSavingsPlan(eligible)
+
+	// STEP 3: Apply SP coverage to instances in priority order until commitment exhausted
+	//
+	// Each Savings Plan has a fixed hourly commitment (e.g., $150/hour). This is what you
+	// SPEND per hour on SP-covered instances. We consume this commitment budget by
+	// applying the SP to instances in the sorted order from Step 2.
+	//
+	// The SP pays the SP rate for each covered instance. This is what gets subtracted from
+	// the commitment budget.
+	//
+	// Example with $0.20/hour commitment:
+	//   - Instance 1: on-demand $0.192/hr, SP rate $0.054/hr → SP pays $0.054/hr
+	//   - Instance 2: on-demand $0.096/hr, SP rate $0.027/hr → SP pays $0.027/hr
 	//
+	// After covering instance 1: remaining commitment = $0.20 - $0.054 = $0.146/hr
+	// After covering instance 2: remaining commitment = $0.146 - $0.027 = $0.119/hr
+	//
+	// Result:
+	//   - Instance 1: fully covered by SP, pays $0.054/hr (the SP rate)
+	//   - Instance 2: fully covered by SP, pays $0.027/hr (the SP rate)
+	//   - Commitment used: $0.054 + $0.027 = $0.081/hr out of $0.20/hr available
+	//
+	// In the shared allocation pass, the budget is whatever the owner pass left.
+	util := utilization[sp.SavingsPlanARN]
+	remainingCommitment := util.RemainingCapacity
+
+	for _, item := range eligible {
+		if remainingCommitment <= 0 {
+			break // SP commitment fully utilized, no more coverage available
+		}
+
+		// Consume SP commitment
+		// This reduces the available budget for covering additional instances
+		remainingCommitment -= applySavingsPlanCoverage(item, costs, remainingCommitment, trace)
+	}
+
+	// STEP 4: Track SP utilization metrics for monitoring and alerting
+	// (see recordSavingsPlanUtilization)
+	recordSavingsPlanUtilization(sp, util, remainingCommitment)
+
+	return eligible
+}
+
+// ec2InstanceSPEligible returns the instances an EC2 Instance Savings Plan can cover,
+// with the savings it offers each, in instance order.
+func ec2InstanceSPEligible(
+	calc *Calculator,
+	sp *aws.SavingsPlan,
+	instances []aws.Instance,
+	inScope func(accountID string) bool,
+	costs map[string]*InstanceCost,
+	trace *explainer,
+) []instanceWithSavings {
 	// An instance is eligible if:
 	// 1. It's not already covered by a Reserved Instance (RIs take priority)
 	// 2. It matches the SP's instance family (e.g., SP for "m5" can cover m5.large, m5.xlarge, etc.)
 	// 3. It's in the same region as the SP (e.g., SP for "us-west-2" only covers us-west-2 instances)
+	// 4. It's in one of the accounts of the current allocation pass (see applySavingsPlans)
 	//
 	// We build a list of eligible instances with their savings calculations so we can
-	// prioritize which instances get coverage first (step 2).
+	// prioritize which instances get coverage first.
 	eligible := make([]instanceWithSavings, 0, len(instances))
 	for idx := range instances {
 		inst := &instances[idx]
@@ -118,34 +302,40 @@ func applyEC2InstanceSavingsPlan(
 		// Spot instances always pay the spot market rate, they cannot use RIs or SPs
 		// Reference: https://docs.aws.amazon.com/savingsplans/latest/userguide/sp-applying.html
 		if inst.Lifecycle == lifecycleSpot {
+			trace.rejectSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, RejectedSpot)
+			continue
+		}
+		if !inScope(inst.AccountID) {
+			trace.rejectSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, RejectedAccount)
 			continue
 		}
 
-		// Skip if already RI-covered
+		// Skip if fully RI-covered
 		// Reserved Instances have already been applied (higher priority than SPs).
-		// If an instance is RI-covered, it won't benefit from SP coverage, so skip it.
-		if cost.RICoverage > 0 {
+		// If an instance is fully RI-covered, it won't benefit from SP coverage, so skip it.
+		// Instances partially covered by a size-flexible RI remain eligible for the rest.
+		if riUncoveredFraction(cost) <= 0 {
+			trace.rejectSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, RejectedRICovered)
 			continue
 		}
 
-		// Skip if already covered by ANY Savings Plan
-		//
-		// SIMPLIFIED MODEL: Once an instance is covered by any Savings Plan (EC2 Instance
-		// or Compute), no additional Savings Plans should apply. This prevents:
-		// 1. SP commitment waste (paying twice for the same instance hour)
-		// 2. EffectiveCost calculation errors
-		// 3. Mismatch between sum(instance costs) and sum(SP utilization)
+		// Skip if Savings Plans already cover the rest of the instance
 		//
-		// This is a conservative model that prioritizes correctness over maximizing
-		// SP utilization in edge cases (e.g., partial coverage fill-in).
-		if cost.SavingsPlanCoverage > 0 {
+		// SPLIT COVERAGE: An instance that a previous Savings Plan (EC2 Instance or
+		// Compute) covered only in part, because its commitment ran out, stays
+		// eligible for the rest. Only the share still billed at on-demand rates can
+		// be covered, so no instance hour is paid for twice, and the coverage each
+		// plan applies matches the commitment it consumes.
+		if onDemandRemainder(cost) <= 1e-9 {
+			trace.rejectSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, RejectedSPCovered)
 			continue
 		}
 
 		// Check if instance matches SP criteria (family + region)
 		// For example, an EC2 Instance SP for "m5" family in "us-west-2" will only match
 		// m5.* instances (m5.large, m5.xlarge, etc.) running in us-west-2.
-		if !matchesEC2InstanceSP(inst, sp) {
+		if mismatch := ec2InstanceSPMismatch(inst, sp); mismatch != "" {
+			trace.rejectSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, mismatch)
 			continue
 		}
 
@@ -163,6 +353,7 @@ func applyEC2InstanceSavingsPlan(
 		)
 
 		if odRate <= 0 || spRate <= 0 {
+			trace.rejectSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, RejectedNoRate)
 			continue // Can't calculate savings - skip this instance
 		}
 
@@ -180,212 +371,14 @@ func applyEC2InstanceSavingsPlan(
 
 		eligible = append(eligible, instanceWithSavings{
 			Instance:       inst,
+			SavingsPlan:    sp,
 			SavingsPercent: savingsPct,
 			SPRate:         spRate,
 			ODRate:         odRate,
 			IsAccurate:     isAccurate,
 		})
 	}
-
-	// STEP 2: Sort eligible instances to prioritize which ones get coverage first
-	//
-	// AWS's allocation algorithm prioritizes instances to maximize cost savings across
-	// the organization. The sorting order is:
-	//
-	// 1. PRIMARY: Highest savings percentage first
-	//    - This ensures we apply the SP to instances where it provides the most value
-	//    - Example: If instance A saves 72% and instance B saves 60%, cover A first
-	//
-	// 2. TIE-BREAKER: Lowest SP rate first (when savings percentages are equal)
-	//    - This is a secondary optimization to prefer covering cheaper instances
-	//    - Helps the SP commitment stretch to cover more instances
-	//
-	// 3. STABILITY TIE-BREAKER: Oldest instances first (by launch time)
-	//    - When instances have identical savings % and SP rates, prioritize older instances
-	//    - This provides stable, predictable discount assignment across reconciliation loops
-	//    - Prevents discounts from "jumping" between instances when they come and go
-	//    - Older instances keep their discounts; new instances only get coverage if capacity remains
-	//
-	// 4. FINAL TIE-BREAKER: Instance ID (for complete determinism)
-	//    - Handles edge case where instances launched at exactly the same time
-	//
-	// Example scenario:
-	//   - Instance A: m5.2xlarge, on-demand $0.384/hr, SP rate $0.107/hr (72% savings)
-	//   - Instance B: m5.xlarge, on-demand $0.192/hr, SP rate $0.054/hr (72% savings)
-	//   - Instance C: m5.large, on-demand $0.096/hr, SP rate $0.058/hr (60% savings)
-	//
-	// Sorted order: A, B, C
-	//   - A and B both have 72% savings (higher than C's 60%), so they come first
-	//   - Between A and B (tie at 72%), B has lower SP rate ($0.054 < $0.107), so B comes first
-	//   - Final order: B ($0.054, 72%), A ($0.107, 72%), C ($0.058, 60%)
-	sort.Slice(eligible, func(i, j int) bool {
-		// Use epsilon comparison for floating-point values to handle precision issues
-		const epsilon = 1e-9
-
-		// Primary sort: highest savings percentage first
-		savingsDiff := eligible[i].SavingsPercent - eligible[j].SavingsPercent
-		if savingsDiff > epsilon {
-			return true // i has higher savings
-		}
-		if savingsDiff < -epsilon {
-			return false // j has higher savings
-		}
-
-		// Tie-breaker: lowest SP rate first
-		rateDiff := eligible[i].SPRate - eligible[j].SPRate
-		if rateDiff < -epsilon {
-			return true // i has lower rate
-		}
-		if rateDiff > epsilon {
-			return false // j has lower rate
-		}
-
-		// Stability tie-breaker: older instances first (by launch time)
-		if !eligible[i].Instance.LaunchTime.Equal(eligible[j].Instance.LaunchTime) {
-			return eligible[i].Instance.LaunchTime.Before(eligible[j].Instance.LaunchTime)
-		}
-
-		// Final tie-breaker: instance ID (for deterministic sort)
-		return eligible[i].Instance.InstanceID < eligible[j].Instance.InstanceID
-	})
-
-	// STEP 3: Apply SP coverage to instances in priority order until commitment exhausted
-	//
-	// Each Savings Plan has a fixed hourly commitment (e.g., $150/hour). This is what you
-	// SPEND per hour on SP-covered instances. We consume this commitment budget by
-	// applying the SP to instances in the sorted order from Step 2.
-	//
-	// The SP pays the SP rate for each covered instance. This is what gets subtracted from
-	// the commitment budget.
-	//
-	// Example with $0.20/hour commitment:
-	//   - Instance 1: on-demand $0.192/hr, SP rate $0.054/hr → SP pays $0.054/hr
-	//   - Instance 2: on-demand $0.096/hr, SP rate $0.027/hr → SP pays $0.027/hr
-	//
-	// After covering instance 1: remaining commitment = $0.20 - $0.054 = $0.146/hr
-	// After covering instance 2: remaining commitment = $0.146 - $0.027 = $0.119/hr
-	//
-	// Result:
-	//   - Instance 1: fully covered by SP, pays $0.054/hr (the SP rate)
-	//   - Instance 2: fully covered by SP, pays $0.027/hr (the SP rate)
-	//   - Commitment used: $0.054 + $0.027 = $0.081/hr out of $0.20/hr available
-	remainingCommitment := sp.Commitment
-
-	for _, item := range eligible {
-		if remainingCommitment <= 0 {
-			break // SP commitment fully utilized, no more coverage available
-		}
-
-		inst := item.Instance
-		cost := costs[inst.InstanceID]
-
-		// Calculate how much the SP will pay for this instance
-		//
-		// The SP commitment represents what you SPEND per hour on SP-covered instances.
-		// The SP rate is the discounted rate you pay when using the SP.
-		//
-		// Example:
-		//   - on-demand rate: $0.192/hour
-		//   - SP rate: $0.054/hour (with 72% discount)
-		//   - SP will pay: $0.054/hour from the commitment
-		//
-		// This $0.054/hour is consumed from the SP's hourly commitment budget.
-		spCost := item.SPRate
-
-		// FULL vs PARTIAL COVERAGE
-		//
-		// Full coverage: SP has enough commitment to pay the full SP rate
-		// Partial coverage: SP runs out of commitment, can only contribute what's left
-		//
-		// Example of partial coverage:
-		//   - SP rate: $0.068/hr (what the instance would cost with full SP)
-		//   - Remaining commitment: $0.028/hr (not enough!)
-		//   - SP contributes: $0.028/hr (partial)
-		//   - Instance pays: $0.192 - $0.028 = $0.164/hr (on-demand spillover)
-		//
-		// The instance gets partial benefit: pays less than on-demand but more than SP rate.
-		spContribution := spCost
-		if spContribution > remainingCommitment {
-			// Partial coverage: SP can only contribute what's left in the commitment
-			spContribution = remainingCommitment
-		}
-
-		// CRITICAL: Limit SP contribution to not exceed remaining effective cost
-		// This prevents negative costs when an instance is already partially covered
-		// by Reserved Instances. The SP contribution cannot reduce EffectiveCost below zero.
-		if spContribution > cost.EffectiveCost {
-			spContribution = cost.EffectiveCost
-		}
-
-		// Apply SP contribution to this instance
-		//
-		// SavingsPlanCoverage tracks the SP COMMITMENT consumed (what the SP pays),
-		// which is spContribution. This is NOT the discount amount!
-		//
-		// For fully covered: spContribution = SP rate = $0.34
-		// For partially covered: spContribution = remaining commitment = e.g. $0.12
-		//
-		// SavingsPlanARN links this instance to the specific SP providing coverage
-		//
-		// For EffectiveCost:
-		//   - If fully covered (spContribution == spCost): you pay the SP rate
-		//   - If partially covered: SP pays what it can, you pay the rest at on-demand
-		cost.SavingsPlanARN = sp.SavingsPlanARN
-		cost.SavingsPlanCoverage += spContribution
-
-		if spContribution == spCost {
-			// Fully covered: you pay the SP rate
-			cost.EffectiveCost = spCost
-		} else {
-			// Partially covered: SP contributes, you pay the rest
-			cost.EffectiveCost -= spContribution
-		}
-
-		// OnDemandCost should remain at shelf price, not be modified by SP coverage
-		// (This field tracks what the instance would cost without any discounts)
-
-		if cost.SavingsPlanCoverage > 0 {
-			cost.CoverageType = CoverageEC2InstanceSavingsPlan
-			// Set pricing accuracy based on whether we used actual API rates or fallback estimates
-			if item.IsAccurate {
-				cost.PricingAccuracy = PricingAccurate // Actual SP rate from DescribeSavingsPlanRates API
-			} else {
-				cost.PricingAccuracy = PricingEstimated // Estimated using configured discount multiplier
-			}
-		}
-
-		// Consume SP commitment
-		// This reduces the available budget for covering additional instances
-		remainingCommitment -= spContribution
-	}
-
-	// STEP 4: Track SP utilization metrics for monitoring and alerting
-	//
-	// These metrics help answer questions like:
-	// - "How much of my SP commitment am I using right now?"
-	// - "Do I have unused SP capacity that I'm wasting?"
-	// - "Am I over-utilizing my SP (causing spillover to on-demand)?"
-	//
-	// Metrics calculated:
-	// - CurrentUtilizationRate: How much of the SP commitment is being used ($/hour)
-	// - RemainingCapacity: How much unused commitment is left ($/hour)
-	// - UtilizationPercent: Utilization as a percentage (0-100%, can exceed 100%)
-	//
-	// Example with $0.20/hour commitment:
-	//   - Used $0.138/hr covering instances
-	//   - CurrentUtilizationRate = $0.138/hr
-	//   - RemainingCapacity = $0.062/hr
-	//   - UtilizationPercent = 69%
-	//
-	// These are rate-based metrics (instantaneous snapshot), not cumulative over time.
-	// They represent "if these instances keep running for the rest of the hour, this is
-	// how much of the SP commitment will be used."
-	util := utilization[sp.SavingsPlanARN]
-	util.CurrentUtilizationRate = sp.Commitment - remainingCommitment
-	util.RemainingCapacity = remainingCommitment
-	if sp.Commitment > 0 {
-		util.UtilizationPercent = (util.CurrentUtilizationRate / sp.Commitment) * 100
-	}
+	return eligible
 }
 
 // applyComputeSavingsPlan applies a single Compute Savings Plan to eligible instances.
@@ -393,7 +386,7 @@ func applyEC2InstanceSavingsPlan(
 // Compute SPs are more flexible than EC2 Instance SPs:
 //   - Match ANY instance family (m5, c5, r5, t3, etc.) - not restricted to one family
 //   - Match ANY region (us-west-2, us-east-1, eu-west-1, etc.) - apply globally
-//   - Instance must NOT already be RI-covered or fully EC2 Instance SP-covered
+//   - Instance must NOT already be fully RI-covered or EC2 Instance SP-covered
 //
 // Priority: Compute SPs apply AFTER EC2 Instance SPs (lower priority)
 // This means Compute SPs can only cover instances that:
@@ -401,21 +394,71 @@ func applyEC2InstanceSavingsPlan(
 // 2. Don't have full EC2 Instance Savings Plan coverage
 //
 // Algorithm is identical to EC2 Instance SPs (steps 1-4), but with broader eligibility.
+// Fargate and Lambda usage competes with instances for the commitment on the same
+// terms: by savings percentage at its own (smaller) Savings Plan discount.
+// Returns the eligible instances and serverless usage in priority order.
 func applyComputeSavingsPlan(
 	calc *Calculator,
 	sp *aws.SavingsPlan,
 	instances []aws.Instance,
+	inScope func(accountID string) bool,
 	costs map[string]*InstanceCost,
+	serverless map[string]*ServerlessCost,
 	utilization map[string]*SavingsPlanUtilization,
-) {
-	// STEP 1: Find all instances eligible for this Compute Savings Plan
+	trace *explainer,
+) []instanceWithSavings {
+	// STEP 1: Find all instances and serverless usage eligible for this Compute
+	// Savings Plan (see computeSPEligible)
+	eligible := computeSPEligible(calc, sp, instances, inScope, costs, serverless, trace)
+
+	// STEP 2: Sort eligible instances by savings priority
+	//
+	// Uses the same prioritization algorithm as EC2 Instance SPs. See sortBySavings
+	// for the rationale.
+	sortBySavings(eligible)
+	trace.rankSavingsPlan(eligible)
+
+	// STEP 3: Apply SP coverage in priority order until commitment exhausted
+	//
+	// This is identical to the EC2 Instance SP algorithm. The key difference is
+	// that Compute SPs can cover ANY instance type (not restricted by family/region),
+	// so they typically cover a wider variety of instances.
 	//
+	// See applySavingsPlanCoverage for how coverage works, including partial coverage
+	// scenarios and commitment consumption.
+	util := utilization[sp.SavingsPlanARN]
+	remainingCommitment := util.RemainingCapacity
+
+	for _, item := range eligible {
+		if remainingCommitment <= 0 {
+			break
+		}
+		remainingCommitment -= applySavingsPlanCoverage(item, costs, remainingCommitment, trace)
+	}
+
+	// STEP 4: Track SP utilization metrics
+	recordSavingsPlanUtilization(sp, util, remainingCommitment)
+
+	return eligible
+}
+
+// computeSPEligible returns the instances and serverless usage a Compute Savings Plan
+// can cover, with the savings it offers each.
+func computeSPEligible(
+	calc *Calculator,
+	sp *aws.SavingsPlan,
+	instances []aws.Instance,
+	inScope func(accountID string) bool,
+	costs map[string]*InstanceCost,
+	serverless map[string]*ServerlessCost,
+	trace *explainer,
+) []instanceWithSavings {
 	// Compute SPs have broader eligibility than EC2 Instance SPs:
 	// - No instance family restriction (can cover m5, c5, r5, anything)
 	// - No region restriction (can cover any region)
 	//
 	// However, instances are only eligible if they're not already fully covered:
-	// - Must not be RI-covered (RIs have highest priority)
+	// - Must not be fully RI-covered (RIs have highest priority)
 	// - Must not be fully covered by an EC2 Instance SP (those apply first)
 	//
 	// The second check is important: if an EC2 Instance SP already fully covers
@@ -433,26 +476,25 @@ func applyComputeSavingsPlan(
 		// Spot instances always pay the spot market rate, they cannot use RIs or SPs
 		// Reference: https://docs.aws.amazon.com/savingsplans/latest/userguide/sp-applying.html
 		if inst.Lifecycle == lifecycleSpot {
+			trace.rejectSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, RejectedSpot)
+			continue
+		}
+		if !inScope(inst.AccountID) {
+			trace.rejectSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, RejectedAccount)
 			continue
 		}
 
-		// Skip if already RI-covered
+		// Skip if fully RI-covered
 		// Reserved Instances have already been applied (highest priority).
-		if cost.RICoverage > 0 {
+		if riUncoveredFraction(cost) <= 0 {
+			trace.rejectSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, RejectedRICovered)
 			continue
 		}
 
-		// Skip if already covered by ANY Savings Plan
-		//
-		// SIMPLIFIED MODEL: Once an instance is covered by any Savings Plan (EC2 Instance
-		// or Compute), no additional Savings Plans should apply. This prevents:
-		// 1. SP commitment waste (paying twice for the same instance hour)
-		// 2. EffectiveCost calculation errors
-		// 3. Mismatch between sum(instance costs) and sum(SP utilization)
-		//
-		// This is a conservative model that prioritizes correctness over maximizing
-		// SP utilization in edge cases (e.g., partial coverage fill-in).
-		if cost.SavingsPlanCoverage > 0 {
+		// Skip if Savings Plans already cover the rest of the instance
+		// (see ec2InstanceSPEligible for split coverage)
+		if onDemandRemainder(cost) <= 1e-9 {
+			trace.rejectSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, RejectedSPCovered)
 			continue
 		}
 
@@ -464,6 +506,7 @@ func applyComputeSavingsPlan(
 		)
 
 		if odRate <= 0 || spRate <= 0 {
+			trace.rejectSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, RejectedNoRate)
 			continue
 		}
 
@@ -471,6 +514,7 @@ func applyComputeSavingsPlan(
 
 		eligible = append(eligible, instanceWithSavings{
 			Instance:       inst,
+			SavingsPlan:    sp,
 			SavingsPercent: savingsPct,
 			SPRate:         spRate,
 			ODRate:         odRate,
@@ -478,15 +522,120 @@ func applyComputeSavingsPlan(
 		})
 	}
 
-	// STEP 2: Sort eligible instances by savings priority
+	// Fargate and Lambda usage is eligible on the same terms, except that there is no
+	// RI coverage to account for and its Savings Plan rate is supplied with the usage.
+	for _, usage := range serverless {
+		if serverlessOnDemandRemainder(usage) <= 1e-9 || usage.ShelfPrice <= 0 || usage.SavingsPlanRate <= 0 ||
+			!inScope(usage.AccountID) {
+			continue
+		}
+		eligible = append(eligible, instanceWithSavings{
+			Serverless:     usage,
+			SavingsPlan:    sp,
+			SavingsPercent: (usage.ShelfPrice - usage.SavingsPlanRate) / usage.ShelfPrice,
+			SPRate:         usage.SavingsPlanRate,
+			ODRate:         usage.ShelfPrice,
+		})
+	}
+	return eligible
+}
+
+// applySavingsPlansBySavings applies Savings Plans of one type together
+// (SavingsPlanAllocationGlobal). Each plan's eligible usage is found the same way as
+// when plans are applied one by one, but the (usage, plan) pairs of all the plans are
+// sorted into a single savings order. Each pair in turn is covered from its own plan's
+// remaining commitment, unless a plan earlier in the order already covered the usage.
+//
+// Applied one by one, the first plan covers whatever usage it ranks highest, even
+// usage another plan discounts more deeply. For example, with a 1-year Compute SP
+// (28% off everything) listed before a 3-year one (50% off everything), the 1-year
+// plan takes the instances first and the 3-year plan's commitment goes to whatever
+// is left, if anything. Ranked together, every instance's 50% pair comes before any
+// 28% pair, so the 3-year plan is filled first, as AWS does.
+//
+// Returns the pairs in priority order, so each usage's first pair is with the plan
+// that discounts it most.
+func applySavingsPlansBySavings(
+	calc *Calculator,
+	savingsPlans []*aws.SavingsPlan,
+	instances []aws.Instance,
+	inScope func(sp *aws.SavingsPlan) func(accountID string) bool,
+	costs map[string]*InstanceCost,
+	serverless map[string]*ServerlessCost,
+	utilization map[string]*SavingsPlanUtilization,
+	trace *explainer,
+) []instanceWithSavings {
+	var eligible []instanceWithSavings
+	remaining := make(map[string]float64, len(savingsPlans))
+	for _, sp := range savingsPlans {
+		remaining[sp.SavingsPlanARN] = utilization[sp.SavingsPlanARN].RemainingCapacity
+		if sp.SavingsPlanType == aws.SavingsPlanTypeCompute {
+			eligible = append(eligible, computeSPEligible(calc, sp, instances, inScope(sp), costs, serverless, trace)...)
+		} else {
+			eligible = append(eligible, ec2InstanceSPEligible(calc, sp, instances, inScope(sp), costs, trace)...)
+		}
+	}
+
+	sortBySavings(eligible)
+	trace.rankSavingsPlan(eligible)
+
+	for _, item := range eligible {
+		spARN := item.SavingsPlan.SavingsPlanARN
+		if remaining[spARN] <= 0 {
+			continue // Other plans may still have commitment for later pairs
+		}
+
+		// Plans earlier in the order may have covered the usage since it was found
+		// eligible
+		covered := item.Serverless != nil && serverlessOnDemandRemainder(item.Serverless) <= 1e-9 ||
+			item.Instance != nil && onDemandRemainder(costs[item.Instance.InstanceID]) <= 1e-9
+		if covered {
+			trace.rejectSavingsPlan(item.id(), spARN, RejectedSPCovered)
+			continue
+		}
+
+		remaining[spARN] -= applySavingsPlanCoverage(item, costs, remaining[spARN], trace)
+	}
+
+	for _, sp := range savingsPlans {
+		recordSavingsPlanUtilization(sp, utilization[sp.SavingsPlanARN], remaining[sp.SavingsPlanARN])
+	}
+	return eligible
+}
+
+// sortBySavings sorts eligible usage into the order Savings Plans cover it.
+func sortBySavings(eligible []instanceWithSavings) {
+	// AWS's allocation algorithm prioritizes instances to maximize cost savings across
+	// the organization. The sorting order is:
+	//
+	// 1. PRIMARY: Highest savings percentage first
+	//    - This ensures we apply the SP to instances where it provides the most value
+	//    - Example: If instance A saves 72% and instance B saves 60%, cover A first
 	//
-	// Uses the same prioritization algorithm as EC2 Instance SPs:
-	// 1. Highest savings percentage first
-	// 2. Tie-breaker: lowest SP rate first
-	// 3. Stability tie-breaker: oldest instances first (by launch time)
-	// 4. Final tie-breaker: instance ID (for deterministic sort)
+	// 2. TIE-BREAKER: Lowest SP rate first (when savings percentages are equal)
+	//    - This is a secondary optimization to prefer covering cheaper instances
+	//    - Helps the SP commitment stretch to cover more instances
+	//
+	// 3. STABILITY TIE-BREAKER: Oldest instances first (by launch time), then
+	//    instances before serverless usage
+	//    - When instances have identical savings % and SP rates, prioritize older instances
+	//    - This provides stable, predictable discount assignment across reconciliation loops
+	//    - Prevents discounts from "jumping" between instances when they come and go
+	//    - Older instances keep their discounts; new instances only get coverage if capacity remains
+	//
+	// 4. FINAL TIE-BREAKER: Instance or usage ID, then SP ARN (for complete determinism)
+	//    - Handles edge case where instances launched at exactly the same time
+	//    - The ARN only matters when plans are ranked together (applySavingsPlansBySavings)
+	//
+	// Example scenario:
+	//   - Instance A: m5.2xlarge, on-demand $0.384/hr, SP rate $0.107/hr (72% savings)
+	//   - Instance B: m5.xlarge, on-demand $0.192/hr, SP rate $0.054/hr (72% savings)
+	//   - Instance C: m5.large, on-demand $0.096/hr, SP rate $0.058/hr (60% savings)
 	//
-	// See detailed comments in applyEC2InstanceSavingsPlan() for the rationale.
+	// Sorted order: A, B, C
+	//   - A and B both have 72% savings (higher than C's 60%), so they come first
+	//   - Between A and B (tie at 72%), B has lower SP rate ($0.054 < $0.107), so B comes first
+	//   - Final order: B ($0.054, 72%), A ($0.107, 72%), C ($0.058, 60%)
 	sort.Slice(eligible, func(i, j int) bool {
 		// Use epsilon comparison for floating-point values to handle precision issues
 		const epsilon = 1e-9
@@ -509,91 +658,146 @@ func applyComputeSavingsPlan(
 			return false // j has lower rate
 		}
 
-		// Stability tie-breaker: older instances first (by launch time)
-		if !eligible[i].Instance.LaunchTime.Equal(eligible[j].Instance.LaunchTime) {
+		// Stability tie-breaker: older instances first (by launch time). Serverless
+		// usage has no launch time and sorts after instances.
+		if (eligible[i].Instance == nil) != (eligible[j].Instance == nil) {
+			return eligible[i].Instance != nil
+		}
+		if eligible[i].Instance != nil &&
+			!eligible[i].Instance.LaunchTime.Equal(eligible[j].Instance.LaunchTime) {
 			return eligible[i].Instance.LaunchTime.Before(eligible[j].Instance.LaunchTime)
 		}
 
-		// Final tie-breaker: instance ID (for deterministic sort)
-		return eligible[i].Instance.InstanceID < eligible[j].Instance.InstanceID
+		// Final tie-breaker: instance or usage ID, then SP ARN (for deterministic sort)
+		if eligible[i].id() != eligible[j].id() {
+			return eligible[i].id() < eligible[j].id()
+		}
+		return eligible[i].SavingsPlan.SavingsPlanARN < eligible[j].SavingsPlan.SavingsPlanARN
 	})
+}
 
-	// STEP 3: Apply SP coverage in priority order until commitment exhausted
-	//
-	// This is identical to the EC2 Instance SP algorithm. The key difference is
-	// that Compute SPs can cover ANY instance type (not restricted by family/region),
-	// so they typically cover a wider variety of instances.
-	//
-	// See detailed comments in applyEC2InstanceSavingsPlan() for how coverage works,
-	// including partial coverage scenarios and commitment consumption.
-	remainingCommitment := sp.Commitment
+// applySavingsPlanCoverage covers an eligible instance or serverless usage with up to
+// remainingCommitment of its Savings Plan, and returns the commitment consumed.
+func applySavingsPlanCoverage(
+	item instanceWithSavings,
+	costs map[string]*InstanceCost,
+	remainingCommitment float64,
+	trace *explainer,
+) float64 {
+	sp := item.SavingsPlan
+	if item.Serverless != nil {
+		return applyServerlessSavingsPlan(sp, item, remainingCommitment)
+	}
 
-	for _, item := range eligible {
-		if remainingCommitment <= 0 {
-			break
-		}
+	inst := item.Instance
+	cost := costs[inst.InstanceID]
 
-		inst := item.Instance
-		cost := costs[inst.InstanceID]
+	// Calculate how much the SP will pay for this instance
+	//
+	// The SP commitment represents what you SPEND per hour on SP-covered instances.
+	// The SP rate is the discounted rate you pay when using the SP.
+	//
+	// Example:
+	//   - on-demand rate: $0.192/hour
+	//   - SP rate: $0.054/hour (with 72% discount)
+	//   - SP will pay: $0.054/hour from the commitment
+	//
+	// This $0.054/hour is consumed from the SP's hourly commitment budget.
+	//
+	// The SP only pays for the share of the instance still billed at on-demand
+	// rates: the share no RI covers, less what earlier SPs already covered (e.g.,
+	// half the SP rate for an instance an RI or another SP half covers).
+	onDemand := onDemandRemainder(cost)
+	spCost := item.SPRate * onDemand / cost.ShelfPrice
+	if spCost > onDemand {
+		// An SP rate above the shelf price would make coverage cost more than on-demand
+		spCost = onDemand
+	}
 
-		// Calculate how much the SP will pay for this instance
-		// (Same logic as EC2 Instance SPs - see detailed comments there)
-		spCost := item.SPRate
+	// FULL vs PARTIAL COVERAGE
+	//
+	// Full coverage: SP has enough commitment to pay the full SP rate
+	// Partial coverage: SP runs out of commitment, and covers the share of the
+	// instance its remaining commitment pays for at the SP rate
+	//
+	// Example of partial coverage:
+	//   - on-demand rate: $0.192/hr
+	//   - SP rate: $0.048/hr (what the instance would cost with full SP)
+	//   - Remaining commitment: $0.012/hr (a quarter of the SP rate)
+	//   - SP covers a quarter of the instance: $0.012/hr from the commitment
+	//   - Instance pays: $0.012 + 3/4 × $0.192 = $0.156/hr
+	//
+	// The rest stays on-demand, where a later SP can still cover it (split coverage).
+	spContribution := spCost
+	if spContribution > remainingCommitment {
+		// Partial coverage: SP can only contribute what's left in the commitment
+		spContribution = remainingCommitment
+	}
+	if spContribution <= 1e-9 {
+		return 0 // Floating-point dust left in the commitment
+	}
+	coveredShare := spContribution / spCost
 
-		// Handle full vs partial coverage
-		spContribution := spCost
-		if spContribution > remainingCommitment {
-			// Partial coverage: SP can only contribute what's left in the commitment
-			spContribution = remainingCommitment
-		}
+	// Apply SP contribution to this instance
+	//
+	// SavingsPlanCoverage tracks the SP COMMITMENT consumed (what the SP pays),
+	// summed over SavingsPlanContributions. This is NOT the discount amount!
+	//
+	// For fully covered: spContribution = SP rate = $0.34
+	// For partially covered: spContribution = remaining commitment = e.g. $0.12
+	//
+	// EffectiveCost swaps the covered share's on-demand cost for the SP's contribution.
+	cost.SavingsPlanContributions = append(cost.SavingsPlanContributions, SavingsPlanContribution{
+		SavingsPlanARN: sp.SavingsPlanARN,
+		Coverage:       spContribution,
+	})
+	cost.SavingsPlanCoverage += spContribution
+	cost.EffectiveCost += spContribution - onDemand*coveredShare
+	trace.coverSavingsPlan(inst.InstanceID, sp.SavingsPlanARN, remainingCommitment, spContribution)
 
-		// CRITICAL: Limit SP contribution to not exceed remaining effective cost
-		// This prevents negative costs when an instance is already partially covered
-		// by RIs or EC2 Instance SPs. The SP contribution cannot reduce EffectiveCost below zero.
-		if spContribution > cost.EffectiveCost {
-			spContribution = cost.EffectiveCost
-		}
+	// OnDemandCost should remain at shelf price, not be modified by SP coverage
+	// (This field tracks what the instance would cost without any discounts)
 
-		// Apply SP contribution (same logic as EC2 Instance SPs)
-		//
-		// SavingsPlanCoverage tracks the SP COMMITMENT consumed (what the SP pays),
-		// which is spContribution. This is NOT the discount amount!
-		cost.SavingsPlanARN = sp.SavingsPlanARN
-		cost.SavingsPlanCoverage += spContribution
-
-		if spContribution == spCost {
-			// Fully covered: you pay the SP rate
-			cost.EffectiveCost = spCost
-		} else {
-			// Partially covered: SP contributes, you pay the rest
-			cost.EffectiveCost -= spContribution
-		}
-
-		// OnDemandCost should remain at shelf price, not be modified by SP coverage
-		// (This field tracks what the instance would cost without any discounts)
-
-		// Set coverage type to Compute SP ONLY if this is the instance's first SP coverage
-		// If an EC2 Instance SP already partially covered this instance, we want to keep
-		// the CoverageType as EC2InstanceSavingsPlan (the more specific/higher priority type)
-		if cost.SavingsPlanCoverage > 0 && cost.CoverageType == CoverageOnDemand {
-			cost.CoverageType = CoverageComputeSavingsPlan
-			// Set pricing accuracy based on whether we used actual API rates or fallback estimates
-			if item.IsAccurate {
-				cost.PricingAccuracy = PricingAccurate // Actual SP rate from DescribeSavingsPlanRates API
-			} else {
-				cost.PricingAccuracy = PricingEstimated // Estimated using configured discount multiplier
+	if cost.SavingsPlanCoverage > 0 {
+		// Instances partially covered by an RI keep CoverageReservedInstance
+		if cost.CoverageType == CoverageOnDemand {
+			cost.CoverageType = CoverageEC2InstanceSavingsPlan
+			if sp.SavingsPlanType == aws.SavingsPlanTypeCompute {
+				cost.CoverageType = CoverageComputeSavingsPlan
 			}
 		}
-
-		// Consume SP commitment
-		remainingCommitment -= spContribution
+		// Set pricing accuracy based on whether we used actual API rates or fallback estimates.
+		// An accurate SP rate doesn't upgrade an instance whose shelf price was estimated.
+		if !item.IsAccurate {
+			cost.PricingAccuracy = PricingEstimated // Estimated using configured discount multiplier
+		}
 	}
 
-	// STEP 4: Track SP utilization metrics
+	return spContribution
+}
+
+// recordSavingsPlanUtilization records how much of sp's commitment is used, given the
+// commitment remaining after it was applied.
+func recordSavingsPlanUtilization(sp *aws.SavingsPlan, util *SavingsPlanUtilization, remainingCommitment float64) {
+	// These metrics help answer questions like:
+	// - "How much of my SP commitment am I using right now?"
+	// - "Do I have unused SP capacity that I'm wasting?"
+	// - "Am I over-utilizing my SP (causing spillover to on-demand)?"
 	//
-	// Same metrics as EC2 Instance SPs. See detailed comments in
-	// applyEC2InstanceSavingsPlan() for what these metrics mean and how to use them.
-	util := utilization[sp.SavingsPlanARN]
+	// Metrics calculated:
+	// - CurrentUtilizationRate: How much of the SP commitment is being used ($/hour)
+	// - RemainingCapacity: How much unused commitment is left ($/hour)
+	// - UtilizationPercent: Utilization as a percentage (0-100%, can exceed 100%)
+	//
+	// Example with $0.20/hour commitment:
+	//   - Used $0.138/hr covering instances
+	//   - CurrentUtilizationRate = $0.138/hr
+	//   - RemainingCapacity = $0.062/hr
+	//   - UtilizationPercent = 69%
+	//
+	// These are rate-based metrics (instantaneous snapshot), not cumulative over time.
+	// They represent "if these instances keep running for the rest of the hour, this is
+	// how much of the SP commitment will be used."
 	util.CurrentUtilizationRate = sp.Commitment - remainingCommitment
 	util.RemainingCapacity = remainingCommitment
 	if sp.Commitment > 0 {
@@ -601,23 +805,46 @@ func applyComputeSavingsPlan(
 	}
 }
 
-// matchesEC2InstanceSP checks if an instance is eligible for an EC2 Instance Savings Plan.
-// Returns true if the instance's family and region match the SP.
-func matchesEC2InstanceSP(instance *aws.Instance, sp *aws.SavingsPlan) bool {
+// applyServerlessSavingsPlan covers Fargate or Lambda usage with up to
+// remainingCommitment of a Compute Savings Plan, the same way instances are covered
+// (see applySavingsPlanCoverage), and returns the commitment consumed.
+func applyServerlessSavingsPlan(sp *aws.SavingsPlan, item instanceWithSavings, remainingCommitment float64) float64 {
+	usage := item.Serverless
+	onDemand := serverlessOnDemandRemainder(usage)
+	spCost := min(item.SPRate*onDemand/usage.ShelfPrice, onDemand)
+	spContribution := min(spCost, remainingCommitment)
+	if spContribution <= 1e-9 {
+		return 0
+	}
+	coveredShare := spContribution / spCost
+
+	usage.SavingsPlanContributions = append(usage.SavingsPlanContributions, SavingsPlanContribution{
+		SavingsPlanARN: sp.SavingsPlanARN,
+		Coverage:       spContribution,
+	})
+	usage.SavingsPlanCoverage += spContribution
+	usage.EffectiveCost += spContribution - onDemand*coveredShare
+	usage.CoverageType = CoverageComputeSavingsPlan
+	return spContribution
+}
+
+// ec2InstanceSPMismatch checks if an instance is eligible for an EC2 Instance Savings Plan.
+// Returns why the instance's family or region doesn't match the SP, or "" if both do.
+func ec2InstanceSPMismatch(instance *aws.Instance, sp *aws.SavingsPlan) RejectionReason {
 	// Extract instance family from instance type (e.g., "m5.xlarge" → "m5")
 	family := extractInstanceFamily(instance.InstanceType)
 
 	// SP must match this instance family
 	if sp.InstanceFamily != family {
-		return false
+		return RejectedInstanceType
 	}
 
 	// SP must match this region
 	if sp.Region != instance.Region {
-		return false
+		return RejectedLocation
 	}
 
-	return true
+	return ""
 }
 
 // getSavingsPlanRate returns the Savings Plan rate ($/hour) for a given instance type, region, and tenancy,
@@ -695,12 +922,48 @@ func extractInstanceFamily(instanceType string) string {
 	return instanceType
 }
 
+// onDemandRemainder returns the share of an instance's cost ($/hour) still billed at
+// on-demand rates: the shelf price less RI coverage and the on-demand cost of the
+// usage Savings Plans cover.
+func onDemandRemainder(cost *InstanceCost) float64 {
+	return cost.EffectiveCost - cost.SavingsPlanCoverage
+}
+
+// serverlessOnDemandRemainder is onDemandRemainder for Fargate or Lambda usage: its
+// on-demand cost less the on-demand cost of the usage Savings Plans cover.
+func serverlessOnDemandRemainder(usage *ServerlessCost) float64 {
+	return usage.EffectiveCost - usage.SavingsPlanCoverage
+}
+
+// riUncoveredFraction returns the fraction (0-1) of an instance's shelf price that
+// is not covered by Reserved Instances. This is 1 for instances with no RI coverage
+// and 0 for fully RI-covered instances.
+func riUncoveredFraction(cost *InstanceCost) float64 {
+	if cost.ShelfPrice <= 0 || cost.RICoverage >= cost.ShelfPrice {
+		return 0
+	}
+	return 1 - cost.RICoverage/cost.ShelfPrice
+}
+
 // instanceWithSavings is a helper struct used for sorting instances by savings potential.
-// This is used internally by the SP allocation algorithm.
+// This is used internally by the SP allocation algorithm. For Fargate and Lambda usage
+// eligible for Compute SPs, Serverless is set instead of Instance.
 type instanceWithSavings struct {
 	Instance       *aws.Instance
+	Serverless     *ServerlessCost
 	SavingsPercent float64 // (ODRate - SPRate) / ODRate
 	SPRate         float64 // Savings Plan rate ($/hour)
 	ODRate         float64 // On-Demand rate ($/hour)
 	IsAccurate     bool    // Whether SPRate came from actual API data or estimated
+
+	// SavingsPlan is the SP offering these savings
+	SavingsPlan *aws.SavingsPlan
+}
+
+// id returns the instance ID, or the usage ID for serverless usage.
+func (i instanceWithSavings) id() string {
+	if i.Serverless != nil {
+		return i.Serverless.ID
+	}
+	return i.Instance.InstanceID
 }
@@ -0,0 +1,250 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/aws/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newScenarioInput builds calculation input from a pkg/aws/testdata scenario, with
+// the on-demand prices the scenario loads into the mock pricing client.
+//
+// The fixtures are normalized the way the AWS client reports inventory: Savings Plan
+// types as the Savings Plans API names them, Linux instances with an empty Platform,
+// spot instances by lifecycle, and every resource stamped with its account.
+func newScenarioInput(t *testing.T, scenario testdata.Scenario) CalculationInput {
+	t.Helper()
+	client := aws.NewMockClient()
+	testdata.LoadScenario(scenario, client)
+
+	spTypes := map[string]string{
+		"ComputeSavingsPlans":     aws.SavingsPlanTypeCompute,
+		"EC2InstanceSavingsPlans": aws.SavingsPlanTypeEC2Instance,
+	}
+
+	input := CalculationInput{
+		OnDemandPrices: make(map[string]float64),
+		PricingCache:   &mockPricingCache{spRates: make(map[string]float64)},
+	}
+	for _, account := range scenario.Accounts {
+		for _, inst := range account.Instances {
+			price, err := client.PricingClientInstance.GetOnDemandPrice(
+				context.Background(), inst.Region, inst.InstanceType, inst.Platform)
+			require.NoError(t, err)
+			input.OnDemandPrices[OnDemandPriceKey(inst.InstanceType, inst.Region, aws.PlatformLinux, "")] =
+				price.PricePerHour
+
+			inst.AccountID = account.ID
+			inst.Platform = ""
+			if inst.SpotInstanceRequestID != "" {
+				inst.Lifecycle = lifecycleSpot
+			}
+			input.Instances = append(input.Instances, inst)
+		}
+		for _, ri := range account.ReservedInstances {
+			ri.AccountID = account.ID
+			input.ReservedInstances = append(input.ReservedInstances, ri)
+		}
+		for _, sp := range account.SavingsPlans {
+			sp.AccountID = account.ID
+			sp.SavingsPlanType = spTypes[sp.SavingsPlanType]
+			input.SavingsPlans = append(input.SavingsPlans, sp)
+		}
+	}
+	return input
+}
+
+// TestSavingsPlanAllocation_Scenarios verifies that global allocation agrees with
+// per-plan allocation when no two plans of a type compete for the same usage, which
+// is the case in both scenario fixtures.
+func TestSavingsPlanAllocation_Scenarios(t *testing.T) {
+	for _, scenario := range []testdata.Scenario{testdata.SimpleScenario, testdata.ComplexScenario} {
+		t.Run(scenario.Name, func(t *testing.T) {
+			calc := NewCalculator(nil, nil)
+			input := newScenarioInput(t, scenario)
+			input.Now = time.Now()
+
+			perPlan := calc.Calculate(input)
+			input.SavingsPlanAllocation = SavingsPlanAllocationGlobal
+			global := calc.Calculate(input)
+
+			require.Empty(t, perPlan.InvariantViolations)
+			require.Empty(t, global.InvariantViolations)
+			assert.Positive(t, perPlan.TotalSavings)
+
+			assert.Equal(t, perPlan.InstanceCosts, global.InstanceCosts)
+			assert.Equal(t, perPlan.SavingsPlanUtilization, global.SavingsPlanUtilization)
+			assert.InDelta(t, perPlan.TotalEstimatedCost, global.TotalEstimatedCost, 1e-9)
+		})
+	}
+}
+
+// TestSavingsPlanAllocation_OverlappingPlans adds a deeper-discount plan of each type
+// to the complex scenario, listed after the scenario's own plans, which have enough
+// commitment to cover all of their eligible usage. Applied one by one, the scenario's
+// plans leave the new ones nothing to cover. Ranked together, the new plans' 50%
+// discounts win every instance they can cover.
+func TestSavingsPlanAllocation_OverlappingPlans(t *testing.T) {
+	const (
+		ec2SP     = "arn:aws:savingsplans::111111111111:savingsplan/prod-ec2-instance-sp"
+		ec2SP3yr  = "arn:aws:savingsplans::111111111111:savingsplan/prod-ec2-instance-sp-3yr"
+		computeSP = "arn:aws:savingsplans::111111111111:savingsplan/org-compute-sp-3yr"
+	)
+
+	input := newScenarioInput(t, testdata.ComplexScenario)
+	input.SavingsPlans = append(input.SavingsPlans,
+		aws.SavingsPlan{
+			SavingsPlanARN:  ec2SP3yr,
+			SavingsPlanType: aws.SavingsPlanTypeEC2Instance,
+			Region:          "us-west-2",
+			InstanceFamily:  "r5",
+			Commitment:      0.50,
+			AccountID:       "111111111111",
+		},
+		aws.SavingsPlan{
+			SavingsPlanARN:  computeSP,
+			SavingsPlanType: aws.SavingsPlanTypeCompute,
+			Commitment:      1.00,
+			AccountID:       "111111111111",
+		},
+	)
+
+	// The new plans' rates are half of on-demand; the scenario's plans use the
+	// estimated 0.72 multiplier
+	pricing := input.PricingCache.(*mockPricingCache)
+	for _, inst := range input.Instances {
+		price := input.OnDemandPrices[OnDemandPriceKey(inst.InstanceType, inst.Region, aws.PlatformLinux, "")]
+		for _, arn := range []string{ec2SP3yr, computeSP} {
+			key := fmt.Sprintf("%s,%s,%s,%s,linux", arn, inst.InstanceType, inst.Region, inst.Tenancy)
+			pricing.spRates[strings.ToLower(key)] = price * 0.5
+		}
+	}
+
+	calc := NewCalculator(pricing, nil)
+	perPlan := calc.Calculate(input)
+	input.SavingsPlanAllocation = SavingsPlanAllocationGlobal
+	global := calc.Calculate(input)
+
+	require.Empty(t, perPlan.InvariantViolations)
+	require.Empty(t, global.InvariantViolations)
+
+	for _, arn := range []string{ec2SP3yr, computeSP} {
+		assert.Zero(t, perPlan.SavingsPlanUtilization[arn].CurrentUtilizationRate, arn)
+		assert.InDelta(t, 0, global.SavingsPlanUtilization[arn].RemainingCapacity, 1e-9, arn)
+	}
+	assert.Less(t, global.TotalEstimatedCost, perPlan.TotalEstimatedCost)
+
+	// The r5 usage the 3-year plan can't afford still falls to the 1-year plan,
+	// including the rest of the instance its commitment ran out on
+	coveredBy := make(map[string]int)
+	for _, instanceCost := range global.InstanceCosts {
+		if instanceCost.InstanceType == "r5.2xlarge" {
+			for _, contribution := range instanceCost.SavingsPlanContributions {
+				coveredBy[contribution.SavingsPlanARN]++
+			}
+		}
+	}
+	assert.Equal(t, map[string]int{ec2SP3yr: 2, ec2SP: 3}, coveredBy)
+
+	// The explanation of an r5 instance covered by the 3-year plan records the 1-year
+	// plan's pair, ranked lower, as already covered
+	var instanceID string
+	for id, instanceCost := range global.InstanceCosts {
+		contributions := instanceCost.SavingsPlanContributions
+		if len(contributions) == 1 && contributions[0].SavingsPlanARN == ec2SP3yr {
+			instanceID = id
+			break
+		}
+	}
+	require.NotEmpty(t, instanceID)
+	explanation, ok := calc.Explain(input, instanceID)
+	require.True(t, ok)
+
+	decisions := make(map[string]SavingsPlanDecision)
+	for _, decision := range explanation.SavingsPlans {
+		if !decision.Shared && decision.SavingsPlanType == aws.SavingsPlanTypeEC2Instance {
+			decisions[decision.SavingsPlanARN] = decision
+		}
+	}
+	require.Len(t, decisions, 2)
+	assert.Empty(t, decisions[ec2SP3yr].Rejection)
+	assert.InDelta(t, 0.5, decisions[ec2SP3yr].SavingsPercent, 1e-9)
+	assert.Equal(t, RejectedSPCovered, decisions[ec2SP].Rejection)
+	assert.Greater(t, decisions[ec2SP].Rank, decisions[ec2SP3yr].Rank)
+	assert.Equal(t, 8, decisions[ec2SP].Eligible)
+}
+
+// TestSavingsPlanSplitCoverage verifies that an instance a Savings Plan only partly
+// covers, because its commitment ran out, draws the rest from the next plan and then
+// from on-demand rates.
+func TestSavingsPlanSplitCoverage(t *testing.T) {
+	const planA = "arn:aws:savingsplans::111111111111:savingsplan/sp-a"
+	const planB = "arn:aws:savingsplans::111111111111:savingsplan/sp-b"
+
+	// At the default 0.72 rate an m5.xlarge costs $0.13824/hour under a plan. Plan A
+	// covers i-old and $0.06176 of i-new, and plan B another $0.05 of i-new.
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newSharingTestInstance("i-old", payerAccount, 1*time.Hour),
+			newSharingTestInstance("i-new", payerAccount, 2*time.Hour),
+		},
+		SavingsPlans: []aws.SavingsPlan{
+			newSharingTestSP(planA, payerAccount, 0.20),
+			newSharingTestSP(planB, payerAccount, 0.05),
+		},
+		OnDemandPrices: sizeFlexTestPrices(),
+	}
+
+	for _, allocation := range []SavingsPlanAllocation{SavingsPlanAllocationPerPlan, SavingsPlanAllocationGlobal} {
+		t.Run(string(allocation), func(t *testing.T) {
+			input.SavingsPlanAllocation = allocation
+			result := NewCalculator(nil, nil).Calculate(input)
+			require.Empty(t, result.InvariantViolations)
+
+			old := result.InstanceCosts["i-old"]
+			require.Len(t, old.SavingsPlanContributions, 1)
+			assert.Equal(t, planA, old.SavingsPlanContributions[0].SavingsPlanARN)
+			assert.InDelta(t, 0.13824, old.EffectiveCost, 1e-9)
+
+			// i-new is covered 0.06176/0.13824 by plan A and 0.05/0.13824 by plan B,
+			// and pays on-demand rates for the remaining 19.2%
+			split := result.InstanceCosts["i-new"]
+			require.Len(t, split.SavingsPlanContributions, 2)
+			assert.Equal(t, planA, split.SavingsPlanContributions[0].SavingsPlanARN)
+			assert.InDelta(t, 0.06176, split.SavingsPlanContributions[0].Coverage, 1e-9)
+			assert.Equal(t, planB, split.SavingsPlanContributions[1].SavingsPlanARN)
+			assert.InDelta(t, 0.05, split.SavingsPlanContributions[1].Coverage, 1e-9)
+			assert.InDelta(t, 0.11176, split.SavingsPlanCoverage, 1e-9)
+			assert.InDelta(t, 0.11176+0.192*(1-0.11176/0.13824), split.EffectiveCost, 1e-9)
+			assert.Equal(t, CoverageComputeSavingsPlan, split.CoverageType)
+
+			// Both plans are used up, and the usage they couldn't cover is unmet demand
+			// of the plan i-new was first eligible for
+			assert.InDelta(t, 0, result.SavingsPlanUtilization[planA].RemainingCapacity, 1e-9)
+			assert.InDelta(t, 0, result.SavingsPlanUtilization[planB].RemainingCapacity, 1e-9)
+			assert.InDelta(t, 0.13824-0.11176, result.SavingsPlanUtilization[planA].UnmetDemandRate, 1e-9)
+			assert.Zero(t, result.SavingsPlanUtilization[planB].UnmetDemandRate)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// bytesPerGB converts Kubernetes memory quantities to the GB Fargate bills in.
+// Fargate's "GB" is a binary gigabyte (GiB).
+const bytesPerGB = 1 << 30
+
+// fargateMemoryOverheadGB is the memory EKS adds to each Fargate pod's request for
+// the Kubernetes components (kubelet, kube-proxy, containerd) that run beside it.
+const fargateMemoryOverheadGB = 0.25
+
+// fargateConfigurations are the vCPU and memory combinations Fargate runs pods
+// with, smallest first. Memory goes from minMemoryGB to maxMemoryGB in steps of
+// stepGB, except that 0.25 vCPU also offers 0.5 GB.
+var fargateConfigurations = []struct {
+	vCPU        float64
+	minMemoryGB float64
+	maxMemoryGB float64
+	stepGB      float64
+}{
+	{vCPU: 0.25, minMemoryGB: 0.5, maxMemoryGB: 2, stepGB: 1},
+	{vCPU: 0.5, minMemoryGB: 1, maxMemoryGB: 4, stepGB: 1},
+	{vCPU: 1, minMemoryGB: 2, maxMemoryGB: 8, stepGB: 1},
+	{vCPU: 2, minMemoryGB: 4, maxMemoryGB: 16, stepGB: 1},
+	{vCPU: 4, minMemoryGB: 8, maxMemoryGB: 30, stepGB: 1},
+	{vCPU: 8, minMemoryGB: 16, maxMemoryGB: 60, stepGB: 4},
+	{vCPU: 16, minMemoryGB: 32, maxMemoryGB: 120, stepGB: 8},
+}
+
+// FargatePodCapacity returns the vCPU and memory (GB) Fargate bills a pod for.
+//
+// capacityProvisioned is the pod's CapacityProvisioned annotation (e.g.,
+// "0.25vCPU 0.5GB"), which Fargate sets once it has chosen the pod's size. Until then
+// the size is estimated from the pod's CPU (cores) and memory (bytes) requests the
+// way Fargate chooses it: the smallest configuration with at least the requested
+// vCPU and the requested memory plus 256 MB. Pods larger than any configuration are
+// sized as the largest one.
+func FargatePodCapacity(capacityProvisioned string, cpuCores, memoryBytes float64) (vCPU, memoryGB float64) {
+	if vCPU, memoryGB, ok := parseFargateCapacity(capacityProvisioned); ok {
+		return vCPU, memoryGB
+	}
+
+	memoryGB = memoryBytes/bytesPerGB + fargateMemoryOverheadGB
+	for _, option := range fargateConfigurations {
+		if option.vCPU < cpuCores || option.maxMemoryGB < memoryGB {
+			continue
+		}
+		if memoryGB <= option.minMemoryGB {
+			return option.vCPU, option.minMemoryGB
+		}
+		return option.vCPU, math.Ceil(memoryGB/option.stepGB) * option.stepGB
+	}
+
+	largest := fargateConfigurations[len(fargateConfigurations)-1]
+	return largest.vCPU, largest.maxMemoryGB
+}
+
+// parseFargateCapacity parses a CapacityProvisioned annotation such as
+// "0.25vCPU 0.5GB".
+func parseFargateCapacity(capacityProvisioned string) (vCPU, memoryGB float64, ok bool) {
+	fields := strings.Fields(capacityProvisioned)
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+	vCPU, cpuErr := strconv.ParseFloat(strings.TrimSuffix(fields[0], "vCPU"), 64)
+	memoryGB, memoryErr := strconv.ParseFloat(strings.TrimSuffix(fields[1], "GB"), 64)
+	if cpuErr != nil || memoryErr != nil || vCPU <= 0 || memoryGB <= 0 {
+		return 0, 0, false
+	}
+	return vCPU, memoryGB, true
+}
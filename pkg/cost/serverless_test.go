@@ -0,0 +1,167 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFargatePodCapacity tests sizing pods from the CapacityProvisioned annotation
+// and from their requests.
+func TestFargatePodCapacity(t *testing.T) {
+	const gb = 1 << 30
+	tests := []struct {
+		name       string
+		annotation string
+		cpu        float64
+		memory     float64
+		wantVCPU   float64
+		wantMemory float64
+	}{
+		{name: "annotation", annotation: "2vCPU 8GB", cpu: 0.1, memory: 0.1 * gb, wantVCPU: 2, wantMemory: 8},
+		{name: "smallest", cpu: 0.1, memory: 0.1 * gb, wantVCPU: 0.25, wantMemory: 0.5},
+		{name: "memory overhead rounds up", cpu: 0.25, memory: 0.5 * gb, wantVCPU: 0.25, wantMemory: 1},
+		{name: "memory needs more vCPU", cpu: 0.25, memory: 3 * gb, wantVCPU: 0.5, wantMemory: 4},
+		{name: "large step", cpu: 6, memory: 17 * gb, wantVCPU: 8, wantMemory: 20},
+		{name: "larger than any configuration", cpu: 32, memory: 200 * gb, wantVCPU: 16, wantMemory: 120},
+		{name: "malformed annotation", annotation: "big", cpu: 1, memory: 1 * gb, wantVCPU: 1, wantMemory: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vCPU, memoryGB := FargatePodCapacity(tt.annotation, tt.cpu, tt.memory)
+			assert.Equal(t, tt.wantVCPU, vCPU)
+			assert.Equal(t, tt.wantMemory, memoryGB)
+		})
+	}
+}
+
+// TestCalculatorServerlessComputeSavingsPlan tests that Fargate and Lambda usage
+// competes with instances for Compute Savings Plan commitment by savings percentage,
+// and isn't covered by EC2 Instance Savings Plans.
+func TestCalculatorServerlessComputeSavingsPlan(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	input := CalculationInput{
+		Instances: []aws.Instance{{
+			InstanceID:       "i-001",
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			LaunchTime:       testBaseTime(),
+		}},
+		SavingsPlans: []aws.SavingsPlan{
+			{
+				SavingsPlanARN:  "arn:aws:savingsplans::123456789012:savingsplan/ec2",
+				SavingsPlanType: "EC2Instance",
+				Region:          "us-west-2",
+				InstanceFamily:  "c5",
+				Commitment:      5.00,
+			},
+			{
+				SavingsPlanARN:  "arn:aws:savingsplans::123456789012:savingsplan/compute",
+				SavingsPlanType: "Compute",
+				Region:          "all",
+				Commitment:      1.00,
+			},
+		},
+		PricingCache:   &mockPricingCache{},
+		OnDemandPrices: map[string]float64{"m5.xlarge:us-west-2:linux": 1.00},
+		ServerlessUsage: []ServerlessUsage{
+			{ID: "fargate/web/api", Service: ServiceFargate, OnDemandCost: 0.50, SavingsPlanRate: 0.40},
+			{ID: "estimate/functions", Service: ServiceLambda, OnDemandCost: 1.00, SavingsPlanRate: 0.88},
+			{ID: "estimate/unused", Service: ServiceLambda},
+		},
+	}
+
+	result := calc.Calculate(input)
+	require.Len(t, result.ServerlessCosts, 2, "usage without a cost is skipped")
+
+	// The instance saves 28%, so it's covered first at the default 0.72 rate
+	instance := result.InstanceCosts["i-001"]
+	assert.Equal(t, CoverageComputeSavingsPlan, instance.CoverageType)
+	assert.InDelta(t, 0.72, instance.EffectiveCost, 0.0001)
+
+	// Fargate saves 20% and gets the rest of the commitment: $0.28 covers 70% of it,
+	// and it pays on-demand rates for the other 30%
+	fargate := result.ServerlessCosts["fargate/web/api"]
+	assert.Equal(t, CoverageComputeSavingsPlan, fargate.CoverageType)
+	require.Len(t, fargate.SavingsPlanContributions, 1)
+	assert.Equal(t, "arn:aws:savingsplans::123456789012:savingsplan/compute",
+		fargate.SavingsPlanContributions[0].SavingsPlanARN)
+	assert.InDelta(t, 0.28, fargate.SavingsPlanCoverage, 0.0001)
+	assert.InDelta(t, 0.28+0.30*0.50, fargate.EffectiveCost, 0.0001)
+
+	// Lambda saves only 12% and pays on-demand
+	lambda := result.ServerlessCosts["estimate/functions"]
+	assert.Equal(t, CoverageOnDemand, lambda.CoverageType)
+	assert.Equal(t, 1.00, lambda.EffectiveCost)
+
+	compute := result.SavingsPlanUtilization["arn:aws:savingsplans::123456789012:savingsplan/compute"]
+	assert.InDelta(t, 100.0, compute.UtilizationPercent, 0.0001)
+	assert.InDelta(t, 0.12+0.88, compute.UnmetDemandRate, 0.0001)
+
+	ec2 := result.SavingsPlanUtilization["arn:aws:savingsplans::123456789012:savingsplan/ec2"]
+	assert.Equal(t, 0.0, ec2.CurrentUtilizationRate)
+
+	// Serverless costs aren't part of the instance totals
+	assert.InDelta(t, 0.72, result.TotalEstimatedCost, 0.0001)
+}
+
+// TestCalculatorServerlessSplitCoverage tests that Fargate usage a Compute Savings
+// Plan only partly covers draws the rest from the next plan and then from on-demand
+// rates, the same way instances do.
+func TestCalculatorServerlessSplitCoverage(t *testing.T) {
+	const planA = "arn:aws:savingsplans::123456789012:savingsplan/compute-a"
+	const planB = "arn:aws:savingsplans::123456789012:savingsplan/compute-b"
+
+	input := CalculationInput{
+		SavingsPlans: []aws.SavingsPlan{
+			{SavingsPlanARN: planA, SavingsPlanType: "Compute", Region: "all", Commitment: 0.20},
+			{SavingsPlanARN: planB, SavingsPlanType: "Compute", Region: "all", Commitment: 0.10},
+		},
+		ServerlessUsage: []ServerlessUsage{
+			{ID: "fargate/web/api", Service: ServiceFargate, OnDemandCost: 0.50, SavingsPlanRate: 0.40},
+		},
+	}
+
+	for _, allocation := range []SavingsPlanAllocation{SavingsPlanAllocationPerPlan, SavingsPlanAllocationGlobal} {
+		t.Run(string(allocation), func(t *testing.T) {
+			input.SavingsPlanAllocation = allocation
+			result := NewCalculator(nil, nil).Calculate(input)
+			require.Empty(t, result.InvariantViolations)
+
+			// Plan A covers half the usage and plan B a quarter, leaving a quarter
+			// at on-demand rates: $0.20 + $0.10 + $0.125
+			fargate := result.ServerlessCosts["fargate/web/api"]
+			assert.Equal(t, []SavingsPlanContribution{
+				{SavingsPlanARN: planA, Coverage: 0.20},
+				{SavingsPlanARN: planB, Coverage: 0.10},
+			}, fargate.SavingsPlanContributions)
+			assert.InDelta(t, 0.30, fargate.SavingsPlanCoverage, 1e-9)
+			assert.InDelta(t, 0.425, fargate.EffectiveCost, 1e-9)
+
+			// The uncovered quarter is unmet demand of the first plan, at the SP rate
+			assert.InDelta(t, 0.10, result.SavingsPlanUtilization[planA].UnmetDemandRate, 1e-9)
+			assert.Zero(t, result.SavingsPlanUtilization[planB].UnmetDemandRate)
+		})
+	}
+}
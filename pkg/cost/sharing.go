@@ -0,0 +1,54 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+// AWS applies a Reserved Instance or Savings Plan to usage in the account that
+// purchased it first. Whatever is left is then shared with the other accounts in the
+// consolidated billing family, unless discount sharing is turned off for the
+// purchasing account or for the account receiving the discount. An account with
+// sharing turned off keeps its commitments to itself and receives no other
+// account's.
+//
+// The calculator models this with two allocation passes: every commitment is first
+// applied to its owner's usage, and only then to other accounts' usage.
+//
+// Reference: https://docs.aws.amazon.com/awsaccountbilling/latest/aboutv2/ri-turn-off.html
+
+// allocationPasses are the allocation passes in order: owner account usage first
+// (shared=false), then other accounts' usage (shared=true).
+var allocationPasses = []bool{false, true}
+
+// discountSharing is the set of account IDs with RI and SP discount sharing turned off.
+type discountSharing map[string]bool
+
+// shares reports whether a commitment owned by owner is applied to other accounts'
+// usage at all.
+func (d discountSharing) shares(owner string) bool {
+	return !d[owner]
+}
+
+// eligible reports whether usage in accountID can receive the discount of a
+// commitment owned by owner in the owner (shared=false) or shared (shared=true) pass.
+// A commitment whose owner isn't known is treated as owned by no account, so it
+// covers usage only in the shared pass.
+func (d discountSharing) eligible(owner, accountID string, shared bool) bool {
+	if owner == "" {
+		return shared && !d[accountID]
+	}
+	if !shared {
+		return accountID == owner
+	}
+	return accountID != owner && !d[owner] && !d[accountID]
+}
@@ -0,0 +1,295 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	payerAccount  = "111111111111"
+	memberAccount = "222222222222"
+)
+
+// newSharingTestInstance creates an m5.xlarge instance in accountID.
+func newSharingTestInstance(id, accountID string, launchOffset time.Duration) aws.Instance {
+	inst := newSizeFlexTestInstance(id, "m5.xlarge", launchOffset)
+	inst.AccountID = accountID
+	return inst
+}
+
+// newSharingTestSP creates a Compute Savings Plan owned by accountID.
+func newSharingTestSP(arn, accountID string, commitment float64) aws.SavingsPlan {
+	return aws.SavingsPlan{
+		SavingsPlanARN:  arn,
+		SavingsPlanType: "Compute",
+		Region:          "all",
+		Commitment:      commitment,
+		AccountID:       accountID,
+	}
+}
+
+func TestDiscountSharingEligible(t *testing.T) {
+	sharing := discountSharing{memberAccount: true}
+	const otherAccount = "333333333333"
+
+	tests := []struct {
+		name      string
+		owner     string
+		accountID string
+		shared    bool
+		want      bool
+	}{
+		{name: "owner pass covers owner", owner: payerAccount, accountID: payerAccount, want: true},
+		{name: "owner pass skips others", owner: payerAccount, accountID: otherAccount},
+		{name: "shared pass covers others", owner: payerAccount, accountID: otherAccount, shared: true, want: true},
+		{name: "shared pass skips owner", owner: payerAccount, accountID: payerAccount, shared: true},
+		{name: "receiver not sharing", owner: payerAccount, accountID: memberAccount, shared: true},
+		{name: "owner not sharing", owner: memberAccount, accountID: otherAccount, shared: true},
+		{name: "owner not sharing covers itself", owner: memberAccount, accountID: memberAccount, want: true},
+		{name: "unknown owner has no owner pass", owner: "", accountID: ""},
+		{name: "unknown owner shares", owner: "", accountID: otherAccount, shared: true, want: true},
+		{name: "unknown owner respects receiver", owner: "", accountID: memberAccount, shared: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sharing.eligible(tt.owner, tt.accountID, tt.shared))
+		})
+	}
+}
+
+// TestRISharedAcrossAccounts verifies that an RI bought in one account covers
+// matching instances in other accounts when its own account has none.
+func TestRISharedAcrossAccounts(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	ri := newRegionalTestRI("ri-payer", "m5.xlarge", 2)
+	ri.AccountID = payerAccount
+	zonal := newRegionalTestRI("ri-zonal", "m5.xlarge", 1)
+	zonal.AccountID = payerAccount
+	zonal.AvailabilityZone = "us-west-2a"
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newSharingTestInstance("i-001", memberAccount, 1*time.Hour),
+			newSharingTestInstance("i-002", memberAccount, 2*time.Hour),
+			newSharingTestInstance("i-003", memberAccount, 3*time.Hour),
+		},
+		ReservedInstances: []aws.ReservedInstance{ri, zonal},
+		OnDemandPrices:    sizeFlexTestPrices(),
+	}
+
+	result := calc.Calculate(input)
+
+	// The zonal RI is still applied before the regional one
+	require.Len(t, result.InstanceCosts["i-001"].RIContributions, 1)
+	assert.Equal(t, "ri-zonal", result.InstanceCosts["i-001"].RIContributions[0].ReservedInstanceID)
+	for _, id := range []string{"i-002", "i-003"} {
+		assert.Equal(t, CoverageReservedInstance, result.InstanceCosts[id].CoverageType, id)
+		assert.Equal(t, 0.0, result.InstanceCosts[id].EffectiveCost, id)
+	}
+	assert.InDelta(t, 2, result.ReservedInstanceUtilization["ri-payer"].UtilizedInstances, 1e-9)
+	assert.InDelta(t, 1, result.ReservedInstanceUtilization["ri-zonal"].UtilizedInstances, 1e-9)
+}
+
+// TestRIOwnerAccountFirst verifies that an RI covers its own account's instances
+// before older instances in other accounts, and that each account's RI covers its
+// own instances before either is shared.
+func TestRIOwnerAccountFirst(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	payerRI := newRegionalTestRI("ri-payer", "m5.xlarge", 2)
+	payerRI.AccountID = payerAccount
+	memberRI := newRegionalTestRI("ri-member", "m5.2xlarge", 1)
+	memberRI.AccountID = memberAccount
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newSharingTestInstance("i-member-old", memberAccount, 1*time.Hour),
+			newSharingTestInstance("i-member-new", memberAccount, 2*time.Hour),
+			newSharingTestInstance("i-member-newest", memberAccount, 3*time.Hour),
+			newSharingTestInstance("i-payer", payerAccount, 4*time.Hour),
+		},
+		// The payer RI comes first, but mustn't take the member's instances before
+		// the member's own RI has covered them
+		ReservedInstances: []aws.ReservedInstance{payerRI, memberRI},
+		OnDemandPrices:    sizeFlexTestPrices(),
+	}
+
+	result := calc.Calculate(input)
+
+	contributor := func(id string) string {
+		contributions := result.InstanceCosts[id].RIContributions
+		require.Len(t, contributions, 1, id)
+		return contributions[0].ReservedInstanceID
+	}
+	assert.Equal(t, "ri-payer", contributor("i-payer"))
+	assert.Equal(t, "ri-member", contributor("i-member-old"))
+	assert.Equal(t, "ri-member", contributor("i-member-new"))
+	// The payer RI's second instance is shared with the member's remaining instance
+	assert.Equal(t, "ri-payer", contributor("i-member-newest"))
+
+	assert.InDelta(t, 2, result.ReservedInstanceUtilization["ri-payer"].UtilizedInstances, 1e-9)
+	assert.InDelta(t, 1, result.ReservedInstanceUtilization["ri-member"].UtilizedInstances, 1e-9)
+}
+
+// TestRISharedSizeFlexibleRemainder verifies that only the units a size-flexible RI
+// didn't spend in its own account are shared.
+func TestRISharedSizeFlexibleRemainder(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	ri := newRegionalTestRI("ri-payer", "m5.2xlarge", 1) // 16 units
+	ri.AccountID = payerAccount
+	payerInstance := newSizeFlexTestInstance("i-payer", "m5.large", 2*time.Hour) // 4 units
+	payerInstance.AccountID = payerAccount
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newSharingTestInstance("i-member", memberAccount, 1*time.Hour), // 8 units
+			newSharingTestInstance("i-member-2", memberAccount, 3*time.Hour),
+			payerInstance,
+		},
+		ReservedInstances: []aws.ReservedInstance{ri},
+		OnDemandPrices:    sizeFlexTestPrices(),
+	}
+
+	result := calc.Calculate(input)
+
+	assert.Equal(t, 0.0, result.InstanceCosts["i-payer"].EffectiveCost)
+	assert.Equal(t, 0.0, result.InstanceCosts["i-member"].EffectiveCost)
+	// 16 - 4 - 8 = 4 of the second member instance's 8 units are left
+	assert.InDelta(t, 0.096, result.InstanceCosts["i-member-2"].RICoverage, 1e-9)
+	assert.InDelta(t, 0.096, result.InstanceCosts["i-member-2"].EffectiveCost, 1e-9)
+	assert.InDelta(t, 1, result.ReservedInstanceUtilization["ri-payer"].UtilizedInstances, 1e-9)
+}
+
+// TestRISharingDisabled verifies that an account with discount sharing turned off
+// neither receives other accounts' RIs nor shares its own.
+func TestRISharingDisabled(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	payerRI := newRegionalTestRI("ri-payer", "m5.xlarge", 1)
+	payerRI.AccountID = payerAccount
+	memberRI := newRegionalTestRI("ri-member", "m5.xlarge", 2)
+	memberRI.AccountID = memberAccount
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			newSharingTestInstance("i-member", memberAccount, 1*time.Hour),
+			newSharingTestInstance("i-member-2", memberAccount, 2*time.Hour),
+			newSharingTestInstance("i-member-3", memberAccount, 3*time.Hour),
+			newSharingTestInstance("i-payer", payerAccount, 4*time.Hour),
+			newSharingTestInstance("i-payer-2", payerAccount, 5*time.Hour),
+		},
+		ReservedInstances:       []aws.ReservedInstance{payerRI, memberRI},
+		SharingDisabledAccounts: map[string]bool{memberAccount: true},
+		OnDemandPrices:          sizeFlexTestPrices(),
+	}
+
+	result := calc.Calculate(input)
+
+	assert.Equal(t, CoverageReservedInstance, result.InstanceCosts["i-payer"].CoverageType)
+	assert.Equal(t, CoverageOnDemand, result.InstanceCosts["i-payer-2"].CoverageType,
+		"the member's unused RI isn't shared")
+	assert.Equal(t, CoverageReservedInstance, result.InstanceCosts["i-member"].CoverageType)
+	assert.Equal(t, CoverageReservedInstance, result.InstanceCosts["i-member-2"].CoverageType)
+	assert.Equal(t, CoverageOnDemand, result.InstanceCosts["i-member-3"].CoverageType,
+		"the member doesn't receive the payer's RI")
+}
+
+// TestSavingsPlanOwnerAccountFirst verifies that every Savings Plan covers its own
+// account's usage before any plan's commitment is shared with other accounts.
+func TestSavingsPlanOwnerAccountFirst(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	// Each plan has enough commitment for one m5.xlarge at the default 0.72 rate
+	const payerARN = "arn:aws:savingsplans::111111111111:savingsplan/payer"
+	const memberARN = "arn:aws:savingsplans::222222222222:savingsplan/member"
+	commitment := 0.192 * 0.72
+
+	input := CalculationInput{
+		Instances: []aws.Instance{
+			// Older instances are covered first within a pass
+			newSharingTestInstance("i-member", memberAccount, 1*time.Hour),
+			newSharingTestInstance("i-payer", payerAccount, 2*time.Hour),
+		},
+		SavingsPlans: []aws.SavingsPlan{
+			newSharingTestSP(payerARN, payerAccount, commitment),
+			newSharingTestSP(memberARN, memberAccount, commitment),
+		},
+		OnDemandPrices: sizeFlexTestPrices(),
+	}
+
+	result := calc.Calculate(input)
+
+	assert.Equal(t, []SavingsPlanContribution{{SavingsPlanARN: payerARN, Coverage: commitment}},
+		result.InstanceCosts["i-payer"].SavingsPlanContributions)
+	assert.Equal(t, []SavingsPlanContribution{{SavingsPlanARN: memberARN, Coverage: commitment}},
+		result.InstanceCosts["i-member"].SavingsPlanContributions)
+	assert.InDelta(t, 100, result.SavingsPlanUtilization[payerARN].UtilizationPercent, 1e-9)
+	assert.InDelta(t, 100, result.SavingsPlanUtilization[memberARN].UtilizationPercent, 1e-9)
+}
+
+// TestSavingsPlanSharedRemainder verifies that the commitment a plan doesn't use in
+// its own account is shared, unless sharing is turned off for either account.
+func TestSavingsPlanSharedRemainder(t *testing.T) {
+	const payerARN = "arn:aws:savingsplans::111111111111:savingsplan/payer"
+	spRate := 0.192 * 0.72
+
+	tests := []struct {
+		name              string
+		sharingDisabled   map[string]bool
+		wantMemberCovered bool
+	}{
+		{name: "sharing on", wantMemberCovered: true},
+		{name: "payer not sharing", sharingDisabled: map[string]bool{payerAccount: true}},
+		{name: "member not sharing", sharingDisabled: map[string]bool{memberAccount: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc := NewCalculator(nil, nil)
+			input := CalculationInput{
+				Instances: []aws.Instance{
+					newSharingTestInstance("i-member", memberAccount, 1*time.Hour),
+					newSharingTestInstance("i-payer", payerAccount, 2*time.Hour),
+				},
+				SavingsPlans:            []aws.SavingsPlan{newSharingTestSP(payerARN, payerAccount, 2*spRate)},
+				SharingDisabledAccounts: tt.sharingDisabled,
+				OnDemandPrices:          sizeFlexTestPrices(),
+			}
+
+			result := calc.Calculate(input)
+
+			assert.InDelta(t, spRate, result.InstanceCosts["i-payer"].SavingsPlanCoverage, 1e-9)
+			util := result.SavingsPlanUtilization[payerARN]
+			if tt.wantMemberCovered {
+				assert.InDelta(t, spRate, result.InstanceCosts["i-member"].SavingsPlanCoverage, 1e-9)
+				assert.InDelta(t, 2*spRate, util.CurrentUtilizationRate, 1e-9)
+				assert.InDelta(t, 0, util.RemainingCapacity, 1e-9)
+			} else {
+				assert.Equal(t, CoverageOnDemand, result.InstanceCosts["i-member"].CoverageType)
+				assert.InDelta(t, spRate, util.CurrentUtilizationRate, 1e-9)
+				assert.InDelta(t, spRate, util.RemainingCapacity, 1e-9)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"strings"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+)
+
+// hoursPerMonth converts EBS's monthly prices to hourly costs. AWS prorates EBS
+// charges by the hour using a 730-hour month.
+const hoursPerMonth = 730
+
+// gp3 volumes include a baseline of IOPS and throughput in the storage price;
+// only what's provisioned above it is billed.
+const (
+	gp3BaselineIOPS       = 3000
+	gp3BaselineThroughput = 125 // MiB/s
+)
+
+// EBSPriceKey builds the CalculationInput.EBSPrices key for a volume type in a
+// region, e.g. "us-west-2:gp3".
+func EBSPriceKey(region, volumeType string) string {
+	return strings.ToLower(region + ":" + volumeType)
+}
+
+// VolumeHourlyCost returns the hourly cost of an EBS volume: its provisioned size,
+// plus provisioned IOPS (gp3 above the baseline, io1, io2) and throughput (gp3
+// above the baseline). gp2, st1, sc1, and standard volumes are billed on size only.
+// All io2 IOPS are priced at the first tier, so volumes above 32,000 IOPS are
+// slightly overestimated.
+func VolumeHourlyCost(vol aws.Volume, price aws.EBSPrice) float64 {
+	monthly := float64(vol.SizeGiB) * price.PerGBMonth
+
+	switch vol.VolumeType {
+	case aws.VolumeTypeGP3:
+		monthly += float64(max(vol.IOPS-gp3BaselineIOPS, 0)) * price.PerIOPSMonth
+		monthly += float64(max(vol.Throughput-gp3BaselineThroughput, 0)) * price.PerMiBpsMonth
+	case aws.VolumeTypeIO1, aws.VolumeTypeIO2:
+		monthly += float64(vol.IOPS) * price.PerIOPSMonth
+	}
+
+	return monthly / hoursPerMonth
+}
+
+// applyStorageCosts sets the StorageCost of each instance to the cost of the EBS
+// volumes attached to it. A Multi-Attach volume's cost is split evenly between its
+// instances. Volumes without a price (e.g., before EBS pricing has loaded) are
+// left out.
+func applyStorageCosts(input CalculationInput, costs map[string]*InstanceCost) {
+	for instanceID, vols := range input.Volumes {
+		cost, ok := costs[instanceID]
+		if !ok {
+			continue
+		}
+		for _, vol := range vols {
+			price, ok := input.EBSPrices[EBSPriceKey(vol.Region, vol.VolumeType)]
+			if !ok {
+				continue
+			}
+			cost.StorageCost += VolumeHourlyCost(vol, price) / float64(max(len(vol.AttachedInstanceIDs), 1))
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVolumeHourlyCost tests which IOPS and throughput are billed for each volume type.
+func TestVolumeHourlyCost(t *testing.T) {
+	price := aws.EBSPrice{PerGBMonth: 0.10, PerIOPSMonth: 0.01, PerMiBpsMonth: 0.05}
+	tests := []struct {
+		name        string
+		volume      aws.Volume
+		wantMonthly float64
+	}{
+		{
+			name:        "gp2 is billed on size only",
+			volume:      aws.Volume{VolumeType: aws.VolumeTypeGP2, SizeGiB: 100, IOPS: 300},
+			wantMonthly: 10,
+		},
+		{
+			name:        "gp3 within baseline",
+			volume:      aws.Volume{VolumeType: aws.VolumeTypeGP3, SizeGiB: 100, IOPS: 3000, Throughput: 125},
+			wantMonthly: 10,
+		},
+		{
+			name:        "gp3 above baseline",
+			volume:      aws.Volume{VolumeType: aws.VolumeTypeGP3, SizeGiB: 100, IOPS: 5000, Throughput: 225},
+			wantMonthly: 10 + 2000*0.01 + 100*0.05,
+		},
+		{
+			name:        "io2 bills every provisioned IOPS",
+			volume:      aws.Volume{VolumeType: aws.VolumeTypeIO2, SizeGiB: 100, IOPS: 1000},
+			wantMonthly: 10 + 1000*0.01,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.wantMonthly/hoursPerMonth, VolumeHourlyCost(tt.volume, price), 1e-9)
+		})
+	}
+}
+
+// TestCalculatorStorageCosts tests that attached volume costs are attributed to
+// instances without changing their compute cost.
+func TestCalculatorStorageCosts(t *testing.T) {
+	calc := NewCalculator(nil, nil)
+
+	instance := func(id string) aws.Instance {
+		return aws.Instance{
+			InstanceID:       id,
+			InstanceType:     "m5.xlarge",
+			Region:           "us-west-2",
+			AccountID:        "123456789012",
+			AvailabilityZone: "us-west-2a",
+			State:            "running",
+			LaunchTime:       testBaseTime(),
+		}
+	}
+	shared := aws.Volume{
+		VolumeID: "vol-shared", VolumeType: aws.VolumeTypeIO2, SizeGiB: 73, IOPS: 100, Region: "us-west-2",
+		AttachedInstanceIDs: []string{"i-001", "i-002"},
+	}
+
+	input := CalculationInput{
+		Instances:      []aws.Instance{instance("i-001"), instance("i-002")},
+		PricingCache:   &mockPricingCache{},
+		OnDemandPrices: map[string]float64{"m5.xlarge:us-west-2:linux": 0.192},
+		Volumes: map[string][]aws.Volume{
+			"i-001": {
+				{VolumeID: "vol-root", VolumeType: aws.VolumeTypeGP3, SizeGiB: 730, Region: "us-west-2"},
+				{VolumeID: "vol-old", VolumeType: aws.VolumeTypeGP2, SizeGiB: 100, Region: "us-west-2"},
+				shared,
+			},
+			"i-002":      {shared},
+			"i-unpriced": {{VolumeID: "vol-x", VolumeType: aws.VolumeTypeGP3, SizeGiB: 10, Region: "us-west-2"}},
+		},
+		EBSPrices: map[string]aws.EBSPrice{
+			EBSPriceKey("us-west-2", aws.VolumeTypeGP3): {PerGBMonth: 0.08},
+			EBSPriceKey("us-west-2", aws.VolumeTypeIO2): {PerGBMonth: 0.10, PerIOPSMonth: 0.073},
+		},
+	}
+
+	result := calc.Calculate(input)
+	require.Len(t, result.InstanceCosts, 2)
+	assert.True(t, result.IncludesStorage)
+
+	// 730 GiB of gp3 at $0.08/GB-month is $0.08/hour; gp2 has no price and is left out;
+	// the shared io2 volume costs (7.3 + 7.3) / 730 = $0.02/hour, split between both
+	first := result.InstanceCosts["i-001"]
+	assert.InDelta(t, 0.08+0.01, first.StorageCost, 1e-9)
+	assert.InDelta(t, 0.192, first.EffectiveCost, 1e-9, "compute cost is unchanged")
+	assert.InDelta(t, 0.192+0.09, first.TotalCost(), 1e-9)
+	assert.Equal(t, PricingAccurate, first.PricingAccuracy)
+
+	second := result.InstanceCosts["i-002"]
+	assert.InDelta(t, 0.01, second.StorageCost, 1e-9)
+
+	assert.InDelta(t, 0.10, result.TotalStorageCost, 1e-9)
+	assert.InDelta(t, 2*0.192, result.TotalEstimatedCost, 1e-9, "storage isn't part of the compute total")
+}
@@ -104,7 +104,8 @@ func TestCalculatorTenancyHandling(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 0.192, // Same on-demand price for both tenancies
+			"m5.xlarge:us-west-2:linux":           0.192,
+			"m5.xlarge:us-west-2:linux:dedicated": 0.2112, // Dedicated instances cost more on-demand
 		},
 	}
 
@@ -125,7 +126,7 @@ func TestCalculatorTenancyHandling(t *testing.T) {
 	// Check dedicated tenancy instance - should get accurate pricing with higher rate
 	dedicatedCost := result.InstanceCosts["i-dedicated"]
 	assert.Equal(t, "i-dedicated", dedicatedCost.InstanceID)
-	assert.Equal(t, 0.192, dedicatedCost.ShelfPrice)
+	assert.Equal(t, 0.2112, dedicatedCost.ShelfPrice)             // Dedicated tenancy on-demand price
 	assert.InDelta(t, 0.1708, dedicatedCost.EffectiveCost, 0.001) // Pays SP rate for dedicated tenancy
 	assert.InDelta(t, 0.1708, dedicatedCost.SavingsPlanCoverage, 0.001)
 	assert.Equal(t, CoverageEC2InstanceSavingsPlan, dedicatedCost.CoverageType)
@@ -180,7 +181,8 @@ func TestCalculatorTenancyMismatch(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 0.192,
+			"m5.xlarge:us-west-2:linux":           0.192,
+			"m5.xlarge:us-west-2:linux:dedicated": 0.2112,
 		},
 	}
 
@@ -192,12 +194,12 @@ func TestCalculatorTenancyMismatch(t *testing.T) {
 	// Check dedicated tenancy instance - should fall back to estimated pricing
 	dedicatedCost := result.InstanceCosts["i-dedicated"]
 	assert.Equal(t, "i-dedicated", dedicatedCost.InstanceID)
-	assert.Equal(t, 0.192, dedicatedCost.ShelfPrice)
+	assert.Equal(t, 0.2112, dedicatedCost.ShelfPrice)
 
 	// Should use estimated pricing (28% discount = 0.72 multiplier)
-	// $0.192 * 0.72 = $0.13824
-	assert.InDelta(t, 0.13824, dedicatedCost.EffectiveCost, 0.001)
-	assert.InDelta(t, 0.13824, dedicatedCost.SavingsPlanCoverage, 0.001)
+	// $0.2112 * 0.72 = $0.152064
+	assert.InDelta(t, 0.152064, dedicatedCost.EffectiveCost, 0.001)
+	assert.InDelta(t, 0.152064, dedicatedCost.SavingsPlanCoverage, 0.001)
 	assert.Equal(t, CoverageEC2InstanceSavingsPlan, dedicatedCost.CoverageType)
 	assert.Equal(t, PricingEstimated, dedicatedCost.PricingAccuracy,
 		"Should use estimated pricing when accurate rate not found for tenancy")
@@ -253,7 +255,8 @@ func TestCalculatorTenancyWithComputeSP(t *testing.T) {
 			},
 		},
 		OnDemandPrices: map[string]float64{
-			"m5.xlarge:us-west-2": 0.192,
+			"m5.xlarge:us-west-2:linux":           0.192,
+			"m5.xlarge:us-west-2:linux:dedicated": 0.2112,
 		},
 	}
 
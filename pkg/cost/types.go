@@ -17,9 +17,11 @@
 // Reserved Instances and Savings Plans are applied to running EC2 instances.
 //
 // The cost calculation follows AWS's documented priority order:
-//  1. Reserved Instances (RIs) - applied first to exact instance type + AZ matches
+//  1. Reserved Instances (RIs) - applied first to exact type + AZ matches, or by
+//     normalization factor within a family for size-flexible Regional RIs
 //  2. EC2 Instance Savings Plans - applied to specific instance family + region
-//  3. Compute Savings Plans - applied to any instance family, any region
+//  3. Compute Savings Plans - applied to any instance family, any region, and to
+//     Fargate and Lambda usage
 //  4. On-Demand pricing - applied to remaining uncovered usage
 //
 // The implementation uses a rate-based model ($/hour) rather than cumulative tracking
@@ -31,7 +33,11 @@
 //   - Operational: Good enough for cost-aware provisioning decisions
 //   - Approximate: May not match AWS's exact cumulative billing within an hour
 //
-// For billing-accurate validation, reconcile with AWS Cost Explorer API.
+// Callers that need the cumulative view can feed each CalculationResult into an
+// optional SavingsPlanLedger, which tracks commitment consumed per billing hour.
+//
+// For billing-accurate validation, DailyEstimates accumulates results into daily
+// totals that pkg/billing compares with the Cost and Usage Report.
 //
 // Algorithm Reference: AWS Savings Plans documentation
 // https://docs.aws.amazon.com/savingsplans/latest/userguide/sp-applying.html
@@ -86,6 +92,7 @@ const (
 	// PricingEstimated indicates the cost uses fallback/estimated values:
 	//   - Missing SP rates: using on-demand price as estimate
 	//   - Missing spot price: using on-demand price as estimate
+	//   - Missing OS/tenancy on-demand price: using shared Linux price as estimate
 	//   - Missing on-demand price: no cost calculated
 	PricingEstimated PricingAccuracy = "estimated"
 )
@@ -129,19 +136,26 @@ type InstanceCost struct {
 	// or estimated/fallback values. Use the PricingAccurate or PricingEstimated constants.
 	PricingAccuracy PricingAccuracy
 
-	// RICoverage is the amount of cost covered by a Reserved Instance ($/hour).
-	// For RI-covered instances, this is typically equal to ShelfPrice, and
-	// EffectiveCost is typically $0 (since RIs are pre-paid).
+	// RICoverage is the amount of cost covered by Reserved Instances ($/hour).
+	// For fully RI-covered instances, this is equal to ShelfPrice, and
+	// EffectiveCost is $0 (since RIs are pre-paid). Size-flexible Regional RIs
+	// may cover only part of an instance, in which case the remainder is billed
+	// at on-demand rates (or picked up by a Savings Plan).
 	RICoverage float64
 
+	// RIContributions lists each Reserved Instance that contributed to RICoverage.
+	// Empty if the instance has no RI coverage.
+	RIContributions []RIContribution
+
 	// SavingsPlanCoverage is the amount of cost covered by any Savings Plan
-	// (EC2 Instance SP or Compute SP) in $/hour.
-	// If multiple SPs apply (shouldn't happen per AWS rules), this is the total.
+	// (EC2 Instance SP or Compute SP) in $/hour: the sum of SavingsPlanContributions.
 	SavingsPlanCoverage float64
 
-	// SavingsPlanARN is the ARN of the Savings Plan that provided coverage,
-	// if applicable. Empty string if no SP coverage.
-	SavingsPlanARN string
+	// SavingsPlanContributions lists each Savings Plan that contributed to
+	// SavingsPlanCoverage, in the order they were applied. An SP that runs out of
+	// commitment covers only part of an instance, leaving the rest for later SPs and
+	// then on-demand rates. Empty if the instance has no SP coverage.
+	SavingsPlanContributions []SavingsPlanContribution
 
 	// OnDemandCost is the remaining cost charged at on-demand rates ($/hour)
 	// after all discounts have been applied. This represents "spillover" when
@@ -158,6 +172,46 @@ type InstanceCost struct {
 	// Lifecycle is the EC2 instance lifecycle type (e.g., "on-demand", "spot", "scheduled")
 	// This is used for metric labeling to distinguish instance types
 	Lifecycle string
+
+	// CapacityReservationID is the On-Demand Capacity Reservation the instance runs
+	// in, if any. Instances in a reservation are billed like any other instance, so
+	// this doesn't change the cost; it marks capacity that isn't wasted.
+	CapacityReservationID string
+
+	// StorageCost is the hourly cost of the EBS volumes attached to the instance
+	// (root and data volumes, including persistent volumes) in $/hour. It's not
+	// part of EffectiveCost, which covers compute only. Zero unless volume data
+	// is provided.
+	StorageCost float64
+}
+
+// TotalCost returns the instance's compute and storage cost ($/hour).
+func (ic InstanceCost) TotalCost() float64 {
+	return ic.EffectiveCost + ic.StorageCost
+}
+
+// RIContribution records the portion of an instance covered by a single Reserved Instance.
+type RIContribution struct {
+	// ReservedInstanceID is the ID of the contributing Reserved Instance
+	ReservedInstanceID string
+
+	// NormalizedUnits is the number of normalization units this RI applied to the
+	// instance (e.g., 8 for a full m5.xlarge). Zero for exact-match coverage of
+	// sizes without a normalization factor (e.g., metal).
+	NormalizedUnits float64
+
+	// Coverage is the amount of the instance's shelf price covered by this RI ($/hour)
+	Coverage float64
+}
+
+// SavingsPlanContribution records the portion of an instance covered by a single
+// Savings Plan.
+type SavingsPlanContribution struct {
+	// SavingsPlanARN is the ARN of the contributing Savings Plan
+	SavingsPlanARN string
+
+	// Coverage is the Savings Plan commitment this SP spent on the instance ($/hour)
+	Coverage float64
 }
 
 // SavingsPlanUtilization represents the current utilization state of a single
@@ -206,6 +260,12 @@ type SavingsPlanUtilization struct {
 	// Can exceed 100% if over-utilized.
 	UtilizationPercent float64
 
+	// UnmetDemandRate is the usage ($/hour at this SP's rates) of eligible instances
+	// that didn't fit within the commitment and is billed at on-demand rates instead.
+	// Each instance's unmet usage is attributed to the first SP it was eligible for.
+	// CurrentUtilizationRate + UnmetDemandRate is the total demand on this SP.
+	UnmetDemandRate float64
+
 	// RemainingHours is the number of hours until this Savings Plan expires.
 	// Useful for alerting on upcoming expirations.
 	RemainingHours float64
@@ -214,6 +274,146 @@ type SavingsPlanUtilization struct {
 	EndTime time.Time
 }
 
+// ReservedInstanceUtilization represents how much of a single Reserved Instance is
+// used by the instances currently running. Like SavingsPlanUtilization, this is a
+// snapshot of the current rate, not cumulative usage over the billing hour.
+type ReservedInstanceUtilization struct {
+	// ReservedInstanceID is the unique identifier for this Reserved Instance
+	ReservedInstanceID string
+
+	// AccountID is the AWS account that owns this Reserved Instance
+	AccountID string
+
+	// AccountName is the friendly name of the AWS account
+	AccountName string
+
+	// InstanceType is the instance type the RI was purchased for (e.g., "m5.xlarge")
+	InstanceType string
+
+	// Region is the AWS region of the RI
+	Region string
+
+	// AvailabilityZone is the AZ of a zonal RI, or empty/"regional" for regional RIs
+	AvailabilityZone string
+
+	// InstanceCount is the number of instances the RI was purchased for
+	InstanceCount int32
+
+	// UtilizedInstances is how many of InstanceCount are covering running instances.
+	// Size-flexible RIs can be partly used, so this is the normalized units applied
+	// divided by the RI type's normalization factor (e.g., 1.5 when an RI for
+	// 2x m5.xlarge covers one m5.xlarge and one m5.large).
+	UtilizedInstances float64
+
+	// UtilizationPercent is the utilization as a percentage of InstanceCount.
+	// Calculated as: (UtilizedInstances / InstanceCount) * 100
+	UtilizationPercent float64
+
+	// UnusedHourlyCost is the on-demand value of the unused part of the RI ($/hour):
+	// the unused instances times the shelf price of the RI's instance type. RIs are
+	// paid whether used or not, so this is how much coverage is currently wasted.
+	// Zero if no on-demand price is known for the RI's instance type.
+	UnusedHourlyCost float64
+}
+
+// CapacityReservationUtilization represents how much of an On-Demand Capacity
+// Reservation is used by the instances currently running. Unused capacity is billed
+// at on-demand rates, so it's reported as wasted cost.
+type CapacityReservationUtilization struct {
+	// CapacityReservationID is the unique identifier for this reservation
+	CapacityReservationID string
+
+	// AccountID is the AWS account that owns this reservation
+	AccountID string
+
+	// AccountName is the friendly name of the AWS account
+	AccountName string
+
+	// InstanceType is the instance type capacity is reserved for (e.g., "m5.xlarge")
+	InstanceType string
+
+	// Region is the AWS region of the reservation
+	Region string
+
+	// AvailabilityZone is the AZ capacity is reserved in
+	AvailabilityZone string
+
+	// TotalInstanceCount is the number of instances capacity is reserved for
+	TotalInstanceCount int32
+
+	// UsedInstances is how many running instances are in the reservation. Instances
+	// of accounts that aren't monitored can't be seen, so reservations shared with
+	// them may be reported as less used than they are.
+	UsedInstances int32
+
+	// UnusedHourlyCost is the on-demand cost of the unused capacity ($/hour): the
+	// unused instances times the shelf price of the reservation's instance type.
+	// Zero if no on-demand price is known for the reservation's instance type.
+	UnusedHourlyCost float64
+}
+
+// Serverless compute services that Compute Savings Plans apply to besides EC2.
+const (
+	ServiceFargate = "fargate"
+	ServiceLambda  = "lambda"
+)
+
+// ServerlessUsage is Fargate or Lambda usage that can draw on Compute Savings Plan
+// commitment. It has no instance type to look up prices for, so the caller supplies
+// both its on-demand cost and its cost at Compute Savings Plan rates.
+type ServerlessUsage struct {
+	// ID uniquely identifies the usage (e.g., "fargate/namespace/pod").
+	// It must not collide with an EC2 instance ID.
+	ID string
+
+	// Service is ServiceFargate or ServiceLambda
+	Service string
+
+	// AccountID is the AWS account the usage is billed to
+	AccountID string
+
+	// Region is the AWS region of the usage
+	Region string
+
+	// OnDemandCost is the usage's cost at on-demand rates ($/hour)
+	OnDemandCost float64
+
+	// SavingsPlanRate is the usage's cost at Compute Savings Plan rates ($/hour).
+	// Fargate and Lambda have their own Savings Plan discounts, which are smaller
+	// than EC2's for the same plan.
+	SavingsPlanRate float64
+}
+
+// ServerlessCost represents the calculated cost of a ServerlessUsage.
+type ServerlessCost struct {
+	// ID, Service, AccountID and Region are copied from the ServerlessUsage
+	ID        string
+	Service   string
+	AccountID string
+	Region    string
+
+	// ShelfPrice is the on-demand cost ($/hour)
+	ShelfPrice float64
+
+	// SavingsPlanRate is the cost at Compute Savings Plan rates ($/hour)
+	SavingsPlanRate float64
+
+	// EffectiveCost is the estimated cost after Compute Savings Plan coverage ($/hour)
+	EffectiveCost float64
+
+	// CoverageType is CoverageComputeSavingsPlan or CoverageOnDemand
+	CoverageType CoverageType
+
+	// SavingsPlanCoverage is the Savings Plan commitment this usage consumes ($/hour):
+	// the sum of SavingsPlanContributions
+	SavingsPlanCoverage float64
+
+	// SavingsPlanContributions lists each Compute Savings Plan that contributed to
+	// SavingsPlanCoverage, in the order they were applied (see
+	// InstanceCost.SavingsPlanContributions)
+	SavingsPlanContributions []SavingsPlanContribution
+}
+
 // CalculationInput contains all the data needed to run the cost calculation algorithm.
 // This represents a point-in-time snapshot of the organization's compute resources
 // and discount instruments.
@@ -228,18 +428,54 @@ type CalculationInput struct {
 	ReservedInstances []aws.ReservedInstance
 
 	// SavingsPlans is the list of all active Savings Plans across the organization.
-	// Includes both EC2 Instance SPs and Compute SPs.
+	// Only EC2 Instance SPs and Compute SPs are applied; other types (e.g.,
+	// SageMaker) are ignored.
 	SavingsPlans []aws.SavingsPlan
 
+	// SharingDisabledAccounts is the set of account IDs with RI and Savings Plans
+	// discount sharing turned off. Their RIs and SPs only cover their own usage, and
+	// they receive no other account's. Optional: by default every account shares.
+	SharingDisabledAccounts map[string]bool
+
+	// SavingsPlanAllocation selects how Savings Plans of the same type share usage
+	// they can all cover. Optional: defaults to SavingsPlanAllocationPerPlan.
+	SavingsPlanAllocation SavingsPlanAllocation
+
+	// CapacityReservations is the list of all active On-Demand Capacity Reservations
+	// across the organization. Optional.
+	CapacityReservations []aws.CapacityReservation
+
 	// PricingCache provides access to on-demand and spot pricing data.
 	// The calculator uses accessor methods (GetSpotPrice, GetOnDemandPrice) to
 	// retrieve prices, avoiding fragile key format dependencies.
 	PricingCache PricingCacheInterface
 
-	// OnDemandPrices maps instance-type+region to on-demand price ($/hour).
-	// Key format: "instance_type:region" (e.g., "m5.xlarge:us-west-2")
-	// This is the shelf price with no discounts applied.
+	// OnDemandPrices maps instance-type+region+OS(+tenancy) to on-demand price ($/hour).
+	// Key format: "instance_type:region:os" for shared tenancy (e.g., "m5.xlarge:us-west-2:linux")
+	// and "instance_type:region:os:tenancy" for dedicated/host tenancy.
+	// Use OnDemandPriceKey to build keys. This is the shelf price with no discounts applied.
+	//
+	// For instances whose OS or tenancy price is missing, the shared tenancy and Linux
+	// prices are used as an estimate (PricingEstimated), so callers should include
+	// those prices as well.
 	OnDemandPrices map[string]float64
+
+	// ServerlessUsage is Fargate and Lambda usage competing with instances for
+	// Compute Savings Plan commitment. Optional.
+	ServerlessUsage []ServerlessUsage
+
+	// Volumes maps instance ID to the EBS volumes attached to it, for attributing
+	// storage cost to instances. Optional.
+	Volumes map[string][]aws.Volume
+
+	// EBSPrices maps region+volume type to EBS pricing. Use EBSPriceKey to build
+	// keys. Required for storage costs when Volumes is set.
+	EBSPrices map[string]aws.EBSPrice
+
+	// Now is the time the calculation is made at. CalculatedAt and Savings Plan
+	// RemainingHours are measured from it, so calculations that are compared with
+	// each other should share it. Optional: defaults to the current time.
+	Now time.Time
 }
 
 // CalculationResult contains the output of running the cost calculation algorithm.
@@ -250,9 +486,22 @@ type CalculationResult struct {
 	InstanceCosts map[string]InstanceCost
 
 	// SavingsPlanUtilization maps Savings Plan ARN to its utilization state.
-	// Includes all Savings Plans, even if unutilized (utilization = 0).
+	// Includes all EC2 Instance and Compute Savings Plans, even if unutilized
+	// (utilization = 0).
 	SavingsPlanUtilization map[string]SavingsPlanUtilization
 
+	// ReservedInstanceUtilization maps Reserved Instance ID to its utilization state.
+	// Includes all Reserved Instances in the input, even if unutilized.
+	ReservedInstanceUtilization map[string]ReservedInstanceUtilization
+
+	// ServerlessCosts maps ServerlessUsage ID to its calculated cost. Serverless
+	// costs aren't included in the instance totals below.
+	ServerlessCosts map[string]ServerlessCost
+
+	// CapacityReservationUtilization maps Capacity Reservation ID to its utilization
+	// state. Unused reservation cost isn't included in the instance totals below.
+	CapacityReservationUtilization map[string]CapacityReservationUtilization
+
 	// CalculatedAt is when this calculation was performed.
 	// Used for tracking data freshness.
 	CalculatedAt time.Time
@@ -268,4 +517,71 @@ type CalculationResult struct {
 	// TotalSavings is the difference between shelf price and effective cost ($/hour).
 	// Calculated as: TotalShelfPrice - TotalEstimatedCost
 	TotalSavings float64
+
+	// TotalStorageCost is the sum of all instance StorageCosts ($/hour). It isn't
+	// included in TotalEstimatedCost.
+	TotalStorageCost float64
+
+	// IncludesStorage reports whether volume data was provided, i.e. whether
+	// instance StorageCosts were calculated.
+	IncludesStorage bool
+
+	// InvariantViolations lists the math invariants this calculation broke. Any
+	// violation means the costs above can't be trusted.
+	InvariantViolations []InvariantViolation
+}
+
+// Invariants checked after Savings Plans are applied.
+const (
+	// InvariantCommitmentBalance: a Savings Plan's utilization plus remaining
+	// capacity equals its commitment
+	InvariantCommitmentBalance = "savings_plan_commitment_balance"
+
+	// InvariantNonNegativeCost: no instance has a negative effective cost
+	InvariantNonNegativeCost = "non_negative_cost"
+
+	// InvariantCoverageBounds: an instance's RI and Savings Plan coverage together
+	// don't exceed its shelf price
+	InvariantCoverageBounds = "coverage_bounds"
+
+	// InvariantContributionSum: an instance's Savings Plan contributions add up to
+	// its Savings Plan coverage, and a Savings Plan's contributions add up to its
+	// utilization
+	InvariantContributionSum = "savings_plan_contribution_sum"
+)
+
+// InvariantViolation is a math invariant broken by a cost calculation. It points to
+// a bug in the algorithm or to inconsistent input, such as the same instance listed
+// twice by overlapping account configurations.
+type InvariantViolation struct {
+	// Invariant is the invariant that was broken (one of the Invariant* constants)
+	Invariant string
+
+	// Description is a human-readable description of what failed
+	Description string
+
+	// InstanceID is the instance involved, if any
+	InstanceID string
+
+	// SavingsPlanARN is the Savings Plan involved, if any
+	SavingsPlanARN string
+
+	// Expected and Actual are the values that should have matched (or, for
+	// bounds, the limit and the value that exceeded it)
+	Expected float64
+	Actual   float64
+
+	// Details is additional debugging context
+	Details map[string]interface{}
+}
+
+// Error implements the error interface for InvariantViolation.
+func (v InvariantViolation) Error() string {
+	if v.InstanceID != "" {
+		return v.Description + ": instance " + v.InstanceID
+	}
+	if v.SavingsPlanARN != "" {
+		return v.Description + ": savings plan " + v.SavingsPlanARN
+	}
+	return v.Description
 }
@@ -0,0 +1,101 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+)
+
+// UnitCost is the effective cost per unit of capacity of the instances of one
+// instance type in a region with the same coverage type.
+type UnitCost struct {
+	InstanceType string
+	Region       string
+	CoverageType CoverageType
+
+	// InstanceCount is the number of instances in the group
+	InstanceCount int
+
+	// EffectiveCost is the sum of the instances' EffectiveCost ($/hour)
+	EffectiveCost float64
+
+	// PerVCPUHour is EffectiveCost divided by the group's vCPUs ($/vCPU-hour)
+	PerVCPUHour float64
+
+	// PerGiBHour is EffectiveCost divided by the group's memory ($/GiB-hour)
+	PerGiBHour float64
+
+	// PerGPUHour is EffectiveCost divided by the group's GPUs ($/GPU-hour), or 0
+	// for instance types without GPUs
+	PerGPUHour float64
+}
+
+// UnitCosts groups instance costs by instance type, region, and coverage type and
+// divides each group's effective cost by its capacity, so instance families can be
+// compared after discounts rather than at list price. Instances whose type isn't in
+// instanceTypes are skipped.
+//
+// Each ratio charges the instance's whole cost to one resource, so they're for
+// comparing the same resource across types, not for summing. Results are sorted
+// by instance type, region, and coverage type.
+func UnitCosts(costs map[string]InstanceCost, instanceTypes map[string]aws.InstanceTypeInfo) []UnitCost {
+	type groupKey struct {
+		instanceType string
+		region       string
+		coverageType CoverageType
+	}
+	groups := make(map[groupKey]*UnitCost)
+
+	for _, ic := range costs {
+		info, exists := instanceTypes[ic.InstanceType]
+		if !exists || info.VCPUs <= 0 || info.MemoryMiB <= 0 {
+			continue
+		}
+
+		key := groupKey{instanceType: ic.InstanceType, region: ic.Region, coverageType: ic.CoverageType}
+		group, exists := groups[key]
+		if !exists {
+			group = &UnitCost{InstanceType: ic.InstanceType, Region: ic.Region, CoverageType: ic.CoverageType}
+			groups[key] = group
+		}
+		group.InstanceCount++
+		group.EffectiveCost += ic.EffectiveCost
+	}
+
+	result := make([]UnitCost, 0, len(groups))
+	for _, group := range groups {
+		// All instances in a group have the same capacity
+		info := instanceTypes[group.InstanceType]
+		count := float64(group.InstanceCount)
+		group.PerVCPUHour = group.EffectiveCost / (count * float64(info.VCPUs))
+		group.PerGiBHour = group.EffectiveCost / (count * info.MemoryGiB())
+		if info.GPUs > 0 {
+			group.PerGPUHour = group.EffectiveCost / (count * float64(info.GPUs))
+		}
+		result = append(result, *group)
+	}
+
+	slices.SortFunc(result, func(a, b UnitCost) int {
+		return cmp.Or(
+			cmp.Compare(a.InstanceType, b.InstanceType),
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.CoverageType, b.CoverageType),
+		)
+	})
+	return result
+}
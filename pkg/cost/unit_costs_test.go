@@ -0,0 +1,68 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUnitCosts tests grouping instances by type, region, and coverage type and
+// dividing their effective cost by capacity.
+func TestUnitCosts(t *testing.T) {
+	costs := map[string]InstanceCost{
+		"i-sp-1": {InstanceType: "m5.xlarge", Region: "us-west-2", CoverageType: CoverageComputeSavingsPlan,
+			EffectiveCost: 0.10},
+		"i-sp-2": {InstanceType: "m5.xlarge", Region: "us-west-2", CoverageType: CoverageComputeSavingsPlan,
+			EffectiveCost: 0.14},
+		"i-od":   {InstanceType: "m5.xlarge", Region: "us-west-2", CoverageType: CoverageOnDemand, EffectiveCost: 0.192},
+		"i-east": {InstanceType: "m5.xlarge", Region: "us-east-1", CoverageType: CoverageOnDemand, EffectiveCost: 0.192},
+		"i-gpu":  {InstanceType: "g5.xlarge", Region: "us-west-2", CoverageType: CoverageSpot, EffectiveCost: 0.40},
+		"i-unknown": {InstanceType: "x9.huge", Region: "us-west-2", CoverageType: CoverageOnDemand,
+			EffectiveCost: 9.99},
+	}
+	instanceTypes := map[string]aws.InstanceTypeInfo{
+		"m5.xlarge": {InstanceType: "m5.xlarge", VCPUs: 4, MemoryMiB: 16384},
+		"g5.xlarge": {InstanceType: "g5.xlarge", VCPUs: 4, MemoryMiB: 16384, GPUs: 1},
+	}
+
+	result := UnitCosts(costs, instanceTypes)
+	require.Len(t, result, 4, "instances without capacity are skipped")
+
+	// Sorted by instance type, region, then coverage type
+	gpu := result[0]
+	assert.Equal(t, "g5.xlarge", gpu.InstanceType)
+	assert.InDelta(t, 0.10, gpu.PerVCPUHour, 0.0001)
+	assert.InDelta(t, 0.025, gpu.PerGiBHour, 0.0001)
+	assert.InDelta(t, 0.40, gpu.PerGPUHour, 0.0001)
+
+	assert.Equal(t, "us-east-1", result[1].Region)
+
+	sp := result[2]
+	assert.Equal(t, CoverageComputeSavingsPlan, sp.CoverageType)
+	assert.Equal(t, 2, sp.InstanceCount)
+	assert.InDelta(t, 0.24, sp.EffectiveCost, 0.0001)
+	assert.InDelta(t, 0.03, sp.PerVCPUHour, 0.0001)
+	assert.InDelta(t, 0.0075, sp.PerGiBHour, 0.0001)
+	assert.Zero(t, sp.PerGPUHour)
+
+	od := result[3]
+	assert.Equal(t, CoverageOnDemand, od.CoverageType)
+	assert.InDelta(t, 0.048, od.PerVCPUHour, 0.0001)
+	assert.InDelta(t, 0.012, od.PerGiBHour, 0.0001)
+}
@@ -0,0 +1,362 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// segmentPrefix and segmentSuffix frame the UTC date in segment file names,
+	// e.g. "costs-2025-01-31.jsonl"
+	segmentPrefix = "costs-"
+	segmentSuffix = ".jsonl"
+
+	// MaxResolution is the longest supported sample interval. Queries read one
+	// extra day of segments to find samples that started before the range, which
+	// only works if no sample is longer than a day.
+	MaxResolution = 24 * time.Hour
+)
+
+// Store records calculated costs and answers queries over them.
+//
+// Each recorded set of rates is assumed to hold from the time it was recorded until
+// the next one (the same convention as cost.DailyEstimates). Costs are accumulated
+// into intervals of the configured resolution, aligned the way time.Truncate aligns
+// them (1h intervals start on the hour, 15m ones on the quarter hour). When an
+// interval completes, it's appended as one JSON line to the segment file for the UTC
+// day it started on. Segments older than the retention are deleted.
+//
+// The interval in progress is kept in memory until it completes, so it isn't
+// visible to queries and is lost if the controller stops.
+//
+// Thread-safety: All methods are safe for concurrent access.
+type Store struct {
+	dir           string
+	resolution    time.Duration
+	retentionDays int
+
+	mu sync.Mutex
+
+	// lastRecorded is when the most recent rates were recorded
+	lastRecorded time.Time
+
+	// current holds the most recent rates; they apply from lastRecorded onwards
+	current []Rate
+
+	// pending is the interval in progress, nil before the first one starts
+	pending *pendingSample
+}
+
+// pendingSample accumulates costs for the interval in progress.
+type pendingSample struct {
+	start    time.Time
+	observed float64
+	costs    map[Attributes]float64
+}
+
+// NewStore creates a store writing segments to dir, which is created if needed.
+// retentionDays below 1 keeps only the current day.
+func NewStore(dir string, resolution time.Duration, retentionDays int) (*Store, error) {
+	if resolution <= 0 || resolution > MaxResolution {
+		return nil, fmt.Errorf("invalid history resolution %s, must be between 0 and %s", resolution, MaxResolution)
+	}
+	if retentionDays < 1 {
+		retentionDays = 1
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	return &Store{
+		dir:           dir,
+		resolution:    resolution,
+		retentionDays: retentionDays,
+	}, nil
+}
+
+// Record adds the previous rates' costs up to now, writing every interval that
+// completes, then makes rates the current ones. Calls with a now earlier than the
+// previous call add nothing.
+//
+// Write errors are returned, but recording continues: the failed interval is lost
+// and later intervals are written normally.
+func (s *Store) Record(rates []Rate, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	if !s.lastRecorded.IsZero() {
+		// Split the elapsed time at interval boundaries so each interval gets its share
+		for s.lastRecorded.Before(now) {
+			start := s.intervalStart(s.lastRecorded)
+			intervalEnd := start.Add(s.resolution)
+			end := now
+			if end.After(intervalEnd) {
+				end = intervalEnd
+			}
+
+			if s.pending != nil && !s.pending.start.Equal(start) {
+				errs = append(errs, s.flush())
+			}
+			if s.pending == nil {
+				s.pending = &pendingSample{start: start, costs: make(map[Attributes]float64)}
+			}
+			s.add(end.Sub(s.lastRecorded))
+			s.lastRecorded = end
+
+			if end.Equal(intervalEnd) {
+				errs = append(errs, s.flush())
+			}
+		}
+	}
+
+	s.current = slices.Clone(rates)
+	if now.After(s.lastRecorded) {
+		s.lastRecorded = now
+	}
+	return errors.Join(errs...)
+}
+
+// Query sums the recorded costs matching q.
+func (s *Store) Query(q Query) (Result, error) {
+	if err := q.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	type group struct {
+		labels map[string]string
+		cost   float64
+	}
+	groups := make(map[string]*group)
+	result := Result{Start: q.Start, End: q.End, GroupBy: q.GroupBy}
+
+	// Segments are only appended to, so reading without the lock is safe: a
+	// concurrent write is either seen in full or not at all (partial lines are skipped).
+	for day := utcDayStart(q.Start).AddDate(0, 0, -1); day.Before(q.End); day = day.AddDate(0, 0, 1) {
+		samples, err := s.readSegment(day)
+		if err != nil {
+			return Result{}, err
+		}
+
+		for _, sample := range samples {
+			fraction := overlapFraction(sample, q.Start, q.End)
+			if fraction <= 0 {
+				continue
+			}
+			result.ObservedSeconds += sample.ObservedSeconds * fraction
+
+			for _, entry := range sample.Entries {
+				if !matches(entry.Attributes, q.Filters) {
+					continue
+				}
+				labels := make(map[string]string, len(q.GroupBy))
+				values := make([]string, len(q.GroupBy))
+				for i, dimension := range q.GroupBy {
+					values[i], _ = entry.Get(dimension) // Validated above
+					labels[dimension] = values[i]
+				}
+				key := strings.Join(values, "\x00")
+				if groups[key] == nil {
+					groups[key] = &group{labels: labels}
+				}
+				groups[key].cost += entry.Cost * fraction
+			}
+		}
+	}
+
+	result.Groups = make([]Group, 0, len(groups))
+	for _, g := range groups {
+		result.Groups = append(result.Groups, Group{Labels: g.labels, Cost: g.cost})
+		result.TotalCost += g.cost
+	}
+	slices.SortFunc(result.Groups, func(a, b Group) int {
+		if a.Cost != b.Cost {
+			if a.Cost > b.Cost {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(fmt.Sprint(a.Labels), fmt.Sprint(b.Labels))
+	})
+	return result, nil
+}
+
+// intervalStart returns the start of the interval containing t.
+func (s *Store) intervalStart(t time.Time) time.Time {
+	return t.UTC().Truncate(s.resolution)
+}
+
+// add accumulates the current rates' costs over d into the pending interval.
+func (s *Store) add(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	hours := d.Hours()
+	for _, rate := range s.current {
+		s.pending.costs[rate.Attributes] += rate.HourlyCost * hours
+	}
+	s.pending.observed += d.Seconds()
+}
+
+// flush appends the pending interval to its segment, prunes old segments, and
+// clears the pending interval.
+func (s *Store) flush() error {
+	pending := s.pending
+	s.pending = nil
+
+	sample := Sample{
+		Start:           pending.start,
+		End:             pending.start.Add(s.resolution),
+		ObservedSeconds: pending.observed,
+		Entries:         make([]Entry, 0, len(pending.costs)),
+	}
+	for attributes, cost := range pending.costs {
+		sample.Entries = append(sample.Entries, Entry{Attributes: attributes, Cost: cost})
+	}
+	slices.SortFunc(sample.Entries, func(a, b Entry) int {
+		return strings.Compare(a.InstanceID, b.InstanceID)
+	})
+
+	if err := s.appendSample(sample); err != nil {
+		return err
+	}
+	return s.prune(sample.Start)
+}
+
+// appendSample writes sample as one line to the segment for the day it started on.
+func (s *Store) appendSample(sample Sample) error {
+	line, err := json.Marshal(sample)
+	if err != nil {
+		return fmt.Errorf("failed to encode history sample: %w", err)
+	}
+
+	f, err := os.OpenFile(s.segmentPath(sample.Start), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history segment: %w", err)
+	}
+	// A single write keeps the line intact for concurrent readers
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write history sample: %w", err)
+	}
+	return f.Close()
+}
+
+// readSegment returns the samples in the segment for day. A missing segment has no
+// samples. Lines that can't be decoded (e.g., cut short by a crash) are skipped.
+func (s *Store) readSegment(day time.Time) ([]Sample, error) {
+	f, err := os.Open(s.segmentPath(day))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history segment: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var samples []Sample
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 && err == nil {
+			var sample Sample
+			if json.Unmarshal(line, &sample) == nil {
+				samples = append(samples, sample)
+			}
+		}
+		if err == io.EOF {
+			return samples, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history segment: %w", err)
+		}
+	}
+}
+
+// prune deletes segments that fall outside the retention window ending at now.
+func (s *Store) prune(now time.Time) error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to list history segments: %w", err)
+	}
+
+	oldest := utcDayStart(now).AddDate(0, 0, -(s.retentionDays - 1))
+	var errs []error
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, segmentPrefix) || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		day, err := time.Parse(time.DateOnly, strings.TrimSuffix(strings.TrimPrefix(name, segmentPrefix), segmentSuffix))
+		if err != nil || !day.Before(oldest) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, name)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete history segment: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// segmentPath returns the path of the segment for the UTC day containing t.
+func (s *Store) segmentPath(t time.Time) string {
+	return filepath.Join(s.dir, segmentPrefix+utcDayStart(t).Format(time.DateOnly)+segmentSuffix)
+}
+
+// overlapFraction returns how much of the sample's interval lies within [start, end).
+func overlapFraction(sample Sample, start, end time.Time) float64 {
+	length := sample.End.Sub(sample.Start)
+	if length <= 0 {
+		return 0
+	}
+	from := sample.Start
+	if start.After(from) {
+		from = start
+	}
+	to := sample.End
+	if end.Before(to) {
+		to = end
+	}
+	if !to.After(from) {
+		return 0
+	}
+	return to.Sub(from).Seconds() / length.Seconds()
+}
+
+// matches returns true if attributes has every filtered dimension value.
+func matches(attributes Attributes, filters map[string]string) bool {
+	for dimension, want := range filters {
+		if got, _ := attributes.Get(dimension); got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// utcDayStart returns the start of the UTC day containing t.
+func utcDayStart(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRates returns $1/hour and $2/hour instances in two clusters of one account,
+// plus a $4/hour instance in another account.
+func testRates() []Rate {
+	return []Rate{
+		{Attributes: Attributes{AccountID: "111111111111", ClusterName: "prod", InstanceID: "i-001"}, HourlyCost: 1},
+		{Attributes: Attributes{AccountID: "111111111111", ClusterName: "staging", InstanceID: "i-002"}, HourlyCost: 2},
+		{Attributes: Attributes{AccountID: "222222222222", ClusterName: "prod", InstanceID: "i-003"}, HourlyCost: 4},
+	}
+}
+
+// TestStoreRecordAndQuery tests accumulating rates into hourly samples and querying
+// them with filters and grouping.
+func TestStoreRecordAndQuery(t *testing.T) {
+	store, err := NewStore(t.TempDir(), time.Hour, 7)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Record(testRates(), start))
+	require.NoError(t, store.Record(testRates(), start.Add(90*time.Minute)))
+	require.NoError(t, store.Record(nil, start.Add(2*time.Hour)))
+	require.NoError(t, store.Record(nil, start.Add(3*time.Hour)))
+
+	// The third hour had no instances, but was still observed
+	tests := []struct {
+		name         string
+		query        Query
+		wantGroups   []Group
+		wantTotal    float64
+		wantObserved float64
+	}{
+		{
+			name:         "total",
+			query:        Query{Start: start, End: start.Add(2 * time.Hour)},
+			wantGroups:   []Group{{Labels: map[string]string{}, Cost: 14}},
+			wantTotal:    14,
+			wantObserved: 7200,
+		},
+		{
+			name: "account grouped by cluster",
+			query: Query{
+				Start:   start,
+				End:     start.Add(2 * time.Hour),
+				Filters: map[string]string{DimensionAccountID: "111111111111"},
+				GroupBy: []string{DimensionClusterName},
+			},
+			wantGroups: []Group{
+				{Labels: map[string]string{DimensionClusterName: "staging"}, Cost: 4},
+				{Labels: map[string]string{DimensionClusterName: "prod"}, Cost: 2},
+			},
+			wantTotal:    6,
+			wantObserved: 7200,
+		},
+		{
+			name:         "idle hour",
+			query:        Query{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)},
+			wantGroups:   []Group{},
+			wantTotal:    0,
+			wantObserved: 3600,
+		},
+		{
+			// Half of the first hour overlaps the range
+			name:         "partial overlap",
+			query:        Query{Start: start.Add(30 * time.Minute), End: start.Add(time.Hour)},
+			wantGroups:   []Group{{Labels: map[string]string{}, Cost: 3.5}},
+			wantTotal:    3.5,
+			wantObserved: 1800,
+		},
+		{
+			name:         "no data",
+			query:        Query{Start: start.AddDate(0, 0, -3), End: start.AddDate(0, 0, -2)},
+			wantGroups:   []Group{},
+			wantTotal:    0,
+			wantObserved: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := store.Query(tt.query)
+			require.NoError(t, err)
+
+			require.Len(t, result.Groups, len(tt.wantGroups))
+			for i, want := range tt.wantGroups {
+				assert.Equal(t, want.Labels, result.Groups[i].Labels)
+				assert.InDelta(t, want.Cost, result.Groups[i].Cost, 0.0001)
+			}
+			assert.InDelta(t, tt.wantTotal, result.TotalCost, 0.0001)
+			assert.InDelta(t, tt.wantObserved, result.ObservedSeconds, 0.001)
+		})
+	}
+}
+
+// TestStoreRecordAcrossDays tests that intervals are split at boundaries and written
+// to the segment of the day they started on, including gaps spanning several intervals.
+func TestStoreRecordAcrossDays(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(dir, 6*time.Hour, 7)
+	require.NoError(t, err)
+
+	rates := testRates()[:1] // $1/hour
+	start := time.Date(2025, 1, 15, 21, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Record(rates, start))
+	require.NoError(t, store.Record(rates, start.Add(15*time.Hour))) // 2025-01-16 12:00
+
+	// 18:00-00:00 (partial, 3h observed), 00:00-06:00, 06:00-12:00
+	day1, err := store.readSegment(start)
+	require.NoError(t, err)
+	require.Len(t, day1, 1)
+	assert.InDelta(t, 3, day1[0].Entries[0].Cost, 0.0001)
+	assert.InDelta(t, 3*3600, day1[0].ObservedSeconds, 0.001)
+
+	day2, err := store.readSegment(start.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, day2, 2)
+	assert.InDelta(t, 6, day2[1].Entries[0].Cost, 0.0001)
+
+	// Querying from midnight excludes the interval recorded the day before
+	result, err := store.Query(Query{Start: start.Add(3 * time.Hour), End: start.Add(15 * time.Hour)})
+	require.NoError(t, err)
+	assert.InDelta(t, 12, result.TotalCost, 0.0001)
+}
+
+// TestStoreSkipsCorruptLines tests that a line cut short by a crash doesn't hide
+// the samples written before and after it.
+func TestStoreSkipsCorruptLines(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(dir, time.Hour, 7)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Record(testRates(), start))
+	require.NoError(t, store.Record(testRates(), start.Add(time.Hour)))
+
+	f, err := os.OpenFile(store.segmentPath(start), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"start":"2025-01-15T11:00:00Z","entr` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, store.Record(testRates(), start.Add(2*time.Hour)))
+
+	result, err := store.Query(Query{Start: start, End: start.Add(2 * time.Hour)})
+	require.NoError(t, err)
+	assert.InDelta(t, 14, result.TotalCost, 0.0001)
+}
+
+// TestStorePrune tests that segments older than the retention are deleted.
+func TestStorePrune(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(dir, time.Hour, 2)
+	require.NoError(t, err)
+
+	old := filepath.Join(dir, "costs-2025-01-10.jsonl")
+	require.NoError(t, os.WriteFile(old, nil, 0o644))
+	unrelated := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(unrelated, nil, 0o644))
+
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Record(testRates(), start))
+	require.NoError(t, store.Record(testRates(), start.Add(time.Hour)))
+
+	assert.NoFileExists(t, old)
+	assert.FileExists(t, unrelated)
+	assert.FileExists(t, store.segmentPath(start))
+}
+
+// TestQueryValidate tests rejection of invalid ranges and dimension names.
+func TestQueryValidate(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, Query{Start: start, End: start.Add(time.Hour), GroupBy: Dimensions}.Validate())
+	assert.Error(t, Query{Start: start, End: start}.Validate())
+	assert.Error(t, Query{Start: start, End: start.Add(time.Hour), GroupBy: []string{"team"}}.Validate())
+	assert.Error(t, Query{Start: start, End: start.Add(time.Hour), Filters: map[string]string{"team": "x"}}.Validate())
+}
+
+// TestNewStoreInvalidResolution tests that resolutions outside (0, 24h] are rejected.
+func TestNewStoreInvalidResolution(t *testing.T) {
+	_, err := NewStore(t.TempDir(), 0, 7)
+	assert.Error(t, err)
+	_, err = NewStore(t.TempDir(), 48*time.Hour, 7)
+	assert.Error(t, err)
+}
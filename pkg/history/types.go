@@ -0,0 +1,178 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package history keeps a local, append-only record of the costs calculated by
+// pkg/cost, so questions like "what did account X cost last month, per cluster"
+// can be answered without a long-retention Prometheus.
+//
+// The calculator produces instantaneous $/hour rates. Store turns them into $
+// totals per instance over fixed intervals (the resolution), and appends each
+// completed interval as a Sample to a daily segment file. Queries sum the samples
+// in a time range, optionally filtered and grouped by any Dimensions field.
+package history
+
+import (
+	"fmt"
+	"time"
+)
+
+// Dimension names accepted by queries for filtering and grouping. They match the
+// default metric label names, so the same words work in PromQL and here.
+const (
+	DimensionAccountID    = "account_id"
+	DimensionAccountName  = "account_name"
+	DimensionRegion       = "region"
+	DimensionClusterName  = "cluster_name"
+	DimensionNodeName     = "node_name"
+	DimensionInstanceID   = "instance_id"
+	DimensionInstanceType = "instance_type"
+	DimensionCostType     = "cost_type"
+)
+
+// Dimensions lists every dimension name, in the order they're reported.
+var Dimensions = []string{
+	DimensionAccountID,
+	DimensionAccountName,
+	DimensionRegion,
+	DimensionClusterName,
+	DimensionNodeName,
+	DimensionInstanceID,
+	DimensionInstanceType,
+	DimensionCostType,
+}
+
+// Attributes identify what a cost is attributed to.
+type Attributes struct {
+	AccountID    string `json:"accountId"`
+	AccountName  string `json:"accountName,omitempty"`
+	Region       string `json:"region,omitempty"`
+	ClusterName  string `json:"clusterName,omitempty"`
+	NodeName     string `json:"nodeName,omitempty"`
+	InstanceID   string `json:"instanceId"`
+	InstanceType string `json:"instanceType,omitempty"`
+	CostType     string `json:"costType,omitempty"`
+}
+
+// Get returns the value of the named dimension.
+func (a Attributes) Get(dimension string) (string, error) {
+	switch dimension {
+	case DimensionAccountID:
+		return a.AccountID, nil
+	case DimensionAccountName:
+		return a.AccountName, nil
+	case DimensionRegion:
+		return a.Region, nil
+	case DimensionClusterName:
+		return a.ClusterName, nil
+	case DimensionNodeName:
+		return a.NodeName, nil
+	case DimensionInstanceID:
+		return a.InstanceID, nil
+	case DimensionInstanceType:
+		return a.InstanceType, nil
+	case DimensionCostType:
+		return a.CostType, nil
+	default:
+		return "", fmt.Errorf("unknown dimension %q", dimension)
+	}
+}
+
+// Rate is the instantaneous cost of one instance, as calculated by pkg/cost.
+type Rate struct {
+	Attributes
+
+	// HourlyCost is the instance's effective cost ($/hour)
+	HourlyCost float64
+}
+
+// Entry is the cost of one instance over a sample's interval.
+type Entry struct {
+	Attributes
+
+	// Cost is the total cost ($) over the interval
+	Cost float64 `json:"cost"`
+}
+
+// Sample holds the costs of all instances over one interval.
+type Sample struct {
+	// Start and End bound the interval. End-Start is at most the store's resolution.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// ObservedSeconds is how much of the interval the controller was recording.
+	// It's less than End-Start for the first interval after a restart.
+	ObservedSeconds float64 `json:"observedSeconds"`
+
+	Entries []Entry `json:"entries"`
+}
+
+// Query selects and aggregates recorded costs.
+type Query struct {
+	// Start (inclusive) and End (exclusive) bound the time range. Samples that
+	// partially overlap the range contribute in proportion to the overlap.
+	Start time.Time
+	End   time.Time
+
+	// Filters keeps only costs whose dimensions equal the given values
+	Filters map[string]string
+
+	// GroupBy lists the dimensions to group by. Empty returns a single total.
+	GroupBy []string
+}
+
+// Validate checks that the query's range and dimension names are valid.
+func (q Query) Validate() error {
+	if !q.End.After(q.Start) {
+		return fmt.Errorf("query end %s must be after start %s",
+			q.End.Format(time.RFC3339), q.Start.Format(time.RFC3339))
+	}
+	var a Attributes
+	for dimension := range q.Filters {
+		if _, err := a.Get(dimension); err != nil {
+			return err
+		}
+	}
+	for _, dimension := range q.GroupBy {
+		if _, err := a.Get(dimension); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Group is the total cost of one combination of GroupBy dimension values.
+type Group struct {
+	// Labels maps each GroupBy dimension to its value
+	Labels map[string]string `json:"labels"`
+
+	// Cost is the total cost ($) over the query range
+	Cost float64 `json:"cost"`
+}
+
+// Result is the answer to a Query.
+type Result struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	GroupBy []string  `json:"groupBy"`
+
+	// Groups is sorted by descending cost
+	Groups []Group `json:"groups"`
+
+	// TotalCost is the sum of all groups ($)
+	TotalCost float64 `json:"totalCost"`
+
+	// ObservedSeconds is how much of the range has recorded data. Costs only cover
+	// observed time, so compare it with End-Start before trusting a total.
+	ObservedSeconds float64 `json:"observedSeconds"`
+}
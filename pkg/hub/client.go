@@ -0,0 +1,83 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hub
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// clientTimeout bounds each request to the hub
+const clientTimeout = 30 * time.Second
+
+// Client fetches instance costs from a hub.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the hub at baseURL, e.g. "http://lumina.lumina-system:8080".
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: clientTimeout},
+	}
+}
+
+// InstanceCosts returns the hub's latest costs of instanceIDs. It fails if the hub
+// hasn't calculated costs yet.
+func (c *Client) InstanceCosts(ctx context.Context, instanceIDs []string) (InstanceCosts, error) {
+	body, err := json.Marshal(InstanceCostsRequest{InstanceIDs: instanceIDs})
+	if err != nil {
+		return InstanceCosts{}, fmt.Errorf("failed to encode instance costs request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+InstanceCostsPath, bytes.NewReader(body))
+	if err != nil {
+		return InstanceCosts{}, fmt.Errorf("failed to create instance costs request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return InstanceCosts{}, fmt.Errorf("instance costs request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		// Errors are plain text from http.Error; a short excerpt is enough to diagnose
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return InstanceCosts{}, fmt.Errorf("hub returned status %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var costs InstanceCosts
+	if err := json.NewDecoder(resp.Body).Decode(&costs); err != nil {
+		return InstanceCosts{}, fmt.Errorf("failed to decode instance costs response: %w", err)
+	}
+	// GetInstance searches by instance ID, so don't rely on the hub's ordering
+	slices.SortFunc(costs.Instances, func(a, b InstanceCost) int {
+		return cmp.Compare(a.Cost.InstanceID, b.Cost.InstanceID)
+	})
+	return costs, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_InstanceCosts(t *testing.T) {
+	result, instances := testResult()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, InstanceCostsPath, r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		var request InstanceCostsRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		costs := NewInstanceCosts(result, instances, request.InstanceIDs)
+		// Reverse the order to check the client doesn't depend on it
+		costs.Instances[0], costs.Instances[1] = costs.Instances[1], costs.Instances[0]
+		_ = json.NewEncoder(w).Encode(costs)
+	}))
+	defer server.Close()
+
+	costs, err := NewClient(server.URL+"/").InstanceCosts(context.Background(), []string{"i-001", "i-002"})
+	require.NoError(t, err)
+	require.Len(t, costs.Instances, 2)
+	assert.Equal(t, "i-001", costs.Instances[0].Cost.InstanceID)
+	assert.Equal(t, 0.72, costs.Instances[0].Cost.EffectiveCost)
+	assert.True(t, costs.CalculatedAt.Equal(testCalculatedAt))
+	instance, found := costs.GetInstance("i-002")
+	require.True(t, found)
+	assert.Equal(t, "ip-10-0-0-2.ec2.internal", instance.PrivateDNSName)
+}
+
+func TestClient_InstanceCostsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/malformed"+InstanceCostsPath {
+			_, _ = w.Write([]byte(`{"instances": [`))
+			return
+		}
+		http.Error(w, "costs not calculated yet", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL).InstanceCosts(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 503: costs not calculated yet")
+
+	_, err = NewClient(server.URL+"/malformed").InstanceCosts(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode")
+}
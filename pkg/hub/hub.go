@@ -0,0 +1,135 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hub is the API between a hub Lumina, which runs the cost calculation for
+// every account in the organization, and the agents in each Kubernetes cluster,
+// which only watch their own nodes and pods.
+//
+// Savings Plans apply to usage across the whole organization, so a calculation for
+// one cluster's instances needs every other cluster's instances as input. Rather
+// than each cluster collecting the same AWS data and exporting the same Savings Plan
+// series, agents ask the hub for the costs of their nodes' instances and export
+// node, pod, and NodePool metrics from those.
+package hub
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/cost"
+)
+
+// InstanceCostsPath is the URL path of the hub's instance costs endpoint.
+const InstanceCostsPath = "/api/v1/costs/instances"
+
+// InstanceCostsRequest is the body of a POST to InstanceCostsPath.
+type InstanceCostsRequest struct {
+	// InstanceIDs are the EC2 instances to return the costs of
+	InstanceIDs []string `json:"instanceIds"`
+}
+
+// InstanceCosts is the response from InstanceCostsPath: the costs of the requested
+// instances from the hub's latest calculation.
+type InstanceCosts struct {
+	// CalculatedAt is when the hub performed the calculation
+	CalculatedAt time.Time `json:"calculatedAt"`
+
+	// IncludesStorage is whether the costs include EBS volumes (hub config
+	// storage.enabled)
+	IncludesStorage bool `json:"includesStorage"`
+
+	// Instances are sorted by instance ID. Requested instances the hub doesn't know
+	// about, such as ones launched since its last EC2 refresh, are omitted.
+	Instances []InstanceCost `json:"instances"`
+}
+
+// InstanceCost is an instance's calculated cost and the EC2 data it was calculated
+// from.
+type InstanceCost struct {
+	Cost cost.InstanceCost `json:"cost"`
+
+	// Instance supplies the tags and DNS name for the cluster_name, host_name, and
+	// node_name metric labels. nil if the instance has left the hub's EC2 cache.
+	Instance *aws.Instance `json:"instance,omitempty"`
+}
+
+// InstanceLookup finds EC2 instances by ID, like cache.EC2Cache.
+type InstanceLookup interface {
+	GetInstance(instanceID string) (*aws.Instance, bool)
+}
+
+// NewInstanceCosts selects the costs of instanceIDs from result, or of every
+// instance if instanceIDs is nil. instances supplies the EC2 data of each one.
+func NewInstanceCosts(result cost.CalculationResult, instances InstanceLookup, instanceIDs []string) InstanceCosts {
+	if instanceIDs == nil {
+		instanceIDs = make([]string, 0, len(result.InstanceCosts))
+		for instanceID := range result.InstanceCosts {
+			instanceIDs = append(instanceIDs, instanceID)
+		}
+	}
+
+	costs := InstanceCosts{
+		CalculatedAt:    result.CalculatedAt,
+		IncludesStorage: result.IncludesStorage,
+		Instances:       make([]InstanceCost, 0, len(instanceIDs)),
+	}
+	for _, instanceID := range instanceIDs {
+		ic, found := result.InstanceCosts[instanceID]
+		if !found {
+			continue
+		}
+		instance, _ := instances.GetInstance(instanceID)
+		costs.Instances = append(costs.Instances, InstanceCost{Cost: ic, Instance: instance})
+	}
+	slices.SortFunc(costs.Instances, func(a, b InstanceCost) int {
+		return cmp.Compare(a.Cost.InstanceID, b.Cost.InstanceID)
+	})
+	costs.Instances = slices.CompactFunc(costs.Instances, func(a, b InstanceCost) bool {
+		return a.Cost.InstanceID == b.Cost.InstanceID
+	})
+	return costs
+}
+
+// Result returns the instance costs as a calculation result, for the metrics that
+// are built from one. It has no Savings Plan, Reserved Instance, or other
+// organization-wide entries, since the hub exports those.
+func (c InstanceCosts) Result() cost.CalculationResult {
+	result := cost.CalculationResult{
+		InstanceCosts:   make(map[string]cost.InstanceCost, len(c.Instances)),
+		CalculatedAt:    c.CalculatedAt,
+		IncludesStorage: c.IncludesStorage,
+	}
+	for _, ic := range c.Instances {
+		result.InstanceCosts[ic.Cost.InstanceID] = ic.Cost
+		result.TotalEstimatedCost += ic.Cost.EffectiveCost
+		result.TotalShelfPrice += ic.Cost.ShelfPrice
+		result.TotalStorageCost += ic.Cost.StorageCost
+	}
+	result.TotalSavings = result.TotalShelfPrice - result.TotalEstimatedCost
+	return result
+}
+
+// GetInstance returns the EC2 data of an instance in c. It implements
+// InstanceLookup, so c can stand in for the EC2 cache the agent doesn't have.
+func (c InstanceCosts) GetInstance(instanceID string) (*aws.Instance, bool) {
+	i, found := slices.BinarySearchFunc(c.Instances, instanceID, func(ic InstanceCost, id string) int {
+		return cmp.Compare(ic.Cost.InstanceID, id)
+	})
+	if !found || c.Instances[i].Instance == nil {
+		return nil, false
+	}
+	return c.Instances[i].Instance, true
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/cost"
+)
+
+// instanceMap implements InstanceLookup over a map.
+type instanceMap map[string]*aws.Instance
+
+func (m instanceMap) GetInstance(instanceID string) (*aws.Instance, bool) {
+	instance, found := m[instanceID]
+	return instance, found
+}
+
+var testCalculatedAt = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// testResult returns a calculation with three instances, of which i-003 has left
+// the EC2 cache, and a Savings Plan.
+func testResult() (cost.CalculationResult, instanceMap) {
+	result := cost.CalculationResult{
+		InstanceCosts: map[string]cost.InstanceCost{
+			"i-001": {InstanceID: "i-001", ShelfPrice: 1.00, EffectiveCost: 0.72, StorageCost: 0.10},
+			"i-002": {InstanceID: "i-002", ShelfPrice: 1.00, EffectiveCost: 1.00},
+			"i-003": {InstanceID: "i-003", ShelfPrice: 0.50, EffectiveCost: 0.00},
+		},
+		SavingsPlanUtilization: map[string]cost.SavingsPlanUtilization{
+			"arn:aws:savingsplans::123456789012:savingsplan/sp-001": {},
+		},
+		CalculatedAt:    testCalculatedAt,
+		IncludesStorage: true,
+	}
+	instances := instanceMap{
+		"i-001": {InstanceID: "i-001", PrivateDNSName: "ip-10-0-0-1.ec2.internal"},
+		"i-002": {InstanceID: "i-002", PrivateDNSName: "ip-10-0-0-2.ec2.internal"},
+	}
+	return result, instances
+}
+
+func TestNewInstanceCosts(t *testing.T) {
+	result, instances := testResult()
+
+	t.Run("selected instances", func(t *testing.T) {
+		costs := NewInstanceCosts(result, instances, []string{"i-003", "i-missing", "i-001", "i-001"})
+		assert.Equal(t, testCalculatedAt, costs.CalculatedAt)
+		assert.True(t, costs.IncludesStorage)
+		require.Len(t, costs.Instances, 2, "unknown and duplicate IDs are dropped")
+		assert.Equal(t, "i-001", costs.Instances[0].Cost.InstanceID)
+		assert.Equal(t, "i-003", costs.Instances[1].Cost.InstanceID)
+		assert.Nil(t, costs.Instances[1].Instance)
+	})
+
+	t.Run("all instances", func(t *testing.T) {
+		costs := NewInstanceCosts(result, instances, nil)
+		require.Len(t, costs.Instances, 3)
+		assert.Equal(t, "i-002", costs.Instances[1].Cost.InstanceID)
+	})
+
+	t.Run("no instances", func(t *testing.T) {
+		costs := NewInstanceCosts(result, instances, []string{})
+		assert.NotNil(t, costs.Instances)
+		assert.Empty(t, costs.Instances)
+	})
+}
+
+func TestInstanceCosts_Result(t *testing.T) {
+	result, instances := testResult()
+	costs := NewInstanceCosts(result, instances, []string{"i-001", "i-003"})
+
+	got := costs.Result()
+	assert.Len(t, got.InstanceCosts, 2)
+	assert.Empty(t, got.SavingsPlanUtilization, "organization-wide results stay with the hub")
+	assert.Equal(t, testCalculatedAt, got.CalculatedAt)
+	assert.True(t, got.IncludesStorage)
+	assert.InDelta(t, 0.72, got.TotalEstimatedCost, 0.0001)
+	assert.InDelta(t, 1.50, got.TotalShelfPrice, 0.0001)
+	assert.InDelta(t, 0.78, got.TotalSavings, 0.0001)
+	assert.InDelta(t, 0.10, got.TotalStorageCost, 0.0001)
+
+	instance, found := costs.GetInstance("i-001")
+	require.True(t, found)
+	assert.Equal(t, "ip-10-0-0-1.ec2.internal", instance.PrivateDNSName)
+	_, found = costs.GetInstance("i-003")
+	assert.False(t, found, "instances missing from the EC2 cache aren't found")
+	_, found = costs.GetInstance("i-002")
+	assert.False(t, found, "instances that weren't requested aren't found")
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/nextdoor/lumina/pkg/billing"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UpdateBillingDriftMetrics updates the billing_* metrics from a drift report.
+// This is called by the BillingReconciler each time it compares a day of AWS
+// billing data with Lumina's estimates.
+//
+// All billing metrics are reset first, so accounts and Savings Plans that aren't in
+// the report disappear. Account names are looked up from the configured AWS accounts.
+func (m *Metrics) UpdateBillingDriftMetrics(report billing.Report) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	m.BillingAccountActualDailyCost.Reset()
+	m.BillingAccountEstimatedDailyCost.Reset()
+	m.BillingAccountDriftPercent.Reset()
+	m.BillingSavingsPlanActualDailyCost.Reset()
+	m.BillingSavingsPlanEstimatedDailyCost.Reset()
+	m.BillingSavingsPlanDriftPercent.Reset()
+
+	accountNames := make(map[string]string, len(m.config.AWSAccounts))
+	for _, account := range m.config.AWSAccounts {
+		accountNames[account.AccountID] = account.Name
+	}
+
+	for accountID, drift := range report.Accounts {
+		labels := prometheus.Labels{
+			m.config.GetAccountIDLabel():   accountID,
+			m.config.GetAccountNameLabel(): accountNames[accountID],
+		}
+		m.BillingAccountActualDailyCost.With(labels).Set(drift.Actual)
+		m.BillingAccountEstimatedDailyCost.With(labels).Set(drift.Estimated)
+		m.BillingAccountDriftPercent.With(labels).Set(drift.Percent())
+	}
+
+	for arn, drift := range report.SavingsPlans {
+		labels := prometheus.Labels{LabelSavingsPlanARN: arn}
+		m.BillingSavingsPlanActualDailyCost.With(labels).Set(drift.Actual)
+		m.BillingSavingsPlanEstimatedDailyCost.With(labels).Set(drift.Estimated)
+		m.BillingSavingsPlanDriftPercent.With(labels).Set(drift.Percent())
+	}
+
+	m.BillingReconciledDate.WithLabelValues().Set(float64(report.Date.Unix()))
+}
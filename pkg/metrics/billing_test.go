@@ -0,0 +1,61 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/billing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateBillingDriftMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	// Nothing is exported before the first reconciliation
+	assert.Equal(t, 0, testutil.CollectAndCount(m.BillingReconciledDate))
+
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	m.UpdateBillingDriftMetrics(billing.Report{
+		Date: date,
+		Accounts: map[string]billing.Drift{
+			"123456789012": {Actual: 200, Estimated: 210},
+		},
+		SavingsPlans: map[string]billing.Drift{
+			"arn:aws:savingsplans::123456789012:savingsplan/abc": {Actual: 48, Estimated: 36},
+		},
+	})
+
+	accountLabels := prometheus.Labels{"account_id": "123456789012", "account_name": "Test"}
+	assert.Equal(t, 200.0, testutil.ToFloat64(m.BillingAccountActualDailyCost.With(accountLabels)))
+	assert.Equal(t, 210.0, testutil.ToFloat64(m.BillingAccountEstimatedDailyCost.With(accountLabels)))
+	assert.InDelta(t, 5.0, testutil.ToFloat64(m.BillingAccountDriftPercent.With(accountLabels)), 0.0001)
+
+	spLabels := prometheus.Labels{"savings_plan_arn": "arn:aws:savingsplans::123456789012:savingsplan/abc"}
+	assert.Equal(t, 48.0, testutil.ToFloat64(m.BillingSavingsPlanActualDailyCost.With(spLabels)))
+	assert.Equal(t, 36.0, testutil.ToFloat64(m.BillingSavingsPlanEstimatedDailyCost.With(spLabels)))
+	assert.InDelta(t, -25.0, testutil.ToFloat64(m.BillingSavingsPlanDriftPercent.With(spLabels)), 0.0001)
+
+	assert.Equal(t, float64(date.Unix()), testutil.ToFloat64(m.BillingReconciledDate.WithLabelValues()))
+
+	// The next report replaces the previous one
+	m.UpdateBillingDriftMetrics(billing.Report{Date: date.Add(24 * time.Hour)})
+	assert.Equal(t, 0, testutil.CollectAndCount(m.BillingAccountActualDailyCost))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.BillingSavingsPlanDriftPercent))
+}
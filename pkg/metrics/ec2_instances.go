@@ -44,6 +44,9 @@ import (
 //	allInstances := ec2Cache.GetRunningInstances()
 //	metrics.UpdateEC2InstanceMetrics(allInstances)
 func (m *Metrics) UpdateEC2InstanceMetrics(instances []aws.Instance) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	// Reset all existing metrics to ensure terminated/stopped instances are removed.
 	// This is more reliable than trying to track which specific instances changed state.
 	m.EC2Instance.Reset()
@@ -0,0 +1,48 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/nextdoor/lumina/pkg/whatif"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UpdateExpirationForecastMetrics updates the commitment_expiration_* metrics from a
+// whatif.Forecast. This is called by the CostReconciler after each cost calculation
+// when forecast.enabled is set.
+//
+// The metrics are reset first, so commitments that have expired or been renewed
+// past the horizon disappear.
+func (m *Metrics) UpdateExpirationForecastMetrics(expiries []whatif.Expiry) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	m.CommitmentExpirationTimestamp.Reset()
+	m.CommitmentExpirationHourlyCostIncrease.Reset()
+	m.CommitmentExpirationHourlySpilloverIncrease.Reset()
+
+	for _, expiry := range expiries {
+		labels := prometheus.Labels{
+			LabelCommitmentType:            expiry.CommitmentType,
+			LabelCommitmentID:              expiry.ID,
+			m.config.GetAccountIDLabel():   expiry.AccountID,
+			m.config.GetAccountNameLabel(): expiry.AccountName,
+		}
+
+		m.CommitmentExpirationTimestamp.With(labels).Set(float64(expiry.End.Unix()))
+		m.CommitmentExpirationHourlyCostIncrease.With(labels).Set(expiry.HourlyCostIncrease)
+		m.CommitmentExpirationHourlySpilloverIncrease.With(labels).Set(expiry.SpilloverIncrease)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/whatif"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateExpirationForecastMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	end := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	m.UpdateExpirationForecastMetrics([]whatif.Expiry{
+		{
+			CommitmentType: whatif.CommitmentReservedInstance, ID: "ri-001",
+			AccountID: "111111111111", AccountName: "prod", End: end,
+			HourlyCostIncrease: 1.0, SpilloverIncrease: 1.0,
+		},
+		{
+			CommitmentType: whatif.CommitmentSavingsPlan, ID: "arn:aws:savingsplans::111111111111:savingsplan/sp-001",
+			AccountID: "111111111111", AccountName: "prod", End: end.AddDate(0, 1, 0),
+			HourlyCostIncrease: 0.28, SpilloverIncrease: 1.0,
+		},
+	})
+
+	assert.Equal(t, 2, testutil.CollectAndCount(m.CommitmentExpirationTimestamp))
+	assert.Equal(t, float64(end.Unix()), testutil.ToFloat64(
+		m.CommitmentExpirationTimestamp.WithLabelValues("reserved_instance", "ri-001", "111111111111", "prod")))
+	assert.Equal(t, 0.28, testutil.ToFloat64(m.CommitmentExpirationHourlyCostIncrease.WithLabelValues(
+		"savings_plan", "arn:aws:savingsplans::111111111111:savingsplan/sp-001", "111111111111", "prod")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(
+		m.CommitmentExpirationHourlySpilloverIncrease.WithLabelValues("reserved_instance", "ri-001", "111111111111", "prod")))
+
+	// Commitments that leave the horizon are removed
+	m.UpdateExpirationForecastMetrics(nil)
+	assert.Equal(t, 0, testutil.CollectAndCount(m.CommitmentExpirationTimestamp))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.CommitmentExpirationHourlyCostIncrease))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.CommitmentExpirationHourlySpilloverIncrease))
+}
@@ -44,11 +44,15 @@ type EC2CacheReader interface {
 //  2. Sets new values for all currently running instances
 //  3. Terminated instances are automatically removed by the reset
 //
-// The function handles four types of metrics:
+// The function handles these metrics:
 //   - ec2_instance_hourly_cost: Per-instance effective hourly cost ($/hour)
+//   - ec2_instance_storage_hourly_cost: Per-instance EBS volume cost ($/hour)
+//   - ec2_instance_total_hourly_cost: Per-instance compute plus storage cost ($/hour)
 //   - savings_plan_current_utilization_rate: Current SP consumption ($/hour)
 //   - savings_plan_remaining_capacity: Unused SP capacity ($/hour)
 //   - savings_plan_utilization_percent: SP utilization percentage (0-100+)
+//   - ec2_reserved_instance_utilization_percent: RI utilization percentage (0-100)
+//   - ec2_reserved_instance_unused_hourly_cost: On-demand value of unused RI capacity ($/hour)
 //
 // Multi-cluster enhancements:
 //   - If config.Metrics.DisableInstanceMetrics is true, skips emitting instance metrics entirely
@@ -74,12 +78,20 @@ func (m *Metrics) UpdateInstanceCostMetrics(
 	nodeCache NodeCacheReader,
 	ec2Cache EC2CacheReader,
 ) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	// Reset all existing cost metrics to ensure terminated instances and expired SPs are removed.
 	// This is more reliable than trying to track which specific resources changed.
 	m.EC2InstanceHourlyCost.Reset()
+	m.EC2InstanceStorageHourlyCost.Reset()
+	m.EC2InstanceTotalHourlyCost.Reset()
 	m.SavingsPlanCurrentUtilizationRate.Reset()
 	m.SavingsPlanRemainingCapacity.Reset()
 	m.SavingsPlanUtilizationPercent.Reset()
+	m.ReservedInstanceUtilizationPercent.Reset()
+	m.ReservedInstanceUnusedHourlyCost.Reset()
+	m.CapacityReservationUnusedHourlyCost.Reset()
 
 	// Skip instance metrics if disabled (multi-cluster deployment mode)
 	if !m.config.Metrics.DisableInstanceMetrics {
@@ -154,6 +166,24 @@ func (m *Metrics) UpdateInstanceCostMetrics(
 				m.config.GetClusterNameLabel(): clusterName,
 				m.config.GetHostNameLabel():    hostName,
 			}).Set(ic.EffectiveCost)
+
+			// Storage is reported next to compute, and summed with it for the
+			// node's total cost, only when volume costs were calculated
+			if result.IncludesStorage {
+				labels := prometheus.Labels{
+					LabelInstanceID:                ic.InstanceID,
+					m.config.GetAccountIDLabel():   ic.AccountID,
+					m.config.GetAccountNameLabel(): ic.AccountName,
+					m.config.GetRegionLabel():      ic.Region,
+					LabelInstanceType:              ic.InstanceType,
+					LabelAvailabilityZone:          ic.AvailabilityZone,
+					m.config.GetNodeNameLabel():    nodeName,
+					m.config.GetClusterNameLabel(): clusterName,
+					m.config.GetHostNameLabel():    hostName,
+				}
+				m.EC2InstanceStorageHourlyCost.With(labels).Set(ic.StorageCost)
+				m.EC2InstanceTotalHourlyCost.With(labels).Set(ic.TotalCost())
+			}
 		}
 	}
 
@@ -190,4 +220,39 @@ func (m *Metrics) UpdateInstanceCostMetrics(
 			LabelType:                      spType,
 		}).Set(sp.UtilizationPercent)
 	}
+
+	// Set Reserved Instance utilization metrics
+	for _, ri := range result.ReservedInstanceUtilization {
+		labels := prometheus.Labels{
+			LabelReservedInstanceID:        ri.ReservedInstanceID,
+			m.config.GetAccountIDLabel():   ri.AccountID,
+			m.config.GetAccountNameLabel(): ri.AccountName,
+			m.config.GetRegionLabel():      ri.Region,
+			LabelInstanceType:              ri.InstanceType,
+			LabelAvailabilityZone:          ri.AvailabilityZone,
+		}
+		m.ReservedInstanceUtilizationPercent.With(labels).Set(ri.UtilizationPercent)
+		m.ReservedInstanceUnusedHourlyCost.With(labels).Set(ri.UnusedHourlyCost)
+	}
+
+	// Set Capacity Reservation unused cost metrics
+	for _, cr := range result.CapacityReservationUtilization {
+		m.CapacityReservationUnusedHourlyCost.With(prometheus.Labels{
+			LabelCapacityReservationID:     cr.CapacityReservationID,
+			m.config.GetAccountIDLabel():   cr.AccountID,
+			m.config.GetAccountNameLabel(): cr.AccountName,
+			m.config.GetRegionLabel():      cr.Region,
+			LabelInstanceType:              cr.InstanceType,
+			LabelAvailabilityZone:          cr.AvailabilityZone,
+		}).Set(cr.UnusedHourlyCost)
+	}
+}
+
+// RecordInvariantViolations counts the math invariant violations of a cost
+// calculation, by invariant. The CostReconciler records them instead of publishing
+// the calculation.
+func (m *Metrics) RecordInvariantViolations(violations []cost.InvariantViolation) {
+	for _, violation := range violations {
+		m.CalculationInvariantViolations.WithLabelValues(violation.Invariant).Inc()
+	}
 }
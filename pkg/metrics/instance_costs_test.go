@@ -527,3 +527,174 @@ func TestUpdateInstanceCostMetrics_SPUnderAndOverUtilization(t *testing.T) {
 		"type":             "compute",
 	})))
 }
+
+func TestUpdateInstanceCostMetrics_ReservedInstanceUtilization(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	result := cost.CalculationResult{
+		ReservedInstanceUtilization: map[string]cost.ReservedInstanceUtilization{
+			"ri-partial": {
+				ReservedInstanceID: "ri-partial",
+				AccountID:          "111111111111",
+				AccountName:        "test-account",
+				InstanceType:       "m5.xlarge",
+				Region:             "us-west-2",
+				InstanceCount:      2,
+				UtilizedInstances:  1.5,
+				UtilizationPercent: 75,
+				UnusedHourlyCost:   0.096,
+			},
+			"ri-idle": {
+				ReservedInstanceID: "ri-idle",
+				AccountID:          "111111111111",
+				AccountName:        "test-account",
+				InstanceType:       "c5.xlarge",
+				Region:             "us-west-2",
+				AvailabilityZone:   "us-west-2a",
+				InstanceCount:      1,
+				UnusedHourlyCost:   0.17,
+			},
+		},
+		CalculatedAt: time.Now(),
+	}
+
+	m.UpdateInstanceCostMetrics(result, nil, nil)
+
+	partial := prometheus.Labels{
+		"reserved_instance_id": "ri-partial",
+		"account_id":           "111111111111",
+		"account_name":         "test-account",
+		"region":               "us-west-2",
+		"instance_type":        "m5.xlarge",
+		"availability_zone":    "",
+	}
+	assert.Equal(t, 75.0, testutil.ToFloat64(m.ReservedInstanceUtilizationPercent.With(partial)))
+	assert.Equal(t, 0.096, testutil.ToFloat64(m.ReservedInstanceUnusedHourlyCost.With(partial)))
+
+	idle := prometheus.Labels{
+		"reserved_instance_id": "ri-idle",
+		"account_id":           "111111111111",
+		"account_name":         "test-account",
+		"region":               "us-west-2",
+		"instance_type":        "c5.xlarge",
+		"availability_zone":    "us-west-2a",
+	}
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.ReservedInstanceUtilizationPercent.With(idle)))
+	assert.Equal(t, 0.17, testutil.ToFloat64(m.ReservedInstanceUnusedHourlyCost.With(idle)))
+
+	// RIs missing from the next result are removed
+	m.UpdateInstanceCostMetrics(cost.CalculationResult{}, nil, nil)
+	assert.Equal(t, 0, testutil.CollectAndCount(m.ReservedInstanceUtilizationPercent))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.ReservedInstanceUnusedHourlyCost))
+}
+
+func TestUpdateInstanceCostMetrics_CapacityReservationUnusedCost(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	result := cost.CalculationResult{
+		CapacityReservationUtilization: map[string]cost.CapacityReservationUtilization{
+			"cr-001": {
+				CapacityReservationID: "cr-001",
+				AccountID:             "111111111111",
+				AccountName:           "test-account",
+				InstanceType:          "m5.xlarge",
+				Region:                "us-west-2",
+				AvailabilityZone:      "us-west-2a",
+				TotalInstanceCount:    3,
+				UsedInstances:         1,
+				UnusedHourlyCost:      0.384,
+			},
+		},
+		CalculatedAt: time.Now(),
+	}
+
+	m.UpdateInstanceCostMetrics(result, nil, nil)
+
+	assert.Equal(t, 0.384, testutil.ToFloat64(m.CapacityReservationUnusedHourlyCost.With(prometheus.Labels{
+		"capacity_reservation_id": "cr-001",
+		"account_id":              "111111111111",
+		"account_name":            "test-account",
+		"region":                  "us-west-2",
+		"instance_type":           "m5.xlarge",
+		"availability_zone":       "us-west-2a",
+	})))
+
+	// Reservations missing from the next result are removed
+	m.UpdateInstanceCostMetrics(cost.CalculationResult{}, nil, nil)
+	assert.Equal(t, 0, testutil.CollectAndCount(m.CapacityReservationUnusedHourlyCost))
+}
+
+func TestUpdateInstanceCostMetrics_StorageCost(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	result := cost.CalculationResult{
+		InstanceCosts: map[string]cost.InstanceCost{
+			"i-abc123": {
+				InstanceID:       "i-abc123",
+				InstanceType:     "m5.xlarge",
+				Region:           "us-west-2",
+				AccountID:        "111111111111",
+				AccountName:      "test-account",
+				AvailabilityZone: "us-west-2a",
+				EffectiveCost:    0.192,
+				StorageCost:      0.011,
+				CoverageType:     cost.CoverageOnDemand,
+				PricingAccuracy:  cost.PricingAccurate,
+				Lifecycle:        "on-demand",
+			},
+		},
+		IncludesStorage: true,
+		CalculatedAt:    time.Now(),
+	}
+
+	m.UpdateInstanceCostMetrics(result, nil, nil)
+
+	labels := prometheus.Labels{
+		"instance_id":       "i-abc123",
+		"account_id":        "111111111111",
+		"account_name":      "test-account",
+		"region":            "us-west-2",
+		"instance_type":     "m5.xlarge",
+		"availability_zone": "us-west-2a",
+		"node_name":         "",
+		"cluster_name":      "",
+		"host_name":         "",
+	}
+	assert.Equal(t, 0.011, testutil.ToFloat64(m.EC2InstanceStorageHourlyCost.With(labels)))
+	assert.InDelta(t, 0.203, testutil.ToFloat64(m.EC2InstanceTotalHourlyCost.With(labels)), 0.0001)
+
+	// Without volume data neither metric is emitted
+	result.IncludesStorage = false
+	m.UpdateInstanceCostMetrics(result, nil, nil)
+	assert.Equal(t, 0, testutil.CollectAndCount(m.EC2InstanceStorageHourlyCost))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.EC2InstanceTotalHourlyCost))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.EC2InstanceHourlyCost))
+}
+
+func TestRecordInvariantViolations(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	m.RecordInvariantViolations([]cost.InvariantViolation{
+		{Invariant: cost.InvariantCoverageBounds, InstanceID: "i-001"},
+		{Invariant: cost.InvariantCoverageBounds, InstanceID: "i-002"},
+		{Invariant: cost.InvariantCommitmentBalance, SavingsPlanARN: "arn:aws:savingsplans::111111111111:savingsplan/sp"},
+	})
+	m.RecordInvariantViolations(nil)
+
+	violations := func(invariant string) float64 {
+		return testutil.ToFloat64(m.CalculationInvariantViolations.WithLabelValues(invariant))
+	}
+	assert.Equal(t, 2.0, violations(cost.InvariantCoverageBounds))
+	assert.Equal(t, 1.0, violations(cost.InvariantCommitmentBalance))
+
+	// The counter keeps counting across a config reload that recreates the vectors
+	cfg := newTestConfig()
+	cfg.Metrics.Labels.AccountID = "aws_account_id"
+	m.SetConfig(cfg)
+	m.RecordInvariantViolations([]cost.InvariantViolation{{Invariant: cost.InvariantCoverageBounds}})
+	assert.Equal(t, 3.0, violations(cost.InvariantCoverageBounds))
+}
@@ -35,18 +35,30 @@ const (
 	LabelLifecycle      = "lifecycle"
 
 	// Kubernetes labels
-	LabelNodeName    = "node_name"
-	LabelClusterName = "cluster_name"
-	LabelHostName    = "host_name"
+	LabelNodeName     = "node_name"
+	LabelClusterName  = "cluster_name"
+	LabelHostName     = "host_name"
+	LabelNamespace    = "namespace"
+	LabelPod          = "pod"
+	LabelNodePool     = "nodepool"
+	LabelCapacityType = "capacity_type"
 
 	// Cost labels
 	LabelCostType        = "cost_type"
 	LabelPricingAccuracy = "pricing_accuracy"
 
-	// Savings Plan / Reserved Instance labels
-	LabelSavingsPlanARN = "savings_plan_arn"
-	LabelType           = "type"
+	// Savings Plan / Reserved Instance / Capacity Reservation labels
+	LabelSavingsPlanARN        = "savings_plan_arn"
+	LabelReservedInstanceID    = "reserved_instance_id"
+	LabelCapacityReservationID = "capacity_reservation_id"
+	LabelType                  = "type"
+	LabelTerm                  = "term"
+	LabelCommitmentType        = "commitment_type"
+	LabelCommitmentID          = "commitment_id"
 
 	// Data freshness labels
 	LabelDataType = "data_type"
+
+	// Calculation audit labels
+	LabelInvariant = "invariant"
 )
@@ -18,6 +18,8 @@
 package metrics
 
 import (
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,6 +34,10 @@ type Metrics struct {
 	// config holds the Lumina configuration for accessing label names and settings
 	config *config.Config
 
+	// mu guards config and the metric vectors. Methods that update metrics hold
+	// the read lock; SetConfig holds the write lock while replacing them.
+	mu sync.RWMutex
+
 	// lastUpdateTimes tracks when each data type was last updated.
 	// Key format: "account_id:account_name:region:data_type" (e.g., "123456789012:Production:us-west-2:ec2_instances")
 	// This is used by the background goroutine to calculate age for DataFreshness metrics.
@@ -45,6 +51,12 @@ type Metrics struct {
 	// from the metrics endpoint, it indicates the controller has crashed.
 	ControllerRunning prometheus.Gauge
 
+	// CalculationInvariantViolations counts the math invariant violations found in
+	// cost calculations. Its label isn't configurable, so unlike the vectors below
+	// it's never recreated and keeps counting across config reloads.
+	// Labels: invariant
+	CalculationInvariantViolations *prometheus.CounterVec
+
 	// AccountValidationStatus tracks the validation status for each configured
 	// AWS account. A value of 1 indicates successful validation (AssumeRole
 	// succeeded), while 0 indicates validation failure.
@@ -85,6 +97,21 @@ type Metrics struct {
 	// Labels: account_id, region, instance_family
 	ReservedInstanceCount *prometheus.GaugeVec
 
+	// ReservedInstanceUtilizationPercent tracks the share of a Reserved Instance's
+	// instance count covering running instances (0-100).
+	// Labels: reserved_instance_id, account_id, region, instance_type, availability_zone
+	ReservedInstanceUtilizationPercent *prometheus.GaugeVec
+
+	// ReservedInstanceUnusedHourlyCost tracks the on-demand value of a Reserved
+	// Instance's unused capacity ($/hour).
+	// Labels: reserved_instance_id, account_id, region, instance_type, availability_zone
+	ReservedInstanceUnusedHourlyCost *prometheus.GaugeVec
+
+	// CapacityReservationUnusedHourlyCost tracks the on-demand cost of an On-Demand
+	// Capacity Reservation's unused capacity ($/hour).
+	// Labels: capacity_reservation_id, account_id, region, instance_type, availability_zone
+	CapacityReservationUnusedHourlyCost *prometheus.GaugeVec
+
 	// SavingsPlanCommitment tracks the hourly commitment amount ($/hour) for each Savings Plan.
 	// Value is the fixed hourly commitment. When the SP expires or is removed, the metric
 	// is deleted entirely.
@@ -113,6 +140,16 @@ type Metrics struct {
 	// Labels: instance_id, account_id, region, instance_type, cost_type, availability_zone, lifecycle, pricing_accuracy
 	EC2InstanceHourlyCost *prometheus.GaugeVec
 
+	// EC2InstanceStorageHourlyCost tracks the hourly cost of the EBS volumes attached
+	// to each EC2 instance. Only set when storage.enabled is set. Value is in USD/hour.
+	// Labels: instance_id, account_id, region, instance_type, availability_zone, node_name, cluster_name, host_name
+	EC2InstanceStorageHourlyCost *prometheus.GaugeVec
+
+	// EC2InstanceTotalHourlyCost tracks each EC2 instance's compute plus storage cost.
+	// Only set when storage.enabled is set. Value is in USD/hour.
+	// Labels: instance_id, account_id, region, instance_type, availability_zone, node_name, cluster_name, host_name
+	EC2InstanceTotalHourlyCost *prometheus.GaugeVec
+
 	// SavingsPlanCurrentUtilizationRate tracks the current hourly rate being consumed by
 	// instances covered by this Savings Plan. This is a snapshot of current usage ($/hour).
 	// Labels: savings_plan_arn, account_id, type
@@ -129,6 +166,137 @@ type Metrics struct {
 	// Can exceed 100% if the SP is over-utilized.
 	// Labels: savings_plan_arn, account_id, type
 	SavingsPlanUtilizationPercent *prometheus.GaugeVec
+
+	// SavingsPlanBillingHourCommitmentUsed tracks the commitment ($) consumed so far in
+	// the current billing hour. Only set when the Savings Plan ledger is enabled.
+	// Labels: savings_plan_arn, account_id, type
+	SavingsPlanBillingHourCommitmentUsed *prometheus.GaugeVec
+
+	// SavingsPlanBillingHourSpillover tracks usage ($ at SP rates) beyond the commitment
+	// so far in the current billing hour. Only set when the Savings Plan ledger is enabled.
+	// Labels: savings_plan_arn, account_id, type
+	SavingsPlanBillingHourSpillover *prometheus.GaugeVec
+
+	// SavingsPlanBillingHourUtilizationPercent tracks the projected end-of-hour utilization
+	// of a Savings Plan. Only set when the Savings Plan ledger is enabled.
+	// Labels: savings_plan_arn, account_id, type
+	SavingsPlanBillingHourUtilizationPercent *prometheus.GaugeVec
+
+	// SavingsPlanBillingHourInstanceSeconds tracks instance-seconds covered by a Savings
+	// Plan so far in the current billing hour. Only set when the Savings Plan ledger is enabled.
+	// Labels: savings_plan_arn, account_id, type
+	SavingsPlanBillingHourInstanceSeconds *prometheus.GaugeVec
+
+	// BillingAccountActualDailyCost tracks an account's amortized EC2 instance cost ($)
+	// for the reconciled day, from AWS billing data.
+	// Labels: account_id
+	BillingAccountActualDailyCost *prometheus.GaugeVec
+
+	// BillingAccountEstimatedDailyCost tracks an account's estimated EC2 instance cost ($)
+	// for the reconciled day.
+	// Labels: account_id
+	BillingAccountEstimatedDailyCost *prometheus.GaugeVec
+
+	// BillingAccountDriftPercent tracks the estimate's error relative to the actual cost.
+	// Labels: account_id
+	BillingAccountDriftPercent *prometheus.GaugeVec
+
+	// BillingSavingsPlanActualDailyCost tracks the commitment ($) a Savings Plan applied
+	// for the reconciled day, from AWS billing data.
+	// Labels: savings_plan_arn
+	BillingSavingsPlanActualDailyCost *prometheus.GaugeVec
+
+	// BillingSavingsPlanEstimatedDailyCost tracks the estimated commitment ($) a Savings
+	// Plan consumed for the reconciled day.
+	// Labels: savings_plan_arn
+	BillingSavingsPlanEstimatedDailyCost *prometheus.GaugeVec
+
+	// BillingSavingsPlanDriftPercent tracks the estimate's error relative to the actual
+	// commitment consumed.
+	// Labels: savings_plan_arn
+	BillingSavingsPlanDriftPercent *prometheus.GaugeVec
+
+	// BillingReconciledDate is the Unix timestamp of the day the billing metrics describe.
+	// A GaugeVec without labels so nothing is exported until a day has been reconciled.
+	BillingReconciledDate *prometheus.GaugeVec
+
+	// PodHourlyCost tracks a pod's share of its node's effective hourly cost (USD/hour).
+	// Only populated when allocation.enabled is set.
+	// Labels: namespace, pod, node_name
+	PodHourlyCost *prometheus.GaugeVec
+
+	// NamespaceHourlyCost tracks the total pod cost per namespace (USD/hour), with
+	// unrequested node capacity under the "__idle__" namespace.
+	// Labels: namespace
+	NamespaceHourlyCost *prometheus.GaugeVec
+
+	// NodePoolHourlyCost tracks the effective cost of a Karpenter NodePool's
+	// instances (USD/hour). Only populated when karpenter.enabled is set.
+	// Labels: nodepool
+	NodePoolHourlyCost *prometheus.GaugeVec
+
+	// NodePoolShelfHourlyCost tracks the on-demand price of a NodePool's instances (USD/hour).
+	// Labels: nodepool
+	NodePoolShelfHourlyCost *prometheus.GaugeVec
+
+	// NodePoolCommitmentCoveragePercent tracks the share of a NodePool's shelf price
+	// covered by Reserved Instances or Savings Plans (0-100).
+	// Labels: nodepool
+	NodePoolCommitmentCoveragePercent *prometheus.GaugeVec
+
+	// NodePoolSpotPercent tracks the share of a NodePool's shelf price on spot (0-100).
+	// Labels: nodepool
+	NodePoolSpotPercent *prometheus.GaugeVec
+
+	// NodePoolNodeCount tracks a NodePool's instances by Karpenter capacity type.
+	// Labels: nodepool, capacity_type
+	NodePoolNodeCount *prometheus.GaugeVec
+
+	// InstanceTypeVCPUHourlyCost tracks the effective cost per vCPU of an instance
+	// type's instances (USD/vCPU-hour). Only populated when unitCosts.enabled is set.
+	// Labels: instance_type, region, cost_type
+	InstanceTypeVCPUHourlyCost *prometheus.GaugeVec
+
+	// InstanceTypeMemoryGiBHourlyCost tracks the effective cost per GiB of memory of
+	// an instance type's instances (USD/GiB-hour).
+	// Labels: instance_type, region, cost_type
+	InstanceTypeMemoryGiBHourlyCost *prometheus.GaugeVec
+
+	// InstanceTypeGPUHourlyCost tracks the effective cost per GPU of an instance
+	// type's instances (USD/GPU-hour). Only GPU instance types are reported.
+	// Labels: instance_type, region, cost_type
+	InstanceTypeGPUHourlyCost *prometheus.GaugeVec
+
+	// SavingsPlanRecommendedHourlyCommitment tracks the recommended commitment of a
+	// new Savings Plan (USD/hour). Only populated when recommendations.enabled is set.
+	// Labels: type, term, region, instance_family
+	SavingsPlanRecommendedHourlyCommitment *prometheus.GaugeVec
+
+	// SavingsPlanRecommendedHourlySavings tracks the recommended plan's projected
+	// average saving, net of the commitment (USD/hour).
+	// Labels: type, term, region, instance_family
+	SavingsPlanRecommendedHourlySavings *prometheus.GaugeVec
+
+	// SavingsPlanRecommendedUtilizationPercent tracks the recommended plan's projected
+	// average utilization (0-100).
+	// Labels: type, term, region, instance_family
+	SavingsPlanRecommendedUtilizationPercent *prometheus.GaugeVec
+
+	// CommitmentExpirationTimestamp tracks when a Savings Plan or Reserved Instance
+	// expiring within the forecast horizon expires (Unix seconds). Only populated
+	// when forecast.enabled is set.
+	// Labels: commitment_type, commitment_id, account_id, account_name
+	CommitmentExpirationTimestamp *prometheus.GaugeVec
+
+	// CommitmentExpirationHourlyCostIncrease tracks the projected increase in total
+	// hourly cost when the commitment expires (USD/hour).
+	// Labels: commitment_type, commitment_id, account_id, account_name
+	CommitmentExpirationHourlyCostIncrease *prometheus.GaugeVec
+
+	// CommitmentExpirationHourlySpilloverIncrease tracks the projected increase in
+	// on-demand spillover when the commitment expires (USD/hour).
+	// Labels: commitment_type, commitment_id, account_id, account_name
+	CommitmentExpirationHourlySpilloverIncrease *prometheus.GaugeVec
 }
 
 // NewMetrics creates and registers all Prometheus metrics with the provided
@@ -154,123 +322,366 @@ func NewMetrics(reg prometheus.Registerer, cfg *config.Config) *Metrics {
 			Name: MetricLuminaControllerRunning,
 			Help: "Indicates whether the Lumina controller is running (1 = running)",
 		}),
+		CalculationInvariantViolations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricLuminaCalculationInvariantViolationsTotal,
+			Help: "Math invariant violations found in cost calculations",
+		}, []string{LabelInvariant}),
+	}
+	m.createVectors(cfg)
 
-		AccountValidationStatus: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricLuminaAccountValidationStatus,
-			Help: "AWS account validation status (1 = success, 0 = failed)",
-		}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel()}),
-
-		AccountValidationLastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricLuminaAccountValidationLastSuccess,
-			Help: "Unix timestamp of last successful validation",
-		}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel()}),
-
-		AccountValidationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
-			Name: MetricLuminaAccountValidationDurationSeconds,
-			Help: "Time taken to validate account access",
-			// Buckets cover 100ms to 10 seconds, reasonable for AssumeRole calls
-			Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10},
-		}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel()}),
-
-		DataFreshness: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricLuminaDataFreshnessSeconds,
-			Help: "Age of cached data in seconds since last successful update (updated every second)",
-		}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), cfg.GetRegionLabel(), LabelDataType}),
-
-		DataLastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricLuminaDataLastSuccess,
-			Help: "Indicator of whether last data collection succeeded (1 = success, 0 = failed, Phase 2+)",
-		}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), cfg.GetRegionLabel(), LabelDataType}),
-
-		ReservedInstance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricEC2ReservedInstance,
-			Help: "Indicates presence of a Reserved Instance (1 = exists, metric absent = does not exist)",
-		}, []string{
-			cfg.GetAccountIDLabel(),
-			cfg.GetAccountNameLabel(),
-			cfg.GetRegionLabel(),
-			LabelInstanceType,
-			LabelAvailabilityZone,
-		}),
+	// Register all metrics with the provided registry. The vectors are collected
+	// through vectorCollector so SetConfig can replace them.
+	reg.MustRegister(m.ControllerRunning, m.CalculationInvariantViolations, vectorCollector{m})
 
-		ReservedInstanceCount: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricEC2ReservedInstanceCount,
-			Help: "Count of Reserved Instances by instance family",
-		}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), cfg.GetRegionLabel(), LabelInstanceFamily}),
-
-		SavingsPlanCommitment: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricSavingsPlanHourlyCommitment,
-			Help: "Hourly commitment amount ($/hour) for a Savings Plan",
-		}, []string{
-			LabelSavingsPlanARN,
-			cfg.GetAccountIDLabel(),
-			cfg.GetAccountNameLabel(),
-			LabelType,
-			cfg.GetRegionLabel(),
-			LabelInstanceFamily,
-		}),
+	// Start background goroutine to update data freshness metrics every second
+	go m.updateDataFreshnessLoop()
 
-		SavingsPlanRemainingHours: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricSavingsPlanRemainingHours,
-			Help: "Number of hours remaining until Savings Plan expires",
-		}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType}),
-
-		EC2Instance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricEC2Instance,
-			Help: "Indicates presence of a running EC2 instance (1 = exists, metric absent = stopped or terminated)",
-		}, []string{
-			cfg.GetAccountIDLabel(),
-			cfg.GetAccountNameLabel(),
-			cfg.GetRegionLabel(),
-			LabelInstanceType,
-			LabelAvailabilityZone,
-			LabelInstanceID,
-			LabelTenancy,
-			LabelPlatform,
-		}),
+	return m
+}
 
-		EC2InstanceCount: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricEC2InstanceCount,
-			Help: "Count of running EC2 instances by instance family",
-		}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), cfg.GetRegionLabel(), LabelInstanceFamily}),
-
-		EC2InstanceHourlyCost: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricEC2InstanceHourlyCost,
-			Help: "Effective hourly cost for an EC2 instance after applying all discounts (USD/hour)",
-		}, []string{
-			LabelInstanceID,
-			cfg.GetAccountIDLabel(),
-			cfg.GetAccountNameLabel(),
-			cfg.GetRegionLabel(),
-			LabelInstanceType,
-			LabelCostType,
-			LabelAvailabilityZone,
-			LabelLifecycle,
-			LabelPricingAccuracy,
-			cfg.GetNodeNameLabel(),
-			cfg.GetClusterNameLabel(),
-			cfg.GetHostNameLabel(),
-		}),
+// createVectors creates the metric vectors, whose label names come from cfg.
+func (m *Metrics) createVectors(cfg *config.Config) {
+	m.AccountValidationStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricLuminaAccountValidationStatus,
+		Help: "AWS account validation status (1 = success, 0 = failed)",
+	}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel()})
+
+	m.AccountValidationLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricLuminaAccountValidationLastSuccess,
+		Help: "Unix timestamp of last successful validation",
+	}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel()})
+
+	m.AccountValidationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: MetricLuminaAccountValidationDurationSeconds,
+		Help: "Time taken to validate account access",
+		// Buckets cover 100ms to 10 seconds, reasonable for AssumeRole calls
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel()})
+
+	m.DataFreshness = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricLuminaDataFreshnessSeconds,
+		Help: "Age of cached data in seconds since last successful update (updated every second)",
+	}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), cfg.GetRegionLabel(), LabelDataType})
+
+	m.DataLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricLuminaDataLastSuccess,
+		Help: "Indicator of whether last data collection succeeded (1 = success, 0 = failed, Phase 2+)",
+	}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), cfg.GetRegionLabel(), LabelDataType})
+
+	m.ReservedInstance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricEC2ReservedInstance,
+		Help: "Indicates presence of a Reserved Instance (1 = exists, metric absent = does not exist)",
+	}, []string{
+		cfg.GetAccountIDLabel(),
+		cfg.GetAccountNameLabel(),
+		cfg.GetRegionLabel(),
+		LabelInstanceType,
+		LabelAvailabilityZone,
+	})
+
+	m.ReservedInstanceCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricEC2ReservedInstanceCount,
+		Help: "Count of Reserved Instances by instance family",
+	}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), cfg.GetRegionLabel(), LabelInstanceFamily})
+
+	m.ReservedInstanceUtilizationPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricEC2ReservedInstanceUtilizationPercent,
+		Help: "Share of a Reserved Instance's instance count covering running instances (0-100)",
+	}, []string{
+		LabelReservedInstanceID,
+		cfg.GetAccountIDLabel(),
+		cfg.GetAccountNameLabel(),
+		cfg.GetRegionLabel(),
+		LabelInstanceType,
+		LabelAvailabilityZone,
+	})
+
+	m.ReservedInstanceUnusedHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricEC2ReservedInstanceUnusedHourlyCost,
+		Help: "On-demand value of a Reserved Instance's unused capacity (USD/hour)",
+	}, []string{
+		LabelReservedInstanceID,
+		cfg.GetAccountIDLabel(),
+		cfg.GetAccountNameLabel(),
+		cfg.GetRegionLabel(),
+		LabelInstanceType,
+		LabelAvailabilityZone,
+	})
+
+	m.CapacityReservationUnusedHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricEC2CapacityReservationUnusedHourlyCost,
+		Help: "On-demand cost of a Capacity Reservation's unused capacity (USD/hour)",
+	}, []string{
+		LabelCapacityReservationID,
+		cfg.GetAccountIDLabel(),
+		cfg.GetAccountNameLabel(),
+		cfg.GetRegionLabel(),
+		LabelInstanceType,
+		LabelAvailabilityZone,
+	})
+
+	m.SavingsPlanCommitment = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanHourlyCommitment,
+		Help: "Hourly commitment amount ($/hour) for a Savings Plan",
+	}, []string{
+		LabelSavingsPlanARN,
+		cfg.GetAccountIDLabel(),
+		cfg.GetAccountNameLabel(),
+		LabelType,
+		cfg.GetRegionLabel(),
+		LabelInstanceFamily,
+	})
+
+	m.SavingsPlanRemainingHours = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanRemainingHours,
+		Help: "Number of hours remaining until Savings Plan expires",
+	}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType})
+
+	m.EC2Instance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricEC2Instance,
+		Help: "Indicates presence of a running EC2 instance (1 = exists, metric absent = stopped or terminated)",
+	}, []string{
+		cfg.GetAccountIDLabel(),
+		cfg.GetAccountNameLabel(),
+		cfg.GetRegionLabel(),
+		LabelInstanceType,
+		LabelAvailabilityZone,
+		LabelInstanceID,
+		LabelTenancy,
+		LabelPlatform,
+	})
+
+	m.EC2InstanceCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricEC2InstanceCount,
+		Help: "Count of running EC2 instances by instance family",
+	}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), cfg.GetRegionLabel(), LabelInstanceFamily})
+
+	m.EC2InstanceHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricEC2InstanceHourlyCost,
+		Help: "Effective hourly cost for an EC2 instance after applying all discounts (USD/hour)",
+	}, []string{
+		LabelInstanceID,
+		cfg.GetAccountIDLabel(),
+		cfg.GetAccountNameLabel(),
+		cfg.GetRegionLabel(),
+		LabelInstanceType,
+		LabelCostType,
+		LabelAvailabilityZone,
+		LabelLifecycle,
+		LabelPricingAccuracy,
+		cfg.GetNodeNameLabel(),
+		cfg.GetClusterNameLabel(),
+		cfg.GetHostNameLabel(),
+	})
+
+	instanceCostLabels := []string{
+		LabelInstanceID,
+		cfg.GetAccountIDLabel(),
+		cfg.GetAccountNameLabel(),
+		cfg.GetRegionLabel(),
+		LabelInstanceType,
+		LabelAvailabilityZone,
+		cfg.GetNodeNameLabel(),
+		cfg.GetClusterNameLabel(),
+		cfg.GetHostNameLabel(),
+	}
+	m.EC2InstanceStorageHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricEC2InstanceStorageHourlyCost,
+		Help: "Hourly cost of the EBS volumes attached to an EC2 instance (USD/hour)",
+	}, instanceCostLabels)
+
+	m.EC2InstanceTotalHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricEC2InstanceTotalHourlyCost,
+		Help: "Total hourly cost of an EC2 instance including its EBS volumes (USD/hour)",
+	}, instanceCostLabels)
+
+	m.SavingsPlanCurrentUtilizationRate = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanCurrentUtilizationRate,
+		Help: "Current hourly rate being consumed by instances covered by this Savings Plan (USD/hour)",
+	}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType})
+
+	m.SavingsPlanRemainingCapacity = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanRemainingCapacity,
+		Help: "Unused capacity in USD/hour for a Savings Plan (negative if over-utilized)",
+	}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType})
+
+	m.SavingsPlanUtilizationPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanUtilizationPercent,
+		Help: "Utilization percentage of a Savings Plan (can exceed 100% if over-utilized)",
+	}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType})
+
+	m.SavingsPlanBillingHourCommitmentUsed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanBillingHourCommitmentUsed,
+		Help: "Savings Plan commitment consumed so far in the current billing hour (USD)",
+	}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType})
+
+	m.SavingsPlanBillingHourSpillover = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanBillingHourSpillover,
+		Help: "Eligible usage beyond the Savings Plan commitment so far in the current billing hour (USD at SP rates)",
+	}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType})
+
+	m.SavingsPlanBillingHourUtilizationPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanBillingHourUtilizationPercent,
+		Help: "Projected utilization percentage of a Savings Plan at the end of the current billing hour",
+	}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType})
+
+	m.SavingsPlanBillingHourInstanceSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanBillingHourInstanceSeconds,
+		Help: "Instance-seconds covered by a Savings Plan so far in the current billing hour",
+	}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType})
+
+	m.BillingAccountActualDailyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricBillingAccountActualDailyCost,
+		Help: "Amortized EC2 instance cost for an account on the reconciled day, from AWS billing data (USD)",
+	}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel()})
+
+	m.BillingAccountEstimatedDailyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricBillingAccountEstimatedDailyCost,
+		Help: "Estimated EC2 instance cost for an account on the reconciled day (USD)",
+	}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel()})
+
+	m.BillingAccountDriftPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricBillingAccountDriftPercent,
+		Help: "Error of the estimated account cost relative to AWS billing data (positive = over-estimated)",
+	}, []string{cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel()})
+
+	m.BillingSavingsPlanActualDailyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricBillingSavingsPlanActualDailyCost,
+		Help: "Savings Plan commitment applied on the reconciled day, from AWS billing data (USD)",
+	}, []string{LabelSavingsPlanARN})
+
+	m.BillingSavingsPlanEstimatedDailyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricBillingSavingsPlanEstimatedDailyCost,
+		Help: "Estimated Savings Plan commitment consumed on the reconciled day (USD)",
+	}, []string{LabelSavingsPlanARN})
+
+	m.BillingSavingsPlanDriftPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricBillingSavingsPlanDriftPercent,
+		Help: "Error of the estimated Savings Plan commitment relative to AWS billing data (positive = over-estimated)",
+	}, []string{LabelSavingsPlanARN})
+
+	m.BillingReconciledDate = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricBillingReconciledDate,
+		Help: "Unix timestamp of the start of the day described by the billing_* metrics",
+	}, nil)
+
+	m.PodHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricPodHourlyCost,
+		Help: "Share of the node's effective hourly cost allocated to a pod by its CPU and memory requests (USD/hour)",
+	}, []string{LabelNamespace, LabelPod, cfg.GetNodeNameLabel()})
+
+	m.NamespaceHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricNamespaceHourlyCost,
+		Help: "Total hourly cost allocated to pods in a namespace, with unrequested capacity under __idle__ (USD/hour)",
+	}, []string{LabelNamespace})
+
+	m.NodePoolHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricNodePoolHourlyCost,
+		Help: "Total effective hourly cost of the instances in a Karpenter NodePool (USD/hour)",
+	}, []string{LabelNodePool})
+
+	m.NodePoolShelfHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricNodePoolShelfHourlyCost,
+		Help: "Total on-demand price of the instances in a Karpenter NodePool, before discounts (USD/hour)",
+	}, []string{LabelNodePool})
+
+	m.NodePoolCommitmentCoveragePercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricNodePoolCommitmentCoveragePercent,
+		Help: "Percentage of a Karpenter NodePool's on-demand price covered by Reserved Instances or Savings Plans",
+	}, []string{LabelNodePool})
+
+	m.NodePoolSpotPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricNodePoolSpotPercent,
+		Help: "Percentage of a Karpenter NodePool's on-demand price running on spot instances",
+	}, []string{LabelNodePool})
+
+	m.NodePoolNodeCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricNodePoolNodeCount,
+		Help: "Number of instances in a Karpenter NodePool by capacity type",
+	}, []string{LabelNodePool, LabelCapacityType})
+
+	unitCostLabels := []string{LabelInstanceType, cfg.GetRegionLabel(), LabelCostType}
+
+	m.InstanceTypeVCPUHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricInstanceTypeVCPUHourlyCost,
+		Help: "Effective hourly cost per vCPU of an instance type's instances after discounts (USD/vCPU-hour)",
+	}, unitCostLabels)
+
+	m.InstanceTypeMemoryGiBHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricInstanceTypeMemoryGiBHourlyCost,
+		Help: "Effective hourly cost per GiB of memory of an instance type's instances after discounts (USD/GiB-hour)",
+	}, unitCostLabels)
+
+	m.InstanceTypeGPUHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricInstanceTypeGPUHourlyCost,
+		Help: "Effective hourly cost per GPU of an instance type's instances after discounts (USD/GPU-hour)",
+	}, unitCostLabels)
+
+	m.SavingsPlanRecommendedHourlyCommitment = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanRecommendedHourlyCommitment,
+		Help: "Recommended hourly commitment for a new Savings Plan, sized against recent on-demand spillover (USD/hour)",
+	}, []string{LabelType, LabelTerm, cfg.GetRegionLabel(), LabelInstanceFamily})
+
+	m.SavingsPlanRecommendedHourlySavings = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanRecommendedHourlySavings,
+		Help: "Projected average saving of the recommended Savings Plan, net of the commitment (USD/hour)",
+	}, []string{LabelType, LabelTerm, cfg.GetRegionLabel(), LabelInstanceFamily})
+
+	m.SavingsPlanRecommendedUtilizationPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricSavingsPlanRecommendedUtilizationPercent,
+		Help: "Projected average utilization of the recommended Savings Plan (0-100)",
+	}, []string{LabelType, LabelTerm, cfg.GetRegionLabel(), LabelInstanceFamily})
+
+	expirationLabels := []string{
+		LabelCommitmentType,
+		LabelCommitmentID,
+		cfg.GetAccountIDLabel(),
+		cfg.GetAccountNameLabel(),
+	}
+
+	m.CommitmentExpirationTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricCommitmentExpirationTimestamp,
+		Help: "Unix timestamp when a Savings Plan or Reserved Instance within the forecast horizon expires",
+	}, expirationLabels)
+
+	m.CommitmentExpirationHourlyCostIncrease = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricCommitmentExpirationHourlyCostIncrease,
+		Help: "Projected increase in total hourly cost when the commitment expires (USD/hour)",
+	}, expirationLabels)
+
+	m.CommitmentExpirationHourlySpilloverIncrease = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricCommitmentExpirationHourlySpilloverIncrease,
+		Help: "Projected increase in on-demand spillover when the commitment expires (USD/hour)",
+	}, expirationLabels)
+}
+
+// vectorCollector collects the current metric vectors of a Metrics.
+//
+// A Prometheus registry pins the label names of each metric name for the life
+// of the process, even across Unregister, so vectors recreated with new label
+// names can't be registered again. Instead this collector is registered once
+// as an unchecked collector (its Describe sends nothing) and collects whichever
+// vectors are current.
+type vectorCollector struct {
+	m *Metrics
+}
 
-		SavingsPlanCurrentUtilizationRate: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricSavingsPlanCurrentUtilizationRate,
-			Help: "Current hourly rate being consumed by instances covered by this Savings Plan (USD/hour)",
-		}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType}),
+// Describe sends no descriptors, which makes vectorCollector unchecked.
+func (c vectorCollector) Describe(chan<- *prometheus.Desc) {}
 
-		SavingsPlanRemainingCapacity: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricSavingsPlanRemainingCapacity,
-			Help: "Unused capacity in USD/hour for a Savings Plan (negative if over-utilized)",
-		}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType}),
+// Collect collects every current metric vector.
+func (c vectorCollector) Collect(ch chan<- prometheus.Metric) {
+	c.m.mu.RLock()
+	defer c.m.mu.RUnlock()
 
-		SavingsPlanUtilizationPercent: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: MetricSavingsPlanUtilizationPercent,
-			Help: "Utilization percentage of a Savings Plan (can exceed 100% if over-utilized)",
-		}, []string{LabelSavingsPlanARN, cfg.GetAccountIDLabel(), cfg.GetAccountNameLabel(), LabelType}),
+	for _, vector := range c.m.vectors() {
+		vector.Collect(ch)
 	}
+}
 
-	// Register all metrics with the provided registry
-	reg.MustRegister(
-		m.ControllerRunning,
+// vectors returns the metric vectors.
+func (m *Metrics) vectors() []prometheus.Collector {
+	return []prometheus.Collector{
 		m.AccountValidationStatus,
 		m.AccountValidationLastSuccess,
 		m.AccountValidationDuration,
@@ -278,20 +689,47 @@ func NewMetrics(reg prometheus.Registerer, cfg *config.Config) *Metrics {
 		m.DataLastSuccess,
 		m.ReservedInstance,
 		m.ReservedInstanceCount,
+		m.ReservedInstanceUtilizationPercent,
+		m.ReservedInstanceUnusedHourlyCost,
+		m.CapacityReservationUnusedHourlyCost,
 		m.SavingsPlanCommitment,
 		m.SavingsPlanRemainingHours,
 		m.EC2Instance,
 		m.EC2InstanceCount,
 		m.EC2InstanceHourlyCost,
+		m.EC2InstanceStorageHourlyCost,
+		m.EC2InstanceTotalHourlyCost,
 		m.SavingsPlanCurrentUtilizationRate,
 		m.SavingsPlanRemainingCapacity,
 		m.SavingsPlanUtilizationPercent,
-	)
-
-	// Start background goroutine to update data freshness metrics every second
-	go m.updateDataFreshnessLoop()
-
-	return m
+		m.SavingsPlanBillingHourCommitmentUsed,
+		m.SavingsPlanBillingHourSpillover,
+		m.SavingsPlanBillingHourUtilizationPercent,
+		m.SavingsPlanBillingHourInstanceSeconds,
+		m.BillingAccountActualDailyCost,
+		m.BillingAccountEstimatedDailyCost,
+		m.BillingAccountDriftPercent,
+		m.BillingSavingsPlanActualDailyCost,
+		m.BillingSavingsPlanEstimatedDailyCost,
+		m.BillingSavingsPlanDriftPercent,
+		m.BillingReconciledDate,
+		m.PodHourlyCost,
+		m.NamespaceHourlyCost,
+		m.NodePoolHourlyCost,
+		m.NodePoolShelfHourlyCost,
+		m.NodePoolCommitmentCoveragePercent,
+		m.NodePoolSpotPercent,
+		m.NodePoolNodeCount,
+		m.InstanceTypeVCPUHourlyCost,
+		m.InstanceTypeMemoryGiBHourlyCost,
+		m.InstanceTypeGPUHourlyCost,
+		m.SavingsPlanRecommendedHourlyCommitment,
+		m.SavingsPlanRecommendedHourlySavings,
+		m.SavingsPlanRecommendedUtilizationPercent,
+		m.CommitmentExpirationTimestamp,
+		m.CommitmentExpirationHourlyCostIncrease,
+		m.CommitmentExpirationHourlySpilloverIncrease,
+	}
 }
 
 // RecordAccountValidation records the result of an AWS account validation
@@ -311,6 +749,9 @@ func NewMetrics(reg prometheus.Registerer, cfg *config.Config) *Metrics {
 //	duration := time.Since(start)
 //	metrics.RecordAccountValidation(accountID, accountName, err == nil, duration)
 func (m *Metrics) RecordAccountValidation(accountID, accountName string, success bool, duration time.Duration) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	labels := prometheus.Labels{
 		m.config.GetAccountIDLabel():   accountID,
 		m.config.GetAccountNameLabel(): accountName,
@@ -331,6 +772,25 @@ func (m *Metrics) RecordAccountValidation(accountID, accountName string, success
 	}
 }
 
+// RecordDataCollection records whether the latest attempt to collect data of a
+// specific type succeeded, as lumina_data_last_success. Reconcilers call this
+// instead of using DataLastSuccess directly, so the update can't race with
+// SetConfig replacing the metric vectors.
+//
+// Example usage:
+//
+//	metrics.RecordDataCollection("329239342014", "Production", "us-west-2", "ec2_instances", err == nil)
+func (m *Metrics) RecordDataCollection(accountID, accountName, region, dataType string, success bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	value := 0.0
+	if success {
+		value = 1
+	}
+	m.DataLastSuccess.WithLabelValues(accountID, accountName, region, dataType).Set(value)
+}
+
 // MarkDataUpdated marks that data of a specific type has been successfully updated.
 // This records the current timestamp, which is used by the background goroutine to
 // calculate the age for the lumina_data_freshness_seconds metric.
@@ -374,6 +834,9 @@ func (m *Metrics) updateDataFreshnessLoop() {
 // updateAllDataFreshnessMetrics updates all data freshness gauges with current ages.
 // This is called every second by the background goroutine.
 func (m *Metrics) updateAllDataFreshnessMetrics() {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	now := time.Now()
 
 	m.lastUpdateMu.RLock()
@@ -433,6 +896,9 @@ func (m *Metrics) Stop() {
 //
 //	metrics.DeleteAccountMetrics("329239342014", "Production")
 func (m *Metrics) DeleteAccountMetrics(accountID, accountName string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	labels := prometheus.Labels{
 		m.config.GetAccountIDLabel():   accountID,
 		m.config.GetAccountNameLabel(): accountName,
@@ -442,4 +908,48 @@ func (m *Metrics) DeleteAccountMetrics(accountID, accountName string) {
 	m.AccountValidationStatus.Delete(labels)
 	m.AccountValidationLastSuccess.Delete(labels)
 	m.AccountValidationDuration.Delete(labels)
+
+	// Data collection metrics have a series per region and data type
+	m.DataFreshness.DeletePartialMatch(labels)
+	m.DataLastSuccess.DeletePartialMatch(labels)
+
+	// Stop the background goroutine from recreating freshness series for the account
+	prefix := accountID + ":" + accountName + ":"
+	m.lastUpdateMu.Lock()
+	for key := range m.lastUpdateTimes {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.lastUpdateTimes, key)
+		}
+	}
+	m.lastUpdateMu.Unlock()
+}
+
+// SetConfig switches the metrics to a reloaded configuration. The configuration
+// determines label names and whether instance metrics are published.
+//
+// Label names are fixed when a metric vector is created, so if any changed, every
+// vector is recreated with the new names. Recreated vectors are empty until the
+// next update of each metric: cost metrics on the next cost calculation, data
+// freshness within a second, and the rest on their reconciler's next cycle.
+func (m *Metrics) SetConfig(cfg *config.Config) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous := m.config
+	m.config = cfg
+	if !slices.Equal(labelNames(previous), labelNames(cfg)) {
+		m.createVectors(cfg)
+	}
+}
+
+// labelNames returns the configurable label names, for detecting changes.
+func labelNames(cfg *config.Config) []string {
+	return []string{
+		cfg.GetClusterNameLabel(),
+		cfg.GetAccountNameLabel(),
+		cfg.GetAccountIDLabel(),
+		cfg.GetRegionLabel(),
+		cfg.GetNodeNameLabel(),
+		cfg.GetHostNameLabel(),
+	}
 }
@@ -385,6 +385,65 @@ func TestDeleteAccountMetrics_NonExistent(t *testing.T) {
 	})
 }
 
+// TestDeleteAccountMetrics_DataCollection verifies that per-region data collection
+// metrics of a removed account are deleted and not recreated.
+func TestDeleteAccountMetrics_DataCollection(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+	defer m.Stop()
+
+	m.RecordDataCollection("555555555555", "ToBeDeleted", "us-west-2", "ec2_instances", true)
+	m.RecordDataCollection("555555555555", "ToBeDeleted", "us-east-1", "ec2_instances", false)
+	m.RecordDataCollection("123456789012", "Kept", "us-west-2", "ec2_instances", true)
+	m.MarkDataUpdated("555555555555", "ToBeDeleted", "us-west-2", "ec2_instances")
+	m.MarkDataUpdated("123456789012", "Kept", "us-west-2", "ec2_instances")
+	m.updateAllDataFreshnessMetrics()
+
+	m.DeleteAccountMetrics("555555555555", "ToBeDeleted")
+	m.updateAllDataFreshnessMetrics()
+
+	assert.Equal(t, 1, testutil.CollectAndCount(m.DataLastSuccess))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.DataFreshness))
+}
+
+// TestSetConfig_LabelNames verifies that changing label names re-registers the
+// metrics with the new names.
+func TestSetConfig_LabelNames(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+	defer m.Stop()
+
+	m.RecordDataCollection("123456789012", "Test", "us-west-2", "ec2_instances", true)
+	oldVector := m.DataLastSuccess
+
+	// Unchanged label names keep the existing metrics
+	m.SetConfig(newTestConfig())
+	assert.Same(t, oldVector, m.DataLastSuccess)
+
+	cfg := newTestConfig()
+	cfg.Metrics.Labels.AccountID = "aws_account_id"
+	m.SetConfig(cfg)
+	m.RecordDataCollection("123456789012", "Test", "us-west-2", "ec2_instances", true)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	var found bool
+	for _, family := range families {
+		if family.GetName() != MetricLuminaDataLastSuccess {
+			continue
+		}
+		found = true
+		require.Len(t, family.GetMetric(), 1)
+		var names []string
+		for _, label := range family.GetMetric()[0].GetLabel() {
+			names = append(names, label.GetName())
+		}
+		assert.Contains(t, names, "aws_account_id")
+		assert.NotContains(t, names, "account_id")
+	}
+	assert.True(t, found, "data last success metric not registered after SetConfig")
+}
+
 // TestDataFreshnessMetrics verifies the data freshness metrics work correctly.
 // DataFreshness stores age in seconds since last successful data collection.
 // The metric is automatically updated every second by a background goroutine.
@@ -61,6 +61,14 @@ const (
 	// Type: Gauge
 	// Labels: account_id, account_name, region, data_type
 	MetricLuminaDataLastSuccess = "lumina_data_last_success"
+
+	// MetricLuminaCalculationInvariantViolationsTotal counts the math invariant
+	// violations found in cost calculations. A calculation with violations isn't
+	// published; cost metrics keep the last good calculation's values. Details of the
+	// latest violations are served by /debug/cost/invariants.
+	// Type: Counter
+	// Labels: invariant
+	MetricLuminaCalculationInvariantViolationsTotal = "lumina_calculation_invariant_violations_total"
 )
 
 // AWS Account Validation Metrics
@@ -138,6 +146,95 @@ const (
 	MetricSavingsPlanUtilizationPercent = "savings_plan_utilization_percent"
 )
 
+// Savings Plans Billing Hour Metrics
+//
+// These metrics are only emitted when cost.savingsPlanLedger is enabled. They report
+// Savings Plan usage accumulated over the current AWS billing hour, which is how AWS
+// applies the hourly commitment. Unlike the instantaneous savings_plan_* metrics, a
+// short burst above the commitment doesn't spill over if commitment went unused
+// earlier in the same hour.
+
+const (
+	// MetricSavingsPlanBillingHourCommitmentUsed tracks the commitment consumed so far
+	// in the current billing hour (USD). Resets to 0 at the top of each hour and never
+	// exceeds savings_plan_hourly_commitment.
+	// Type: Gauge
+	// Labels: savings_plan_arn, account_id, account_name, type
+	MetricSavingsPlanBillingHourCommitmentUsed = "savings_plan_billing_hour_commitment_used"
+
+	// MetricSavingsPlanBillingHourSpillover tracks eligible usage so far in the current
+	// billing hour (USD at SP rates) that exceeded the commitment and is billed at
+	// on-demand rates.
+	// Type: Gauge
+	// Labels: savings_plan_arn, account_id, account_name, type
+	MetricSavingsPlanBillingHourSpillover = "savings_plan_billing_hour_spillover"
+
+	// MetricSavingsPlanBillingHourUtilizationPercent tracks the projected utilization
+	// of the commitment at the end of the current billing hour, assuming the current
+	// instances keep running until then. Capped at 100.
+	// Type: Gauge
+	// Labels: savings_plan_arn, account_id, account_name, type
+	MetricSavingsPlanBillingHourUtilizationPercent = "savings_plan_billing_hour_utilization_percent"
+
+	// MetricSavingsPlanBillingHourInstanceSeconds tracks the instance-seconds covered
+	// by the Savings Plan so far in the current billing hour.
+	// Type: Gauge
+	// Labels: savings_plan_arn, account_id, account_name, type
+	MetricSavingsPlanBillingHourInstanceSeconds = "savings_plan_billing_hour_instance_seconds"
+)
+
+// Billing Drift Metrics
+//
+// These metrics are only emitted when billing.curPath is set. They compare Lumina's
+// estimated EC2 instance costs for the most recent fully-observed UTC day with the
+// amortized costs in the AWS Cost and Usage Report, to catch estimates going wrong.
+
+const (
+	// MetricBillingAccountActualDailyCost tracks the amortized EC2 instance cost (USD)
+	// for an account on the reconciled day, from the Cost and Usage Report.
+	// Type: Gauge
+	// Labels: account_id, account_name
+	MetricBillingAccountActualDailyCost = "billing_account_actual_daily_cost"
+
+	// MetricBillingAccountEstimatedDailyCost tracks Lumina's estimated EC2 instance
+	// cost (USD) for an account on the reconciled day (the daily sum of
+	// ec2_instance_hourly_cost).
+	// Type: Gauge
+	// Labels: account_id, account_name
+	MetricBillingAccountEstimatedDailyCost = "billing_account_estimated_daily_cost"
+
+	// MetricBillingAccountDriftPercent tracks the estimate's error relative to the
+	// actual cost: (estimated - actual) / actual * 100. Positive means Lumina
+	// over-estimated. 0 when there's no actual cost.
+	// Type: Gauge
+	// Labels: account_id, account_name
+	MetricBillingAccountDriftPercent = "billing_account_drift_percent"
+
+	// MetricBillingSavingsPlanActualDailyCost tracks the Savings Plan commitment (USD)
+	// applied to EC2 instance usage on the reconciled day, from the Cost and Usage Report.
+	// Type: Gauge
+	// Labels: savings_plan_arn
+	MetricBillingSavingsPlanActualDailyCost = "billing_savings_plan_actual_daily_cost"
+
+	// MetricBillingSavingsPlanEstimatedDailyCost tracks Lumina's estimated Savings Plan
+	// commitment consumed (USD) on the reconciled day (the daily sum of
+	// savings_plan_current_utilization_rate).
+	// Type: Gauge
+	// Labels: savings_plan_arn
+	MetricBillingSavingsPlanEstimatedDailyCost = "billing_savings_plan_estimated_daily_cost"
+
+	// MetricBillingSavingsPlanDriftPercent tracks the estimate's error relative to the
+	// actual commitment consumed: (estimated - actual) / actual * 100.
+	// Type: Gauge
+	// Labels: savings_plan_arn
+	MetricBillingSavingsPlanDriftPercent = "billing_savings_plan_drift_percent"
+
+	// MetricBillingReconciledDate tracks which day the billing_* metrics describe,
+	// as the Unix timestamp of its start (UTC midnight).
+	// Type: Gauge
+	MetricBillingReconciledDate = "billing_reconciled_date_timestamp"
+)
+
 // Reserved Instances Metrics
 //
 // These metrics track AWS EC2 Reserved Instance inventory and provide both
@@ -159,6 +256,28 @@ const (
 	// Type: Gauge
 	// Labels: account_id, account_name, region, instance_family
 	MetricEC2ReservedInstanceCount = "ec2_reserved_instance_count"
+
+	// MetricEC2ReservedInstanceUtilizationPercent tracks how much of a Reserved
+	// Instance is covering running instances, as a percentage of its instance count.
+	// Size-flexible RIs can be partly used, e.g. 75 when an RI for 2x m5.xlarge
+	// covers one m5.xlarge and one m5.large.
+	// Type: Gauge
+	// Labels: reserved_instance_id, account_id, account_name, region, instance_type, availability_zone
+	MetricEC2ReservedInstanceUtilizationPercent = "ec2_reserved_instance_utilization_percent"
+
+	// MetricEC2ReservedInstanceUnusedHourlyCost tracks the on-demand value of the
+	// unused part of a Reserved Instance ($/hour). RIs are paid for whether used or
+	// not, so a non-zero value is coverage going to waste.
+	// Type: Gauge
+	// Labels: reserved_instance_id, account_id, account_name, region, instance_type, availability_zone
+	MetricEC2ReservedInstanceUnusedHourlyCost = "ec2_reserved_instance_unused_hourly_cost"
+
+	// MetricEC2CapacityReservationUnusedHourlyCost tracks the on-demand cost of the
+	// unused part of an On-Demand Capacity Reservation ($/hour). Reservations are
+	// billed for their full capacity, so a non-zero value is money spent on nothing.
+	// Type: Gauge
+	// Labels: capacity_reservation_id, account_id, account_name, region, instance_type, availability_zone
+	MetricEC2CapacityReservationUnusedHourlyCost = "ec2_capacity_reservation_unused_hourly_cost"
 )
 
 // EC2 Instance Metrics
@@ -191,4 +310,164 @@ const (
 	// Labels: instance_id, account_id, account_name, region, instance_type, cost_type,
 	//         availability_zone, lifecycle, pricing_accuracy
 	MetricEC2InstanceHourlyCost = "ec2_instance_hourly_cost"
+
+	// MetricEC2InstanceStorageHourlyCost tracks the hourly cost of the EBS volumes
+	// attached to each EC2 instance: provisioned storage, IOPS, and throughput.
+	// Multi-Attach volumes are split evenly between their instances. Only emitted
+	// when storage.enabled is set. Value is in USD/hour.
+	// Type: Gauge
+	// Labels: instance_id, account_id, account_name, region, instance_type,
+	//         availability_zone, node_name, cluster_name, host_name
+	MetricEC2InstanceStorageHourlyCost = "ec2_instance_storage_hourly_cost"
+
+	// MetricEC2InstanceTotalHourlyCost tracks the total cost of each EC2 instance:
+	// ec2_instance_hourly_cost plus ec2_instance_storage_hourly_cost. Only emitted
+	// when storage.enabled is set. Value is in USD/hour.
+	// Type: Gauge
+	// Labels: instance_id, account_id, account_name, region, instance_type,
+	//         availability_zone, node_name, cluster_name, host_name
+	MetricEC2InstanceTotalHourlyCost = "ec2_instance_total_hourly_cost"
+)
+
+// Pod Cost Allocation Metrics
+//
+// These metrics are only emitted when allocation.enabled is set. Each node's
+// ec2_instance_hourly_cost is divided among the pods scheduled on it by their CPU
+// and memory requests, so costs can be attributed to teams and workloads.
+
+const (
+	// MetricPodHourlyCost tracks a pod's share of its node's effective hourly cost,
+	// based on the pod's CPU and memory requests. Value is in USD/hour.
+	// Type: Gauge
+	// Labels: namespace, pod, node_name
+	MetricPodHourlyCost = "pod_hourly_cost"
+
+	// MetricNamespaceHourlyCost tracks the summed pod_hourly_cost of all pods in a
+	// namespace. Node capacity that no pod requested is reported under the
+	// namespace "__idle__", so the namespaces add up to the cost of all nodes.
+	// Type: Gauge
+	// Labels: namespace
+	MetricNamespaceHourlyCost = "namespace_hourly_cost"
+)
+
+// Karpenter NodePool Metrics
+//
+// These metrics are only emitted when karpenter.enabled is set. Instances are
+// grouped by the Karpenter NodePool that provisioned them, so NodePools can be
+// compared by their real discounted cost.
+
+const (
+	// MetricNodePoolHourlyCost tracks the summed ec2_instance_hourly_cost of a
+	// NodePool's instances. Value is in USD/hour.
+	// Type: Gauge
+	// Labels: nodepool
+	MetricNodePoolHourlyCost = "nodepool_hourly_cost"
+
+	// MetricNodePoolShelfHourlyCost tracks the summed on-demand price of a NodePool's
+	// instances, before any discounts. Value is in USD/hour.
+	// Type: Gauge
+	// Labels: nodepool
+	MetricNodePoolShelfHourlyCost = "nodepool_shelf_hourly_cost"
+
+	// MetricNodePoolCommitmentCoveragePercent tracks the share of a NodePool's shelf
+	// price on instances covered by Reserved Instances or Savings Plans (0-100).
+	// Type: Gauge
+	// Labels: nodepool
+	MetricNodePoolCommitmentCoveragePercent = "nodepool_commitment_coverage_percent"
+
+	// MetricNodePoolSpotPercent tracks the share of a NodePool's shelf price on
+	// spot instances (0-100).
+	// Type: Gauge
+	// Labels: nodepool
+	MetricNodePoolSpotPercent = "nodepool_spot_percent"
+
+	// MetricNodePoolNodeCount tracks the number of instances in a NodePool by their
+	// karpenter.sh/capacity-type ("on-demand", "spot", or "reserved").
+	// Type: Gauge
+	// Labels: nodepool, capacity_type
+	MetricNodePoolNodeCount = "nodepool_node_count"
+)
+
+// Unit Cost Metrics
+//
+// These metrics are only emitted when unitCosts.enabled is set. Instances are
+// grouped by instance type, region and cost_type, and the group's effective cost
+// is divided by its capacity. Each metric charges the whole instance cost to one
+// resource, so compare them across instance types rather than adding them up.
+
+const (
+	// MetricInstanceTypeVCPUHourlyCost tracks the effective cost per vCPU of an
+	// instance type's instances. Value is in USD/vCPU-hour.
+	// Type: Gauge
+	// Labels: instance_type, region, cost_type
+	MetricInstanceTypeVCPUHourlyCost = "instance_type_vcpu_hourly_cost"
+
+	// MetricInstanceTypeMemoryGiBHourlyCost tracks the effective cost per GiB of
+	// memory of an instance type's instances. Value is in USD/GiB-hour.
+	// Type: Gauge
+	// Labels: instance_type, region, cost_type
+	MetricInstanceTypeMemoryGiBHourlyCost = "instance_type_memory_gib_hourly_cost"
+
+	// MetricInstanceTypeGPUHourlyCost tracks the effective cost per GPU of an
+	// instance type's instances. Only instance types with GPUs are reported.
+	// Value is in USD/GPU-hour.
+	// Type: Gauge
+	// Labels: instance_type, region, cost_type
+	MetricInstanceTypeGPUHourlyCost = "instance_type_gpu_hourly_cost"
+)
+
+// Savings Plan Recommendation Metrics
+//
+// These metrics are only emitted when recommendations.enabled is set. Each series is
+// a suggested Savings Plan purchase sized against recent on-demand spillover, keyed
+// by plan type, term and scope. Recommendations for the compute and ec2_instance
+// types are alternatives sized against the same spillover; don't add them up.
+
+const (
+	// MetricSavingsPlanRecommendedHourlyCommitment tracks the recommended hourly
+	// commitment of a new Savings Plan (USD/hour).
+	// Type: Gauge
+	// Labels: type, term, region, instance_family
+	MetricSavingsPlanRecommendedHourlyCommitment = "savings_plan_recommended_hourly_commitment"
+
+	// MetricSavingsPlanRecommendedHourlySavings tracks the projected average saving of
+	// the recommended plan over the lookback window, net of the commitment (USD/hour).
+	// Type: Gauge
+	// Labels: type, term, region, instance_family
+	MetricSavingsPlanRecommendedHourlySavings = "savings_plan_recommended_hourly_savings"
+
+	// MetricSavingsPlanRecommendedUtilizationPercent tracks the projected average
+	// utilization of the recommended commitment over the lookback window (0-100).
+	// Type: Gauge
+	// Labels: type, term, region, instance_family
+	MetricSavingsPlanRecommendedUtilizationPercent = "savings_plan_recommended_utilization_percent"
+)
+
+// Commitment Expiration Forecast Metrics
+//
+// These metrics are only emitted when forecast.enabled is set. Each series is a
+// Savings Plan or Reserved Instance expiring within forecast.horizon. Expirations
+// are simulated cumulatively in order of expiry, so each value is the change from
+// the previous expiry, and the values of a commitment and every one expiring before
+// it add up to the total exposure at its expiry.
+
+const (
+	// MetricCommitmentExpirationTimestamp tracks when the commitment expires, as a
+	// Unix timestamp in seconds.
+	// Type: Gauge
+	// Labels: commitment_type, commitment_id, account_id, account_name
+	MetricCommitmentExpirationTimestamp = "commitment_expiration_timestamp"
+
+	// MetricCommitmentExpirationHourlyCostIncrease tracks the projected increase in
+	// total hourly cost when the commitment expires (USD/hour).
+	// Type: Gauge
+	// Labels: commitment_type, commitment_id, account_id, account_name
+	MetricCommitmentExpirationHourlyCostIncrease = "commitment_expiration_hourly_cost_increase"
+
+	// MetricCommitmentExpirationHourlySpilloverIncrease tracks the projected increase
+	// in instance cost paid at on-demand rates, not covered by any Reserved Instance
+	// or Savings Plan, when the commitment expires (USD/hour).
+	// Type: Gauge
+	// Labels: commitment_type, commitment_id, account_id, account_name
+	MetricCommitmentExpirationHourlySpilloverIncrease = "commitment_expiration_hourly_spillover_increase"
 )
@@ -51,6 +51,11 @@ func TestMetricNameConstants(t *testing.T) {
 			constant:     MetricLuminaDataLastSuccess,
 			actualMetric: m.DataLastSuccess,
 		},
+		{
+			name:         "CalculationInvariantViolationsTotal",
+			constant:     MetricLuminaCalculationInvariantViolationsTotal,
+			actualMetric: m.CalculationInvariantViolations,
+		},
 		// Account validation metrics
 		{
 			name:         "AccountValidationStatus",
@@ -93,6 +98,61 @@ func TestMetricNameConstants(t *testing.T) {
 			constant:     MetricSavingsPlanUtilizationPercent,
 			actualMetric: m.SavingsPlanUtilizationPercent,
 		},
+		{
+			name:         "SavingsPlanBillingHourCommitmentUsed",
+			constant:     MetricSavingsPlanBillingHourCommitmentUsed,
+			actualMetric: m.SavingsPlanBillingHourCommitmentUsed,
+		},
+		{
+			name:         "SavingsPlanBillingHourSpillover",
+			constant:     MetricSavingsPlanBillingHourSpillover,
+			actualMetric: m.SavingsPlanBillingHourSpillover,
+		},
+		{
+			name:         "SavingsPlanBillingHourUtilizationPercent",
+			constant:     MetricSavingsPlanBillingHourUtilizationPercent,
+			actualMetric: m.SavingsPlanBillingHourUtilizationPercent,
+		},
+		{
+			name:         "SavingsPlanBillingHourInstanceSeconds",
+			constant:     MetricSavingsPlanBillingHourInstanceSeconds,
+			actualMetric: m.SavingsPlanBillingHourInstanceSeconds,
+		},
+		{
+			name:         "BillingAccountActualDailyCost",
+			constant:     MetricBillingAccountActualDailyCost,
+			actualMetric: m.BillingAccountActualDailyCost,
+		},
+		{
+			name:         "BillingAccountEstimatedDailyCost",
+			constant:     MetricBillingAccountEstimatedDailyCost,
+			actualMetric: m.BillingAccountEstimatedDailyCost,
+		},
+		{
+			name:         "BillingAccountDriftPercent",
+			constant:     MetricBillingAccountDriftPercent,
+			actualMetric: m.BillingAccountDriftPercent,
+		},
+		{
+			name:         "BillingSavingsPlanActualDailyCost",
+			constant:     MetricBillingSavingsPlanActualDailyCost,
+			actualMetric: m.BillingSavingsPlanActualDailyCost,
+		},
+		{
+			name:         "BillingSavingsPlanEstimatedDailyCost",
+			constant:     MetricBillingSavingsPlanEstimatedDailyCost,
+			actualMetric: m.BillingSavingsPlanEstimatedDailyCost,
+		},
+		{
+			name:         "BillingSavingsPlanDriftPercent",
+			constant:     MetricBillingSavingsPlanDriftPercent,
+			actualMetric: m.BillingSavingsPlanDriftPercent,
+		},
+		{
+			name:         "BillingReconciledDate",
+			constant:     MetricBillingReconciledDate,
+			actualMetric: m.BillingReconciledDate,
+		},
 		// Reserved Instances metrics
 		{
 			name:         "EC2ReservedInstance",
@@ -104,6 +164,21 @@ func TestMetricNameConstants(t *testing.T) {
 			constant:     MetricEC2ReservedInstanceCount,
 			actualMetric: m.ReservedInstanceCount,
 		},
+		{
+			name:         "EC2ReservedInstanceUtilizationPercent",
+			constant:     MetricEC2ReservedInstanceUtilizationPercent,
+			actualMetric: m.ReservedInstanceUtilizationPercent,
+		},
+		{
+			name:         "EC2ReservedInstanceUnusedHourlyCost",
+			constant:     MetricEC2ReservedInstanceUnusedHourlyCost,
+			actualMetric: m.ReservedInstanceUnusedHourlyCost,
+		},
+		{
+			name:         "EC2CapacityReservationUnusedHourlyCost",
+			constant:     MetricEC2CapacityReservationUnusedHourlyCost,
+			actualMetric: m.CapacityReservationUnusedHourlyCost,
+		},
 		// EC2 Instance metrics
 		{
 			name:         "EC2Instance",
@@ -120,6 +195,100 @@ func TestMetricNameConstants(t *testing.T) {
 			constant:     MetricEC2InstanceHourlyCost,
 			actualMetric: m.EC2InstanceHourlyCost,
 		},
+		{
+			name:         "EC2InstanceStorageHourlyCost",
+			constant:     MetricEC2InstanceStorageHourlyCost,
+			actualMetric: m.EC2InstanceStorageHourlyCost,
+		},
+		{
+			name:         "EC2InstanceTotalHourlyCost",
+			constant:     MetricEC2InstanceTotalHourlyCost,
+			actualMetric: m.EC2InstanceTotalHourlyCost,
+		},
+		// Pod cost allocation metrics
+		{
+			name:         "PodHourlyCost",
+			constant:     MetricPodHourlyCost,
+			actualMetric: m.PodHourlyCost,
+		},
+		{
+			name:         "NamespaceHourlyCost",
+			constant:     MetricNamespaceHourlyCost,
+			actualMetric: m.NamespaceHourlyCost,
+		},
+		// Karpenter NodePool metrics
+		{
+			name:         "NodePoolHourlyCost",
+			constant:     MetricNodePoolHourlyCost,
+			actualMetric: m.NodePoolHourlyCost,
+		},
+		{
+			name:         "NodePoolShelfHourlyCost",
+			constant:     MetricNodePoolShelfHourlyCost,
+			actualMetric: m.NodePoolShelfHourlyCost,
+		},
+		{
+			name:         "NodePoolCommitmentCoveragePercent",
+			constant:     MetricNodePoolCommitmentCoveragePercent,
+			actualMetric: m.NodePoolCommitmentCoveragePercent,
+		},
+		{
+			name:         "NodePoolSpotPercent",
+			constant:     MetricNodePoolSpotPercent,
+			actualMetric: m.NodePoolSpotPercent,
+		},
+		{
+			name:         "NodePoolNodeCount",
+			constant:     MetricNodePoolNodeCount,
+			actualMetric: m.NodePoolNodeCount,
+		},
+		// Unit cost metrics
+		{
+			name:         "InstanceTypeVCPUHourlyCost",
+			constant:     MetricInstanceTypeVCPUHourlyCost,
+			actualMetric: m.InstanceTypeVCPUHourlyCost,
+		},
+		{
+			name:         "InstanceTypeMemoryGiBHourlyCost",
+			constant:     MetricInstanceTypeMemoryGiBHourlyCost,
+			actualMetric: m.InstanceTypeMemoryGiBHourlyCost,
+		},
+		{
+			name:         "InstanceTypeGPUHourlyCost",
+			constant:     MetricInstanceTypeGPUHourlyCost,
+			actualMetric: m.InstanceTypeGPUHourlyCost,
+		},
+		// Savings Plan recommendation metrics
+		{
+			name:         "SavingsPlanRecommendedHourlyCommitment",
+			constant:     MetricSavingsPlanRecommendedHourlyCommitment,
+			actualMetric: m.SavingsPlanRecommendedHourlyCommitment,
+		},
+		{
+			name:         "SavingsPlanRecommendedHourlySavings",
+			constant:     MetricSavingsPlanRecommendedHourlySavings,
+			actualMetric: m.SavingsPlanRecommendedHourlySavings,
+		},
+		{
+			name:         "SavingsPlanRecommendedUtilizationPercent",
+			constant:     MetricSavingsPlanRecommendedUtilizationPercent,
+			actualMetric: m.SavingsPlanRecommendedUtilizationPercent,
+		},
+		{
+			name:         "CommitmentExpirationTimestamp",
+			constant:     MetricCommitmentExpirationTimestamp,
+			actualMetric: m.CommitmentExpirationTimestamp,
+		},
+		{
+			name:         "CommitmentExpirationHourlyCostIncrease",
+			constant:     MetricCommitmentExpirationHourlyCostIncrease,
+			actualMetric: m.CommitmentExpirationHourlyCostIncrease,
+		},
+		{
+			name:         "CommitmentExpirationHourlySpilloverIncrease",
+			constant:     MetricCommitmentExpirationHourlySpilloverIncrease,
+			actualMetric: m.CommitmentExpirationHourlySpilloverIncrease,
+		},
 	}
 
 	for _, tt := range tests {
@@ -146,6 +315,7 @@ func TestMetricNameConstantsAreUnique(t *testing.T) {
 		MetricLuminaControllerRunning,
 		MetricLuminaDataFreshnessSeconds,
 		MetricLuminaDataLastSuccess,
+		MetricLuminaCalculationInvariantViolationsTotal,
 		MetricLuminaAccountValidationStatus,
 		MetricLuminaAccountValidationLastSuccess,
 		MetricLuminaAccountValidationDurationSeconds,
@@ -154,11 +324,43 @@ func TestMetricNameConstantsAreUnique(t *testing.T) {
 		MetricSavingsPlanCurrentUtilizationRate,
 		MetricSavingsPlanRemainingCapacity,
 		MetricSavingsPlanUtilizationPercent,
+		MetricSavingsPlanBillingHourCommitmentUsed,
+		MetricSavingsPlanBillingHourSpillover,
+		MetricSavingsPlanBillingHourUtilizationPercent,
+		MetricSavingsPlanBillingHourInstanceSeconds,
+		MetricBillingAccountActualDailyCost,
+		MetricBillingAccountEstimatedDailyCost,
+		MetricBillingAccountDriftPercent,
+		MetricBillingSavingsPlanActualDailyCost,
+		MetricBillingSavingsPlanEstimatedDailyCost,
+		MetricBillingSavingsPlanDriftPercent,
+		MetricBillingReconciledDate,
 		MetricEC2ReservedInstance,
 		MetricEC2ReservedInstanceCount,
+		MetricEC2ReservedInstanceUtilizationPercent,
+		MetricEC2ReservedInstanceUnusedHourlyCost,
+		MetricEC2CapacityReservationUnusedHourlyCost,
 		MetricEC2Instance,
 		MetricEC2InstanceCount,
 		MetricEC2InstanceHourlyCost,
+		MetricEC2InstanceStorageHourlyCost,
+		MetricEC2InstanceTotalHourlyCost,
+		MetricPodHourlyCost,
+		MetricNamespaceHourlyCost,
+		MetricNodePoolHourlyCost,
+		MetricNodePoolShelfHourlyCost,
+		MetricNodePoolCommitmentCoveragePercent,
+		MetricNodePoolSpotPercent,
+		MetricNodePoolNodeCount,
+		MetricInstanceTypeVCPUHourlyCost,
+		MetricInstanceTypeMemoryGiBHourlyCost,
+		MetricInstanceTypeGPUHourlyCost,
+		MetricSavingsPlanRecommendedHourlyCommitment,
+		MetricSavingsPlanRecommendedHourlySavings,
+		MetricSavingsPlanRecommendedUtilizationPercent,
+		MetricCommitmentExpirationTimestamp,
+		MetricCommitmentExpirationHourlyCostIncrease,
+		MetricCommitmentExpirationHourlySpilloverIncrease,
 	}
 
 	seen := make(map[string]bool)
@@ -174,22 +376,55 @@ func TestMetricNameConstantsAreUnique(t *testing.T) {
 // follow Prometheus naming conventions (lowercase with underscores).
 func TestMetricNameConstantsFormat(t *testing.T) {
 	constants := map[string]string{
-		"MetricLuminaControllerRunning":                MetricLuminaControllerRunning,
-		"MetricLuminaDataFreshnessSeconds":             MetricLuminaDataFreshnessSeconds,
-		"MetricLuminaDataLastSuccess":                  MetricLuminaDataLastSuccess,
-		"MetricLuminaAccountValidationStatus":          MetricLuminaAccountValidationStatus,
-		"MetricLuminaAccountValidationLastSuccess":     MetricLuminaAccountValidationLastSuccess,
-		"MetricLuminaAccountValidationDurationSeconds": MetricLuminaAccountValidationDurationSeconds,
-		"MetricSavingsPlanHourlyCommitment":            MetricSavingsPlanHourlyCommitment,
-		"MetricSavingsPlanRemainingHours":              MetricSavingsPlanRemainingHours,
-		"MetricSavingsPlanCurrentUtilizationRate":      MetricSavingsPlanCurrentUtilizationRate,
-		"MetricSavingsPlanRemainingCapacity":           MetricSavingsPlanRemainingCapacity,
-		"MetricSavingsPlanUtilizationPercent":          MetricSavingsPlanUtilizationPercent,
-		"MetricEC2ReservedInstance":                    MetricEC2ReservedInstance,
-		"MetricEC2ReservedInstanceCount":               MetricEC2ReservedInstanceCount,
-		"MetricEC2Instance":                            MetricEC2Instance,
-		"MetricEC2InstanceCount":                       MetricEC2InstanceCount,
-		"MetricEC2InstanceHourlyCost":                  MetricEC2InstanceHourlyCost,
+		"MetricLuminaControllerRunning":                     MetricLuminaControllerRunning,
+		"MetricLuminaDataFreshnessSeconds":                  MetricLuminaDataFreshnessSeconds,
+		"MetricLuminaDataLastSuccess":                       MetricLuminaDataLastSuccess,
+		"MetricLuminaCalculationInvariantViolationsTotal":   MetricLuminaCalculationInvariantViolationsTotal,
+		"MetricLuminaAccountValidationStatus":               MetricLuminaAccountValidationStatus,
+		"MetricLuminaAccountValidationLastSuccess":          MetricLuminaAccountValidationLastSuccess,
+		"MetricLuminaAccountValidationDurationSeconds":      MetricLuminaAccountValidationDurationSeconds,
+		"MetricSavingsPlanHourlyCommitment":                 MetricSavingsPlanHourlyCommitment,
+		"MetricSavingsPlanRemainingHours":                   MetricSavingsPlanRemainingHours,
+		"MetricSavingsPlanCurrentUtilizationRate":           MetricSavingsPlanCurrentUtilizationRate,
+		"MetricSavingsPlanRemainingCapacity":                MetricSavingsPlanRemainingCapacity,
+		"MetricSavingsPlanUtilizationPercent":               MetricSavingsPlanUtilizationPercent,
+		"MetricSavingsPlanBillingHourCommitmentUsed":        MetricSavingsPlanBillingHourCommitmentUsed,
+		"MetricSavingsPlanBillingHourSpillover":             MetricSavingsPlanBillingHourSpillover,
+		"MetricSavingsPlanBillingHourUtilizationPercent":    MetricSavingsPlanBillingHourUtilizationPercent,
+		"MetricSavingsPlanBillingHourInstanceSeconds":       MetricSavingsPlanBillingHourInstanceSeconds,
+		"MetricBillingAccountActualDailyCost":               MetricBillingAccountActualDailyCost,
+		"MetricBillingAccountEstimatedDailyCost":            MetricBillingAccountEstimatedDailyCost,
+		"MetricBillingAccountDriftPercent":                  MetricBillingAccountDriftPercent,
+		"MetricBillingSavingsPlanActualDailyCost":           MetricBillingSavingsPlanActualDailyCost,
+		"MetricBillingSavingsPlanEstimatedDailyCost":        MetricBillingSavingsPlanEstimatedDailyCost,
+		"MetricBillingSavingsPlanDriftPercent":              MetricBillingSavingsPlanDriftPercent,
+		"MetricBillingReconciledDate":                       MetricBillingReconciledDate,
+		"MetricEC2ReservedInstance":                         MetricEC2ReservedInstance,
+		"MetricEC2ReservedInstanceCount":                    MetricEC2ReservedInstanceCount,
+		"MetricEC2ReservedInstanceUtilizationPercent":       MetricEC2ReservedInstanceUtilizationPercent,
+		"MetricEC2ReservedInstanceUnusedHourlyCost":         MetricEC2ReservedInstanceUnusedHourlyCost,
+		"MetricEC2CapacityReservationUnusedHourlyCost":      MetricEC2CapacityReservationUnusedHourlyCost,
+		"MetricEC2Instance":                                 MetricEC2Instance,
+		"MetricEC2InstanceCount":                            MetricEC2InstanceCount,
+		"MetricEC2InstanceHourlyCost":                       MetricEC2InstanceHourlyCost,
+		"MetricEC2InstanceStorageHourlyCost":                MetricEC2InstanceStorageHourlyCost,
+		"MetricEC2InstanceTotalHourlyCost":                  MetricEC2InstanceTotalHourlyCost,
+		"MetricPodHourlyCost":                               MetricPodHourlyCost,
+		"MetricNamespaceHourlyCost":                         MetricNamespaceHourlyCost,
+		"MetricNodePoolHourlyCost":                          MetricNodePoolHourlyCost,
+		"MetricNodePoolShelfHourlyCost":                     MetricNodePoolShelfHourlyCost,
+		"MetricNodePoolCommitmentCoveragePercent":           MetricNodePoolCommitmentCoveragePercent,
+		"MetricNodePoolSpotPercent":                         MetricNodePoolSpotPercent,
+		"MetricNodePoolNodeCount":                           MetricNodePoolNodeCount,
+		"MetricInstanceTypeVCPUHourlyCost":                  MetricInstanceTypeVCPUHourlyCost,
+		"MetricInstanceTypeMemoryGiBHourlyCost":             MetricInstanceTypeMemoryGiBHourlyCost,
+		"MetricInstanceTypeGPUHourlyCost":                   MetricInstanceTypeGPUHourlyCost,
+		"MetricSavingsPlanRecommendedHourlyCommitment":      MetricSavingsPlanRecommendedHourlyCommitment,
+		"MetricSavingsPlanRecommendedHourlySavings":         MetricSavingsPlanRecommendedHourlySavings,
+		"MetricSavingsPlanRecommendedUtilizationPercent":    MetricSavingsPlanRecommendedUtilizationPercent,
+		"MetricCommitmentExpirationTimestamp":               MetricCommitmentExpirationTimestamp,
+		"MetricCommitmentExpirationHourlyCostIncrease":      MetricCommitmentExpirationHourlyCostIncrease,
+		"MetricCommitmentExpirationHourlySpilloverIncrease": MetricCommitmentExpirationHourlySpilloverIncrease,
 	}
 
 	for name, value := range constants {
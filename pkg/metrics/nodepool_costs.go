@@ -0,0 +1,52 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UpdateNodePoolCostMetrics updates the nodepool_* metrics from per-NodePool cost
+// rollups. This is called by the CostReconciler after each cost calculation when
+// karpenter.enabled is set.
+//
+// All metrics are reset first, so deleted NodePools and capacity types a NodePool
+// no longer runs disappear.
+func (m *Metrics) UpdateNodePoolCostMetrics(pools map[string]cost.NodePoolCost) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	m.NodePoolHourlyCost.Reset()
+	m.NodePoolShelfHourlyCost.Reset()
+	m.NodePoolCommitmentCoveragePercent.Reset()
+	m.NodePoolSpotPercent.Reset()
+	m.NodePoolNodeCount.Reset()
+
+	for name, pool := range pools {
+		labels := prometheus.Labels{LabelNodePool: name}
+		m.NodePoolHourlyCost.With(labels).Set(pool.EffectiveCost)
+		m.NodePoolShelfHourlyCost.With(labels).Set(pool.ShelfPrice)
+		m.NodePoolCommitmentCoveragePercent.With(labels).Set(pool.CommitmentCoveragePercent())
+		m.NodePoolSpotPercent.With(labels).Set(pool.SpotPercent())
+
+		for capacityType, count := range pool.NodeCounts {
+			m.NodePoolNodeCount.With(prometheus.Labels{
+				LabelNodePool:     name,
+				LabelCapacityType: capacityType,
+			}).Set(float64(count))
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateNodePoolCostMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	m.UpdateNodePoolCostMetrics(map[string]cost.NodePoolCost{
+		"default": {
+			NodePool:             "default",
+			EffectiveCost:        3.02,
+			ShelfPrice:           5.00,
+			CommitmentShelfPrice: 2.00,
+			SpotShelfPrice:       1.00,
+			NodeCounts:           map[string]int{"on-demand": 3, "spot": 1},
+		},
+	})
+
+	assert.Equal(t, 3.02, testutil.ToFloat64(m.NodePoolHourlyCost.WithLabelValues("default")))
+	assert.Equal(t, 5.00, testutil.ToFloat64(m.NodePoolShelfHourlyCost.WithLabelValues("default")))
+	assert.InDelta(t, 40.0, testutil.ToFloat64(m.NodePoolCommitmentCoveragePercent.WithLabelValues("default")), 0.0001)
+	assert.InDelta(t, 20.0, testutil.ToFloat64(m.NodePoolSpotPercent.WithLabelValues("default")), 0.0001)
+	assert.Equal(t, 3.0, testutil.ToFloat64(m.NodePoolNodeCount.With(prometheus.Labels{
+		"nodepool":      "default",
+		"capacity_type": "on-demand",
+	})))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.NodePoolNodeCount))
+
+	// NodePools missing from the next update are removed
+	m.UpdateNodePoolCostMetrics(nil)
+	assert.Equal(t, 0, testutil.CollectAndCount(m.NodePoolHourlyCost))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.NodePoolNodeCount))
+}
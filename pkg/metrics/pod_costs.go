@@ -0,0 +1,51 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UpdatePodCostMetrics updates the pod_hourly_cost and namespace_hourly_cost metrics
+// from per-node cost allocations. This is called by the CostReconciler after each
+// cost calculation when allocation.enabled is set.
+//
+// Both metrics are reset first, so deleted pods and emptied namespaces disappear.
+// Idle node capacity is only reported in namespace_hourly_cost (under
+// cost.IdleNamespace), not as a pod.
+func (m *Metrics) UpdatePodCostMetrics(allocations []cost.NodeAllocation) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	m.PodHourlyCost.Reset()
+	m.NamespaceHourlyCost.Reset()
+
+	for _, allocation := range allocations {
+		for _, pod := range allocation.Pods {
+			m.PodHourlyCost.With(prometheus.Labels{
+				LabelNamespace:              pod.Namespace,
+				LabelPod:                    pod.Name,
+				m.config.GetNodeNameLabel(): allocation.NodeName,
+			}).Set(pod.HourlyCost)
+		}
+	}
+
+	for namespace, hourlyCost := range cost.NamespaceCosts(allocations) {
+		m.NamespaceHourlyCost.With(prometheus.Labels{
+			LabelNamespace: namespace,
+		}).Set(hourlyCost)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePodCostMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	m.UpdatePodCostMetrics([]cost.NodeAllocation{
+		{
+			NodeName:   "node-1",
+			InstanceID: "i-001",
+			Pods: []cost.PodCost{
+				{Namespace: "web", Name: "frontend-1", HourlyCost: 0.30},
+				{Namespace: "batch", Name: "worker-1", HourlyCost: 0.20},
+			},
+			IdleCost: 0.50,
+		},
+		{
+			NodeName:   "node-2",
+			InstanceID: "i-002",
+			Pods: []cost.PodCost{
+				{Namespace: "web", Name: "frontend-2", HourlyCost: 0.40},
+			},
+			IdleCost: 0.10,
+		},
+	})
+
+	assert.Equal(t, 3, testutil.CollectAndCount(m.PodHourlyCost))
+	assert.Equal(t, 0.30, testutil.ToFloat64(m.PodHourlyCost.With(prometheus.Labels{
+		"namespace": "web",
+		"pod":       "frontend-1",
+		"node_name": "node-1",
+	})))
+
+	assert.Equal(t, 3, testutil.CollectAndCount(m.NamespaceHourlyCost))
+	assert.InDelta(t, 0.70, testutil.ToFloat64(m.NamespaceHourlyCost.WithLabelValues("web")), 0.0001)
+	assert.InDelta(t, 0.60, testutil.ToFloat64(m.NamespaceHourlyCost.WithLabelValues(cost.IdleNamespace)), 0.0001)
+
+	// Deleted pods disappear on the next update
+	m.UpdatePodCostMetrics(nil)
+	assert.Equal(t, 0, testutil.CollectAndCount(m.PodHourlyCost))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.NamespaceHourlyCost))
+}
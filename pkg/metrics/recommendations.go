@@ -0,0 +1,48 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/nextdoor/lumina/pkg/recommend"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UpdateRecommendationMetrics updates the savings_plan_recommended_* metrics from a
+// recommend.Report. This is called by the CostReconciler after each cost calculation
+// when recommendations.enabled is set.
+//
+// All three metrics are reset first, so plans that are no longer worth buying
+// disappear instead of keeping their last values.
+func (m *Metrics) UpdateRecommendationMetrics(report recommend.Report) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	m.SavingsPlanRecommendedHourlyCommitment.Reset()
+	m.SavingsPlanRecommendedHourlySavings.Reset()
+	m.SavingsPlanRecommendedUtilizationPercent.Reset()
+
+	for _, rec := range report.Recommendations {
+		labels := prometheus.Labels{
+			LabelType:                 rec.Type,
+			LabelTerm:                 rec.Term,
+			m.config.GetRegionLabel(): rec.Region,
+			LabelInstanceFamily:       rec.InstanceFamily,
+		}
+
+		m.SavingsPlanRecommendedHourlyCommitment.With(labels).Set(rec.HourlyCommitment)
+		m.SavingsPlanRecommendedHourlySavings.With(labels).Set(rec.HourlySavings)
+		m.SavingsPlanRecommendedUtilizationPercent.With(labels).Set(rec.UtilizationPercent)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/nextdoor/lumina/pkg/recommend"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateRecommendationMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	m.UpdateRecommendationMetrics(recommend.Report{
+		ObservedHours: 168,
+		Recommendations: []recommend.Recommendation{
+			{
+				Type: recommend.TypeCompute, Term: recommend.Term1Year, Region: "all", InstanceFamily: "all",
+				HourlyCommitment: 7.2, HourlySavings: 2.8, UtilizationPercent: 99.5,
+			},
+			{
+				Type: recommend.TypeEC2Instance, Term: recommend.Term3Year, Region: "us-west-2", InstanceFamily: "m5",
+				HourlyCommitment: 5.0, HourlySavings: 5.0, UtilizationPercent: 100,
+			},
+		},
+	})
+
+	assert.Equal(t, 2, testutil.CollectAndCount(m.SavingsPlanRecommendedHourlyCommitment))
+	assert.Equal(t, 7.2, testutil.ToFloat64(
+		m.SavingsPlanRecommendedHourlyCommitment.WithLabelValues("compute", "1y", "all", "all")))
+	assert.Equal(t, 5.0, testutil.ToFloat64(
+		m.SavingsPlanRecommendedHourlySavings.WithLabelValues("ec2_instance", "3y", "us-west-2", "m5")))
+	assert.Equal(t, 99.5, testutil.ToFloat64(
+		m.SavingsPlanRecommendedUtilizationPercent.WithLabelValues("compute", "1y", "all", "all")))
+
+	// Recommendations that drop out are removed
+	m.UpdateRecommendationMetrics(recommend.Report{})
+	assert.Equal(t, 0, testutil.CollectAndCount(m.SavingsPlanRecommendedHourlyCommitment))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.SavingsPlanRecommendedHourlySavings))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.SavingsPlanRecommendedUtilizationPercent))
+}
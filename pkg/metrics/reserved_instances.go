@@ -39,6 +39,9 @@ import (
 //	ris := rispCache.GetAllReservedInstances()
 //	metrics.UpdateReservedInstanceMetrics(ris)
 func (m *Metrics) UpdateReservedInstanceMetrics(ris []aws.ReservedInstance) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	// Reset all existing RI metrics to ensure deleted/expired RIs are removed.
 	// This is more reliable than trying to track which specific RIs were deleted.
 	m.ReservedInstance.Reset()
@@ -0,0 +1,56 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UpdateSavingsPlanLedgerMetrics updates the billing-hour Savings Plan metrics from
+// the cost.SavingsPlanLedger. This is called by the CostReconciler after each cost
+// calculation when the ledger is enabled.
+//
+// Like UpdateInstanceCostMetrics, this resets all existing billing-hour metrics first
+// so that expired or removed Savings Plans disappear.
+//
+// The function handles four metrics:
+//   - savings_plan_billing_hour_commitment_used: Commitment consumed this hour ($)
+//   - savings_plan_billing_hour_spillover: Usage beyond the commitment this hour ($)
+//   - savings_plan_billing_hour_utilization_percent: Projected end-of-hour utilization (0-100)
+//   - savings_plan_billing_hour_instance_seconds: Instance-seconds covered this hour
+func (m *Metrics) UpdateSavingsPlanLedgerMetrics(entries map[string]cost.SavingsPlanLedgerEntry) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	m.SavingsPlanBillingHourCommitmentUsed.Reset()
+	m.SavingsPlanBillingHourSpillover.Reset()
+	m.SavingsPlanBillingHourUtilizationPercent.Reset()
+	m.SavingsPlanBillingHourInstanceSeconds.Reset()
+
+	for _, entry := range entries {
+		labels := prometheus.Labels{
+			LabelSavingsPlanARN:            entry.SavingsPlanARN,
+			m.config.GetAccountIDLabel():   entry.AccountID,
+			m.config.GetAccountNameLabel(): entry.AccountName,
+			LabelType:                      normalizeSPType(entry.Type),
+		}
+
+		m.SavingsPlanBillingHourCommitmentUsed.With(labels).Set(entry.CommitmentUsed)
+		m.SavingsPlanBillingHourSpillover.With(labels).Set(entry.Spillover)
+		m.SavingsPlanBillingHourUtilizationPercent.With(labels).Set(entry.ProjectedUtilizationPercent)
+		m.SavingsPlanBillingHourInstanceSeconds.With(labels).Set(entry.InstanceSeconds)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSavingsPlanLedgerMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	m.UpdateSavingsPlanLedgerMetrics(map[string]cost.SavingsPlanLedgerEntry{
+		"arn:aws:savingsplans::111111111111:savingsplan/abc": {
+			SavingsPlanARN:              "arn:aws:savingsplans::111111111111:savingsplan/abc",
+			AccountID:                   "111111111111",
+			AccountName:                 "test-account",
+			Type:                        "Compute",
+			HourlyCommitment:            10.00,
+			CommitmentUsed:              6.50,
+			Spillover:                   0.25,
+			ProjectedUtilizationPercent: 90.0,
+			InstanceSeconds:             7200,
+		},
+	})
+
+	labels := prometheus.Labels{
+		"savings_plan_arn": "arn:aws:savingsplans::111111111111:savingsplan/abc",
+		"account_id":       "111111111111",
+		"account_name":     "test-account",
+		"type":             "compute",
+	}
+	assert.Equal(t, 6.50, testutil.ToFloat64(m.SavingsPlanBillingHourCommitmentUsed.With(labels)))
+	assert.Equal(t, 0.25, testutil.ToFloat64(m.SavingsPlanBillingHourSpillover.With(labels)))
+	assert.Equal(t, 90.0, testutil.ToFloat64(m.SavingsPlanBillingHourUtilizationPercent.With(labels)))
+	assert.Equal(t, 7200.0, testutil.ToFloat64(m.SavingsPlanBillingHourInstanceSeconds.With(labels)))
+
+	// A removed Savings Plan disappears on the next update
+	m.UpdateSavingsPlanLedgerMetrics(map[string]cost.SavingsPlanLedgerEntry{})
+	assert.Equal(t, 0, testutil.CollectAndCount(m.SavingsPlanBillingHourCommitmentUsed))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.SavingsPlanBillingHourInstanceSeconds))
+}
@@ -39,6 +39,9 @@ const (
 // This should be called by the RISP reconciler after successfully updating
 // the SP cache (typically hourly).
 //
+// All SP types are reported, including SageMaker and Database SPs, which don't
+// cover EC2 instances and so have no utilization metrics.
+//
 // Note: This function handles INVENTORY metrics only. Utilization metrics
 // (current usage, remaining capacity, utilization %) will be added in Phase 6
 // after cost calculation is implemented.
@@ -48,6 +51,9 @@ const (
 //	sps := rispCache.GetAllSavingsPlans()
 //	metrics.UpdateSavingsPlansInventoryMetrics(sps)
 func (m *Metrics) UpdateSavingsPlansInventoryMetrics(sps []aws.SavingsPlan) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	// Reset all existing SP metrics to ensure deleted/expired SPs are removed.
 	// This is more reliable than trying to track which specific SPs were deleted.
 	m.SavingsPlanCommitment.Reset()
@@ -62,7 +68,7 @@ func (m *Metrics) UpdateSavingsPlansInventoryMetrics(sps []aws.SavingsPlan) {
 			continue
 		}
 
-		// Determine type label: "ec2_instance" or "compute"
+		// Determine type label: "ec2_instance", "compute", "sagemaker" or "database"
 		// AWS uses PascalCase, we normalize to snake_case for consistency
 		spType := normalizeSPType(sp.SavingsPlanType)
 
@@ -71,7 +77,8 @@ func (m *Metrics) UpdateSavingsPlansInventoryMetrics(sps []aws.SavingsPlan) {
 		instanceFamily := sp.InstanceFamily
 
 		// For Compute SPs: apply globally to all regions and families
-		if spType == "compute" {
+		// SageMaker and Database SPs aren't tied to a region or EC2 family either
+		if spType == "compute" || spType == "sagemaker" || spType == "database" {
 			region = spLabelAll
 			instanceFamily = spLabelAll
 		}
@@ -100,14 +107,18 @@ func (m *Metrics) UpdateSavingsPlansInventoryMetrics(sps []aws.SavingsPlan) {
 }
 
 // normalizeSPType converts AWS SavingsPlan type to normalized form.
-// AWS API returns: "EC2Instance" or "Compute"
-// We normalize to: "ec2_instance" or "compute"
+// AWS API returns: "EC2Instance", "Compute", "SageMaker" or "Database"
+// We normalize to: "ec2_instance", "compute", "sagemaker" or "database"
 func normalizeSPType(spType string) string {
 	switch spType {
-	case "EC2Instance":
+	case aws.SavingsPlanTypeEC2Instance:
 		return "ec2_instance"
-	case "Compute":
+	case aws.SavingsPlanTypeCompute:
 		return "compute"
+	case aws.SavingsPlanTypeSageMaker:
+		return "sagemaker"
+	case aws.SavingsPlanTypeDatabase:
+		return "database"
 	default:
 		// Unknown type - return as-is (defensive programming)
 		return spType
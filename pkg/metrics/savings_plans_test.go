@@ -294,6 +294,33 @@ func TestUpdateSavingsPlansInventoryMetrics_EC2InstanceVsCompute(t *testing.T) {
 	})))
 }
 
+func TestUpdateSavingsPlansInventoryMetrics_SageMaker(t *testing.T) {
+	// SageMaker SPs don't cover instances but are still part of the inventory
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	now := time.Now()
+
+	m.UpdateSavingsPlansInventoryMetrics([]aws.SavingsPlan{{
+		SavingsPlanARN:  "arn:aws:savingsplans::111111111111:savingsplan/sagemaker",
+		SavingsPlanType: "SageMaker",
+		State:           "active",
+		Commitment:      25.00,
+		End:             now.Add(100 * time.Hour),
+		AccountID:       "111111111111",
+		AccountName:     "test-account",
+	}})
+
+	assert.Equal(t, 25.00, testutil.ToFloat64(m.SavingsPlanCommitment.With(prometheus.Labels{
+		"savings_plan_arn": "arn:aws:savingsplans::111111111111:savingsplan/sagemaker",
+		"account_id":       "111111111111",
+		"account_name":     "test-account",
+		"type":             "sagemaker",
+		"region":           "all",
+		"instance_family":  "all",
+	})))
+}
+
 //nolint:dupl // Test data structures are intentionally similar
 func TestUpdateSavingsPlansInventoryMetrics_MultipleAccounts(t *testing.T) {
 	// Test SPs across multiple accounts
@@ -516,6 +543,16 @@ func TestNormalizeSPType(t *testing.T) {
 			spType:   "Compute",
 			expected: "compute",
 		},
+		{
+			name:     "SageMaker type",
+			spType:   "SageMaker",
+			expected: "sagemaker",
+		},
+		{
+			name:     "Database type",
+			spType:   "Database",
+			expected: "database",
+		},
 		{
 			name:     "unknown type (defensive)",
 			spType:   "Unknown",
@@ -0,0 +1,48 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UpdateUnitCostMetrics updates the instance_type_*_hourly_cost metrics from
+// per-instance-type unit costs. This is called by the CostReconciler after each
+// cost calculation when unitCosts.enabled is set.
+//
+// All metrics are reset first, so instance types that are no longer running
+// disappear.
+func (m *Metrics) UpdateUnitCostMetrics(unitCosts []cost.UnitCost) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	m.InstanceTypeVCPUHourlyCost.Reset()
+	m.InstanceTypeMemoryGiBHourlyCost.Reset()
+	m.InstanceTypeGPUHourlyCost.Reset()
+
+	for _, uc := range unitCosts {
+		labels := prometheus.Labels{
+			LabelInstanceType:         uc.InstanceType,
+			m.config.GetRegionLabel(): uc.Region,
+			LabelCostType:             string(uc.CoverageType),
+		}
+		m.InstanceTypeVCPUHourlyCost.With(labels).Set(uc.PerVCPUHour)
+		m.InstanceTypeMemoryGiBHourlyCost.With(labels).Set(uc.PerGiBHour)
+		if uc.PerGPUHour > 0 {
+			m.InstanceTypeGPUHourlyCost.With(labels).Set(uc.PerGPUHour)
+		}
+	}
+}
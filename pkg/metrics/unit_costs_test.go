@@ -0,0 +1,65 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateUnitCostMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg, newTestConfig())
+
+	m.UpdateUnitCostMetrics([]cost.UnitCost{
+		{
+			InstanceType: "m5.xlarge",
+			Region:       "us-west-2",
+			CoverageType: cost.CoverageComputeSavingsPlan,
+			PerVCPUHour:  0.03,
+			PerGiBHour:   0.0075,
+		},
+		{
+			InstanceType: "g5.xlarge",
+			Region:       "us-west-2",
+			CoverageType: cost.CoverageSpot,
+			PerVCPUHour:  0.10,
+			PerGiBHour:   0.025,
+			PerGPUHour:   0.40,
+		},
+	})
+
+	labels := prometheus.Labels{"instance_type": "m5.xlarge", "region": "us-west-2", "cost_type": "compute_savings_plan"}
+	assert.Equal(t, 0.03, testutil.ToFloat64(m.InstanceTypeVCPUHourlyCost.With(labels)))
+	assert.Equal(t, 0.0075, testutil.ToFloat64(m.InstanceTypeMemoryGiBHourlyCost.With(labels)))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.InstanceTypeVCPUHourlyCost))
+
+	// Only GPU instance types have a per-GPU cost
+	assert.Equal(t, 1, testutil.CollectAndCount(m.InstanceTypeGPUHourlyCost))
+	assert.Equal(t, 0.40, testutil.ToFloat64(m.InstanceTypeGPUHourlyCost.With(prometheus.Labels{
+		"instance_type": "g5.xlarge",
+		"region":        "us-west-2",
+		"cost_type":     "spot",
+	})))
+
+	// Instance types missing from the next update are removed
+	m.UpdateUnitCostMetrics(nil)
+	assert.Equal(t, 0, testutil.CollectAndCount(m.InstanceTypeVCPUHourlyCost))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.InstanceTypeGPUHourlyCost))
+}
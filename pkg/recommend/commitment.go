@@ -0,0 +1,95 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommend
+
+import (
+	"math"
+	"slices"
+)
+
+// commitmentGranularity is the smallest commitment increment AWS accepts ($/hour).
+const commitmentGranularity = 0.001
+
+// recommend sizes a Savings Plan against hourly on-demand spend rates ($/hour) at
+// the given rate multiplier. Returns false if no commitment would save money.
+// The caller fills in the plan's type, term and scope.
+func recommend(spend []float64, multiplier float64) (Recommendation, bool) {
+	if len(spend) == 0 || multiplier <= 0 || multiplier >= 1 {
+		return Recommendation{}, false
+	}
+
+	commitment := optimalCommitment(spend, multiplier)
+	if commitment < commitmentGranularity {
+		return Recommendation{}, false
+	}
+	savings, utilization := evaluate(spend, commitment, multiplier)
+	if savings <= 0 {
+		return Recommendation{}, false
+	}
+
+	var total float64
+	for _, s := range spend {
+		total += s
+	}
+	onDemand := total / float64(len(spend))
+	return Recommendation{
+		HourlyCommitment:   commitment,
+		RateMultiplier:     multiplier,
+		OnDemandSpend:      onDemand,
+		HourlySavings:      savings,
+		SavingsPercent:     savings / onDemand * 100,
+		UtilizationPercent: utilization,
+	}, true
+}
+
+// optimalCommitment returns the hourly commitment that would have saved the most
+// over the given hours, rounded down to commitmentGranularity.
+//
+// A commitment C covers up to C/multiplier of on-demand spend each hour and is paid
+// whether used or not, so an hour spending s saves min(s, C/multiplier) - C. Total
+// savings are concave in C and piecewise linear, with breaks where C/multiplier
+// equals an hour's spend, so the optimum is at one of those breaks. (Equivalently,
+// C/multiplier is the spend exceeded in a multiplier fraction of the hours.)
+func optimalCommitment(spend []float64, multiplier float64) float64 {
+	sorted := slices.Sorted(slices.Values(spend))
+	n := float64(len(sorted))
+
+	var best, bestCoverage, below float64
+	for i, coverage := range sorted {
+		// Hours before i spend less than coverage and are fully covered; the rest
+		// are covered up to coverage
+		savings := below + (n-float64(i))*coverage - n*multiplier*coverage
+		if savings > best {
+			best, bestCoverage = savings, coverage
+		}
+		below += coverage
+	}
+	// The epsilon keeps float error (10 × 0.72 = 7.1999...) from dropping an increment
+	return math.Floor(bestCoverage*multiplier/commitmentGranularity+1e-9) * commitmentGranularity
+}
+
+// evaluate returns the average hourly savings ($/hour) and commitment utilization
+// (0-100) of a commitment over the given hours.
+func evaluate(spend []float64, commitment, multiplier float64) (savings, utilization float64) {
+	coverage := commitment / multiplier
+	var covered float64
+	for _, s := range spend {
+		covered += math.Min(s, coverage)
+	}
+	n := float64(len(spend))
+	savings = (covered - n*commitment) / n
+	utilization = covered * multiplier / (n * commitment) * 100
+	return savings, utilization
+}
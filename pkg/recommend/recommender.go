@@ -0,0 +1,300 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recommend suggests Savings Plan purchases from recent on-demand spillover.
+//
+// Spillover is the part of each instance's cost that no Reserved Instance or
+// Savings Plan covers (cost.InstanceCost.EffectiveCost less the Savings Plan
+// commitment it consumes). Recommender records it per hour, and for each plan type
+// and term finds the hourly commitment that would have saved the most over the
+// lookback window, had it been bought at its start.
+//
+// Recommendations for the two plan types are alternatives, not a combined purchase:
+// both are sized against the same spillover, the way AWS Cost Explorer presents them.
+package recommend
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/cost"
+)
+
+// Savings Plan types, matching the type label of the savings_plan_* metrics.
+const (
+	TypeCompute     = "compute"
+	TypeEC2Instance = "ec2_instance"
+)
+
+// Savings Plan terms.
+const (
+	Term1Year = "1y"
+	Term3Year = "3y"
+)
+
+const (
+	// MinObservedHours is how many complete hours of spillover must be recorded
+	// before anything is recommended. Less than a day misses the daily usage cycle.
+	MinObservedHours = 24
+
+	// scopeAll is the Region and InstanceFamily of Compute Savings Plan
+	// recommendations, which apply to any region and family.
+	scopeAll = "all"
+)
+
+// RateMultipliers are the fractions of the on-demand price paid under newly
+// purchased Savings Plans of one term (e.g., 0.72 for a 28% discount).
+type RateMultipliers struct {
+	Compute     float64
+	EC2Instance float64
+}
+
+// Options configures a Recommender.
+type Options struct {
+	// Lookback is how many hours of spillover are kept and analyzed
+	Lookback time.Duration
+
+	// OneYear and ThreeYear are the rate multipliers assumed for each term
+	OneYear   RateMultipliers
+	ThreeYear RateMultipliers
+}
+
+// Recommendation is a suggested Savings Plan purchase.
+type Recommendation struct {
+	// Type is TypeCompute or TypeEC2Instance
+	Type string `json:"type"`
+
+	// Term is Term1Year or Term3Year
+	Term string `json:"term"`
+
+	// Region and InstanceFamily scope an EC2 Instance Savings Plan; both are "all"
+	// for Compute Savings Plans
+	Region         string `json:"region"`
+	InstanceFamily string `json:"instance_family"`
+
+	// HourlyCommitment is the recommended commitment ($/hour), rounded down to the
+	// $0.001 granularity AWS accepts
+	HourlyCommitment float64 `json:"hourly_commitment"`
+
+	// RateMultiplier is the assumed fraction of the on-demand price paid under the plan
+	RateMultiplier float64 `json:"rate_multiplier"`
+
+	// OnDemandSpend is the average eligible spillover over the lookback ($/hour)
+	OnDemandSpend float64 `json:"on_demand_spend"`
+
+	// HourlySavings is the projected average saving ($/hour), net of the commitment
+	HourlySavings float64 `json:"hourly_savings"`
+
+	// SavingsPercent is HourlySavings as a percentage of OnDemandSpend
+	SavingsPercent float64 `json:"savings_percent"`
+
+	// UtilizationPercent is the projected average use of the commitment (0-100)
+	UtilizationPercent float64 `json:"utilization_percent"`
+}
+
+// Report holds the recommendations computed from the recorded spillover.
+type Report struct {
+	// ObservedHours is how many complete hours the recommendations are based on
+	ObservedHours int `json:"observed_hours"`
+
+	// Recommendations is sorted by type, term, then descending savings. Empty
+	// until MinObservedHours have been recorded.
+	Recommendations []Recommendation `json:"recommendations"`
+}
+
+// usageKey is the scope of an EC2 Instance Savings Plan.
+type usageKey struct {
+	Region         string
+	InstanceFamily string
+}
+
+// hourUsage is the spillover recorded during one clock hour.
+type hourUsage struct {
+	// observedSeconds is how much of the hour was recorded
+	observedSeconds float64
+
+	// spend is the spillover ($) by region and family
+	spend map[usageKey]float64
+}
+
+// Recommender records on-demand spillover and recommends Savings Plans to cover it.
+//
+// Each recorded result is assumed to hold from the time it was recorded until the
+// next one, like cost.DailyEstimates. Spillover is kept in memory in hourly buckets
+// for the lookback window, so it restarts empty with the controller.
+//
+// Thread-safety: All methods are safe for concurrent access.
+type Recommender struct {
+	options Options
+
+	mu sync.Mutex
+
+	// lastRecorded is when the most recent result was recorded
+	lastRecorded time.Time
+
+	// current is the most recent spillover ($/hour); it applies from lastRecorded onwards
+	current map[usageKey]float64
+
+	// hours holds the recorded spillover keyed by the start of the hour
+	hours map[time.Time]*hourUsage
+}
+
+// NewRecommender creates a Recommender with no recorded spillover.
+func NewRecommender(options Options) *Recommender {
+	return &Recommender{
+		options: options,
+		hours:   make(map[time.Time]*hourUsage),
+	}
+}
+
+// Record adds the previous result's spillover up to now, then makes result the
+// current one. Calls with a now earlier than the previous call add nothing.
+func (r *Recommender) Record(result cost.CalculationResult, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.lastRecorded.IsZero() {
+		// Split the interval at each hour so every hour gets its own share
+		for r.lastRecorded.Before(now) {
+			hour := r.lastRecorded.UTC().Truncate(time.Hour)
+			end := now
+			if next := hour.Add(time.Hour); end.After(next) {
+				end = next
+			}
+			r.add(hour, end.Sub(r.lastRecorded).Seconds())
+			r.lastRecorded = end
+		}
+	}
+
+	r.current = spillover(result)
+	if now.After(r.lastRecorded) {
+		r.lastRecorded = now
+	}
+	r.prune(now)
+}
+
+// Recommend computes recommendations from the complete hours recorded so far.
+// The hour in progress isn't used.
+func (r *Recommender) Recommend() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Hourly spillover rates ($/hour) by scope, aligned across scopes by hour index
+	inProgress := r.lastRecorded.UTC().Truncate(time.Hour)
+	var hours []time.Time
+	for hour, usage := range r.hours {
+		if hour.Before(inProgress) && usage.observedSeconds > 0 {
+			hours = append(hours, hour)
+		}
+	}
+	report := Report{ObservedHours: len(hours), Recommendations: []Recommendation{}}
+	if len(hours) < MinObservedHours {
+		return report
+	}
+	slices.SortFunc(hours, func(a, b time.Time) int { return a.Compare(b) })
+
+	total := make([]float64, len(hours))
+	byScope := make(map[usageKey][]float64)
+	for i, hour := range hours {
+		usage := r.hours[hour]
+		observedHours := usage.observedSeconds / 3600
+		for key, spend := range usage.spend {
+			if byScope[key] == nil {
+				byScope[key] = make([]float64, len(hours))
+			}
+			// Extrapolate partially observed hours at the observed rate
+			byScope[key][i] = spend / observedHours
+			total[i] += spend / observedHours
+		}
+	}
+
+	terms := []struct {
+		term        string
+		multipliers RateMultipliers
+	}{
+		{Term1Year, r.options.OneYear},
+		{Term3Year, r.options.ThreeYear},
+	}
+	for _, t := range terms {
+		if rec, ok := recommend(total, t.multipliers.Compute); ok {
+			rec.Type, rec.Term, rec.Region, rec.InstanceFamily = TypeCompute, t.term, scopeAll, scopeAll
+			report.Recommendations = append(report.Recommendations, rec)
+		}
+		for key, rates := range byScope {
+			if rec, ok := recommend(rates, t.multipliers.EC2Instance); ok {
+				rec.Type, rec.Term, rec.Region, rec.InstanceFamily = TypeEC2Instance, t.term, key.Region, key.InstanceFamily
+				report.Recommendations = append(report.Recommendations, rec)
+			}
+		}
+	}
+
+	slices.SortFunc(report.Recommendations, func(a, b Recommendation) int {
+		return cmp.Or(
+			strings.Compare(a.Type, b.Type),
+			strings.Compare(a.Term, b.Term),
+			cmp.Compare(b.HourlySavings, a.HourlySavings),
+			strings.Compare(a.Region, b.Region),
+			strings.Compare(a.InstanceFamily, b.InstanceFamily),
+		)
+	})
+	return report
+}
+
+// add accumulates the current spillover for the given number of seconds of hour.
+func (r *Recommender) add(hour time.Time, seconds float64) {
+	if seconds <= 0 {
+		return
+	}
+
+	usage, exists := r.hours[hour]
+	if !exists {
+		usage = &hourUsage{spend: make(map[usageKey]float64)}
+		r.hours[hour] = usage
+	}
+	for key, rate := range r.current {
+		usage.spend[key] += rate * seconds / 3600
+	}
+	usage.observedSeconds += seconds
+}
+
+// prune drops hours that fall outside the lookback window ending at now.
+func (r *Recommender) prune(now time.Time) {
+	oldest := now.UTC().Truncate(time.Hour).Add(-r.options.Lookback)
+	for hour := range r.hours {
+		if hour.Before(oldest) {
+			delete(r.hours, hour)
+		}
+	}
+}
+
+// spillover returns the on-demand cost ($/hour) of a result that Savings Plans could
+// cover, by region and instance family: what instances pay at on-demand rates after
+// RI and Savings Plan coverage. An instance's EffectiveCost is the Savings Plan
+// commitment it consumes plus that on-demand remainder. Spot instances can't use
+// Savings Plans.
+func spillover(result cost.CalculationResult) map[usageKey]float64 {
+	rates := make(map[usageKey]float64)
+	for _, ic := range result.InstanceCosts {
+		onDemand := ic.EffectiveCost - ic.SavingsPlanCoverage
+		if ic.IsSpot || onDemand <= 1e-9 {
+			continue
+		}
+		family, _, _ := strings.Cut(ic.InstanceType, ".")
+		rates[usageKey{Region: ic.Region, InstanceFamily: family}] += onDemand
+	}
+	return rates
+}
@@ -0,0 +1,201 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextdoor/lumina/pkg/cost"
+)
+
+// testOptions uses the default rate multipliers: 0.72 for 1-year and 0.50 for 3-year plans.
+func testOptions(lookback time.Duration) Options {
+	return Options{
+		Lookback:  lookback,
+		OneYear:   RateMultipliers{Compute: 0.72, EC2Instance: 0.72},
+		ThreeYear: RateMultipliers{Compute: 0.50, EC2Instance: 0.50},
+	}
+}
+
+// testResult returns a steady $10/hour of m5 spillover, plus $4/hour of c5 spillover
+// during the first 12 hours of each UTC day. A spot instance never counts.
+func testResult(now time.Time) cost.CalculationResult {
+	result := cost.CalculationResult{
+		InstanceCosts: map[string]cost.InstanceCost{
+			"i-m5": {
+				InstanceID: "i-m5", InstanceType: "m5.24xlarge", Region: "us-west-2",
+				ShelfPrice: 10, EffectiveCost: 10, OnDemandCost: 10,
+			},
+			"i-spot": {
+				InstanceID: "i-spot", InstanceType: "m5.xlarge", Region: "us-west-2",
+				IsSpot: true, ShelfPrice: 0.192, EffectiveCost: 0.05,
+			},
+		},
+	}
+	if now.UTC().Hour() < 12 {
+		result.InstanceCosts["i-c5"] = cost.InstanceCost{
+			InstanceID: "i-c5", InstanceType: "c5.9xlarge", Region: "us-west-2",
+			ShelfPrice: 4, EffectiveCost: 4, OnDemandCost: 4,
+		}
+	}
+	return result
+}
+
+// recordHours records testResult every 30 minutes for the given number of hours.
+func recordHours(r *Recommender, start time.Time, hours int) {
+	for t := start; !t.After(start.Add(time.Duration(hours) * time.Hour)); t = t.Add(30 * time.Minute) {
+		r.Record(testResult(t), t)
+	}
+}
+
+// findRecommendation returns the recommendation for the given plan, if any.
+func findRecommendation(report Report, spType, term, family string) (Recommendation, bool) {
+	for _, rec := range report.Recommendations {
+		if rec.Type == spType && rec.Term == term && rec.InstanceFamily == family {
+			return rec, true
+		}
+	}
+	return Recommendation{}, false
+}
+
+// TestRecommender_Recommend tests sizing commitments to steady and intermittent spillover.
+func TestRecommender_Recommend(t *testing.T) {
+	r := NewRecommender(testOptions(7 * 24 * time.Hour))
+	recordHours(r, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), 48)
+
+	report := r.Recommend()
+	assert.Equal(t, 48, report.ObservedHours)
+
+	// Covering the $14/hour peaks would leave the commitment half idle at night, so
+	// the Compute plan only covers the steady $10/hour
+	rec, ok := findRecommendation(report, TypeCompute, Term1Year, "all")
+	require.True(t, ok)
+	assert.Equal(t, "all", rec.Region)
+	assert.InDelta(t, 7.2, rec.HourlyCommitment, 0.0001)
+	assert.InDelta(t, 12, rec.OnDemandSpend, 0.0001)
+	assert.InDelta(t, 2.8, rec.HourlySavings, 0.0001)
+	assert.InDelta(t, 2.8/12*100, rec.SavingsPercent, 0.0001)
+	assert.InDelta(t, 100, rec.UtilizationPercent, 0.0001)
+
+	rec, ok = findRecommendation(report, TypeEC2Instance, Term3Year, "m5")
+	require.True(t, ok)
+	assert.Equal(t, "us-west-2", rec.Region)
+	assert.InDelta(t, 5.0, rec.HourlyCommitment, 0.0001)
+	assert.InDelta(t, 5.0, rec.HourlySavings, 0.0001)
+
+	// c5 only runs half the day: a 1-year plan (28% off) can't pay for the idle half,
+	// a 3-year plan (50% off) exactly breaks even
+	_, ok = findRecommendation(report, TypeEC2Instance, Term1Year, "c5")
+	assert.False(t, ok)
+	_, ok = findRecommendation(report, TypeEC2Instance, Term3Year, "c5")
+	assert.False(t, ok)
+
+	// compute < ec2_instance, then 1y < 3y
+	require.Len(t, report.Recommendations, 4)
+	assert.Equal(t, TypeCompute, report.Recommendations[0].Type)
+	assert.Equal(t, Term1Year, report.Recommendations[0].Term)
+	assert.Equal(t, TypeEC2Instance, report.Recommendations[3].Type)
+	assert.Equal(t, Term3Year, report.Recommendations[3].Term)
+}
+
+// TestSpillover tests that only the part of an instance still billed at on-demand
+// rates counts as spillover.
+func TestSpillover(t *testing.T) {
+	result := cost.CalculationResult{
+		InstanceCosts: map[string]cost.InstanceCost{
+			// Fully RI-covered: nothing left to cover
+			"i-ri": {
+				InstanceID: "i-ri", InstanceType: "m5.xlarge", Region: "us-west-2",
+				ShelfPrice: 1.00, EffectiveCost: 0, RICoverage: 1.00, OnDemandCost: 1.00,
+			},
+			// A quarter covered by a Savings Plan at $0.72/hour: $0.18 of commitment plus
+			// $0.75 at on-demand rates
+			"i-sp": {
+				InstanceID: "i-sp", InstanceType: "m5.xlarge", Region: "us-west-2",
+				ShelfPrice: 1.00, EffectiveCost: 0.93, SavingsPlanCoverage: 0.18, OnDemandCost: 1.00,
+			},
+			// Uncovered
+			"i-c5": {
+				InstanceID: "i-c5", InstanceType: "c5.xlarge", Region: "us-west-2",
+				ShelfPrice: 0.17, EffectiveCost: 0.17, OnDemandCost: 0.17,
+			},
+		},
+	}
+
+	rates := spillover(result)
+	require.Len(t, rates, 2)
+	assert.InDelta(t, 0.75, rates[usageKey{Region: "us-west-2", InstanceFamily: "m5"}], 1e-9)
+	assert.InDelta(t, 0.17, rates[usageKey{Region: "us-west-2", InstanceFamily: "c5"}], 1e-9)
+}
+
+// TestRecommender_MinObservedHours tests that nothing is recommended from less than a day.
+func TestRecommender_MinObservedHours(t *testing.T) {
+	r := NewRecommender(testOptions(7 * 24 * time.Hour))
+	recordHours(r, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), 10)
+
+	report := r.Recommend()
+	assert.Equal(t, 10, report.ObservedHours)
+	assert.Empty(t, report.Recommendations)
+	assert.NotNil(t, report.Recommendations)
+}
+
+// TestRecommender_Prune tests that hours outside the lookback window are dropped.
+func TestRecommender_Prune(t *testing.T) {
+	r := NewRecommender(testOptions(48 * time.Hour))
+	recordHours(r, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), 72)
+
+	assert.Equal(t, 48, r.Recommend().ObservedHours)
+}
+
+// TestOptimalCommitment tests commitment sizing against known spend patterns.
+func TestOptimalCommitment(t *testing.T) {
+	tests := []struct {
+		name       string
+		spend      []float64
+		multiplier float64
+		want       float64
+	}{
+		{name: "steady", spend: []float64{10, 10, 10, 10}, multiplier: 0.72, want: 7.2},
+		// Covering the $20 hour (1 in 4) only pays if plans cost under 25% of on-demand
+		{name: "one spike", spend: []float64{10, 10, 10, 20}, multiplier: 0.72, want: 7.2},
+		{name: "one spike, deep discount", spend: []float64{10, 10, 10, 20}, multiplier: 0.2, want: 4},
+		{name: "idle", spend: []float64{0, 0, 0, 0}, multiplier: 0.72, want: 0},
+		{name: "rounds down", spend: []float64{1.23456}, multiplier: 0.5, want: 0.617},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, optimalCommitment(tt.spend, tt.multiplier), 0.000001)
+		})
+	}
+}
+
+// TestEvaluate tests projected savings and utilization of a commitment that's idle
+// half the time.
+func TestEvaluate(t *testing.T) {
+	spend := []float64{10, 10, 0, 0}
+
+	// $3.60/hour covers $5 of spend: two hours save $1.40, two waste $3.60
+	savings, utilization := evaluate(spend, 3.6, 0.72)
+	assert.InDelta(t, (2*5-4*3.6)/4, savings, 0.0001)
+	assert.InDelta(t, 50, utilization, 0.0001)
+
+	_, ok := recommend(spend, 0.72)
+	assert.False(t, ok)
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whatif
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/cost"
+)
+
+// Commitment types of an Expiry.
+const (
+	CommitmentSavingsPlan      = "savings_plan"
+	CommitmentReservedInstance = "reserved_instance"
+)
+
+// Expiry is the projected effect of a Savings Plan or Reserved Instance expiring.
+type Expiry struct {
+	// CommitmentType is CommitmentSavingsPlan or CommitmentReservedInstance
+	CommitmentType string `json:"commitmentType"`
+
+	// ID is the Savings Plan ARN or Reserved Instance ID
+	ID string `json:"id"`
+
+	AccountID   string `json:"accountId"`
+	AccountName string `json:"accountName"`
+
+	// End is when the commitment expires
+	End time.Time `json:"end"`
+
+	// HourlyCostIncrease is how much the total estimated cost rises when the
+	// commitment expires ($/hour)
+	HourlyCostIncrease float64 `json:"hourlyCostIncrease"`
+
+	// SpilloverIncrease is how much the on-demand cost of instances that no
+	// Reserved Instance or Savings Plan covers rises when the commitment expires
+	// ($/hour). Spot instances aren't counted.
+	SpilloverIncrease float64 `json:"spilloverIncrease"`
+}
+
+// Forecast projects the effect of each Savings Plan and Reserved Instance in input
+// that expires within horizon of now, in order of expiry.
+//
+// Expirations are cumulative: each one is simulated with every commitment that
+// expires before it already gone, so the usage a commitment picks up from an earlier
+// expiry counts towards its own exposure. Inventory and prices are held at their
+// current values.
+func Forecast(calc *cost.Calculator, input cost.CalculationInput, now time.Time, horizon time.Duration) []Expiry {
+	expiries := expiringCommitments(input, now, horizon)
+	if len(expiries) == 0 {
+		return nil
+	}
+
+	input.Now = now
+	previous := calc.Calculate(input)
+	for i := range expiries {
+		change := Change{Type: ChangeExpireSavingsPlan, SavingsPlanARN: expiries[i].ID}
+		if expiries[i].CommitmentType == CommitmentReservedInstance {
+			change = Change{Type: ChangeExpireReservedInstance, ReservedInstanceID: expiries[i].ID}
+		}
+		// The commitment was found in input above, so removing it can't fail
+		input, _ = Apply(input, []Change{change}, now)
+
+		result := calc.Calculate(input)
+		expiries[i].HourlyCostIncrease = result.TotalEstimatedCost - previous.TotalEstimatedCost
+		expiries[i].SpilloverIncrease = spillover(result) - spillover(previous)
+		previous = result
+	}
+	return expiries
+}
+
+// expiringCommitments returns the Savings Plans and Reserved Instances in input
+// that expire within horizon of now, in order of expiry, without their effect.
+func expiringCommitments(input cost.CalculationInput, now time.Time, horizon time.Duration) []Expiry {
+	var expiries []Expiry
+	for _, sp := range input.SavingsPlans {
+		if sp.End.After(now) && !sp.End.After(now.Add(horizon)) {
+			expiries = append(expiries, Expiry{
+				CommitmentType: CommitmentSavingsPlan,
+				ID:             sp.SavingsPlanARN,
+				AccountID:      sp.AccountID,
+				AccountName:    sp.AccountName,
+				End:            sp.End,
+			})
+		}
+	}
+	for _, ri := range input.ReservedInstances {
+		if ri.End.After(now) && !ri.End.After(now.Add(horizon)) {
+			expiries = append(expiries, Expiry{
+				CommitmentType: CommitmentReservedInstance,
+				ID:             ri.ReservedInstanceID,
+				AccountID:      ri.AccountID,
+				AccountName:    ri.AccountName,
+				End:            ri.End,
+			})
+		}
+	}
+	slices.SortFunc(expiries, func(a, b Expiry) int {
+		return cmp.Or(a.End.Compare(b.End), cmp.Compare(a.ID, b.ID))
+	})
+	return expiries
+}
+
+// ForecastCache reuses a Forecast while the Savings Plan and Reserved Instance
+// inventory it was made from is unchanged. A forecast runs a full cost calculation
+// per expiring commitment, too many to repeat after every cost calculation for a
+// projection that mostly moves with the commitments themselves.
+//
+// Instances and prices also affect the forecast, so it's recalculated once it's
+// older than the cache's maximum age even if the inventory is unchanged.
+// ForecastCache is safe for concurrent use.
+type ForecastCache struct {
+	maxAge time.Duration
+
+	mu                sync.Mutex             // Protects the fields below
+	calculatedAt      time.Time              // When expiries was forecast
+	horizon           time.Duration          // Horizon expiries was forecast with
+	savingsPlans      []aws.SavingsPlan      // Savings Plans expiries was forecast from
+	reservedInstances []aws.ReservedInstance // Reserved Instances expiries was forecast from
+	expiries          []Expiry               // Cached forecast
+}
+
+// NewForecastCache creates a ForecastCache that recalculates forecasts at least
+// every maxAge.
+func NewForecastCache(maxAge time.Duration) *ForecastCache {
+	return &ForecastCache{maxAge: maxAge}
+}
+
+// Forecast returns Forecast(calc, input, now, horizon), reusing the previous
+// forecast if it was made within the maximum age, with the same horizon, from the
+// same Savings Plans and Reserved Instances, and the same of them expiring within
+// the horizon. Callers must not modify the result.
+func (c *ForecastCache) Forecast(
+	calc *cost.Calculator,
+	input cost.CalculationInput,
+	now time.Time,
+	horizon time.Duration,
+) []Expiry {
+	// Inventory comes from maps, so its order varies between calculations
+	savingsPlans := slices.SortedFunc(slices.Values(input.SavingsPlans), func(a, b aws.SavingsPlan) int {
+		return cmp.Compare(a.SavingsPlanARN, b.SavingsPlanARN)
+	})
+	reservedInstances := slices.SortedFunc(slices.Values(input.ReservedInstances),
+		func(a, b aws.ReservedInstance) int {
+			return cmp.Compare(a.ReservedInstanceID, b.ReservedInstanceID)
+		})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.calculatedAt.IsZero() && now.Sub(c.calculatedAt) < c.maxAge && c.horizon == horizon &&
+		slices.Equal(c.savingsPlans, savingsPlans) &&
+		slices.Equal(c.reservedInstances, reservedInstances) &&
+		slices.EqualFunc(c.expiries, expiringCommitments(input, now, horizon), sameCommitment) {
+		return c.expiries
+	}
+
+	c.expiries = Forecast(calc, input, now, horizon)
+	c.calculatedAt = now
+	c.horizon = horizon
+	c.savingsPlans = savingsPlans
+	c.reservedInstances = reservedInstances
+	return c.expiries
+}
+
+// sameCommitment reports whether two expiries are for the same commitment.
+func sameCommitment(a, b Expiry) bool {
+	return a.CommitmentType == b.CommitmentType && a.ID == b.ID
+}
+
+// spillover returns the on-demand cost of the result's instances that isn't covered
+// by a Reserved Instance or Savings Plan ($/hour). An instance's EffectiveCost is the
+// Savings Plan commitment it consumes plus what it pays at on-demand rates.
+func spillover(result cost.CalculationResult) float64 {
+	var total float64
+	for _, ic := range result.InstanceCosts {
+		if !ic.IsSpot {
+			total += max(ic.EffectiveCost-ic.SavingsPlanCoverage, 0)
+		}
+	}
+	return total
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whatif
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestForecast tests that expirations are simulated cumulatively in order of End:
+// once the Reserved Instance expires, the Savings Plan expiring after it is
+// covering one of two on-demand instances.
+func TestForecast(t *testing.T) {
+	calc := cost.NewCalculator(nil, nil)
+	input := testInput()
+	input.Instances = input.Instances[:2]
+	input.ReservedInstances = []aws.ReservedInstance{
+		{ReservedInstanceID: "ri-001", InstanceType: "m5.xlarge", Region: "us-west-2", AccountID: "111111111111",
+			AccountName: "prod", InstanceCount: 1, State: "active", End: testNow.Add(10 * 24 * time.Hour)},
+	}
+	input.SavingsPlans[0].End = testNow.Add(30 * 24 * time.Hour)
+	input.SavingsPlans = append(input.SavingsPlans,
+		aws.SavingsPlan{SavingsPlanARN: "arn:aws:savingsplans::111111111111:savingsplan/sp-later",
+			SavingsPlanType: "EC2Instance", Region: "us-west-2", InstanceFamily: "c5", Commitment: 1,
+			AccountID: "111111111111", End: testNow.Add(200 * 24 * time.Hour)},
+	)
+
+	expiries := Forecast(calc, input, testNow, 90*24*time.Hour)
+
+	require.Len(t, expiries, 2, "plans expiring outside the horizon aren't forecast")
+	ri := expiries[0]
+	assert.Equal(t, CommitmentReservedInstance, ri.CommitmentType)
+	assert.Equal(t, "ri-001", ri.ID)
+	assert.Equal(t, "prod", ri.AccountName)
+	assert.Equal(t, testNow.Add(10*24*time.Hour), ri.End)
+	// The RI-covered instance goes on-demand; the Savings Plan keeps covering the other
+	assert.InDelta(t, 1.00, ri.HourlyCostIncrease, 0.0001)
+	assert.InDelta(t, 1.00, ri.SpilloverIncrease, 0.0001)
+
+	sp := expiries[1]
+	assert.Equal(t, CommitmentSavingsPlan, sp.CommitmentType)
+	assert.Equal(t, "arn:aws:savingsplans::111111111111:savingsplan/sp-001", sp.ID)
+	// The instance the plan covered pays $1.00 on demand instead of $0.72
+	assert.InDelta(t, 0.28, sp.HourlyCostIncrease, 0.0001)
+	assert.InDelta(t, 1.00, sp.SpilloverIncrease, 0.0001)
+
+	assert.Len(t, input.ReservedInstances, 1, "input isn't modified")
+	assert.Empty(t, Forecast(calc, input, testNow, 24*time.Hour))
+}
+
+// TestForecastCache tests that a forecast is reused while the Savings Plans and
+// Reserved Instances are unchanged, and recalculated when they change or it's
+// older than the maximum age.
+func TestForecastCache(t *testing.T) {
+	calc := cost.NewCalculator(nil, nil)
+	horizon := 90 * 24 * time.Hour
+	input := testInput()
+	input.Instances = nil
+	input.SavingsPlans[0].End = testNow.Add(30 * 24 * time.Hour)
+	input.ReservedInstances = append(input.ReservedInstances, aws.ReservedInstance{
+		ReservedInstanceID: "ri-002", InstanceType: "c5.large", Region: "us-west-2", AccountID: "222222222222",
+		InstanceCount: 1, State: "active", End: testNow.Add(365 * 24 * time.Hour)})
+
+	forecasts := NewForecastCache(time.Hour)
+	first := forecasts.Forecast(calc, input, testNow, horizon)
+	require.Len(t, first, 1)
+	assert.Equal(t, 0.0, first[0].HourlyCostIncrease, "the plan covers nothing")
+
+	// A new instance alone doesn't invalidate the forecast, and the order of the
+	// inventory doesn't matter
+	input.Instances = testInput().Instances[:1]
+	slices.Reverse(input.ReservedInstances)
+	assert.Equal(t, first, forecasts.Forecast(calc, input, testNow.Add(30*time.Minute), horizon))
+
+	// Once the forecast is older than the maximum age, the new instance is included
+	refreshed := forecasts.Forecast(calc, input, testNow.Add(time.Hour), horizon)
+	require.Len(t, refreshed, 1)
+	assert.InDelta(t, 0.28, refreshed[0].HourlyCostIncrease, 0.0001)
+
+	// A changed commitment invalidates it immediately. Half the instance is covered
+	// for $0.36; the other half already pays $0.50 on demand.
+	input.SavingsPlans[0].Commitment = 0.36
+	changed := forecasts.Forecast(calc, input, testNow.Add(time.Hour+time.Minute), horizon)
+	require.Len(t, changed, 1)
+	assert.InDelta(t, 0.14, changed[0].HourlyCostIncrease, 0.0001)
+
+	// As does a commitment leaving the horizon
+	assert.Empty(t, forecasts.Forecast(calc, input, testNow.Add(time.Hour+2*time.Minute), 24*time.Hour))
+}
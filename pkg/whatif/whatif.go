@@ -0,0 +1,384 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package whatif simulates changes to the inventory the cost calculator runs on,
+// such as adding instances or buying a Savings Plan, and reports how the
+// calculation result changes.
+//
+// Apply copies a cost.CalculationInput with the changes applied. The caller prices
+// the new inventory, runs the calculator on both inputs, and passes both results to
+// Compare. Purchases are evaluated with the same coverage algorithm as live costs,
+// so they account for the Reserved Instances and Savings Plans already owned.
+//
+// Forecast uses the same simulation to project what upcoming Savings Plan and
+// Reserved Instance expirations will cost.
+package whatif
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/cost"
+)
+
+// Change types.
+const (
+	// ChangeAddInstances launches Count on-demand instances of InstanceType in Region
+	ChangeAddInstances = "add_instances"
+
+	// ChangeRemoveInstances terminates Count running instances of InstanceType in
+	// Region, most recently launched first, or all of them if Count is 0
+	ChangeRemoveInstances = "remove_instances"
+
+	// ChangeRemoveAccount removes everything owned by AccountID: instances, Reserved
+	// Instances, Savings Plans, Capacity Reservations, and serverless usage
+	ChangeRemoveAccount = "remove_account"
+
+	// ChangePurchaseSavingsPlan buys a Savings Plan with an hourly Commitment
+	ChangePurchaseSavingsPlan = "purchase_savings_plan"
+
+	// ChangeExpireSavingsPlan lets the Savings Plan SavingsPlanARN expire
+	ChangeExpireSavingsPlan = "expire_savings_plan"
+
+	// ChangeExpireReservedInstance lets the Reserved Instance ReservedInstanceID expire
+	ChangeExpireReservedInstance = "expire_reserved_instance"
+)
+
+// Change is one change to simulate. Which fields are used depends on Type.
+type Change struct {
+	// Type is one of the Change* constants
+	Type string `json:"type"`
+
+	// InstanceType and Region select instances to add or remove, e.g. "c6i.4xlarge"
+	// and "us-east-1"
+	InstanceType string `json:"instanceType,omitempty"`
+	Region       string `json:"region,omitempty"`
+
+	// Count is how many instances to add or remove
+	Count int `json:"count,omitempty"`
+
+	// AvailabilityZone of added instances. Optional; zonal Reserved Instances only
+	// cover instances in their zone.
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// Platform and Tenancy of added instances. Default: linux, default tenancy.
+	Platform string `json:"platform,omitempty"`
+	Tenancy  string `json:"tenancy,omitempty"`
+
+	// AccountID owns added instances and purchased Savings Plans, restricts removed
+	// instances, and is the account to remove for ChangeRemoveAccount
+	AccountID string `json:"accountId,omitempty"`
+
+	// SavingsPlanType of a purchased Savings Plan: "Compute" or "EC2Instance"
+	SavingsPlanType string `json:"savingsPlanType,omitempty"`
+
+	// Commitment of a purchased Savings Plan ($/hour)
+	Commitment float64 `json:"commitment,omitempty"`
+
+	// InstanceFamily of a purchased EC2 Instance Savings Plan, e.g. "m5". It also
+	// needs Region.
+	InstanceFamily string `json:"instanceFamily,omitempty"`
+
+	// SavingsPlanARN is the Savings Plan to expire
+	SavingsPlanARN string `json:"savingsPlanArn,omitempty"`
+
+	// ReservedInstanceID is the Reserved Instance to expire
+	ReservedInstanceID string `json:"reservedInstanceId,omitempty"`
+}
+
+// Validate checks that the fields Type needs are set.
+func (c Change) Validate() error {
+	switch c.Type {
+	case ChangeAddInstances:
+		if c.InstanceType == "" || c.Region == "" || c.Count <= 0 {
+			return fmt.Errorf("%s needs instanceType, region, and a positive count", c.Type)
+		}
+	case ChangeRemoveInstances:
+		if c.InstanceType == "" || c.Region == "" || c.Count < 0 {
+			return fmt.Errorf("%s needs instanceType and region, and count can't be negative", c.Type)
+		}
+	case ChangeRemoveAccount:
+		if c.AccountID == "" {
+			return fmt.Errorf("%s needs accountId", c.Type)
+		}
+	case ChangePurchaseSavingsPlan:
+		if c.Commitment <= 0 {
+			return fmt.Errorf("%s needs a positive commitment", c.Type)
+		}
+		switch c.SavingsPlanType {
+		case aws.SavingsPlanTypeCompute:
+		case aws.SavingsPlanTypeEC2Instance:
+			if c.Region == "" || c.InstanceFamily == "" {
+				return fmt.Errorf("%s of an EC2Instance plan needs region and instanceFamily", c.Type)
+			}
+		default:
+			return fmt.Errorf("%s needs savingsPlanType %q or %q, got %q", c.Type,
+				aws.SavingsPlanTypeCompute, aws.SavingsPlanTypeEC2Instance, c.SavingsPlanType)
+		}
+	case ChangeExpireSavingsPlan:
+		if c.SavingsPlanARN == "" {
+			return fmt.Errorf("%s needs savingsPlanArn", c.Type)
+		}
+	case ChangeExpireReservedInstance:
+		if c.ReservedInstanceID == "" {
+			return fmt.Errorf("%s needs reservedInstanceId", c.Type)
+		}
+	default:
+		return fmt.Errorf("unknown change type %q", c.Type)
+	}
+	return nil
+}
+
+// Apply returns a copy of input with the changes applied in order. input isn't
+// modified. now is the launch time of added instances and the start of purchased
+// Savings Plans.
+//
+// The returned input's OnDemandPrices are those of input; the caller must add the
+// prices of added instance types before calculating. Purchased Savings Plans have
+// no rates loaded, so the calculator prices them with the configured default
+// discounts.
+func Apply(input cost.CalculationInput, changes []Change, now time.Time) (cost.CalculationInput, error) {
+	input.Instances = slices.Clone(input.Instances)
+	input.ReservedInstances = slices.Clone(input.ReservedInstances)
+	input.SavingsPlans = slices.Clone(input.SavingsPlans)
+	input.CapacityReservations = slices.Clone(input.CapacityReservations)
+	input.ServerlessUsage = slices.Clone(input.ServerlessUsage)
+
+	for i, change := range changes {
+		if err := change.Validate(); err != nil {
+			return cost.CalculationInput{}, fmt.Errorf("change %d: %w", i+1, err)
+		}
+
+		switch change.Type {
+		case ChangeAddInstances:
+			input.Instances = append(input.Instances, simulatedInstances(i, change, now)...)
+
+		case ChangeRemoveInstances:
+			input.Instances = removeInstances(input.Instances, change)
+
+		case ChangeRemoveAccount:
+			ownedBy := change.AccountID
+			input.Instances = slices.DeleteFunc(input.Instances, func(inst aws.Instance) bool {
+				return inst.AccountID == ownedBy
+			})
+			input.ReservedInstances = slices.DeleteFunc(input.ReservedInstances, func(ri aws.ReservedInstance) bool {
+				return ri.AccountID == ownedBy
+			})
+			input.SavingsPlans = slices.DeleteFunc(input.SavingsPlans, func(sp aws.SavingsPlan) bool {
+				return sp.AccountID == ownedBy
+			})
+			input.CapacityReservations = slices.DeleteFunc(input.CapacityReservations,
+				func(cr aws.CapacityReservation) bool { return cr.AccountID == ownedBy })
+			input.ServerlessUsage = slices.DeleteFunc(input.ServerlessUsage, func(u cost.ServerlessUsage) bool {
+				return u.AccountID == ownedBy
+			})
+
+		case ChangePurchaseSavingsPlan:
+			input.SavingsPlans = append(input.SavingsPlans, simulatedSavingsPlan(i, change, now))
+
+		case ChangeExpireSavingsPlan:
+			n := len(input.SavingsPlans)
+			input.SavingsPlans = slices.DeleteFunc(input.SavingsPlans, func(sp aws.SavingsPlan) bool {
+				return sp.SavingsPlanARN == change.SavingsPlanARN
+			})
+			if len(input.SavingsPlans) == n {
+				return cost.CalculationInput{}, fmt.Errorf("change %d: savings plan %s not found",
+					i+1, change.SavingsPlanARN)
+			}
+
+		case ChangeExpireReservedInstance:
+			n := len(input.ReservedInstances)
+			input.ReservedInstances = slices.DeleteFunc(input.ReservedInstances, func(ri aws.ReservedInstance) bool {
+				return ri.ReservedInstanceID == change.ReservedInstanceID
+			})
+			if len(input.ReservedInstances) == n {
+				return cost.CalculationInput{}, fmt.Errorf("change %d: reserved instance %s not found",
+					i+1, change.ReservedInstanceID)
+			}
+		}
+	}
+	return input, nil
+}
+
+// simulatedInstances returns the instances launched by an add_instances change.
+// IDs are derived from the change's position so results are reproducible.
+func simulatedInstances(index int, change Change, now time.Time) []aws.Instance {
+	platform := cmp.Or(change.Platform, aws.PlatformLinux)
+	tenancy := cmp.Or(change.Tenancy, aws.TenancyDefault)
+
+	instances := make([]aws.Instance, 0, change.Count)
+	for n := range change.Count {
+		instances = append(instances, aws.Instance{
+			InstanceID:       fmt.Sprintf("i-whatif-%d-%d", index+1, n+1),
+			InstanceType:     change.InstanceType,
+			AvailabilityZone: change.AvailabilityZone,
+			Region:           change.Region,
+			Lifecycle:        aws.LifecycleOnDemand,
+			State:            "running",
+			LaunchTime:       now,
+			AccountID:        change.AccountID,
+			Platform:         platform,
+			Tenancy:          tenancy,
+		})
+	}
+	return instances
+}
+
+// removeInstances removes the instances selected by a remove_instances change,
+// most recently launched first, like a scale-in would.
+func removeInstances(instances []aws.Instance, change Change) []aws.Instance {
+	var matching []aws.Instance
+	for _, inst := range instances {
+		if inst.InstanceType == change.InstanceType && inst.Region == change.Region &&
+			(change.AccountID == "" || inst.AccountID == change.AccountID) {
+			matching = append(matching, inst)
+		}
+	}
+	slices.SortFunc(matching, func(a, b aws.Instance) int {
+		return cmp.Or(b.LaunchTime.Compare(a.LaunchTime), cmp.Compare(a.InstanceID, b.InstanceID))
+	})
+	if change.Count > 0 && change.Count < len(matching) {
+		matching = matching[:change.Count]
+	}
+
+	removed := make(map[string]bool, len(matching))
+	for _, inst := range matching {
+		removed[inst.InstanceID] = true
+	}
+	return slices.DeleteFunc(instances, func(inst aws.Instance) bool {
+		return removed[inst.InstanceID]
+	})
+}
+
+// simulatedSavingsPlan returns the Savings Plan bought by a purchase_savings_plan
+// change, with a one year term.
+func simulatedSavingsPlan(index int, change Change, now time.Time) aws.SavingsPlan {
+	id := fmt.Sprintf("whatif-%d", index+1)
+	sp := aws.SavingsPlan{
+		SavingsPlanARN:  fmt.Sprintf("arn:aws:savingsplans::%s:savingsplan/%s", change.AccountID, id),
+		SavingsPlanID:   id,
+		SavingsPlanType: change.SavingsPlanType,
+		State:           "active",
+		Commitment:      change.Commitment,
+		Region:          "all",
+		Start:           now,
+		End:             now.AddDate(1, 0, 0),
+		AccountID:       change.AccountID,
+	}
+	if change.SavingsPlanType == aws.SavingsPlanTypeEC2Instance {
+		sp.Region = change.Region
+		sp.InstanceFamily = change.InstanceFamily
+		sp.EC2InstanceFamily = change.InstanceFamily
+	}
+	return sp
+}
+
+// Totals are the summary figures of a calculation result ($/hour).
+type Totals struct {
+	EstimatedCost float64 `json:"estimatedCost"`
+	ShelfPrice    float64 `json:"shelfPrice"`
+	Savings       float64 `json:"savings"`
+	StorageCost   float64 `json:"storageCost"`
+	Instances     int     `json:"instances"`
+}
+
+// EntryDiff is an entry of a calculation result before and after the changes.
+// Before is nil for added entries, and After is nil for removed ones.
+type EntryDiff[T any] struct {
+	Before *T `json:"before,omitempty"`
+	After  *T `json:"after,omitempty"`
+}
+
+// Result is the difference between the calculation results without and with the
+// simulated changes. The maps only list entries that were added, removed, or
+// changed, keyed like the corresponding cost.CalculationResult maps.
+type Result struct {
+	Baseline   Totals `json:"baseline"`
+	Simulated  Totals `json:"simulated"`
+	Difference Totals `json:"difference"`
+
+	// Instances is keyed by instance ID
+	Instances map[string]EntryDiff[cost.InstanceCost] `json:"instances"`
+
+	// SavingsPlans is keyed by Savings Plan ARN
+	SavingsPlans map[string]EntryDiff[cost.SavingsPlanUtilization] `json:"savingsPlans"`
+
+	// ReservedInstances is keyed by Reserved Instance ID
+	ReservedInstances map[string]EntryDiff[cost.ReservedInstanceUtilization] `json:"reservedInstances"`
+
+	// CapacityReservations is keyed by Capacity Reservation ID
+	CapacityReservations map[string]EntryDiff[cost.CapacityReservationUtilization] `json:"capacityReservations"`
+
+	// Serverless is keyed by serverless usage ID
+	Serverless map[string]EntryDiff[cost.ServerlessCost] `json:"serverless"`
+}
+
+// Compare returns the difference between the baseline and simulated results.
+func Compare(baseline, simulated cost.CalculationResult) Result {
+	before := totals(baseline)
+	after := totals(simulated)
+	return Result{
+		Baseline:  before,
+		Simulated: after,
+		Difference: Totals{
+			EstimatedCost: after.EstimatedCost - before.EstimatedCost,
+			ShelfPrice:    after.ShelfPrice - before.ShelfPrice,
+			Savings:       after.Savings - before.Savings,
+			StorageCost:   after.StorageCost - before.StorageCost,
+			Instances:     after.Instances - before.Instances,
+		},
+		Instances:         diffEntries(baseline.InstanceCosts, simulated.InstanceCosts),
+		SavingsPlans:      diffEntries(baseline.SavingsPlanUtilization, simulated.SavingsPlanUtilization),
+		ReservedInstances: diffEntries(baseline.ReservedInstanceUtilization, simulated.ReservedInstanceUtilization),
+		CapacityReservations: diffEntries(
+			baseline.CapacityReservationUtilization, simulated.CapacityReservationUtilization),
+		Serverless: diffEntries(baseline.ServerlessCosts, simulated.ServerlessCosts),
+	}
+}
+
+// totals returns the summary figures of a calculation result.
+func totals(result cost.CalculationResult) Totals {
+	return Totals{
+		EstimatedCost: result.TotalEstimatedCost,
+		ShelfPrice:    result.TotalShelfPrice,
+		Savings:       result.TotalSavings,
+		StorageCost:   result.TotalStorageCost,
+		Instances:     len(result.InstanceCosts),
+	}
+}
+
+// diffEntries returns the entries that were added, removed, or changed between two
+// result maps.
+func diffEntries[T any](before, after map[string]T) map[string]EntryDiff[T] {
+	diff := make(map[string]EntryDiff[T])
+	for key, b := range before {
+		a, exists := after[key]
+		switch {
+		case !exists:
+			diff[key] = EntryDiff[T]{Before: &b}
+		case !reflect.DeepEqual(a, b):
+			diff[key] = EntryDiff[T]{Before: &b, After: &a}
+		}
+	}
+	for key, a := range after {
+		if _, exists := before[key]; !exists {
+			diff[key] = EntryDiff[T]{After: &a}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 Nextdoor, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whatif
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nextdoor/lumina/pkg/aws"
+	"github.com/nextdoor/lumina/pkg/cost"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// testInput returns two accounts' inventory: three m5.xlarge instances, a Reserved
+// Instance, and a Compute Savings Plan.
+func testInput() cost.CalculationInput {
+	return cost.CalculationInput{
+		Instances: []aws.Instance{
+			{InstanceID: "i-old", InstanceType: "m5.xlarge", Region: "us-west-2", AccountID: "111111111111",
+				State: "running", LaunchTime: testNow.Add(-48 * time.Hour)},
+			{InstanceID: "i-new", InstanceType: "m5.xlarge", Region: "us-west-2", AccountID: "111111111111",
+				State: "running", LaunchTime: testNow.Add(-time.Hour)},
+			{InstanceID: "i-other", InstanceType: "m5.xlarge", Region: "us-west-2", AccountID: "222222222222",
+				State: "running", LaunchTime: testNow.Add(-24 * time.Hour)},
+		},
+		ReservedInstances: []aws.ReservedInstance{
+			{ReservedInstanceID: "ri-001", InstanceType: "c5.large", Region: "us-west-2", AccountID: "222222222222",
+				InstanceCount: 1, State: "active"},
+		},
+		SavingsPlans: []aws.SavingsPlan{
+			{SavingsPlanARN: "arn:aws:savingsplans::111111111111:savingsplan/sp-001", SavingsPlanType: "Compute",
+				Region: "all", Commitment: 0.72, AccountID: "111111111111"},
+		},
+		OnDemandPrices: map[string]float64{"m5.xlarge:us-west-2:linux": 1.00},
+	}
+}
+
+func TestApply(t *testing.T) {
+	input := testInput()
+
+	t.Run("add instances", func(t *testing.T) {
+		got, err := Apply(input, []Change{{
+			Type: ChangeAddInstances, InstanceType: "c6i.4xlarge", Region: "us-east-1", Count: 2,
+		}}, testNow)
+		require.NoError(t, err)
+		require.Len(t, got.Instances, 5)
+		added := got.Instances[3]
+		assert.Equal(t, "i-whatif-1-1", added.InstanceID)
+		assert.Equal(t, "i-whatif-1-2", got.Instances[4].InstanceID)
+		assert.Equal(t, aws.PlatformLinux, added.Platform)
+		assert.Equal(t, aws.TenancyDefault, added.Tenancy)
+		assert.Equal(t, aws.LifecycleOnDemand, added.Lifecycle)
+		assert.Equal(t, testNow, added.LaunchTime)
+		assert.Len(t, input.Instances, 3, "input isn't modified")
+	})
+
+	t.Run("remove most recently launched instances", func(t *testing.T) {
+		got, err := Apply(input, []Change{{
+			Type: ChangeRemoveInstances, InstanceType: "m5.xlarge", Region: "us-west-2", Count: 2,
+		}}, testNow)
+		require.NoError(t, err)
+		require.Len(t, got.Instances, 1)
+		assert.Equal(t, "i-old", got.Instances[0].InstanceID)
+	})
+
+	t.Run("remove all instances of an account", func(t *testing.T) {
+		got, err := Apply(input, []Change{{
+			Type: ChangeRemoveInstances, InstanceType: "m5.xlarge", Region: "us-west-2", AccountID: "111111111111",
+		}}, testNow)
+		require.NoError(t, err)
+		require.Len(t, got.Instances, 1)
+		assert.Equal(t, "i-other", got.Instances[0].InstanceID)
+	})
+
+	t.Run("remove account", func(t *testing.T) {
+		got, err := Apply(input, []Change{{Type: ChangeRemoveAccount, AccountID: "222222222222"}}, testNow)
+		require.NoError(t, err)
+		assert.Len(t, got.Instances, 2)
+		assert.Empty(t, got.ReservedInstances)
+		assert.Len(t, got.SavingsPlans, 1)
+	})
+
+	t.Run("purchase and expire savings plans", func(t *testing.T) {
+		got, err := Apply(input, []Change{
+			{Type: ChangeExpireSavingsPlan, SavingsPlanARN: "arn:aws:savingsplans::111111111111:savingsplan/sp-001"},
+			{Type: ChangePurchaseSavingsPlan, SavingsPlanType: "EC2Instance", Commitment: 50, Region: "us-west-2",
+				InstanceFamily: "m5", AccountID: "111111111111"},
+		}, testNow)
+		require.NoError(t, err)
+		require.Len(t, got.SavingsPlans, 1)
+		sp := got.SavingsPlans[0]
+		assert.Equal(t, "arn:aws:savingsplans::111111111111:savingsplan/whatif-2", sp.SavingsPlanARN)
+		assert.Equal(t, "m5", sp.InstanceFamily)
+		assert.Equal(t, "us-west-2", sp.Region)
+		assert.Equal(t, 50.0, sp.Commitment)
+		assert.Equal(t, testNow.AddDate(1, 0, 0), sp.End)
+	})
+
+	t.Run("expire reserved instance", func(t *testing.T) {
+		got, err := Apply(input, []Change{{Type: ChangeExpireReservedInstance, ReservedInstanceID: "ri-001"}}, testNow)
+		require.NoError(t, err)
+		assert.Empty(t, got.ReservedInstances)
+	})
+
+	errorTests := []struct {
+		name    string
+		change  Change
+		wantErr string
+	}{
+		{name: "unknown type", change: Change{Type: "delete_everything"}, wantErr: "unknown change type"},
+		{name: "add without count", change: Change{Type: ChangeAddInstances, InstanceType: "m5.large",
+			Region: "us-west-2"}, wantErr: "positive count"},
+		{name: "remove account without ID", change: Change{Type: ChangeRemoveAccount}, wantErr: "needs accountId"},
+		{name: "purchase without commitment", change: Change{Type: ChangePurchaseSavingsPlan,
+			SavingsPlanType: "Compute"}, wantErr: "positive commitment"},
+		{name: "purchase unknown type", change: Change{Type: ChangePurchaseSavingsPlan, SavingsPlanType: "SageMaker",
+			Commitment: 1}, wantErr: "savingsPlanType"},
+		{name: "EC2 Instance plan without family", change: Change{Type: ChangePurchaseSavingsPlan,
+			SavingsPlanType: "EC2Instance", Commitment: 1, Region: "us-west-2"}, wantErr: "instanceFamily"},
+		{name: "expire unknown savings plan", change: Change{Type: ChangeExpireSavingsPlan,
+			SavingsPlanARN: "arn:missing"}, wantErr: "not found"},
+		{name: "expire unknown reserved instance", change: Change{Type: ChangeExpireReservedInstance,
+			ReservedInstanceID: "ri-missing"}, wantErr: "not found"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Apply(input, []Change{tt.change}, testNow)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "change 1:")
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// TestCompare tests that a simulated Savings Plan purchase is evaluated with the
+// calculator's coverage algorithm and only changed entries are reported.
+func TestCompare(t *testing.T) {
+	calc := cost.NewCalculator(nil, nil)
+	input := testInput()
+	input.Instances = input.Instances[:1]
+
+	simulated, err := Apply(input, []Change{
+		{Type: ChangeAddInstances, InstanceType: "m5.xlarge", Region: "us-west-2", Count: 1,
+			AccountID: "111111111111"},
+		{Type: ChangePurchaseSavingsPlan, SavingsPlanType: "Compute", Commitment: 0.72, AccountID: "111111111111"},
+	}, testNow)
+	require.NoError(t, err)
+
+	result := Compare(calc.Calculate(input), calc.Calculate(simulated))
+
+	// Each SP covers one instance at the default 0.72 rate
+	assert.InDelta(t, 0.72, result.Baseline.EstimatedCost, 0.0001)
+	assert.InDelta(t, 1.44, result.Simulated.EstimatedCost, 0.0001)
+	assert.InDelta(t, 0.72, result.Difference.EstimatedCost, 0.0001)
+	assert.InDelta(t, 1.00, result.Difference.ShelfPrice, 0.0001)
+	assert.Equal(t, 1, result.Difference.Instances)
+
+	require.Contains(t, result.Instances, "i-whatif-1-1")
+	added := result.Instances["i-whatif-1-1"]
+	assert.Nil(t, added.Before)
+	require.NotNil(t, added.After)
+	assert.Equal(t, cost.CoverageComputeSavingsPlan, added.After.CoverageType)
+	assert.NotContains(t, result.Instances, "i-old", "unchanged instances aren't listed")
+
+	require.Contains(t, result.SavingsPlans, "arn:aws:savingsplans::111111111111:savingsplan/whatif-2")
+	assert.Nil(t, result.SavingsPlans["arn:aws:savingsplans::111111111111:savingsplan/whatif-2"].Before)
+	assert.Empty(t, result.ReservedInstances, "the unused RI is unchanged")
+}
+
+// TestCompareUnchangedSavingsPlans tests that Savings Plans with an end date aren't
+// reported as changed by a simulation that doesn't affect them, as long as both
+// calculations share the same Now.
+func TestCompareUnchangedSavingsPlans(t *testing.T) {
+	calc := cost.NewCalculator(nil, nil)
+	input := testInput()
+	input.SavingsPlans[0].End = testNow.AddDate(1, 0, 0)
+	input.SavingsPlans = append(input.SavingsPlans, aws.SavingsPlan{
+		SavingsPlanARN: "arn:aws:savingsplans::222222222222:savingsplan/sp-002", SavingsPlanType: "EC2Instance",
+		Region: "us-west-2", InstanceFamily: "c5", Commitment: 0.10, AccountID: "222222222222",
+		End: testNow.AddDate(3, 0, 0),
+	})
+	input.Now = testNow
+
+	simulated, err := Apply(input, []Change{
+		{Type: ChangeExpireReservedInstance, ReservedInstanceID: "ri-001"},
+	}, testNow)
+	require.NoError(t, err)
+
+	result := Compare(calc.Calculate(input), calc.Calculate(simulated))
+
+	assert.Empty(t, result.SavingsPlans)
+	assert.Empty(t, result.Instances)
+	require.Contains(t, result.ReservedInstances, "ri-001")
+	assert.Nil(t, result.ReservedInstances["ri-001"].After)
+}